@@ -1,17 +1,34 @@
 package main
 
 import (
+	"context"
+	"fmt"
 	"os"
+	"time"
 
 	_ "github.com/joho/godotenv/autoload"
 	"github.com/spf13/viper"
+
+	"github.com/pkulik0/autocc/api/internal/cache"
+	"github.com/pkulik0/autocc/api/internal/secret"
+	"github.com/pkulik0/autocc/api/internal/store"
+	"github.com/pkulik0/autocc/api/internal/translation"
 )
 
 type config struct {
 	IsProduction bool   `mapstructure:"production"`
 	Port         uint16 `mapstructure:"port"`
 
-	RedisAddr string `mapstructure:"redis_addr"`
+	CacheBackend string        `mapstructure:"cache_backend"`
+	CacheSize    int           `mapstructure:"cache_size"`
+	CacheL1TTL   time.Duration `mapstructure:"cache_l1_ttl"`
+	RedisAddr    string        `mapstructure:"redis_addr"`
+
+	StoreBackend string `mapstructure:"store_backend"`
+	SQLitePath   string `mapstructure:"sqlite_path"`
+
+	MasterKey     string `mapstructure:"master_key"`
+	MasterKeyFile string `mapstructure:"master_key_file"`
 
 	PostgresHost string `mapstructure:"postgres_host"`
 	PostgresPort uint16 `mapstructure:"postgres_port"`
@@ -25,6 +42,8 @@ type config struct {
 	KeycloakClientSecret string `mapstructure:"keycloak_client_secret"`
 
 	GoogleCallbackURL string `mapstructure:"google_callback_url"`
+
+	DeepLURL string `mapstructure:"deepl_url"`
 }
 
 const (
@@ -33,7 +52,16 @@ const (
 	envProd = "PROD"
 	envPort = "PORT"
 
-	envRedisAddr = "REDIS_ADDR"
+	envCacheBackend = "CACHE_BACKEND"
+	envCacheSize    = "CACHE_SIZE"
+	envCacheL1TTL   = "CACHE_L1_TTL"
+	envRedisAddr    = "REDIS_ADDR"
+
+	envStoreBackend = "STORE_BACKEND"
+	envSQLitePath   = "SQLITE_PATH"
+
+	envMasterKey     = "MASTER_KEY"
+	envMasterKeyFile = "MASTER_KEY_FILE"
 
 	envPostgresHost = "POSTGRES_HOST"
 	envPostgresPort = "POSTGRES_PORT"
@@ -47,6 +75,24 @@ const (
 	envKeycloakClientSecret = "KEYCLOAK_CLIENT_SECRET"
 
 	envGoogleCallbackURL = "GOOGLE_CALLBACK_URL"
+
+	envDeepLURL = "DEEPL_URL"
+)
+
+const (
+	// cacheBackendMemory keeps the cache in the process memory.
+	cacheBackendMemory = "memory"
+	// cacheBackendRedis keeps the cache in Redis.
+	cacheBackendRedis = "redis"
+	// cacheBackendTiered keeps the cache in the process memory in front of Redis.
+	cacheBackendTiered = "tiered"
+)
+
+const (
+	// storeBackendPostgres keeps the data in PostgreSQL.
+	storeBackendPostgres = "postgres"
+	// storeBackendSQLite keeps the data in a SQLite database file.
+	storeBackendSQLite = "sqlite"
 )
 
 func bindEnvs(key ...string) error {
@@ -69,10 +115,13 @@ func parseConfig() (*config, error) {
 
 	err := bindEnvs(
 		envPort, envPort,
-		envRedisAddr,
+		envCacheBackend, envCacheSize, envCacheL1TTL, envRedisAddr,
+		envStoreBackend, envSQLitePath,
+		envMasterKey, envMasterKeyFile,
 		envPostgresHost, envPostgresPort, envPostgresUser, envPostgresPass, envPostgresDB,
 		envKeycloakURL, envKeycloakRealm, envKeycloakClientId, envKeycloakClientSecret,
 		envGoogleCallbackURL,
+		envDeepLURL,
 	)
 	if err != nil {
 		return nil, err
@@ -81,13 +130,21 @@ func parseConfig() (*config, error) {
 	viper.SetDefault(envProd, false)
 	viper.SetDefault(envPort, 8080)
 
+	viper.SetDefault(envCacheBackend, cacheBackendRedis)
+	viper.SetDefault(envCacheSize, 10000)
+	viper.SetDefault(envCacheL1TTL, time.Minute*5)
 	viper.SetDefault(envRedisAddr, "localhost:6379")
 
+	viper.SetDefault(envStoreBackend, storeBackendPostgres)
+	viper.SetDefault(envSQLitePath, "autocc.db")
+
 	viper.SetDefault(envPostgresHost, "postgres")
 	viper.SetDefault(envPostgresPort, 5432)
 	viper.SetDefault(envPostgresUser, "autocc")
 	viper.SetDefault(envPostgresDB, "autocc")
 
+	viper.SetDefault(envDeepLURL, translation.DeepLFreeURL)
+
 	if os.Getenv(envPrefix+"_"+envProd) == "" {
 		viper.SetDefault(envPostgresPass, "autocc")
 		viper.SetDefault(envKeycloakURL, "http://localhost:8081")
@@ -114,3 +171,76 @@ func parseConfig() (*config, error) {
 	}
 	return &c, nil
 }
+
+func newCache(ctx context.Context, c *config) (cache.Cache, error) {
+	switch c.CacheBackend {
+	case cacheBackendMemory:
+		return cache.NewMemory(c.CacheSize), nil
+	case cacheBackendRedis:
+		return cache.New(ctx, c.RedisAddr)
+	case cacheBackendTiered:
+		redis, err := cache.New(ctx, c.RedisAddr)
+		if err != nil {
+			return nil, err
+		}
+		return cache.NewTiered(cache.NewMemory(c.CacheSize), redis, c.CacheL1TTL), nil
+	default:
+		return nil, fmt.Errorf("unknown cache backend: %s", c.CacheBackend)
+	}
+}
+
+// newKeyring creates a keyring from master keys in the file or the config, the first key is the current one.
+// It returns nil if no master key is configured.
+func newKeyring(c *config) (*secret.Keyring, error) {
+	masterKeys := c.MasterKey
+	if c.MasterKeyFile != "" {
+		data, err := os.ReadFile(c.MasterKeyFile)
+		if err != nil {
+			return nil, err
+		}
+		masterKeys = string(data)
+	}
+	if masterKeys == "" {
+		return nil, nil
+	}
+
+	keys, err := secret.ParseKeys(masterKeys)
+	if err != nil {
+		return nil, err
+	}
+	return secret.NewKeyring(keys[0], keys[1:]...)
+}
+
+func newStore(c *config) (store.Store, error) {
+	keyring, err := newKeyring(c)
+	if err != nil {
+		return nil, err
+	}
+
+	switch c.StoreBackend {
+	case storeBackendPostgres:
+		return store.New(c.PostgresHost, c.PostgresPort, c.PostgresUser, c.PostgresPass, c.PostgresDB, keyring)
+	case storeBackendSQLite:
+		return store.NewSQLite(c.SQLitePath, keyring)
+	default:
+		return nil, fmt.Errorf("unknown store backend: %s", c.StoreBackend)
+	}
+}
+
+type migrator interface {
+	Check(ctx context.Context) error
+	Up(ctx context.Context) (int, error)
+	Down(ctx context.Context, steps int) (int, error)
+	Status(ctx context.Context) ([]store.MigrationStatus, error)
+}
+
+func newMigrator(c *config) (migrator, error) {
+	switch c.StoreBackend {
+	case storeBackendPostgres:
+		return store.NewMigrator(c.PostgresHost, c.PostgresPort, c.PostgresUser, c.PostgresPass, c.PostgresDB)
+	case storeBackendSQLite:
+		return store.NewSQLiteMigrator(c.SQLitePath)
+	default:
+		return nil, fmt.Errorf("unknown store backend: %s", c.StoreBackend)
+	}
+}
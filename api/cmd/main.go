@@ -10,11 +10,10 @@ import (
 
 	"github.com/pkulik0/autocc/api/internal/auth"
 	"github.com/pkulik0/autocc/api/internal/autocc"
-	"github.com/pkulik0/autocc/api/internal/cache"
+	"github.com/pkulik0/autocc/api/internal/coverage"
 	"github.com/pkulik0/autocc/api/internal/credentials"
 	"github.com/pkulik0/autocc/api/internal/oauth"
 	"github.com/pkulik0/autocc/api/internal/server"
-	"github.com/pkulik0/autocc/api/internal/store"
 	"github.com/pkulik0/autocc/api/internal/translation"
 	"github.com/pkulik0/autocc/api/internal/version"
 	"github.com/pkulik0/autocc/api/internal/youtube"
@@ -41,28 +40,43 @@ func main() {
 
 	log.Info().Str("version", version.Information().Version).Str("build_time", version.Information().BuildTime).Msg("AutoCC API")
 
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		if err := runMigrate(context.Background(), c, os.Args[2:]); err != nil {
+			log.Fatal().Err(err).Msg("failed to migrate")
+		}
+		return
+	}
+	if len(os.Args) > 1 && os.Args[1] == "reencrypt" {
+		if err := runReencrypt(context.Background(), c); err != nil {
+			log.Fatal().Err(err).Msg("failed to re-encrypt secrets")
+		}
+		return
+	}
+
 	auth, err := auth.New(context.Background(), c.KeycloakURL, c.KeycloakRealm, c.KeycloakClientId, c.KeycloakClientSecret)
 	if err != nil {
 		log.Fatal().Err(err).Msg("failed to create auth")
 	}
 
-	store, err := store.New(c.PostgresHost, c.PostgresPort, c.PostgresUser, c.PostgresPass, c.PostgresDB)
+	store, err := newStore(c)
 	if err != nil {
 		log.Fatal().Err(err).Msg("failed to create store")
 	}
 
-	cache, err := cache.New(context.Background(), c.RedisAddr)
+	cache, err := newCache(context.Background(), c)
 	if err != nil {
 		log.Fatal().Err(err).Msg("failed to create cache")
 	}
 
-	translator := translation.New(store, cache)
+	translator := translation.New(store, cache, c.DeepLURL)
 	youtube := youtube.New(store, cache)
-	credentials := credentials.New(store, oauth.New(c.GoogleCallbackURL), translator)
+	credentials := credentials.New(store, oauth.New(c.GoogleCallbackURL), translator, youtube)
+	credentials.StartJanitor(context.Background(), time.Minute*10)
 
-	autocc := autocc.New(translator, youtube)
+	autocc := autocc.New(store, translator, youtube)
+	coverage := coverage.New(store, translator, youtube)
 
-	server := server.New(cache, credentials, auth, youtube, autocc)
+	server := server.New(cache, credentials, auth, youtube, autocc, coverage)
 	err = server.Start(c.Port)
 	if err != nil {
 		log.Fatal().Err(err).Msg("failed to start server")
@@ -0,0 +1,21 @@
+package main
+
+import (
+	"context"
+	"fmt"
+)
+
+// runReencrypt encrypts all secrets with the current master key, so previous keys can be removed from the config.
+func runReencrypt(ctx context.Context, c *config) error {
+	store, err := newStore(c)
+	if err != nil {
+		return err
+	}
+
+	count, err := store.ReencryptSecrets(ctx)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("re-encrypted secrets of %d records\n", count)
+	return nil
+}
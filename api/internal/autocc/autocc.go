@@ -1,55 +1,252 @@
 package autocc
 
 import (
+	"archive/zip"
+	"bytes"
 	"context"
 	"fmt"
+	"slices"
 	"sync"
 
+	"github.com/pkulik0/autocc/api/internal/cache"
 	"github.com/pkulik0/autocc/api/internal/errs"
+	"github.com/pkulik0/autocc/api/internal/model"
+	"github.com/pkulik0/autocc/api/internal/pb"
+	"github.com/pkulik0/autocc/api/internal/srt"
+	"github.com/pkulik0/autocc/api/internal/store"
 	"github.com/pkulik0/autocc/api/internal/translation"
 	"github.com/pkulik0/autocc/api/internal/youtube"
 	"github.com/rs/zerolog/log"
+	"golang.org/x/sync/errgroup"
 )
 
 // AutoCC is the interface that wraps the main workflow of AutoCC.
+//
+//go:generate mockgen -destination=../mock/autocc.go -package=mock . AutoCC
 type AutoCC interface {
-	// Process processes the video and uploads translated closed captions and metadata.
-	Process(ctx context.Context, userID, videoID string) error
+	// Process processes the video of the channel and uploads translated closed captions and metadata.
+	// The options select what's localized besides the closed captions, title and description of the video.
+	Process(ctx context.Context, userID, channelID, videoID string, options Options) error
+	// DetectLanguage detects the language of the video of the channel from its title, description and closed captions.
+	// The user should confirm it before it's set as the default language of the video, see Options.Language.
+	DetectLanguage(ctx context.Context, userID, channelID, videoID string) (string, error)
+	// ListVideos lists a page of videos of the channel with their processing state, filtered and sorted according to the options.
+	// With filters, more pages of the uploads playlist are listed until the page is filled, up to a limit of their number.
+	// The uploads playlist is paged newest first, so the sort order applies to each page separately.
+	// Missing languages are known only for videos without closed captions or with listed ones, they're listed only to filter or sort by them.
+	ListVideos(ctx context.Context, userID, channelID, nextPageToken string, options ListOptions) ([]*pb.Video, string, error)
+
+	// SetSourceCC sets closed captions used as the translation source of the video instead of the ones on YouTube.
+	// If the language is empty the default language of the video is used.
+	SetSourceCC(ctx context.Context, userID, videoID, language string, format srt.Format, data string, uploadOriginal bool) error
+	// RemoveSourceCC removes closed captions set as the translation source of the video.
+	RemoveSourceCC(ctx context.Context, userID, videoID string) error
+	// AdjustSourceTiming adjusts the timing of closed captions set as the translation source of the video.
+	AdjustSourceTiming(ctx context.Context, userID, videoID string, timing srt.Timing) error
+
+	// RemoveTranslationMemory removes the translation memory of segments translated from or to the language.
+	RemoveTranslationMemory(ctx context.Context, language string) (int, error)
+
+	// ExportCC returns a ZIP archive with all closed captions of the video of the channel in the given format.
+	ExportCC(ctx context.Context, userID, channelID, videoID string, format srt.Format) ([]byte, error)
+}
+
+// Options select what's localized by Process besides the closed captions, title and description of the video.
+type Options struct {
+	// Tags adds translated tags to the tags of the video, YouTube doesn't support localized tags.
+	Tags bool
+	// Playlists localizes the titles and descriptions of the playlists containing the video.
+	Playlists bool
+	// Channel localizes the name and description of the channel.
+	Channel bool
+	// Policy decides which existing localizations are replaced by translated ones.
+	Policy youtube.MergePolicy
+	// Language is set as the default language of the video before it's processed, if it's not empty.
+	Language string
 }
 
 var _ AutoCC = &autoCC{}
 
 type autoCC struct {
+	store      store.Store
 	translator translation.Translator
 	youtube    youtube.Youtube
 }
 
 // New creates a new AutoCC service.
-func New(translator translation.Translator, youtube youtube.Youtube) *autoCC {
+func New(store store.Store, translator translation.Translator, youtube youtube.Youtube) *autoCC {
 	return &autoCC{
+		store:      store,
 		translator: translator,
 		youtube:    youtube,
 	}
 }
 
-func (a *autoCC) Process(ctx context.Context, userID, videoID string) error {
+func (a *autoCC) SetSourceCC(ctx context.Context, userID, videoID, language string, format srt.Format, data string, uploadOriginal bool) error {
+	if userID == "" || videoID == "" || data == "" {
+		return errs.InvalidInput
+	}
+
+	cc, err := srt.ParseFormat(data, format)
+	if err != nil {
+		log.Debug().Err(err).Str("video_id", videoID).Str("format", string(format)).Msg("failed to parse source cc")
+		return errs.InvalidInput
+	}
+	if len(cc.Lines) == 0 {
+		return errs.InvalidInput
+	}
+
+	_, err = a.store.SaveSourceCC(ctx, userID, videoID, language, cc.String(), uploadOriginal)
+	return err
+}
+
+func (a *autoCC) RemoveSourceCC(ctx context.Context, userID, videoID string) error {
 	if userID == "" || videoID == "" {
 		return errs.InvalidInput
 	}
 
-	languages, err := a.translator.GetLanguages(ctx)
+	return a.store.RemoveSourceCC(ctx, userID, videoID)
+}
+
+func (a *autoCC) RemoveTranslationMemory(ctx context.Context, language string) (int, error) {
+	if language == "" {
+		return 0, errs.InvalidInput
+	}
+
+	// Translations are stored with the codes of the translation API, the language may be given in the Google API format.
+	languages := []string{language}
+	if code := translation.CodeGoogleToTranslation(language); code != language {
+		languages = append(languages, code)
+	}
+
+	removed := 0
+	for _, language := range languages {
+		for _, pair := range [][2]string{{language, ""}, {"", language}} {
+			n, err := a.store.RemoveTranslationMemory(ctx, pair[0], pair[1])
+			if err != nil {
+				return removed, err
+			}
+			removed += n
+		}
+	}
+	return removed, nil
+}
+
+func (a *autoCC) AdjustSourceTiming(ctx context.Context, userID, videoID string, timing srt.Timing) error {
+	if userID == "" || videoID == "" {
+		return errs.InvalidInput
+	}
+
+	sourceCC, err := a.store.GetSourceCC(ctx, userID, videoID)
 	if err != nil {
 		return err
 	}
 
-	metadata, err := a.youtube.GetMetadata(ctx, userID, videoID)
+	cc, err := srt.Parse(sourceCC.Srt)
 	if err != nil {
 		return err
 	}
 
-	allCC, err := a.youtube.GetCC(ctx, userID, videoID)
+	err = cc.AdjustTiming(timing)
 	if err != nil {
-		return err
+		log.Debug().Err(err).Str("video_id", videoID).Msg("failed to adjust source cc timing")
+		return errs.InvalidInput
+	}
+
+	_, err = a.store.SaveSourceCC(ctx, userID, videoID, sourceCC.Language, cc.String(), sourceCC.UploadOriginal)
+	return err
+}
+
+func (a *autoCC) ExportCC(ctx context.Context, userID, channelID, videoID string, format srt.Format) ([]byte, error) {
+	if userID == "" || channelID == "" || videoID == "" || !format.IsValid() {
+		return nil, errs.InvalidInput
+	}
+
+	allCC, err := a.youtube.GetCC(ctx, userID, channelID, videoID)
+	if err != nil {
+		return nil, err
+	}
+	if len(allCC) == 0 {
+		return nil, errs.NotFound
+	}
+
+	var buf bytes.Buffer
+	archive := zip.NewWriter(&buf)
+	names := make(map[string]int)
+	for _, cc := range allCC {
+		downloaded, err := a.youtube.DownloadCC(ctx, userID, channelID, cc.Id)
+		if err != nil {
+			return nil, err
+		}
+
+		data, err := downloaded.Encode(format)
+		if err != nil {
+			return nil, err
+		}
+
+		// A video can have multiple tracks in the same language.
+		name := cc.Language
+		names[cc.Language]++
+		if names[cc.Language] > 1 {
+			name = fmt.Sprintf("%s-%d", cc.Language, names[cc.Language])
+		}
+
+		f, err := archive.Create(fmt.Sprintf("%s.%s.%s", videoID, name, format))
+		if err != nil {
+			return nil, err
+		}
+		if _, err := f.Write([]byte(data)); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := archive.Close(); err != nil {
+		return nil, err
+	}
+
+	log.Debug().Str("video_id", videoID).Int("count", len(allCC)).Str("format", string(format)).Msg("exported cc")
+	return buf.Bytes(), nil
+}
+
+// getSourceCC returns the closed captions to translate and their language.
+// Captions uploaded by the user take precedence over the ones on YouTube.
+func (a *autoCC) getSourceCC(ctx context.Context, userID, channelID, videoID string, metadata *youtube.Metadata) (*srt.Srt, string, error) {
+	sourceCC, err := a.store.GetSourceCC(ctx, userID, videoID)
+	switch err {
+	case nil:
+		language := sourceCC.Language
+		if language == "" {
+			language = metadata.Language
+		}
+		if language == "" {
+			return nil, "", errs.VideoLanguageNotSet
+		}
+
+		cc, err := srt.Parse(sourceCC.Srt)
+		if err != nil {
+			return nil, "", err
+		}
+
+		if sourceCC.UploadOriginal {
+			_, err = a.youtube.UploadCC(ctx, userID, channelID, videoID, language, cc)
+			if err != nil {
+				return nil, "", err
+			}
+		}
+
+		log.Debug().Str("video_id", videoID).Str("language", language).Msg("using uploaded source cc")
+		return cc, language, nil
+	case errs.NotFound:
+	default:
+		return nil, "", err
+	}
+	if metadata.Language == "" {
+		return nil, "", errs.VideoLanguageNotSet
+	}
+
+	allCC, err := a.youtube.GetCC(ctx, userID, channelID, videoID)
+	if err != nil {
+		return nil, "", err
 	}
 
 	var srcCC *youtube.CC
@@ -60,21 +257,226 @@ func (a *autoCC) Process(ctx context.Context, userID, videoID string) error {
 		}
 	}
 	if srcCC == nil {
-		return errs.SourceClosedCaptionsNotFound
+		return nil, "", errs.SourceClosedCaptionsNotFound
 	}
 
-	srt, err := a.youtube.DownloadCC(ctx, userID, srcCC.Id)
+	cc, err := a.youtube.DownloadCC(ctx, userID, channelID, srcCC.Id)
+	if err != nil {
+		return nil, "", err
+	}
+	return cc, metadata.Language, nil
+}
+
+// translateMetadata translates the metadata to each of the languages except its own.
+// Tags are translated only if requested.
+func (a *autoCC) translateMetadata(ctx context.Context, metadata *youtube.Metadata, languages []string, tags bool) (map[string]*youtube.Metadata, error) {
+	srcLang := translation.CodeGoogleToTranslation(metadata.Language)
+	text := []string{metadata.Title, metadata.Description}
+	if tags {
+		text = append(text, metadata.Tags...)
+	}
+
+	var mu sync.Mutex
+	translated := make(map[string]*youtube.Metadata)
+	group, ctx := errgroup.WithContext(ctx)
+	for _, targetLang := range languages {
+		if targetLang == srcLang {
+			continue
+		}
+
+		group.Go(func() error {
+			translatedText, err := a.translator.Translate(ctx, text, srcLang, targetLang)
+			if err != nil {
+				log.Error().Err(err).Str("src_lang", srcLang).Str("target_lang", targetLang).Msg("failed to translate metadata")
+				return err
+			}
+			if len(translatedText) != len(text) {
+				log.Error().Strs("text", translatedText).Msg("invalid translation response")
+				return fmt.Errorf("invalid translation response")
+			}
+
+			m := &youtube.Metadata{
+				Title:       translatedText[0],
+				Description: translatedText[1],
+				Language:    translation.CodeTranslationToGoogle(targetLang),
+			}
+			if tags {
+				m.Tags = translatedText[2:]
+			}
+
+			mu.Lock()
+			defer mu.Unlock()
+			translated[m.Language] = m
+			return nil
+		})
+	}
+	if err := group.Wait(); err != nil {
+		return nil, err
+	}
+	return translated, nil
+}
+
+// localizePlaylists translates the metadata of the playlists containing the video.
+// Playlists without a default language are treated as if they were in the source language of the video.
+func (a *autoCC) localizePlaylists(ctx context.Context, userID, channelID, videoID, sourceLanguage string, languages []string, policy youtube.MergePolicy) error {
+	playlists, err := a.youtube.GetPlaylists(ctx, userID, channelID, videoID)
 	if err != nil {
 		return err
 	}
 
-	errChan := make(chan error)
-	metadataChan := make(chan *youtube.Metadata, len(languages))
-	waitGroupMetadata := sync.WaitGroup{}
+	for _, playlist := range playlists {
+		if playlist.Language == "" {
+			playlist.Language = sourceLanguage
+		}
+
+		metadata, err := a.translateMetadata(ctx, &playlist.Metadata, languages, false)
+		if err != nil {
+			return err
+		}
+		if len(metadata) == 0 {
+			continue
+		}
+
+		err = a.youtube.UpdatePlaylistMetadata(ctx, userID, channelID, playlist, metadata, policy)
+		if err != nil {
+			return err
+		}
+		log.Debug().Str("playlist_id", playlist.ID).Int("languages", len(metadata)).Msg("localized playlist")
+	}
+	return nil
+}
+
+// localizeChannel translates the name and description of the channel.
+// A channel without a default language is treated as if it was in the source language of the video.
+func (a *autoCC) localizeChannel(ctx context.Context, userID, channelID, sourceLanguage string, languages []string, policy youtube.MergePolicy) error {
+	channel, err := a.youtube.GetChannelMetadata(ctx, userID, channelID)
+	if err != nil {
+		return err
+	}
+	if channel.Language == "" {
+		channel.Language = sourceLanguage
+	}
+
+	metadata, err := a.translateMetadata(ctx, channel, languages, false)
+	if err != nil {
+		return err
+	}
+	if len(metadata) == 0 {
+		return nil
+	}
+
+	err = a.youtube.UpdateChannelMetadata(ctx, userID, channelID, channel, metadata, policy)
+	if err != nil {
+		return err
+	}
+	log.Debug().Str("channel_id", channelID).Int("languages", len(metadata)).Msg("localized channel")
+	return nil
+}
+
+// captionsText returns the text of the closed captions of the video in its spoken language.
+// Captions set as the translation source are preferred, then the ones generated by YouTube, then any not translated by AutoCC.
+// Without such captions there's no text, so that a translation isn't detected as the language of the video.
+func (a *autoCC) captionsText(ctx context.Context, userID, channelID, videoID string) ([]string, error) {
+	sourceCC, err := a.store.GetSourceCC(ctx, userID, videoID)
+	switch err {
+	case nil:
+		cc, err := srt.Parse(sourceCC.Srt)
+		if err != nil {
+			return nil, err
+		}
+		return cc.Text(), nil
+	case errs.NotFound:
+	default:
+		return nil, err
+	}
+
+	allCC, err := a.youtube.GetCC(ctx, userID, channelID, videoID)
+	if err != nil {
+		return nil, err
+	}
+	translatedCC, err := a.store.GetTranslatedCC(ctx, videoID)
+	if err != nil {
+		return nil, err
+	}
+
+	i := slices.IndexFunc(allCC, func(cc *youtube.CC) bool { return cc.TrackKind == youtube.TrackKindASR })
+	if i < 0 {
+		i = slices.IndexFunc(allCC, func(cc *youtube.CC) bool {
+			return !slices.ContainsFunc(translatedCC, func(t model.TranslatedCC) bool { return t.TrackID == cc.Id })
+		})
+	}
+	if i < 0 {
+		return nil, nil
+	}
+	cc, err := a.youtube.DownloadCC(ctx, userID, channelID, allCC[i].Id)
+	if err != nil {
+		return nil, err
+	}
+	return cc.Text(), nil
+}
+
+func (a *autoCC) DetectLanguage(ctx context.Context, userID, channelID, videoID string) (string, error) {
+	if userID == "" || channelID == "" || videoID == "" {
+		return "", errs.InvalidInput
+	}
+
+	metadata, err := a.youtube.GetMetadata(ctx, userID, channelID, videoID)
+	if err != nil {
+		return "", err
+	}
+	captions, err := a.captionsText(ctx, userID, channelID, videoID)
+	if err != nil {
+		return "", err
+	}
+
+	// Only a sample of the text is used, the captions come before the description which often has links and credits.
+	text := append([]string{metadata.Title}, captions...)
+	text = append(text, metadata.Description)
+	language, err := a.translator.DetectLanguage(ctx, text)
+	if err != nil {
+		return "", err
+	}
+	language = translation.CodeTranslationToGoogle(language)
+
+	log.Debug().Str("video_id", videoID).Str("language", language).Msg("detected video language")
+	return language, nil
+}
+
+func (a *autoCC) Process(ctx context.Context, userID, channelID, videoID string, options Options) error {
+	if userID == "" || channelID == "" || videoID == "" {
+		return errs.InvalidInput
+	}
+	// Cached translations and uploads can be invalidated by the video or the user.
+	ctx = cache.WithTags(ctx, cache.TagUser(userID), cache.TagVideo(videoID))
+
+	languages, err := a.translator.GetLanguages(ctx)
+	if err != nil {
+		return err
+	}
+
+	metadata, err := a.youtube.GetMetadata(ctx, userID, channelID, videoID)
+	if err != nil {
+		return err
+	}
+	if options.Language != "" && options.Language != metadata.Language {
+		err = a.youtube.SetLanguage(ctx, userID, channelID, videoID, options.Language)
+		if err != nil {
+			return err
+		}
+		metadata.Language = options.Language
+	}
+
+	srt, sourceLanguage, err := a.getSourceCC(ctx, userID, channelID, videoID, metadata)
+	if err != nil {
+		return err
+	}
+
+	// Failed uploads are reported after the metadata is updated, so the channel is buffered.
+	errChan := make(chan error, len(languages))
 	waitGroupCC := sync.WaitGroup{}
 
 	for i, targetLang := range languages {
-		srcLang := translation.CodeGoogleToTranslation(metadata.Language)
+		srcLang := translation.CodeGoogleToTranslation(sourceLanguage)
 		if targetLang == srcLang {
 			continue
 		}
@@ -101,67 +503,54 @@ func (a *autoCC) Process(ctx context.Context, userID, videoID string) error {
 				return
 			}
 
-			_, err = a.youtube.UploadCC(ctx, userID, videoID, translation.CodeTranslationToGoogle(targetLang), translatedSrt)
+			language := translation.CodeTranslationToGoogle(targetLang)
+			trackID, err := a.youtube.UploadCC(ctx, userID, channelID, videoID, language, translatedSrt)
 			if err != nil {
 				log.Error().Err(err).Str("src_lang", srcLang).Str("target_lang", targetLang).Msg("failed to upload cc")
 				errChan <- err
-			}
-		}()
-
-		waitGroupMetadata.Add(1)
-		go func() {
-			defer func() {
-				log.Debug().Str("src_lang", srcLang).Str("target_lang", targetLang).Int("i", i).Int("len", len(languages)-1).Msg("finished translating metadata")
-				waitGroupMetadata.Done()
-			}()
-			text, err := a.translator.Translate(ctx, []string{metadata.Title, metadata.Description}, srcLang, targetLang)
-			if err != nil {
-				log.Error().Err(err).Str("src_lang", srcLang).Str("target_lang", targetLang).Msg("failed to translate metadata")
-				errChan <- err
-				return
-			}
-
-			if len(text) != 2 {
-				log.Error().Strs("text", text).Msg("invalid translation response")
-				errChan <- fmt.Errorf("invalid translation response")
 				return
 			}
 
-			title := text[0]
-			description := text[1]
-
-			metadataChan <- &youtube.Metadata{
-				Title:       title,
-				Description: description,
-				Language:    translation.CodeTranslationToGoogle(targetLang),
+			// The record tells the translation apart from closed captions uploaded by hand, see coverage.
+			err = a.store.SaveTranslatedCC(ctx, []model.TranslatedCC{{VideoID: videoID, Language: language, TrackID: trackID}})
+			if err != nil {
+				log.Error().Err(err).Str("video_id", videoID).Str("language", language).Msg("failed to save translated cc")
 			}
 		}()
 	}
 
-	waitGroupMetadata.Wait()
-	close(metadataChan)
-
-	if len(errChan) > 0 {
-		err := <-errChan
+	// The metadata is translated from the language of the closed captions.
+	videoMetadata := *metadata
+	videoMetadata.Language = sourceLanguage
+	metadataMap, err := a.translateMetadata(ctx, &videoMetadata, languages, options.Tags)
+	if err != nil {
 		return err
 	}
-
-	metadataMap := make(map[string]*youtube.Metadata)
-	for m := range metadataChan {
-		metadataMap[m.Language] = m
+	if len(metadataMap) > 0 {
+		err = a.youtube.UpdateMetadata(ctx, userID, channelID, videoID, &videoMetadata, metadataMap, options.Policy)
+		if err != nil {
+			return err
+		}
 	}
 
-	err = a.youtube.UpdateMetadata(ctx, userID, videoID, metadataMap)
-	if err != nil {
-		return err
+	if options.Playlists {
+		err = a.localizePlaylists(ctx, userID, channelID, videoID, sourceLanguage, languages, options.Policy)
+		if err != nil {
+			return err
+		}
+	}
+	if options.Channel {
+		err = a.localizeChannel(ctx, userID, channelID, sourceLanguage, languages, options.Policy)
+		if err != nil {
+			return err
+		}
 	}
 
 	waitGroupCC.Wait()
-	if len(errChan) > 0 {
-		err := <-errChan
+	close(errChan)
+	if err, ok := <-errChan; ok {
 		return err
 	}
-	close(errChan)
 
 	return nil
 }
@@ -0,0 +1,485 @@
+package autocc_test
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"io"
+	"testing"
+	"time"
+
+	qt "github.com/frankban/quicktest"
+	"go.uber.org/mock/gomock"
+	yt "google.golang.org/api/youtube/v3"
+
+	"github.com/pkulik0/autocc/api/internal/autocc"
+	"github.com/pkulik0/autocc/api/internal/cache"
+	"github.com/pkulik0/autocc/api/internal/errs"
+	"github.com/pkulik0/autocc/api/internal/fake"
+	"github.com/pkulik0/autocc/api/internal/mock"
+	"github.com/pkulik0/autocc/api/internal/model"
+	"github.com/pkulik0/autocc/api/internal/pb"
+	"github.com/pkulik0/autocc/api/internal/srt"
+	"github.com/pkulik0/autocc/api/internal/store"
+	"github.com/pkulik0/autocc/api/internal/translation"
+	"github.com/pkulik0/autocc/api/internal/youtube"
+)
+
+// setupYoutube returns a fake YouTube server with a channel of the user and one of its videos, and a YouTube service using it.
+func setupYoutube(c *qt.C) (*fake.Youtube, store.Store, youtube.Youtube, string) {
+	f := fake.NewYoutube()
+	c.Cleanup(f.Close)
+	f.AddChannel("channel", "Channel", "token")
+	videoID := f.AddVideo("channel", &yt.Video{Snippet: &yt.VideoSnippet{
+		Title:           "Title",
+		Description:     "Description",
+		DefaultLanguage: "en",
+		CategoryId:      "22",
+		Tags:            []string{"tag"},
+	}})
+
+	s := fake.NewStore(c)
+	f.Session(c, s, "user", "channel", "token")
+
+	return f, s, youtube.New(s, cache.NewMemory(100), f.Options()...), videoID
+}
+
+// setup returns an AutoCC service using a fake YouTube server, see setupYoutube.
+// Translations prefix the text with the target language, see fake.PrefixTranslator.
+func setup(c *qt.C) (*fake.Youtube, autocc.AutoCC, string) {
+	f, s, y, videoID := setupYoutube(c)
+	return f, autocc.New(s, fake.PrefixTranslator(c, "de", "en", "fr"), y), videoID
+}
+
+// captionsText returns the text of the closed captions of the video by language.
+func captionsText(c *qt.C, f *fake.Youtube, videoID string) map[string][]string {
+	text := make(map[string][]string)
+	for _, caption := range f.Captions(videoID) {
+		cc, err := srt.Parse(caption.Body)
+		c.Assert(err, qt.IsNil)
+		text[caption.Language] = cc.Text()
+	}
+	return text
+}
+
+func TestProcess(t *testing.T) {
+	c := qt.New(t)
+	f, a, videoID := setup(c)
+	f.AddCaption(videoID, "en", "", fake.Srt)
+
+	err := a.Process(context.Background(), "user", "channel", videoID, autocc.Options{})
+	c.Assert(err, qt.IsNil)
+
+	c.Assert(captionsText(c, f, videoID), qt.DeepEquals, map[string][]string{
+		"en": {"Hello", "World"},
+		"de": {"[de] Hello", "[de] World"},
+		"fr": {"[fr] Hello", "[fr] World"},
+	})
+	c.Assert(f.Video(videoID).Localizations, qt.DeepEquals, map[string]yt.VideoLocalization{
+		"de": {Title: "[de] Title", Description: "[de] Description"},
+		"fr": {Title: "[fr] Title", Description: "[fr] Description"},
+	})
+
+	// Tags, playlists and the channel aren't localized by default.
+	c.Assert(f.Video(videoID).Snippet.Tags, qt.DeepEquals, []string{"tag"})
+	c.Assert(f.Channel("channel").Localizations, qt.HasLen, 0)
+}
+
+func TestProcessOptions(t *testing.T) {
+	c := qt.New(t)
+	f, a, videoID := setup(c)
+	f.AddCaption(videoID, "en", "", fake.Srt)
+	playlistID := f.AddPlaylist("channel", &yt.Playlist{Snippet: &yt.PlaylistSnippet{Title: "Playlist", Description: "Videos"}}, videoID)
+	otherID := f.AddPlaylist("channel", &yt.Playlist{Snippet: &yt.PlaylistSnippet{Title: "Other"}})
+
+	err := a.Process(context.Background(), "user", "channel", videoID, autocc.Options{Tags: true, Playlists: true, Channel: true})
+	c.Assert(err, qt.IsNil)
+
+	// Translated tags are added to the tags of the video.
+	video := f.Video(videoID)
+	c.Assert(video.Snippet.Tags, qt.DeepEquals, []string{"tag", "[de] tag", "[fr] tag"})
+	c.Assert(video.Snippet.Title, qt.Equals, "Title")
+	c.Assert(video.Localizations, qt.HasLen, 2)
+
+	// Playlists without a default language get the language of the video.
+	playlist := f.Playlist(playlistID)
+	c.Assert(playlist.Snippet.DefaultLanguage, qt.Equals, "en")
+	c.Assert(playlist.Localizations, qt.DeepEquals, map[string]yt.PlaylistLocalization{
+		"de": {Title: "[de] Playlist", Description: "[de] Videos"},
+		"fr": {Title: "[fr] Playlist", Description: "[fr] Videos"},
+	})
+	c.Assert(f.Playlist(otherID).Localizations, qt.HasLen, 0)
+
+	channel := f.Channel("channel")
+	c.Assert(channel.BrandingSettings.Channel.DefaultLanguage, qt.Equals, "en")
+	c.Assert(channel.Localizations, qt.DeepEquals, map[string]yt.ChannelLocalization{
+		"de": {Title: "[de] Channel", Description: "[de] "},
+		"fr": {Title: "[fr] Channel", Description: "[fr] "},
+	})
+}
+
+func TestProcessPolicy(t *testing.T) {
+	c := qt.New(t)
+	f, a, videoID := setup(c)
+	f.AddCaption(videoID, "en", "", fake.Srt)
+	video := f.Video(videoID)
+	video.Localizations = map[string]yt.VideoLocalization{"de": {Title: "Titel", Description: "Beschreibung"}}
+	f.AddVideo("channel", video)
+
+	// Localizations written by hand are kept by default.
+	err := a.Process(context.Background(), "user", "channel", videoID, autocc.Options{})
+	c.Assert(err, qt.IsNil)
+	c.Assert(f.Video(videoID).Localizations, qt.DeepEquals, map[string]yt.VideoLocalization{
+		"de": {Title: "Titel", Description: "Beschreibung"},
+		"fr": {Title: "[fr] Title", Description: "[fr] Description"},
+	})
+
+	err = a.Process(context.Background(), "user", "channel", videoID, autocc.Options{Policy: youtube.MergeOverwriteAll})
+	c.Assert(err, qt.IsNil)
+	c.Assert(f.Video(videoID).Localizations, qt.DeepEquals, map[string]yt.VideoLocalization{
+		"de": {Title: "[de] Title", Description: "[de] Description"},
+		"fr": {Title: "[fr] Title", Description: "[fr] Description"},
+	})
+}
+
+func TestProcessDeepL(t *testing.T) {
+	c := qt.New(t)
+	f, s, y, videoID := setupYoutube(c)
+	f.AddCaption(videoID, "en", "", "1\n00:00:01,000 --> 00:00:02,000\n<i>Hello</i>\n")
+
+	d := fake.NewDeepL()
+	c.Cleanup(d.Close)
+	d.SetLanguages("DE", "EN", "NB")
+	d.AddKey("key", 1000)
+	_, err := s.AddCredentialsDeepL(context.Background(), "key", 0)
+	c.Assert(err, qt.IsNil)
+
+	a := autocc.New(s, translation.New(s, cache.NewMemory(100), d.URL()), y)
+	err = a.Process(context.Background(), "user", "channel", videoID, autocc.Options{})
+	c.Assert(err, qt.IsNil)
+
+	// DeepL codes are translated to the ones used by YouTube.
+	c.Assert(captionsText(c, f, videoID), qt.DeepEquals, map[string][]string{
+		"en": {"<i>Hello</i>"},
+		"de": {"[DE] <i>Hello</i>"},
+		"no": {"[NB] <i>Hello</i>"},
+	})
+	c.Assert(f.Video(videoID).Localizations, qt.DeepEquals, map[string]yt.VideoLocalization{
+		"de": {Title: "[DE] Title", Description: "[DE] Description"},
+		"no": {Title: "[NB] Title", Description: "[NB] Description"},
+	})
+	c.Assert(d.Usage("key") > 0, qt.IsTrue)
+}
+
+func TestProcessLanguage(t *testing.T) {
+	c := qt.New(t)
+	f, s, y, videoID := setupYoutube(c)
+	ctx := context.Background()
+	video := f.Video(videoID)
+	video.Snippet.DefaultLanguage = ""
+	f.AddVideo("channel", video)
+	f.AddCaption(videoID, "no", "", "1\n00:00:01,000 --> 00:00:02,000\nHei\n")
+
+	d := fake.NewDeepL()
+	c.Cleanup(d.Close)
+	d.SetLanguages("DE", "EN", "NB")
+	d.SetDetectedLanguage("NB")
+	d.AddKey("key", 1000)
+	_, err := s.AddCredentialsDeepL(ctx, "key", 0)
+	c.Assert(err, qt.IsNil)
+	a := autocc.New(s, translation.New(s, cache.NewMemory(100), d.URL()), y)
+
+	err = a.Process(ctx, "user", "channel", videoID, autocc.Options{})
+	c.Assert(err, qt.Equals, errs.VideoLanguageNotSet)
+
+	// The detected DeepL code is translated to the one used by YouTube.
+	language, err := a.DetectLanguage(ctx, "user", "channel", videoID)
+	c.Assert(err, qt.IsNil)
+	c.Assert(language, qt.Equals, "no")
+
+	// The confirmed language is set before the video is processed.
+	err = a.Process(ctx, "user", "channel", videoID, autocc.Options{Language: language})
+	c.Assert(err, qt.IsNil)
+	c.Assert(f.Video(videoID).Snippet.DefaultLanguage, qt.Equals, "no")
+	c.Assert(captionsText(c, f, videoID), qt.DeepEquals, map[string][]string{
+		"no": {"Hei"},
+		"de": {"[DE] Hei"},
+		"en": {"[EN] Hei"},
+	})
+}
+
+func TestDetectLanguageCaptions(t *testing.T) {
+	c := qt.New(t)
+	f, s, y, videoID := setupYoutube(c)
+	ctx := context.Background()
+
+	var sample []string
+	translator := mock.NewMockTranslator(gomock.NewController(c))
+	translator.EXPECT().DetectLanguage(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, text []string) (string, error) {
+		sample = text
+		return "EN", nil
+	}).AnyTimes()
+	a := autocc.New(s, translator, y)
+
+	// Captions translated by AutoCC aren't in the language of the video.
+	trackID := f.AddCaption(videoID, "de", "", "1\n00:00:01,000 --> 00:00:02,000\nHallo\n")
+	err := s.SaveTranslatedCC(ctx, []model.TranslatedCC{{VideoID: videoID, Language: "de", TrackID: trackID}})
+	c.Assert(err, qt.IsNil)
+	_, err = a.DetectLanguage(ctx, "user", "channel", videoID)
+	c.Assert(err, qt.IsNil)
+	c.Assert(sample, qt.DeepEquals, []string{"Title", "Description"})
+
+	f.AddCaption(videoID, "pl", "", "1\n00:00:01,000 --> 00:00:02,000\nCześć\n")
+	_, err = a.DetectLanguage(ctx, "user", "channel", videoID)
+	c.Assert(err, qt.IsNil)
+	c.Assert(sample, qt.DeepEquals, []string{"Title", "Cześć", "Description"})
+
+	// Captions generated by YouTube are in the spoken language.
+	f.AddASRCaption(videoID, "en", "1\n00:00:01,000 --> 00:00:02,000\nHello\n")
+	_, err = a.DetectLanguage(ctx, "user", "channel", videoID)
+	c.Assert(err, qt.IsNil)
+	c.Assert(sample, qt.DeepEquals, []string{"Title", "Hello", "Description"})
+}
+
+func TestProcessSourceCC(t *testing.T) {
+	c := qt.New(t)
+	f, a, videoID := setup(c)
+
+	err := a.SetSourceCC(context.Background(), "user", videoID, "", srt.FormatSrt, fake.Srt, true)
+	c.Assert(err, qt.IsNil)
+
+	err = a.Process(context.Background(), "user", "channel", videoID, autocc.Options{})
+	c.Assert(err, qt.IsNil)
+
+	// The source is uploaded in the default language of the video.
+	c.Assert(captionsText(c, f, videoID), qt.DeepEquals, map[string][]string{
+		"en": {"Hello", "World"},
+		"de": {"[de] Hello", "[de] World"},
+		"fr": {"[fr] Hello", "[fr] World"},
+	})
+}
+
+func TestProcessSourceNotFound(t *testing.T) {
+	c := qt.New(t)
+	f, a, videoID := setup(c)
+	f.AddCaption(videoID, "pl", "", fake.Srt)
+
+	err := a.Process(context.Background(), "user", "channel", videoID, autocc.Options{})
+	c.Assert(err, qt.ErrorMatches, "autocc: source closed captions not found")
+}
+
+func TestExportCC(t *testing.T) {
+	c := qt.New(t)
+	f, a, videoID := setup(c)
+	f.AddCaption(videoID, "en", "", fake.Srt)
+	f.AddCaption(videoID, "en", "Other", fake.Srt)
+
+	data, err := a.ExportCC(context.Background(), "user", "channel", videoID, srt.FormatTxt)
+	c.Assert(err, qt.IsNil)
+
+	archive, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	c.Assert(err, qt.IsNil)
+	files := make(map[string]string)
+	for _, file := range archive.File {
+		r, err := file.Open()
+		c.Assert(err, qt.IsNil)
+		content, err := io.ReadAll(r)
+		c.Assert(err, qt.IsNil)
+		files[file.Name] = string(content)
+	}
+	c.Assert(files, qt.HasLen, 2)
+	c.Assert(files[videoID+".en.txt"], qt.Contains, "Hello")
+	c.Assert(files[videoID+".en-2.txt"], qt.Contains, "World")
+}
+
+func TestListVideos(t *testing.T) {
+	c := qt.New(t)
+	f, a, videoID := setup(c)
+	ctx := context.Background()
+	f.AddCaption(videoID, "en", "", fake.Srt)
+	betaID := f.AddVideo("channel", &yt.Video{Snippet: &yt.VideoSnippet{Title: "Beta", DefaultLanguage: "en", PublishedAt: "2024-02-01T00:00:00Z"}})
+	f.AddCaption(betaID, "de", "", fake.Srt)
+	alphaID := f.AddVideo("channel", &yt.Video{Snippet: &yt.VideoSnippet{Title: "alpha", DefaultLanguage: "en", PublishedAt: "2024-01-01T00:00:00Z"}})
+
+	err := a.Process(ctx, "user", "channel", videoID, autocc.Options{})
+	c.Assert(err, qt.IsNil)
+
+	ids := func(videos []*pb.Video) []string {
+		ids := make([]string, len(videos))
+		for i, video := range videos {
+			ids[i] = video.Id
+		}
+		return ids
+	}
+
+	// Closed captions of beta were never listed, so its missing languages are unknown.
+	videos, _, err := a.ListVideos(ctx, "user", "channel", "", autocc.ListOptions{})
+	c.Assert(err, qt.IsNil)
+	c.Assert(ids(videos), qt.DeepEquals, []string{betaID, alphaID, videoID})
+	c.Assert(videos[0].Processed, qt.IsFalse)
+	c.Assert(videos[0].HasCaptions, qt.IsTrue)
+	c.Assert(videos[0].MissingLanguages, qt.HasLen, 0)
+	c.Assert(videos[1].MissingLanguages, qt.DeepEquals, []string{"de", "fr"})
+	c.Assert(videos[2].Processed, qt.IsTrue)
+	c.Assert(videos[2].MissingLanguages, qt.HasLen, 0)
+
+	testCases := []struct {
+		name     string
+		options  autocc.ListOptions
+		expected []string
+	}{
+		{name: "unprocessed", options: autocc.ListOptions{Unprocessed: true}, expected: []string{betaID, alphaID}},
+		{name: "missing languages", options: autocc.ListOptions{MissingLanguages: true}, expected: []string{betaID, alphaID}},
+		{name: "oldest", options: autocc.ListOptions{Sort: autocc.VideoSortOldest}, expected: []string{videoID, alphaID, betaID}},
+		{name: "title", options: autocc.ListOptions{Sort: autocc.VideoSortTitle}, expected: []string{alphaID, betaID, videoID}},
+		{name: "missing", options: autocc.ListOptions{Sort: autocc.VideoSortMissing}, expected: []string{alphaID, betaID, videoID}},
+		{name: "unprocessed oldest", options: autocc.ListOptions{Unprocessed: true, Sort: autocc.VideoSortOldest}, expected: []string{alphaID, betaID}},
+	}
+	for _, tc := range testCases {
+		c.Run(tc.name, func(c *qt.C) {
+			videos, _, err := a.ListVideos(ctx, "user", "channel", "", tc.options)
+			c.Assert(err, qt.IsNil)
+			c.Assert(ids(videos), qt.DeepEquals, tc.expected)
+		})
+	}
+
+	// Closed captions listed to filter by missing languages are cached.
+	videos, _, err = a.ListVideos(ctx, "user", "channel", "", autocc.ListOptions{})
+	c.Assert(err, qt.IsNil)
+	c.Assert(videos[0].MissingLanguages, qt.DeepEquals, []string{"fr"})
+}
+
+func TestListVideosPages(t *testing.T) {
+	c := qt.New(t)
+	f, s, y, videoID := setupYoutube(c)
+	ctx := context.Background()
+
+	translator := mock.NewMockTranslator(gomock.NewController(c))
+	translator.EXPECT().GetLanguages(gomock.Any()).Return([]string{"de", "en", "fr"}, nil).AnyTimes()
+	a := autocc.New(s, translator, y)
+
+	// Only the oldest video wasn't processed, it's on the second page of the uploads.
+	localizations := []model.Localization{{Kind: model.LocalizationKindVideo, ResourceID: videoID, Language: "de"}}
+	for i := range 60 {
+		publishedAt := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC).AddDate(0, 0, i).Format(time.RFC3339)
+		id := f.AddVideo("channel", &yt.Video{Snippet: &yt.VideoSnippet{Title: "Video", DefaultLanguage: "en", PublishedAt: publishedAt}})
+		localizations = append(localizations, model.Localization{Kind: model.LocalizationKindVideo, ResourceID: id, Language: "de"})
+	}
+	oldestID := f.AddVideo("channel", &yt.Video{Snippet: &yt.VideoSnippet{Title: "Oldest", DefaultLanguage: "en", PublishedAt: "2023-01-01T00:00:00Z"}})
+	err := s.SaveLocalizations(ctx, localizations)
+	c.Assert(err, qt.IsNil)
+
+	videos, nextPageToken, err := a.ListVideos(ctx, "user", "channel", "", autocc.ListOptions{Unprocessed: true})
+	c.Assert(err, qt.IsNil)
+	c.Assert(videos, qt.HasLen, 1)
+	c.Assert(videos[0].Id, qt.Equals, oldestID)
+	c.Assert(nextPageToken, qt.Equals, "")
+
+	// Without filters a single page is listed.
+	videos, nextPageToken, err = a.ListVideos(ctx, "user", "channel", "", autocc.ListOptions{})
+	c.Assert(err, qt.IsNil)
+	c.Assert(videos, qt.HasLen, 50)
+	c.Assert(nextPageToken, qt.Not(qt.Equals), "")
+}
+
+func TestListVideosTrimmed(t *testing.T) {
+	c := qt.New(t)
+	f, s, y, videoID := setupYoutube(c)
+	ctx := context.Background()
+	a := autocc.New(s, fake.PrefixTranslator(c, "de", "en", "fr"), y)
+
+	// The 20 newest videos were processed, the rest of the first page of the uploads and the whole second one weren't.
+	localizations := []model.Localization{{Kind: model.LocalizationKindVideo, ResourceID: videoID, Language: "de"}}
+	for i := range 100 {
+		publishedAt := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC).AddDate(0, 0, i).Format(time.RFC3339)
+		id := f.AddVideo("channel", &yt.Video{Snippet: &yt.VideoSnippet{Title: "Video", DefaultLanguage: "en", PublishedAt: publishedAt}})
+		if i >= 80 {
+			localizations = append(localizations, model.Localization{Kind: model.LocalizationKindVideo, ResourceID: id, Language: "de"})
+		}
+	}
+	err := s.SaveLocalizations(ctx, localizations)
+	c.Assert(err, qt.IsNil)
+
+	// The page is filled from the second page of the uploads, the rest of it is returned with the next page.
+	listed := make(map[string]bool)
+	videos, nextPageToken, err := a.ListVideos(ctx, "user", "channel", "", autocc.ListOptions{Unprocessed: true})
+	c.Assert(err, qt.IsNil)
+	c.Assert(videos, qt.HasLen, 50)
+	c.Assert(nextPageToken, qt.Not(qt.Equals), "")
+	for _, video := range videos {
+		listed[video.Id] = true
+	}
+
+	videos, nextPageToken, err = a.ListVideos(ctx, "user", "channel", nextPageToken, autocc.ListOptions{Unprocessed: true})
+	c.Assert(err, qt.IsNil)
+	c.Assert(videos, qt.HasLen, 30)
+	c.Assert(nextPageToken, qt.Equals, "")
+	for _, video := range videos {
+		c.Assert(listed[video.Id], qt.IsFalse)
+		listed[video.Id] = true
+	}
+	c.Assert(listed, qt.HasLen, 80)
+
+	_, _, err = a.ListVideos(ctx, "user", "channel", "token:invalid", autocc.ListOptions{Unprocessed: true})
+	c.Assert(err, qt.Equals, errs.InvalidInput)
+}
+
+func TestListVideosMaxCaptions(t *testing.T) {
+	c := qt.New(t)
+	f, s, y, _ := setupYoutube(c)
+	ctx := context.Background()
+	a := autocc.New(s, fake.PrefixTranslator(c, "de", "en", "fr"), y)
+
+	for range 25 {
+		id := f.AddVideo("channel", &yt.Video{Snippet: &yt.VideoSnippet{Title: "Video", DefaultLanguage: "en", PublishedAt: "2024-01-01T00:00:00Z"}})
+		f.AddCaption(id, "en", "", fake.Srt)
+	}
+
+	// Closed captions of 20 videos are listed in a request, the others are kept because they might miss languages.
+	count := func(videos []*pb.Video) int {
+		unknown := 0
+		for _, video := range videos {
+			if video.HasCaptions && len(video.CaptionLanguages) == 0 {
+				c.Assert(video.MissingLanguages, qt.HasLen, 0)
+				unknown++
+			}
+		}
+		return unknown
+	}
+	videos, _, err := a.ListVideos(ctx, "user", "channel", "", autocc.ListOptions{MissingLanguages: true})
+	c.Assert(err, qt.IsNil)
+	c.Assert(videos, qt.HasLen, 26)
+	c.Assert(count(videos), qt.Equals, 5)
+
+	// Languages listed by the first request are cached, so the next one lists the rest.
+	videos, _, err = a.ListVideos(ctx, "user", "channel", "", autocc.ListOptions{MissingLanguages: true})
+	c.Assert(err, qt.IsNil)
+	c.Assert(videos, qt.HasLen, 26)
+	c.Assert(count(videos), qt.Equals, 0)
+}
+
+func TestRemoveTranslationMemory(t *testing.T) {
+	c := qt.New(t)
+	_, s, y, _ := setupYoutube(c)
+	ctx := context.Background()
+	a := autocc.New(s, nil, y)
+
+	err := s.SaveTranslationMemory(ctx, "en", "nb", map[string]string{"Hello": "Hei"})
+	c.Assert(err, qt.IsNil)
+	err = s.SaveTranslationMemory(ctx, "nb", "en", map[string]string{"Hei": "Hello"})
+	c.Assert(err, qt.IsNil)
+	err = s.SaveTranslationMemory(ctx, "en", "de", map[string]string{"Hello": "Hallo"})
+	c.Assert(err, qt.IsNil)
+
+	// Norwegian is given in the Google API format and in upper case.
+	removed, err := a.RemoveTranslationMemory(ctx, "NO")
+	c.Assert(err, qt.IsNil)
+	c.Assert(removed, qt.Equals, 2)
+
+	memory, err := s.GetTranslationMemory(ctx, "en", "de", []string{"Hello"})
+	c.Assert(err, qt.IsNil)
+	c.Assert(memory, qt.DeepEquals, map[string]string{"Hello": "Hallo"})
+
+	_, err = a.RemoveTranslationMemory(ctx, "")
+	c.Assert(err, qt.Equals, errs.InvalidInput)
+}
@@ -0,0 +1,221 @@
+package autocc
+
+import (
+	"cmp"
+	"context"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/pkulik0/autocc/api/internal/errs"
+	"github.com/pkulik0/autocc/api/internal/model"
+	"github.com/pkulik0/autocc/api/internal/pb"
+	"github.com/pkulik0/autocc/api/internal/translation"
+	"github.com/rs/zerolog/log"
+)
+
+// VideoSort is the order of listed videos.
+type VideoSort string
+
+const (
+	VideoSortNewest VideoSort = "newest"
+	VideoSortOldest VideoSort = "oldest"
+	VideoSortTitle  VideoSort = "title"
+	// VideoSortMissing lists videos with the most missing languages first.
+	VideoSortMissing VideoSort = "missing"
+)
+
+// IsValid returns true if the sort order is known.
+func (s VideoSort) IsValid() bool {
+	switch s {
+	case VideoSortNewest, VideoSortOldest, VideoSortTitle, VideoSortMissing:
+		return true
+	default:
+		return false
+	}
+}
+
+const (
+	// listPageSize is the number of videos a filtered page is filled up to, it's the size of a page of the uploads playlist.
+	listPageSize = 50
+	// listMaxPages is the maximum number of pages of the uploads playlist a filtered page is filled from, each of them costs quota.
+	listMaxPages = 10
+	// listMaxCaptions is the maximum number of videos whose closed captions are listed to find their missing languages in a request.
+	// Listing them costs 50 units of quota per video, languages found are cached for the following requests.
+	listMaxCaptions = 20
+	// listTokenSeparator separates the offset of a filtered page in the page of the uploads playlist from its page token.
+	listTokenSeparator = ":"
+)
+
+// ListOptions filter and sort the videos listed by ListVideos.
+type ListOptions struct {
+	// Unprocessed lists only videos without localizations authored by AutoCC.
+	Unprocessed bool
+	// MissingLanguages lists only videos without closed captions in some of the languages AutoCC translates to.
+	// Videos whose closed captions weren't listed within listMaxCaptions are kept, their missing languages are unknown.
+	MissingLanguages bool
+	// Sort is the order of the videos within the page, newest first if it's empty.
+	Sort VideoSort
+}
+
+// filtered returns true if the options leave out some of the videos.
+func (o ListOptions) filtered() bool {
+	return o.Unprocessed || o.MissingLanguages
+}
+
+// needsCaptions returns true if the options depend on the missing languages of the videos.
+func (o ListOptions) needsCaptions() bool {
+	return o.MissingLanguages || o.Sort == VideoSortMissing
+}
+
+// listToken returns the token of a page starting after offset filtered videos of the page of the uploads playlist.
+func listToken(pageToken string, offset int) string {
+	if offset == 0 {
+		return pageToken
+	}
+	return pageToken + listTokenSeparator + strconv.Itoa(offset)
+}
+
+// parseListToken returns the page token of the uploads playlist and the offset in its filtered videos, see listToken.
+func parseListToken(token string) (string, int, error) {
+	pageToken, offset, ok := strings.Cut(token, listTokenSeparator)
+	if !ok {
+		return token, 0, nil
+	}
+	n, err := strconv.Atoi(offset)
+	if err != nil || n <= 0 {
+		return "", 0, errs.InvalidInput
+	}
+	return pageToken, n, nil
+}
+
+func (a *autoCC) ListVideos(ctx context.Context, userID, channelID, nextPageToken string, options ListOptions) ([]*pb.Video, string, error) {
+	pageToken, offset, err := parseListToken(nextPageToken)
+	if err != nil {
+		return nil, "", err
+	}
+	languages, err := a.translator.GetLanguages(ctx)
+	if err != nil {
+		return nil, "", err
+	}
+
+	// Filters can leave out most of a page, so pages are listed until there are enough videos or the channel has no more.
+	filtered := make([]*pb.Video, 0, listPageSize)
+	listed, maxCaptions := 0, listMaxCaptions
+	for pages := 0; pages < listMaxPages; pages++ {
+		videos, next, err := a.youtube.GetVideos(ctx, userID, channelID, pageToken)
+		if err != nil {
+			return nil, "", err
+		}
+		listed += len(videos)
+
+		if options.needsCaptions() && maxCaptions > 0 {
+			n, err := a.youtube.ListCaptionLanguages(ctx, userID, channelID, videos, maxCaptions)
+			if err != nil {
+				return nil, "", err
+			}
+			maxCaptions -= n
+		}
+		videos, err = a.filterVideos(ctx, videos, languages, options)
+		if err != nil {
+			return nil, "", err
+		}
+		// Videos before the offset were returned with the previous page.
+		videos = videos[min(offset, len(videos)):]
+
+		// The rest of the page of the uploads playlist is returned with the next page, which lists it again.
+		if remaining := listPageSize - len(filtered); len(videos) > remaining {
+			filtered = append(filtered, videos[:remaining]...)
+			pageToken = listToken(pageToken, offset+remaining)
+			break
+		}
+		filtered = append(filtered, videos...)
+		pageToken, offset = next, 0
+
+		if !options.filtered() || len(filtered) == listPageSize || pageToken == "" {
+			break
+		}
+	}
+	sortVideos(filtered, options.Sort)
+
+	log.Debug().Str("channel_id", channelID).Int("videos", listed).Int("filtered", len(filtered)).Msg("listed videos")
+	return filtered, pageToken, nil
+}
+
+// filterVideos sets the processing state of the videos and returns the ones matching the options.
+func (a *autoCC) filterVideos(ctx context.Context, videos []*pb.Video, languages []string, options ListOptions) ([]*pb.Video, error) {
+	if len(videos) == 0 {
+		return videos, nil
+	}
+
+	ids := make([]string, len(videos))
+	for i, video := range videos {
+		ids[i] = video.Id
+	}
+	localizations, err := a.store.GetLocalizations(ctx, model.LocalizationKindVideo, ids...)
+	if err != nil {
+		return nil, err
+	}
+	processed := make(map[string]bool)
+	for _, localization := range localizations {
+		processed[localization.ResourceID] = true
+	}
+
+	filtered := make([]*pb.Video, 0, len(videos))
+	for _, video := range videos {
+		video.Processed = processed[video.Id]
+		if !captionLanguagesKnown(video) {
+			// The closed captions weren't listed, see listMaxCaptions. The video might miss languages, so it isn't left out.
+			video.MissingLanguages = nil
+		} else {
+			video.MissingLanguages = missingLanguages(video, languages)
+			if options.MissingLanguages && len(video.MissingLanguages) == 0 {
+				continue
+			}
+		}
+
+		if options.Unprocessed && video.Processed {
+			continue
+		}
+		filtered = append(filtered, video)
+	}
+	return filtered, nil
+}
+
+// captionLanguagesKnown returns true if the video has no closed captions or their languages were listed.
+func captionLanguagesKnown(video *pb.Video) bool {
+	return !video.HasCaptions || len(video.CaptionLanguages) > 0
+}
+
+// missingLanguages returns the languages AutoCC translates to without closed captions of the video, in the Google API format.
+func missingLanguages(video *pb.Video, languages []string) []string {
+	var missing []string
+	for _, language := range languages {
+		language = translation.CodeTranslationToGoogle(language)
+		if strings.EqualFold(language, video.Language) {
+			continue
+		}
+		if slices.ContainsFunc(video.CaptionLanguages, func(l string) bool { return strings.EqualFold(l, language) }) {
+			continue
+		}
+		missing = append(missing, language)
+	}
+	return missing
+}
+
+// sortVideos sorts a page of videos, the uploads playlist can't be listed in another order so pages aren't sorted across.
+func sortVideos(videos []*pb.Video, sort VideoSort) {
+	// Videos are listed newest first, so it's the order they're in already.
+	switch sort {
+	case VideoSortOldest:
+		slices.Reverse(videos)
+	case VideoSortTitle:
+		slices.SortStableFunc(videos, func(a, b *pb.Video) int {
+			return strings.Compare(strings.ToLower(a.Title), strings.ToLower(b.Title))
+		})
+	case VideoSortMissing:
+		slices.SortStableFunc(videos, func(a, b *pb.Video) int {
+			return cmp.Compare(len(b.MissingLanguages), len(a.MissingLanguages))
+		})
+	}
+}
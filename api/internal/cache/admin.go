@@ -0,0 +1,98 @@
+package cache
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+)
+
+// IsValid returns true if the namespace is known.
+func (n Namespace) IsValid() bool {
+	switch n {
+	case NamespaceTranslation, NamespaceUpload, NamespaceCaptions:
+		return true
+	default:
+		return false
+	}
+}
+
+func namespaceOf(key string) Namespace {
+	namespace, _, _ := strings.Cut(key, ":")
+	return Namespace(namespace)
+}
+
+// Stats holds the numbers of cache hits and misses.
+type Stats struct {
+	Hits   uint64
+	Misses uint64
+}
+
+// KeyInfo describes a key held in the cache.
+type KeyInfo struct {
+	Key string
+	// TTL is the remaining time to live of the key, zero if it doesn't expire.
+	TTL time.Duration
+}
+
+// counters keeps hit and miss counts of a single cache instance, per namespace.
+type counters struct {
+	mu    sync.Mutex
+	stats map[Namespace]Stats
+}
+
+func (c *counters) record(key string, hit bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.stats == nil {
+		c.stats = make(map[Namespace]Stats)
+	}
+	stats := c.stats[namespaceOf(key)]
+	if hit {
+		stats.Hits++
+	} else {
+		stats.Misses++
+	}
+	c.stats[namespaceOf(key)] = stats
+}
+
+func (c *counters) snapshot() map[Namespace]Stats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	stats := make(map[Namespace]Stats, len(c.stats))
+	for namespace, s := range c.stats {
+		stats[namespace] = s
+	}
+	return stats
+}
+
+// TagVideo returns the tag of keys related to a video.
+func TagVideo(videoID string) string {
+	return "video:" + videoID
+}
+
+// TagUser returns the tag of keys related to a user.
+func TagUser(userID string) string {
+	return "user:" + userID
+}
+
+// TagLanguage returns the tag of keys related to a language.
+// Language codes are case-insensitive, translations and uploads use them in different cases.
+func TagLanguage(language string) string {
+	return "language:" + strings.ToLower(language)
+}
+
+type tagsKey struct{}
+
+// WithTags returns a context carrying tags which should be added to keys set while handling it.
+func WithTags(ctx context.Context, tags ...string) context.Context {
+	return context.WithValue(ctx, tagsKey{}, append(TagsFromContext(ctx), tags...))
+}
+
+// TagsFromContext returns tags added to the context with WithTags.
+func TagsFromContext(ctx context.Context) []string {
+	tags, _ := ctx.Value(tagsKey{}).([]string)
+	return tags[:len(tags):len(tags)]
+}
@@ -2,8 +2,11 @@ package cache
 
 import (
 	"context"
+	"crypto/rand"
 	"crypto/sha256"
+	"encoding/binary"
 	"encoding/hex"
+	"fmt"
 	"time"
 
 	"github.com/pkulik0/autocc/api/internal/errs"
@@ -11,13 +14,34 @@ import (
 	"github.com/rs/zerolog/log"
 )
 
-// CreateKey creates a cache key from the given strings.
-func CreateKey(strs ...string) string {
+// Namespace is the prefix of keys holding one kind of values.
+type Namespace string
+
+const (
+	// NamespaceTranslation holds translated text.
+	NamespaceTranslation Namespace = "translation"
+	// NamespaceUpload holds IDs of uploaded closed captions.
+	NamespaceUpload Namespace = "upload"
+	// NamespaceCaptions holds languages of closed captions of videos.
+	NamespaceCaptions Namespace = "captions"
+)
+
+// keySchemaVersion is bumped whenever the way keys are derived or values are stored changes.
+// Keys of other versions are never read and expire on their own.
+// Version 1 keys were bare hashes without a namespace.
+const keySchemaVersion = "v2"
+
+// CreateKey creates a cache key in the namespace from the given strings.
+// Each string is prefixed with its length so different splits of the same text don't collide.
+func CreateKey(namespace Namespace, strs ...string) string {
 	hash := sha256.New()
 	for _, s := range strs {
+		var length [8]byte
+		binary.BigEndian.PutUint64(length[:], uint64(len(s)))
+		hash.Write(length[:])
 		hash.Write([]byte(s))
 	}
-	return hex.EncodeToString(hash.Sum(nil))
+	return fmt.Sprintf("%s:%s:%s", namespace, keySchemaVersion, hex.EncodeToString(hash.Sum(nil)))
 }
 
 // Cache is the interface that wraps cache methods.
@@ -36,12 +60,29 @@ type Cache interface {
 
 	// Del deletes the value for the key.
 	Del(ctx context.Context, key string) error
+
+	// Lock waits until it acquires an exclusive lock on the key, shared between all instances using the cache.
+	// The lock is released by calling the returned function or after the expiration passes.
+	Lock(ctx context.Context, key string, expiration time.Duration) (func() error, error)
+
+	// Tag associates the key with tags, so it can be invalidated together with other keys sharing a tag.
+	Tag(ctx context.Context, key string, tags []string, expiration time.Duration) error
+	// Invalidate deletes all keys associated with the tag and returns their count.
+	Invalidate(ctx context.Context, tag string) (int, error)
+	// Keys returns keys in the namespace with their time to live.
+	Keys(ctx context.Context, namespace Namespace) ([]KeyInfo, error)
+	// Stats returns the numbers of hits and misses per namespace since the cache was created.
+	Stats(ctx context.Context) (map[Namespace]Stats, error)
 }
 
+// Interval between attempts to acquire a taken lock.
+const lockRetryInterval = time.Millisecond * 100
+
 var _ Cache = &redisCache{}
 
 type redisCache struct {
-	client *redis.Client
+	client   *redis.Client
+	counters counters
 }
 
 // New creates a new cache service.
@@ -61,25 +102,29 @@ func New(ctx context.Context, addr string) (*redisCache, error) {
 
 func (c *redisCache) Get(ctx context.Context, key string) (string, error) {
 	value, err := c.client.Get(ctx, key).Result()
-	if err != nil {
+	switch err {
+	case nil:
+		c.counters.record(key, true)
+	case redis.Nil:
+		c.counters.record(key, false)
+		return "", errs.NotFound
+	default:
 		return "", err
 	}
 	return value, nil
 }
 
 func (c *redisCache) GetList(ctx context.Context, key string) ([]string, error) {
-	exists, err := c.client.Exists(ctx, key).Result()
+	// Redis doesn't keep empty lists, so a single LRANGE tells apart missing keys without racing with writes.
+	value, err := c.client.LRange(ctx, key, 0, -1).Result()
 	if err != nil {
 		return nil, err
 	}
-	if exists == 0 {
+	if len(value) == 0 {
+		c.counters.record(key, false)
 		return nil, errs.NotFound
 	}
-
-	value, err := c.client.LRange(ctx, key, 0, -1).Result()
-	if err != nil {
-		return nil, err
-	}
+	c.counters.record(key, true)
 	return value, nil
 }
 
@@ -92,14 +137,21 @@ func (c *redisCache) Set(ctx context.Context, key, value string, expiration time
 }
 
 func (c *redisCache) SetList(ctx context.Context, key string, value []string, expiration time.Duration) error {
-	err := c.client.RPush(ctx, key, value).Err()
+	// The list is replaced in a MULTI/EXEC transaction so concurrent writers never append to or interleave with each other.
+	_, err := c.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.Del(ctx, key)
+		if len(value) == 0 {
+			return nil
+		}
+		pipe.RPush(ctx, key, value)
+		if expiration > 0 {
+			pipe.Expire(ctx, key, expiration)
+		}
+		return nil
+	})
 	if err != nil {
 		return err
 	}
-	err = c.client.Expire(ctx, key, expiration).Err()
-	if err != nil {
-		log.Warn().Err(err).Str("key", key).Msg("failed to set expiration for key")
-	}
 	return nil
 }
 
@@ -110,3 +162,117 @@ func (c *redisCache) Del(ctx context.Context, key string) error {
 	}
 	return nil
 }
+
+// unlockScript deletes the lock only if it's still held by the caller.
+var unlockScript = redis.NewScript(`
+if redis.call("GET", KEYS[1]) == ARGV[1] then
+	return redis.call("DEL", KEYS[1])
+end
+return 0
+`)
+
+func (c *redisCache) Lock(ctx context.Context, key string, expiration time.Duration) (func() error, error) {
+	lockKey := "lock:" + key
+	token := make([]byte, 16)
+	if _, err := rand.Read(token); err != nil {
+		return nil, err
+	}
+	value := hex.EncodeToString(token)
+
+	for {
+		ok, err := c.client.SetNX(ctx, lockKey, value, expiration).Result()
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			break
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(lockRetryInterval):
+		}
+	}
+
+	unlock := func() error {
+		// The caller's context might be done by now, the lock should be released anyway.
+		ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), time.Second*10)
+		defer cancel()
+		return unlockScript.Run(ctx, c.client, []string{lockKey}, value).Err()
+	}
+	return unlock, nil
+}
+
+func (c *redisCache) Tag(ctx context.Context, key string, tags []string, expiration time.Duration) error {
+	_, err := c.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		for _, tag := range tags {
+			pipe.SAdd(ctx, "tag:"+tag, key)
+			if expiration > 0 {
+				pipe.Expire(ctx, "tag:"+tag, expiration)
+			}
+		}
+		return nil
+	})
+	return err
+}
+
+func (c *redisCache) Invalidate(ctx context.Context, tag string) (int, error) {
+	keys, err := c.client.SMembers(ctx, "tag:"+tag).Result()
+	if err != nil {
+		return 0, err
+	}
+
+	count := 0
+	if len(keys) > 0 {
+		deleted, err := c.client.Del(ctx, keys...).Result()
+		if err != nil {
+			return 0, err
+		}
+		count = int(deleted)
+	}
+
+	if err := c.client.Del(ctx, "tag:"+tag).Err(); err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+func (c *redisCache) Keys(ctx context.Context, namespace Namespace) ([]KeyInfo, error) {
+	var keys []string
+	iter := c.client.Scan(ctx, 0, string(namespace)+":"+keySchemaVersion+":*", 100).Iterator()
+	for iter.Next(ctx) {
+		keys = append(keys, iter.Val())
+	}
+	if err := iter.Err(); err != nil {
+		return nil, err
+	}
+
+	cmds, err := c.client.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		for _, key := range keys {
+			pipe.PTTL(ctx, key)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	infos := make([]KeyInfo, 0, len(keys))
+	for i, key := range keys {
+		ttl := cmds[i].(*redis.DurationCmd).Val()
+		switch {
+		case ttl == -2:
+			// The key expired in the meantime.
+			continue
+		case ttl < 0:
+			ttl = 0
+		}
+		infos = append(infos, KeyInfo{Key: key, TTL: ttl})
+	}
+	return infos, nil
+}
+
+func (c *redisCache) Stats(ctx context.Context) (map[Namespace]Stats, error) {
+	return c.counters.snapshot(), nil
+}
@@ -0,0 +1,23 @@
+package cache_test
+
+import (
+	"strings"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+
+	"github.com/pkulik0/autocc/api/internal/cache"
+)
+
+func TestCreateKey(t *testing.T) {
+	c := qt.New(t)
+
+	key := cache.CreateKey(cache.NamespaceTranslation, "ab", "c")
+	c.Assert(strings.HasPrefix(key, "translation:v2:"), qt.IsTrue)
+	c.Assert(key, qt.Equals, cache.CreateKey(cache.NamespaceTranslation, "ab", "c"))
+
+	c.Assert(key, qt.Not(qt.Equals), cache.CreateKey(cache.NamespaceTranslation, "a", "bc"))
+	c.Assert(key, qt.Not(qt.Equals), cache.CreateKey(cache.NamespaceTranslation, "abc"))
+	c.Assert(key, qt.Not(qt.Equals), cache.CreateKey(cache.NamespaceTranslation, "ab", "c", ""))
+	c.Assert(key, qt.Not(qt.Equals), cache.CreateKey(cache.NamespaceUpload, "ab", "c"))
+}
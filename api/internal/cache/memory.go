@@ -0,0 +1,257 @@
+package cache
+
+import (
+	"container/list"
+	"context"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+
+	"github.com/pkulik0/autocc/api/internal/errs"
+)
+
+var _ Cache = &memoryCache{}
+
+type memoryEntry struct {
+	key       string
+	value     string
+	list      []string
+	isList    bool
+	expiresAt time.Time
+}
+
+func (e *memoryEntry) expired(now time.Time) bool {
+	return !e.expiresAt.IsZero() && now.After(e.expiresAt)
+}
+
+type memoryCache struct {
+	mu         sync.Mutex
+	maxEntries int
+	// order holds entries from the most to the least recently used.
+	order   *list.List
+	entries map[string]*list.Element
+	// locks holds channels closed when the lock on the key is released.
+	locks map[string]chan struct{}
+	// tags holds sets of keys associated with each tag.
+	tags map[string]map[string]struct{}
+	// keyTags holds the tags of each key, so the key is removed from their sets when it's evicted.
+	keyTags  map[string][]string
+	counters counters
+}
+
+// NewMemory creates a new in-process LRU cache holding up to maxEntries keys.
+// The least recently used keys are evicted when the cache is full.
+func NewMemory(maxEntries int) *memoryCache {
+	log.Debug().Int("max_entries", maxEntries).Msg("created new memory cache instance")
+	return &memoryCache{
+		maxEntries: maxEntries,
+		order:      list.New(),
+		entries:    make(map[string]*list.Element),
+		locks:      make(map[string]chan struct{}),
+		tags:       make(map[string]map[string]struct{}),
+		keyTags:    make(map[string][]string),
+	}
+}
+
+func (c *memoryCache) get(key string) (*memoryEntry, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	element, ok := c.entries[key]
+	if !ok {
+		return nil, errs.NotFound
+	}
+	entry := element.Value.(*memoryEntry)
+	if entry.expired(time.Now()) {
+		c.remove(element)
+		return nil, errs.NotFound
+	}
+
+	c.order.MoveToFront(element)
+	return entry, nil
+}
+
+func (c *memoryCache) set(entry *memoryEntry, expiration time.Duration) {
+	if expiration > 0 {
+		entry.expiresAt = time.Now().Add(expiration)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if element, ok := c.entries[entry.key]; ok {
+		element.Value = entry
+		c.order.MoveToFront(element)
+		return
+	}
+
+	c.entries[entry.key] = c.order.PushFront(entry)
+	for c.maxEntries > 0 && c.order.Len() > c.maxEntries {
+		c.remove(c.order.Back())
+	}
+}
+
+func (c *memoryCache) remove(element *list.Element) {
+	key := element.Value.(*memoryEntry).key
+	c.order.Remove(element)
+	delete(c.entries, key)
+
+	for _, tag := range c.keyTags[key] {
+		delete(c.tags[tag], key)
+		if len(c.tags[tag]) == 0 {
+			delete(c.tags, tag)
+		}
+	}
+	delete(c.keyTags, key)
+}
+
+func (c *memoryCache) Get(ctx context.Context, key string) (string, error) {
+	entry, err := c.get(key)
+	c.counters.record(key, err == nil)
+	if err != nil {
+		return "", err
+	}
+	if entry.isList {
+		return "", errs.InvalidInput
+	}
+	return entry.value, nil
+}
+
+func (c *memoryCache) GetList(ctx context.Context, key string) ([]string, error) {
+	entry, err := c.get(key)
+	c.counters.record(key, err == nil)
+	if err != nil {
+		return nil, err
+	}
+	if !entry.isList {
+		return nil, errs.InvalidInput
+	}
+	return slices.Clone(entry.list), nil
+}
+
+func (c *memoryCache) Set(ctx context.Context, key, value string, expiration time.Duration) error {
+	c.set(&memoryEntry{key: key, value: value}, expiration)
+	return nil
+}
+
+func (c *memoryCache) SetList(ctx context.Context, key string, value []string, expiration time.Duration) error {
+	c.set(&memoryEntry{key: key, list: slices.Clone(value), isList: true}, expiration)
+	return nil
+}
+
+func (c *memoryCache) Del(ctx context.Context, key string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if element, ok := c.entries[key]; ok {
+		c.remove(element)
+	}
+	return nil
+}
+
+func (c *memoryCache) Lock(ctx context.Context, key string, expiration time.Duration) (func() error, error) {
+	for {
+		c.mu.Lock()
+		released, ok := c.locks[key]
+		if !ok {
+			break
+		}
+		c.mu.Unlock()
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-released:
+		}
+	}
+	defer c.mu.Unlock()
+
+	released := make(chan struct{})
+	c.locks[key] = released
+
+	var once sync.Once
+	release := func() {
+		once.Do(func() {
+			c.mu.Lock()
+			defer c.mu.Unlock()
+
+			delete(c.locks, key)
+			close(released)
+		})
+	}
+	if expiration > 0 {
+		timer := time.AfterFunc(expiration, release)
+		return func() error {
+			timer.Stop()
+			release()
+			return nil
+		}, nil
+	}
+	return func() error {
+		release()
+		return nil
+	}, nil
+}
+
+func (c *memoryCache) Tag(ctx context.Context, key string, tags []string, expiration time.Duration) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	// Tags live as long as the key, which is removed from them when it's evicted, expired or invalidated.
+	if _, ok := c.entries[key]; !ok {
+		return nil
+	}
+	for _, tag := range tags {
+		if c.tags[tag] == nil {
+			c.tags[tag] = make(map[string]struct{})
+		}
+		if _, ok := c.tags[tag][key]; ok {
+			continue
+		}
+		c.tags[tag][key] = struct{}{}
+		c.keyTags[key] = append(c.keyTags[key], tag)
+	}
+	return nil
+}
+
+func (c *memoryCache) Invalidate(ctx context.Context, tag string) (int, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	count := 0
+	for key := range c.tags[tag] {
+		c.remove(c.entries[key])
+		count++
+	}
+	return count, nil
+}
+
+func (c *memoryCache) Keys(ctx context.Context, namespace Namespace) ([]KeyInfo, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	prefix := string(namespace) + ":" + keySchemaVersion + ":"
+	now := time.Now()
+
+	var infos []KeyInfo
+	for element := c.order.Front(); element != nil; element = element.Next() {
+		entry := element.Value.(*memoryEntry)
+		if !strings.HasPrefix(entry.key, prefix) || entry.expired(now) {
+			continue
+		}
+
+		var ttl time.Duration
+		if !entry.expiresAt.IsZero() {
+			ttl = entry.expiresAt.Sub(now)
+		}
+		infos = append(infos, KeyInfo{Key: entry.key, TTL: ttl})
+	}
+	return infos, nil
+}
+
+func (c *memoryCache) Stats(ctx context.Context) (map[Namespace]Stats, error) {
+	return c.counters.snapshot(), nil
+}
@@ -0,0 +1,211 @@
+package cache_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	qt "github.com/frankban/quicktest"
+
+	"github.com/pkulik0/autocc/api/internal/cache"
+	"github.com/pkulik0/autocc/api/internal/errs"
+)
+
+func TestMemory(t *testing.T) {
+	c := qt.New(t)
+	ctx := context.Background()
+	m := cache.NewMemory(2)
+
+	_, err := m.Get(ctx, "a")
+	c.Assert(err, qt.Equals, errs.NotFound)
+
+	err = m.Set(ctx, "a", "1", 0)
+	c.Assert(err, qt.IsNil)
+	err = m.SetList(ctx, "b", []string{"2", "3"}, 0)
+	c.Assert(err, qt.IsNil)
+
+	_, err = m.GetList(ctx, "a")
+	c.Assert(err, qt.Equals, errs.InvalidInput)
+
+	value, err := m.Get(ctx, "a")
+	c.Assert(err, qt.IsNil)
+	c.Assert(value, qt.Equals, "1")
+	list, err := m.GetList(ctx, "b")
+	c.Assert(err, qt.IsNil)
+	c.Assert(list, qt.DeepEquals, []string{"2", "3"})
+
+	// "a" is the least recently used key after reading "b".
+	err = m.Set(ctx, "c", "4", 0)
+	c.Assert(err, qt.IsNil)
+	_, err = m.Get(ctx, "a")
+	c.Assert(err, qt.Equals, errs.NotFound)
+	_, err = m.GetList(ctx, "b")
+	c.Assert(err, qt.IsNil)
+
+	err = m.Del(ctx, "b")
+	c.Assert(err, qt.IsNil)
+	_, err = m.GetList(ctx, "b")
+	c.Assert(err, qt.Equals, errs.NotFound)
+
+	err = m.Set(ctx, "d", "5", time.Millisecond)
+	c.Assert(err, qt.IsNil)
+	time.Sleep(time.Millisecond * 5)
+	_, err = m.Get(ctx, "d")
+	c.Assert(err, qt.Equals, errs.NotFound)
+}
+
+func TestTiered(t *testing.T) {
+	c := qt.New(t)
+	ctx := context.Background()
+	l1, l2 := cache.NewMemory(10), cache.NewMemory(10)
+	tiered := cache.NewTiered(l1, l2, time.Minute)
+
+	err := tiered.Set(ctx, "a", "1", time.Hour)
+	c.Assert(err, qt.IsNil)
+	value, err := l1.Get(ctx, "a")
+	c.Assert(err, qt.IsNil)
+	c.Assert(value, qt.Equals, "1")
+	value, err = l2.Get(ctx, "a")
+	c.Assert(err, qt.IsNil)
+	c.Assert(value, qt.Equals, "1")
+
+	// Values missing in l1 are read through from l2.
+	err = l2.SetList(ctx, "b", []string{"2"}, time.Hour)
+	c.Assert(err, qt.IsNil)
+	list, err := tiered.GetList(ctx, "b")
+	c.Assert(err, qt.IsNil)
+	c.Assert(list, qt.DeepEquals, []string{"2"})
+	list, err = l1.GetList(ctx, "b")
+	c.Assert(err, qt.IsNil)
+	c.Assert(list, qt.DeepEquals, []string{"2"})
+
+	err = tiered.Del(ctx, "a")
+	c.Assert(err, qt.IsNil)
+	_, err = tiered.Get(ctx, "a")
+	c.Assert(err, qt.Equals, errs.NotFound)
+	_, err = l2.Get(ctx, "a")
+	c.Assert(err, qt.Equals, errs.NotFound)
+}
+
+func TestMemoryLock(t *testing.T) {
+	c := qt.New(t)
+	ctx := context.Background()
+	m := cache.NewMemory(10)
+
+	unlock, err := m.Lock(ctx, "a", time.Minute)
+	c.Assert(err, qt.IsNil)
+
+	timeoutCtx, cancel := context.WithTimeout(ctx, time.Millisecond*10)
+	defer cancel()
+	_, err = m.Lock(timeoutCtx, "a", time.Minute)
+	c.Assert(err, qt.Equals, context.DeadlineExceeded)
+
+	acquired := make(chan struct{})
+	go func() {
+		unlock, err := m.Lock(ctx, "a", time.Minute)
+		c.Check(err, qt.IsNil)
+		c.Check(unlock(), qt.IsNil)
+		close(acquired)
+	}()
+
+	err = unlock()
+	c.Assert(err, qt.IsNil)
+	<-acquired
+
+	// The lock is released after it expires.
+	_, err = m.Lock(ctx, "a", time.Millisecond)
+	c.Assert(err, qt.IsNil)
+	unlock, err = m.Lock(ctx, "a", time.Minute)
+	c.Assert(err, qt.IsNil)
+	c.Assert(unlock(), qt.IsNil)
+}
+
+func TestMemoryAdmin(t *testing.T) {
+	c := qt.New(t)
+	ctx := context.Background()
+	m := cache.NewMemory(10)
+
+	translation := cache.CreateKey(cache.NamespaceTranslation, "a")
+	upload := cache.CreateKey(cache.NamespaceUpload, "b")
+
+	err := m.SetList(ctx, translation, []string{"1"}, 0)
+	c.Assert(err, qt.IsNil)
+	err = m.Tag(ctx, translation, []string{cache.TagUser("u")}, 0)
+	c.Assert(err, qt.IsNil)
+	err = m.Set(ctx, upload, "2", time.Hour)
+	c.Assert(err, qt.IsNil)
+
+	_, err = m.Get(ctx, upload)
+	c.Assert(err, qt.IsNil)
+	_, err = m.Get(ctx, cache.CreateKey(cache.NamespaceUpload, "c"))
+	c.Assert(err, qt.Equals, errs.NotFound)
+
+	stats, err := m.Stats(ctx)
+	c.Assert(err, qt.IsNil)
+	c.Assert(stats, qt.DeepEquals, map[cache.Namespace]cache.Stats{cache.NamespaceUpload: {Hits: 1, Misses: 1}})
+
+	keys, err := m.Keys(ctx, cache.NamespaceTranslation)
+	c.Assert(err, qt.IsNil)
+	c.Assert(keys, qt.DeepEquals, []cache.KeyInfo{{Key: translation}})
+	keys, err = m.Keys(ctx, cache.NamespaceUpload)
+	c.Assert(err, qt.IsNil)
+	c.Assert(keys, qt.HasLen, 1)
+	c.Assert(keys[0].TTL > 0 && keys[0].TTL <= time.Hour, qt.IsTrue)
+
+	removed, err := m.Invalidate(ctx, cache.TagUser("u"))
+	c.Assert(err, qt.IsNil)
+	c.Assert(removed, qt.Equals, 1)
+	_, err = m.GetList(ctx, translation)
+	c.Assert(err, qt.Equals, errs.NotFound)
+
+	removed, err = m.Invalidate(ctx, cache.TagUser("u"))
+	c.Assert(err, qt.IsNil)
+	c.Assert(removed, qt.Equals, 0)
+}
+
+func TestMemoryTagEviction(t *testing.T) {
+	c := qt.New(t)
+	ctx := context.Background()
+	m := cache.NewMemory(1)
+
+	// Keys leave their tags when they're evicted, so a key set again without tags isn't invalidated.
+	err := m.Set(ctx, "a", "1", 0)
+	c.Assert(err, qt.IsNil)
+	err = m.Tag(ctx, "a", []string{"tag"}, 0)
+	c.Assert(err, qt.IsNil)
+	err = m.Set(ctx, "b", "2", 0)
+	c.Assert(err, qt.IsNil)
+	err = m.Set(ctx, "a", "3", 0)
+	c.Assert(err, qt.IsNil)
+
+	removed, err := m.Invalidate(ctx, "tag")
+	c.Assert(err, qt.IsNil)
+	c.Assert(removed, qt.Equals, 0)
+	value, err := m.Get(ctx, "a")
+	c.Assert(err, qt.IsNil)
+	c.Assert(value, qt.Equals, "3")
+
+	// Expired keys leave their tags as well.
+	err = m.Set(ctx, "a", "4", time.Millisecond)
+	c.Assert(err, qt.IsNil)
+	err = m.Tag(ctx, "a", []string{"tag"}, time.Millisecond)
+	c.Assert(err, qt.IsNil)
+	time.Sleep(time.Millisecond * 5)
+	_, err = m.Get(ctx, "a")
+	c.Assert(err, qt.Equals, errs.NotFound)
+	err = m.Set(ctx, "a", "5", 0)
+	c.Assert(err, qt.IsNil)
+
+	removed, err = m.Invalidate(ctx, "tag")
+	c.Assert(err, qt.IsNil)
+	c.Assert(removed, qt.Equals, 0)
+
+	// Keys which aren't cached aren't tagged.
+	err = m.Tag(ctx, "missing", []string{"tag"}, 0)
+	c.Assert(err, qt.IsNil)
+	err = m.Set(ctx, "missing", "6", 0)
+	c.Assert(err, qt.IsNil)
+	removed, err = m.Invalidate(ctx, "tag")
+	c.Assert(err, qt.IsNil)
+	c.Assert(removed, qt.Equals, 0)
+}
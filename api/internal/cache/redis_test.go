@@ -0,0 +1,173 @@
+package cache_test
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	qt "github.com/frankban/quicktest"
+
+	"github.com/pkulik0/autocc/api/internal/cache"
+	"github.com/pkulik0/autocc/api/internal/errs"
+)
+
+func setupRedis(c *qt.C) (*miniredis.Miniredis, cache.Cache) {
+	mr := miniredis.RunT(c)
+	r, err := cache.New(context.Background(), mr.Addr())
+	c.Assert(err, qt.IsNil)
+	return mr, r
+}
+
+func TestRedis(t *testing.T) {
+	c := qt.New(t)
+	ctx := context.Background()
+	mr, r := setupRedis(c)
+
+	_, err := r.Get(ctx, "a")
+	c.Assert(err, qt.Equals, errs.NotFound)
+
+	err = r.Set(ctx, "a", "1", time.Hour)
+	c.Assert(err, qt.IsNil)
+	value, err := r.Get(ctx, "a")
+	c.Assert(err, qt.IsNil)
+	c.Assert(value, qt.Equals, "1")
+	c.Assert(mr.TTL("a"), qt.Equals, time.Hour)
+
+	err = r.Del(ctx, "a")
+	c.Assert(err, qt.IsNil)
+	_, err = r.Get(ctx, "a")
+	c.Assert(err, qt.Equals, errs.NotFound)
+}
+
+func TestRedisList(t *testing.T) {
+	c := qt.New(t)
+	ctx := context.Background()
+	mr, r := setupRedis(c)
+
+	_, err := r.GetList(ctx, "a")
+	c.Assert(err, qt.Equals, errs.NotFound)
+
+	err = r.SetList(ctx, "a", []string{"1", "2"}, time.Hour)
+	c.Assert(err, qt.IsNil)
+	c.Assert(mr.TTL("a"), qt.Equals, time.Hour)
+
+	// Setting the list again replaces it instead of appending.
+	err = r.SetList(ctx, "a", []string{"3"}, time.Minute)
+	c.Assert(err, qt.IsNil)
+	value, err := r.GetList(ctx, "a")
+	c.Assert(err, qt.IsNil)
+	c.Assert(value, qt.DeepEquals, []string{"3"})
+	c.Assert(mr.TTL("a"), qt.Equals, time.Minute)
+
+	err = r.SetList(ctx, "a", nil, time.Minute)
+	c.Assert(err, qt.IsNil)
+	_, err = r.GetList(ctx, "a")
+	c.Assert(err, qt.Equals, errs.NotFound)
+
+	mr.FastForward(time.Hour)
+	_, err = r.GetList(ctx, "a")
+	c.Assert(err, qt.Equals, errs.NotFound)
+}
+
+func TestRedisListConcurrent(t *testing.T) {
+	c := qt.New(t)
+	ctx := context.Background()
+	_, r := setupRedis(c)
+
+	lists := make([][]string, 10)
+	for i := range lists {
+		for j := 0; j < 10; j++ {
+			lists[i] = append(lists[i], fmt.Sprintf("%d-%d", i, j))
+		}
+	}
+
+	var wg sync.WaitGroup
+	for _, list := range lists {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			err := r.SetList(ctx, "a", list, time.Hour)
+			c.Check(err, qt.IsNil)
+		}()
+	}
+	wg.Wait()
+
+	value, err := r.GetList(ctx, "a")
+	c.Assert(err, qt.IsNil)
+	c.Assert(lists, qt.Any(qt.DeepEquals), value)
+}
+
+func TestRedisLock(t *testing.T) {
+	c := qt.New(t)
+	ctx := context.Background()
+	mr, r := setupRedis(c)
+
+	unlock, err := r.Lock(ctx, "a", time.Minute)
+	c.Assert(err, qt.IsNil)
+	c.Assert(mr.Exists("lock:a"), qt.IsTrue)
+
+	timeoutCtx, cancel := context.WithTimeout(ctx, time.Millisecond*200)
+	defer cancel()
+	_, err = r.Lock(timeoutCtx, "a", time.Minute)
+	c.Assert(err, qt.Equals, context.DeadlineExceeded)
+
+	err = unlock()
+	c.Assert(err, qt.IsNil)
+	c.Assert(mr.Exists("lock:a"), qt.IsFalse)
+
+	// An expired lock can be taken over and the previous holder doesn't release it.
+	_, err = r.Lock(ctx, "a", time.Minute)
+	c.Assert(err, qt.IsNil)
+	mr.FastForward(time.Minute)
+	unlock, err = r.Lock(ctx, "a", time.Minute)
+	c.Assert(err, qt.IsNil)
+	err = unlock()
+	c.Assert(err, qt.IsNil)
+}
+
+func TestRedisAdmin(t *testing.T) {
+	c := qt.New(t)
+	ctx := context.Background()
+	_, r := setupRedis(c)
+
+	translation := cache.CreateKey(cache.NamespaceTranslation, "a")
+	upload := cache.CreateKey(cache.NamespaceUpload, "b")
+
+	_, err := r.GetList(ctx, translation)
+	c.Assert(err, qt.Equals, errs.NotFound)
+
+	err = r.SetList(ctx, translation, []string{"1"}, time.Hour)
+	c.Assert(err, qt.IsNil)
+	err = r.Tag(ctx, translation, []string{cache.TagVideo("v"), cache.TagLanguage("pl")}, time.Hour)
+	c.Assert(err, qt.IsNil)
+	err = r.Set(ctx, upload, "2", time.Minute)
+	c.Assert(err, qt.IsNil)
+	err = r.Tag(ctx, upload, []string{cache.TagVideo("v")}, time.Minute)
+	c.Assert(err, qt.IsNil)
+
+	_, err = r.GetList(ctx, translation)
+	c.Assert(err, qt.IsNil)
+
+	stats, err := r.Stats(ctx)
+	c.Assert(err, qt.IsNil)
+	c.Assert(stats, qt.DeepEquals, map[cache.Namespace]cache.Stats{cache.NamespaceTranslation: {Hits: 1, Misses: 1}})
+
+	keys, err := r.Keys(ctx, cache.NamespaceUpload)
+	c.Assert(err, qt.IsNil)
+	c.Assert(keys, qt.DeepEquals, []cache.KeyInfo{{Key: upload, TTL: time.Minute}})
+
+	removed, err := r.Invalidate(ctx, cache.TagLanguage("pl"))
+	c.Assert(err, qt.IsNil)
+	c.Assert(removed, qt.Equals, 1)
+	_, err = r.GetList(ctx, translation)
+	c.Assert(err, qt.Equals, errs.NotFound)
+
+	removed, err = r.Invalidate(ctx, cache.TagVideo("v"))
+	c.Assert(err, qt.IsNil)
+	c.Assert(removed, qt.Equals, 1)
+	_, err = r.Get(ctx, upload)
+	c.Assert(err, qt.Equals, errs.NotFound)
+}
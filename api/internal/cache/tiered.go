@@ -0,0 +1,119 @@
+package cache
+
+import (
+	"context"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+var _ Cache = &tieredCache{}
+
+type tieredCache struct {
+	l1       Cache
+	l2       Cache
+	l1TTL    time.Duration
+	counters counters
+}
+
+// NewTiered creates a new two-tier cache with l1 in front of l2.
+// Reads go to l1 first and fill it from l2 on a miss, writes go to both tiers.
+// Values are kept in l1 for at most l1TTL, so changes made in l2 by other instances become visible after it passes.
+func NewTiered(l1, l2 Cache, l1TTL time.Duration) *tieredCache {
+	log.Debug().Dur("l1_ttl", l1TTL).Msg("created new tiered cache instance")
+	return &tieredCache{
+		l1:    l1,
+		l2:    l2,
+		l1TTL: l1TTL,
+	}
+}
+
+func (c *tieredCache) expirationL1(expiration time.Duration) time.Duration {
+	if expiration > 0 && expiration < c.l1TTL {
+		return expiration
+	}
+	return c.l1TTL
+}
+
+func (c *tieredCache) Get(ctx context.Context, key string) (string, error) {
+	if value, err := c.l1.Get(ctx, key); err == nil {
+		c.counters.record(key, true)
+		return value, nil
+	}
+
+	value, err := c.l2.Get(ctx, key)
+	c.counters.record(key, err == nil)
+	if err != nil {
+		return "", err
+	}
+	if err := c.l1.Set(ctx, key, value, c.l1TTL); err != nil {
+		log.Warn().Err(err).Str("key", key).Msg("failed to fill l1 cache")
+	}
+	return value, nil
+}
+
+func (c *tieredCache) GetList(ctx context.Context, key string) ([]string, error) {
+	if value, err := c.l1.GetList(ctx, key); err == nil {
+		c.counters.record(key, true)
+		return value, nil
+	}
+
+	value, err := c.l2.GetList(ctx, key)
+	c.counters.record(key, err == nil)
+	if err != nil {
+		return nil, err
+	}
+	if err := c.l1.SetList(ctx, key, value, c.l1TTL); err != nil {
+		log.Warn().Err(err).Str("key", key).Msg("failed to fill l1 cache")
+	}
+	return value, nil
+}
+
+func (c *tieredCache) Set(ctx context.Context, key, value string, expiration time.Duration) error {
+	if err := c.l2.Set(ctx, key, value, expiration); err != nil {
+		return err
+	}
+	return c.l1.Set(ctx, key, value, c.expirationL1(expiration))
+}
+
+func (c *tieredCache) SetList(ctx context.Context, key string, value []string, expiration time.Duration) error {
+	if err := c.l2.SetList(ctx, key, value, expiration); err != nil {
+		return err
+	}
+	return c.l1.SetList(ctx, key, value, c.expirationL1(expiration))
+}
+
+func (c *tieredCache) Del(ctx context.Context, key string) error {
+	if err := c.l1.Del(ctx, key); err != nil {
+		return err
+	}
+	return c.l2.Del(ctx, key)
+}
+
+func (c *tieredCache) Lock(ctx context.Context, key string, expiration time.Duration) (func() error, error) {
+	// Only l2 is shared between instances.
+	return c.l2.Lock(ctx, key, expiration)
+}
+
+func (c *tieredCache) Tag(ctx context.Context, key string, tags []string, expiration time.Duration) error {
+	if err := c.l2.Tag(ctx, key, tags, expiration); err != nil {
+		return err
+	}
+	return c.l1.Tag(ctx, key, tags, c.expirationL1(expiration))
+}
+
+func (c *tieredCache) Invalidate(ctx context.Context, tag string) (int, error) {
+	// Other instances keep their l1 values until l1TTL passes, as do values filled into l1 from l2, which aren't tagged.
+	if _, err := c.l1.Invalidate(ctx, tag); err != nil {
+		return 0, err
+	}
+	return c.l2.Invalidate(ctx, tag)
+}
+
+func (c *tieredCache) Keys(ctx context.Context, namespace Namespace) ([]KeyInfo, error) {
+	return c.l2.Keys(ctx, namespace)
+}
+
+func (c *tieredCache) Stats(ctx context.Context) (map[Namespace]Stats, error) {
+	return c.counters.snapshot(), nil
+}
@@ -0,0 +1,318 @@
+package coverage
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"golang.org/x/sync/errgroup"
+
+	"github.com/pkulik0/autocc/api/internal/errs"
+	"github.com/pkulik0/autocc/api/internal/model"
+	"github.com/pkulik0/autocc/api/internal/pb"
+	"github.com/pkulik0/autocc/api/internal/store"
+	"github.com/pkulik0/autocc/api/internal/translation"
+	"github.com/pkulik0/autocc/api/internal/youtube"
+)
+
+const (
+	// Maximum number of concurrent requests listing closed captions of videos.
+	ccConcurrency = 8
+	// Times of changes on YouTube come from its clock, they're compared to the times of translations with this tolerance.
+	clockSkew = time.Minute
+)
+
+// Coverage is the interface that wraps reporting which translations videos have.
+//
+//go:generate mockgen -destination=../mock/coverage.go -package=mock . Coverage
+type Coverage interface {
+	// GetVideo returns the state of the closed captions and the localized metadata of the video of the channel by language.
+	GetVideo(ctx context.Context, userID, channelID, videoID string) (*pb.VideoCoverage, error)
+	// GetChannel returns the coverage of a page of videos of the channel and its summary by language.
+	// The closed captions of each video are listed once, so a page costs up to 50 units of quota per video with closed captions.
+	GetChannel(ctx context.Context, userID, channelID, nextPageToken string) ([]*pb.VideoCoverage, []*pb.LanguageSummary, string, error)
+}
+
+var _ Coverage = &coverage{}
+
+type coverage struct {
+	store      store.Store
+	translator translation.Translator
+	youtube    youtube.Youtube
+}
+
+// New creates a new coverage service.
+func New(store store.Store, translator translation.Translator, youtube youtube.Youtube) *coverage {
+	return &coverage{
+		store:      store,
+		translator: translator,
+		youtube:    youtube,
+	}
+}
+
+// video is everything the coverage of a video is computed from.
+type video struct {
+	id       string
+	title    string
+	language string
+
+	cc            []*youtube.CC
+	localizations []youtube.LocalizationState
+	translatedCC  []model.TranslatedCC
+	// sourceUpdated is when the source of the translated closed captions last changed, it's zero if it's unknown.
+	sourceUpdated time.Time
+}
+
+func (c *coverage) GetVideo(ctx context.Context, userID, channelID, videoID string) (*pb.VideoCoverage, error) {
+	if userID == "" || channelID == "" || videoID == "" {
+		return nil, errs.InvalidInput
+	}
+
+	languages, err := c.translator.GetLanguages(ctx)
+	if err != nil {
+		return nil, err
+	}
+	metadata, err := c.youtube.GetMetadata(ctx, userID, channelID, videoID)
+	if err != nil {
+		return nil, err
+	}
+	v := &video{id: videoID, title: metadata.Title, language: metadata.Language}
+
+	v.cc, err = c.youtube.GetCC(ctx, userID, channelID, videoID)
+	if err != nil {
+		return nil, err
+	}
+	states, err := c.youtube.GetLocalizationStates(ctx, userID, channelID, videoID)
+	if err != nil {
+		return nil, err
+	}
+	v.localizations = states[videoID]
+	v.translatedCC, err = c.store.GetTranslatedCC(ctx, videoID)
+	if err != nil {
+		return nil, err
+	}
+	err = c.setSourceUpdated(ctx, userID, v)
+	if err != nil {
+		return nil, err
+	}
+
+	return v.coverage(languages), nil
+}
+
+func (c *coverage) GetChannel(ctx context.Context, userID, channelID, nextPageToken string) ([]*pb.VideoCoverage, []*pb.LanguageSummary, string, error) {
+	if userID == "" || channelID == "" {
+		return nil, nil, "", errs.InvalidInput
+	}
+
+	languages, err := c.translator.GetLanguages(ctx)
+	if err != nil {
+		return nil, nil, "", err
+	}
+	pbVideos, nextPageToken, err := c.youtube.GetVideos(ctx, userID, channelID, nextPageToken)
+	if err != nil {
+		return nil, nil, "", err
+	}
+	if len(pbVideos) == 0 {
+		return []*pb.VideoCoverage{}, []*pb.LanguageSummary{}, nextPageToken, nil
+	}
+
+	ids := make([]string, len(pbVideos))
+	for i, pbVideo := range pbVideos {
+		ids[i] = pbVideo.Id
+	}
+	states, err := c.youtube.GetLocalizationStates(ctx, userID, channelID, ids...)
+	if err != nil {
+		return nil, nil, "", err
+	}
+	translatedCC, err := c.store.GetTranslatedCC(ctx, ids...)
+	if err != nil {
+		return nil, nil, "", err
+	}
+
+	videos := make([]*video, len(pbVideos))
+	for i, pbVideo := range pbVideos {
+		videos[i] = &video{id: pbVideo.Id, title: pbVideo.Title, language: pbVideo.Language, localizations: states[pbVideo.Id]}
+	}
+	for _, cc := range translatedCC {
+		i := slices.Index(ids, cc.VideoID)
+		videos[i].translatedCC = append(videos[i].translatedCC, cc)
+	}
+
+	group, groupCtx := errgroup.WithContext(ctx)
+	group.SetLimit(ccConcurrency)
+	for i, v := range videos {
+		// Closed captions are listed only for videos which have them.
+		if !pbVideos[i].HasCaptions {
+			continue
+		}
+		group.Go(func() error {
+			cc, err := c.youtube.GetCC(groupCtx, userID, channelID, v.id)
+			if err != nil {
+				return err
+			}
+			v.cc = cc
+			return c.setSourceUpdated(groupCtx, userID, v)
+		})
+	}
+	if err := group.Wait(); err != nil {
+		return nil, nil, "", err
+	}
+
+	coverages := make([]*pb.VideoCoverage, len(videos))
+	for i, v := range videos {
+		coverages[i] = v.coverage(languages)
+	}
+
+	log.Debug().Str("channel_id", channelID).Int("videos", len(coverages)).Msg("computed coverage")
+	return coverages, summarize(coverages), nextPageToken, nil
+}
+
+// setSourceUpdated sets when the source of the translated closed captions of the video last changed.
+// Like in AutoCC's processing, closed captions uploaded by the user take precedence over the ones on YouTube.
+func (c *coverage) setSourceUpdated(ctx context.Context, userID string, v *video) error {
+	sourceCC, err := c.store.GetSourceCC(ctx, userID, v.id)
+	switch err {
+	case nil:
+		v.sourceUpdated = sourceCC.UpdatedAt
+		return nil
+	case errs.NotFound:
+	default:
+		return err
+	}
+
+	for _, cc := range v.cc {
+		if v.language != "" && strings.EqualFold(cc.Language, v.language) {
+			v.sourceUpdated = cc.LastUpdated
+			break
+		}
+	}
+	return nil
+}
+
+// authorRank orders authors of closed captions in the same language, the state of the highest ranked one is reported.
+// Closed captions made by a human cover the language even if there are also ones authored by AutoCC.
+var authorRank = map[pb.Author]int{
+	pb.Author_AUTHOR_NONE:    0,
+	pb.Author_AUTHOR_YOUTUBE: 1,
+	pb.Author_AUTHOR_AUTOCC:  2,
+	pb.Author_AUTHOR_HUMAN:   3,
+}
+
+func (v *video) coverage(languages []string) *pb.VideoCoverage {
+	byLanguage := make(map[string]*pb.LanguageCoverage)
+	get := func(language string) *pb.LanguageCoverage {
+		if v.language != "" && strings.EqualFold(language, v.language) {
+			return nil
+		}
+		l, ok := byLanguage[language]
+		if !ok {
+			l = &pb.LanguageCoverage{Language: language, Captions: &pb.TranslationState{}, Metadata: &pb.TranslationState{}}
+			byLanguage[language] = l
+		}
+		return l
+	}
+	for _, language := range languages {
+		get(translation.CodeTranslationToGoogle(language))
+	}
+
+	for _, cc := range v.cc {
+		l := get(cc.Language)
+		if l == nil {
+			continue
+		}
+		author, stale := v.captionsState(cc)
+		if authorRank[author] > authorRank[l.Captions.Author] {
+			l.Captions.Author = author
+			l.Captions.Stale = stale
+		}
+	}
+	for _, state := range v.localizations {
+		l := get(state.Language)
+		if l == nil {
+			continue
+		}
+		l.Metadata.Author = pb.Author_AUTHOR_HUMAN
+		if state.Authored {
+			l.Metadata.Author = pb.Author_AUTHOR_AUTOCC
+			l.Metadata.Stale = state.Stale
+		}
+	}
+
+	coverage := &pb.VideoCoverage{VideoId: v.id, Title: v.title, Language: v.language}
+	for _, l := range byLanguage {
+		coverage.Languages = append(coverage.Languages, l)
+	}
+	slices.SortFunc(coverage.Languages, func(a, b *pb.LanguageCoverage) int {
+		return strings.Compare(a.Language, b.Language)
+	})
+	return coverage
+}
+
+// captionsState returns who authored the closed captions and whether they're stale.
+// Closed captions authored by AutoCC and changed afterwards are considered written by hand.
+func (v *video) captionsState(cc *youtube.CC) (pb.Author, bool) {
+	if cc.TrackKind == youtube.TrackKindASR {
+		return pb.Author_AUTHOR_YOUTUBE, false
+	}
+
+	i := slices.IndexFunc(v.translatedCC, func(t model.TranslatedCC) bool { return t.TrackID == cc.Id })
+	if i == -1 {
+		return pb.Author_AUTHOR_HUMAN, false
+	}
+	translatedAt := v.translatedCC[i].UpdatedAt.Add(clockSkew)
+	if cc.LastUpdated.After(translatedAt) {
+		return pb.Author_AUTHOR_HUMAN, false
+	}
+	return pb.Author_AUTHOR_AUTOCC, v.sourceUpdated.After(translatedAt)
+}
+
+// summarize counts the videos by the state of their translations in each language.
+func summarize(coverages []*pb.VideoCoverage) []*pb.LanguageSummary {
+	byLanguage := make(map[string]*pb.LanguageSummary)
+	for _, coverage := range coverages {
+		for _, l := range coverage.Languages {
+			summary, ok := byLanguage[l.Language]
+			if !ok {
+				summary = &pb.LanguageSummary{Language: l.Language}
+				byLanguage[l.Language] = summary
+			}
+
+			switch l.Captions.Author {
+			case pb.Author_AUTHOR_NONE:
+				summary.CaptionsMissing++
+			case pb.Author_AUTHOR_HUMAN:
+				summary.CaptionsHuman++
+			case pb.Author_AUTHOR_AUTOCC:
+				summary.CaptionsAutocc++
+			case pb.Author_AUTHOR_YOUTUBE:
+				summary.CaptionsYoutube++
+			}
+			if l.Captions.Stale {
+				summary.CaptionsStale++
+			}
+
+			switch l.Metadata.Author {
+			case pb.Author_AUTHOR_NONE:
+				summary.MetadataMissing++
+			case pb.Author_AUTHOR_HUMAN:
+				summary.MetadataHuman++
+			case pb.Author_AUTHOR_AUTOCC:
+				summary.MetadataAutocc++
+			}
+			if l.Metadata.Stale {
+				summary.MetadataStale++
+			}
+		}
+	}
+
+	summaries := make([]*pb.LanguageSummary, 0, len(byLanguage))
+	for _, summary := range byLanguage {
+		summaries = append(summaries, summary)
+	}
+	slices.SortFunc(summaries, func(a, b *pb.LanguageSummary) int {
+		return strings.Compare(a.Language, b.Language)
+	})
+	return summaries
+}
@@ -0,0 +1,170 @@
+package coverage_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	qt "github.com/frankban/quicktest"
+	"go.uber.org/mock/gomock"
+	yt "google.golang.org/api/youtube/v3"
+
+	"github.com/pkulik0/autocc/api/internal/autocc"
+	"github.com/pkulik0/autocc/api/internal/cache"
+	"github.com/pkulik0/autocc/api/internal/coverage"
+	"github.com/pkulik0/autocc/api/internal/errs"
+	"github.com/pkulik0/autocc/api/internal/fake"
+	"github.com/pkulik0/autocc/api/internal/mock"
+	"github.com/pkulik0/autocc/api/internal/pb"
+	"github.com/pkulik0/autocc/api/internal/youtube"
+)
+
+// setup returns a fake YouTube server with a channel of the user and one of its videos processed by AutoCC,
+// and a coverage service using it. The video has English closed captions, AutoCC translates them to German and French.
+func setup(c *qt.C) (*fake.Youtube, coverage.Coverage, string, string) {
+	f := fake.NewYoutube()
+	c.Cleanup(f.Close)
+	f.AddChannel("channel", "Channel", "token")
+	videoID := f.AddVideo("channel", &yt.Video{Snippet: &yt.VideoSnippet{
+		Title:           "Title",
+		Description:     "Description",
+		DefaultLanguage: "en",
+		PublishedAt:     "2024-01-01T00:00:00Z",
+	}})
+	sourceID := f.AddCaption(videoID, "en", "", fake.Srt)
+
+	s := fake.NewStore(c)
+	f.Session(c, s, "user", "channel", "token")
+	y := youtube.New(s, cache.NewMemory(100), f.Options()...)
+	translator := fake.PrefixTranslator(c, "de", "en", "fr")
+
+	err := autocc.New(s, translator, y).Process(context.Background(), "user", "channel", videoID, autocc.Options{})
+	c.Assert(err, qt.IsNil)
+
+	return f, coverage.New(s, translator, y), videoID, sourceID
+}
+
+// states returns the authors of the closed captions and the metadata by language, with a "stale" suffix if they're stale.
+func states(coverage *pb.VideoCoverage) map[string][2]string {
+	format := func(state *pb.TranslationState) string {
+		if state.Stale {
+			return state.Author.String() + " stale"
+		}
+		return state.Author.String()
+	}
+
+	states := make(map[string][2]string)
+	for _, l := range coverage.Languages {
+		states[l.Language] = [2]string{format(l.Captions), format(l.Metadata)}
+	}
+	return states
+}
+
+func TestGetVideo(t *testing.T) {
+	c := qt.New(t)
+	f, cov, videoID, _ := setup(c)
+	ctx := context.Background()
+
+	// Italian is written by hand, Polish closed captions are generated by YouTube.
+	f.AddCaption(videoID, "it", "", fake.Srt)
+	f.AddASRCaption(videoID, "pl", fake.Srt)
+	video := f.Video(videoID)
+	video.Localizations["it"] = yt.VideoLocalization{Title: "Titolo"}
+	f.AddVideo("channel", video)
+
+	coverage, err := cov.GetVideo(ctx, "user", "channel", videoID)
+	c.Assert(err, qt.IsNil)
+	c.Assert(coverage.Title, qt.Equals, "Title")
+	c.Assert(coverage.Language, qt.Equals, "en")
+	c.Assert(states(coverage), qt.DeepEquals, map[string][2]string{
+		"de": {"AUTHOR_AUTOCC", "AUTHOR_AUTOCC"},
+		"fr": {"AUTHOR_AUTOCC", "AUTHOR_AUTOCC"},
+		"it": {"AUTHOR_HUMAN", "AUTHOR_HUMAN"},
+		"pl": {"AUTHOR_YOUTUBE", "AUTHOR_NONE"},
+	})
+
+	_, err = cov.GetVideo(ctx, "user", "channel", "missing")
+	c.Assert(err, qt.IsNotNil)
+}
+
+func TestGetVideoStale(t *testing.T) {
+	c := qt.New(t)
+	f, cov, videoID, sourceID := setup(c)
+	ctx := context.Background()
+
+	// The source and the title change after the translation, then the French closed captions are edited by hand.
+	f.EditCaption(sourceID, fake.Srt, time.Now().Add(time.Hour))
+	for _, caption := range f.Captions(videoID) {
+		if caption.Language == "fr" {
+			f.EditCaption(caption.ID, fake.Srt, time.Now().Add(time.Hour))
+		}
+	}
+	video := f.Video(videoID)
+	video.Snippet.Title = "New title"
+	f.AddVideo("channel", video)
+
+	coverage, err := cov.GetVideo(ctx, "user", "channel", videoID)
+	c.Assert(err, qt.IsNil)
+	c.Assert(states(coverage), qt.DeepEquals, map[string][2]string{
+		"de": {"AUTHOR_AUTOCC stale", "AUTHOR_AUTOCC stale"},
+		"fr": {"AUTHOR_HUMAN", "AUTHOR_AUTOCC stale"},
+	})
+}
+
+func TestGetChannel(t *testing.T) {
+	c := qt.New(t)
+	f, cov, videoID, _ := setup(c)
+	ctx := context.Background()
+	otherID := f.AddVideo("channel", &yt.Video{Snippet: &yt.VideoSnippet{Title: "Other", DefaultLanguage: "de", PublishedAt: "2024-02-01T00:00:00Z"}})
+	f.AddCaption(otherID, "en", "", fake.Srt)
+
+	videos, summary, _, err := cov.GetChannel(ctx, "user", "channel", "")
+	c.Assert(err, qt.IsNil)
+	c.Assert(videos, qt.HasLen, 2)
+	c.Assert(videos[0].VideoId, qt.Equals, otherID)
+	c.Assert(states(videos[0]), qt.DeepEquals, map[string][2]string{
+		"en": {"AUTHOR_HUMAN", "AUTHOR_NONE"},
+		"fr": {"AUTHOR_NONE", "AUTHOR_NONE"},
+	})
+	c.Assert(videos[1].VideoId, qt.Equals, videoID)
+
+	c.Assert(summary, qt.HasLen, 3)
+	c.Assert(summary[0].Language, qt.Equals, "de")
+	c.Assert(summary[0].CaptionsAutocc, qt.Equals, uint32(1))
+	c.Assert(summary[0].MetadataAutocc, qt.Equals, uint32(1))
+	c.Assert(summary[1].Language, qt.Equals, "en")
+	c.Assert(summary[1].CaptionsHuman, qt.Equals, uint32(1))
+	c.Assert(summary[1].MetadataMissing, qt.Equals, uint32(1))
+	c.Assert(summary[2].Language, qt.Equals, "fr")
+	c.Assert(summary[2].CaptionsMissing, qt.Equals, uint32(1))
+	c.Assert(summary[2].CaptionsAutocc, qt.Equals, uint32(1))
+	c.Assert(summary[2].MetadataMissing, qt.Equals, uint32(1))
+	c.Assert(summary[2].MetadataAutocc, qt.Equals, uint32(1))
+}
+
+func TestGetChannelCaptioned(t *testing.T) {
+	c := qt.New(t)
+	ctrl := gomock.NewController(c)
+	ctx := context.Background()
+
+	translator := mock.NewMockTranslator(ctrl)
+	translator.EXPECT().GetLanguages(gomock.Any()).Return([]string{"de", "en"}, nil)
+	s := mock.NewMockStore(ctrl)
+	s.EXPECT().GetTranslatedCC(gomock.Any(), "captioned", "other").Return(nil, nil)
+	s.EXPECT().GetSourceCC(gomock.Any(), "user", "captioned").Return(nil, errs.NotFound)
+
+	// Closed captions are listed only for the video which has them, whether their languages are known or not.
+	y := mock.NewMockYoutube(ctrl)
+	y.EXPECT().GetVideos(gomock.Any(), "user", "channel", "").Return([]*pb.Video{
+		{Id: "captioned", Language: "en", HasCaptions: true},
+		{Id: "other", Language: "en"},
+	}, "", nil)
+	y.EXPECT().GetLocalizationStates(gomock.Any(), "user", "channel", "captioned", "other").Return(nil, nil)
+	y.EXPECT().GetCC(gomock.Any(), "user", "channel", "captioned").Return([]*youtube.CC{{Id: "cc", Language: "de", TrackKind: "standard"}}, nil)
+
+	videos, _, _, err := coverage.New(s, translator, y).GetChannel(ctx, "user", "channel", "")
+	c.Assert(err, qt.IsNil)
+	c.Assert(videos, qt.HasLen, 2)
+	c.Assert(states(videos[0]), qt.DeepEquals, map[string][2]string{"de": {"AUTHOR_HUMAN", "AUTHOR_NONE"}})
+	c.Assert(states(videos[1]), qt.DeepEquals, map[string][2]string{"de": {"AUTHOR_NONE", "AUTHOR_NONE"}})
+}
@@ -4,7 +4,9 @@ import (
 	"context"
 	"crypto/rand"
 	"encoding/hex"
+	"errors"
 	"regexp"
+	"time"
 
 	"github.com/rs/zerolog/log"
 	"golang.org/x/oauth2"
@@ -15,6 +17,7 @@ import (
 	"github.com/pkulik0/autocc/api/internal/oauth"
 	"github.com/pkulik0/autocc/api/internal/store"
 	"github.com/pkulik0/autocc/api/internal/translation"
+	"github.com/pkulik0/autocc/api/internal/youtube"
 )
 
 // Credentials is an interface for the credentials service.
@@ -38,27 +41,39 @@ type Credentials interface {
 	GetSessionGoogleURL(ctx context.Context, credentialsID uint, userID, redirectURL string) (string, error)
 	// CreateSessionGoogle creates a new Google API session.
 	CreateSessionGoogle(ctx context.Context, state, code string) (string, error)
-	// RemoveSessionGoogle removes a Google API session.
-	RemoveSessionGoogle(ctx context.Context, userID string, credentialsID uint) error
+	// RemoveSessionGoogle revokes the tokens of a Google API session of the user and removes it.
+	// A user can have sessions of several channels with the same credentials, only the one with the ID is removed.
+	RemoveSessionGoogle(ctx context.Context, userID string, sessionID uint) error
 	// GetSessionsGoogleByUser returns all Google API sessions for a user.
 	GetSessionsGoogleByUser(ctx context.Context, userID string) ([]model.SessionGoogle, error)
 }
 
 var _ Credentials = &credentials{}
 
+// ErrNoRefreshToken is returned when Google didn't issue a refresh token, so the session couldn't be refreshed.
+var ErrNoRefreshToken = errors.New("credentials: no refresh token issued")
+
+// ErrNoChannel is returned when the authenticated Google account doesn't have a YouTube channel.
+var ErrNoChannel = errors.New("credentials: no youtube channel")
+
+// Time after which an OAuth2 state can't be used to create a session.
+const stateTTL = time.Minute * 10
+
 type credentials struct {
 	store       store.Store
 	oauth       oauth.Configs
 	translation translation.Translator
+	youtube     youtube.Youtube
 }
 
 // New creates a new credentials service.
-func New(s store.Store, o oauth.Configs, t translation.Translator) *credentials {
+func New(s store.Store, o oauth.Configs, t translation.Translator, y youtube.Youtube) *credentials {
 	log.Debug().Msg("created credentials service")
 	return &credentials{
 		store:       s,
 		oauth:       o,
 		translation: t,
+		youtube:     y,
 	}
 }
 
@@ -144,12 +159,14 @@ func (c *credentials) GetSessionGoogleURL(ctx context.Context, credentialsID uin
 	if err != nil {
 		return "", err
 	}
-	err = c.store.SaveSessionState(ctx, credentialsID, userID, state, scopes, redirectURL)
+	codeVerifier := oauth2.GenerateVerifier()
+	err = c.store.SaveSessionState(ctx, credentialsID, userID, state, codeVerifier, scopes, redirectURL)
 	if err != nil {
 		return "", err
 	}
 
-	return oauthClient.AuthCodeURL(state, oauth2.AccessTypeOffline), nil
+	// Google only issues a refresh token when the user is asked for consent, so it's always requested.
+	return oauthClient.AuthCodeURL(state, oauth2.AccessTypeOffline, oauth2.ApprovalForce, oauth2.S256ChallengeOption(codeVerifier)), nil
 }
 
 func (c *credentials) CreateSessionGoogle(ctx context.Context, state, code string) (string, error) {
@@ -158,9 +175,17 @@ func (c *credentials) CreateSessionGoogle(ctx context.Context, state, code strin
 	}
 
 	sessionState, err := c.store.GetSessionState(ctx, state)
-	if err != nil {
+	switch err {
+	case nil:
+	case errs.NotFound:
+		return "", errs.InvalidInput
+	default:
 		return "", err
 	}
+	if time.Since(sessionState.CreatedAt) > stateTTL {
+		log.Debug().Str("user_id", sessionState.UserID).Time("created_at", sessionState.CreatedAt).Msg("session state expired")
+		return "", errs.InvalidInput
+	}
 
 	credentials, err := c.store.GetCredentialsGoogleByID(ctx, sessionState.CredentialsID)
 	if err != nil {
@@ -168,23 +193,89 @@ func (c *credentials) CreateSessionGoogle(ctx context.Context, state, code strin
 	}
 	oauthClient, _ := c.oauth.GetGoogle(credentials.ClientID, credentials.ClientSecret)
 
-	token, err := oauthClient.Exchange(ctx, code)
+	token, err := oauthClient.Exchange(ctx, code, oauth2.VerifierOption(sessionState.CodeVerifier))
 	if err != nil {
 		return "", err
 	}
+	if token.RefreshToken == "" {
+		return "", ErrNoRefreshToken
+	}
 
-	_, err = c.store.CreateSessionGoogle(ctx, sessionState.UserID, token.AccessToken, token.RefreshToken, sessionState.Scopes, token.Expiry, sessionState.Credentials)
-	if err != nil {
+	// Each session is bound to the channel of the account the user authenticated with.
+	channel, err := c.youtube.GetAuthorizedChannel(ctx, oauthClient.Client(ctx, token))
+	switch err {
+	case nil:
+	case errs.NotFound:
+		return "", ErrNoChannel
+	default:
+		return "", err
+	}
+
+	// The state is removed together with creating the session, so it can't be used by another callback.
+	err = c.store.Transaction(ctx, func(ctx context.Context, s store.Store) error {
+		if err := s.RemoveSessionState(ctx, state); err != nil {
+			return err
+		}
+
+		_, err := s.CreateSessionGoogle(ctx, sessionState.UserID, channel.ID, channel.Title, token.AccessToken, token.RefreshToken, sessionState.Scopes, token.Expiry, sessionState.Credentials)
+		return err
+	})
+	switch err {
+	case nil:
+	case errs.NotFound:
+		return "", errs.InvalidInput
+	default:
 		return "", err
 	}
 	return sessionState.RedirectURL, nil
 }
 
-func (c *credentials) RemoveSessionGoogle(ctx context.Context, userID string, credentialsID uint) error {
+// StartJanitor periodically removes expired OAuth2 states until the context is done.
+func (c *credentials) StartJanitor(ctx context.Context, interval time.Duration) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				c.removeExpiredSessionStates(ctx)
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+}
+
+func (c *credentials) removeExpiredSessionStates(ctx context.Context) {
+	count, err := c.store.RemoveSessionStatesBefore(ctx, time.Now().Add(-stateTTL))
+	if err != nil {
+		log.Error().Err(err).Msg("failed to remove expired session states")
+		return
+	}
+	log.Debug().Int("count", count).Msg("removed expired session states")
+}
+
+func (c *credentials) RemoveSessionGoogle(ctx context.Context, userID string, sessionID uint) error {
 	if userID == "" {
 		return errs.InvalidInput
 	}
-	return c.store.RemoveSessionGoogle(ctx, userID, credentialsID)
+
+	session, err := c.store.GetSessionGoogle(ctx, userID, sessionID)
+	if err != nil {
+		return err
+	}
+
+	// The session is removed even if revoking fails, the user asked for it to be gone.
+	token := session.RefreshToken
+	if token == "" {
+		token = session.AccessToken
+	}
+	if err := c.oauth.RevokeGoogle(ctx, token); err != nil {
+		log.Warn().Err(err).Str("user_id", userID).Uint("session_id", session.ID).Msg("failed to revoke google token")
+	}
+
+	return c.store.RemoveSessionGoogle(ctx, userID, session.ID)
 }
 
 func (c *credentials) GetSessionsGoogleByUser(ctx context.Context, userID string) ([]model.SessionGoogle, error) {
@@ -3,17 +3,21 @@ package credentials_test
 import (
 	"context"
 	"errors"
+	"net/http"
 	"testing"
 	"time"
 
 	qt "github.com/frankban/quicktest"
 	"go.uber.org/mock/gomock"
 	"golang.org/x/oauth2"
+	"gorm.io/gorm"
 
 	"github.com/pkulik0/autocc/api/internal/credentials"
 	"github.com/pkulik0/autocc/api/internal/errs"
 	"github.com/pkulik0/autocc/api/internal/mock"
 	"github.com/pkulik0/autocc/api/internal/model"
+	st "github.com/pkulik0/autocc/api/internal/store"
+	"github.com/pkulik0/autocc/api/internal/youtube"
 )
 
 func TestService(t *testing.T) {
@@ -126,14 +130,14 @@ func TestService(t *testing.T) {
 		{
 			name: "GetSessionGoogleURL",
 			setupMock: func(store *mock.MockStore, oauth *mock.MockOAuth2Config, mockTranslation *mock.MockTranslator) {
-				oauth.EXPECT().AuthCodeURL(gomock.Any(), oauth2.AccessTypeOffline).Return("url").Times(1)
+				oauth.EXPECT().AuthCodeURL(gomock.Any(), oauth2.AccessTypeOffline, oauth2.ApprovalForce, gomock.Any()).Return("url").Times(1)
 				store.EXPECT().GetCredentialsGoogleByID(gomock.Any(), uint(1)).Return(&model.CredentialsGoogle{ClientID: "clientID"}, nil).Times(1)
-				store.EXPECT().SaveSessionState(gomock.Any(), uint(1), "userID", gomock.Any(), gomock.Any(), "http://example.com").Return(nil).Times(1)
+				store.EXPECT().SaveSessionState(gomock.Any(), uint(1), "userID", gomock.Any(), gomock.Any(), gomock.Any(), "http://example.com").Return(nil).Times(1)
 
 				store.EXPECT().GetCredentialsGoogleByID(gomock.Any(), uint(1)).Return(nil, retErr).Times(1)
 
 				store.EXPECT().GetCredentialsGoogleByID(gomock.Any(), uint(1)).Return(&model.CredentialsGoogle{ClientID: "clientID"}, nil).Times(1)
-				store.EXPECT().SaveSessionState(gomock.Any(), uint(1), "userID", gomock.Any(), gomock.Any(), "http://example.com").Return(retErr).Times(1)
+				store.EXPECT().SaveSessionState(gomock.Any(), uint(1), "userID", gomock.Any(), gomock.Any(), gomock.Any(), "http://example.com").Return(retErr).Times(1)
 			},
 			test: func(c *qt.C, s credentials.Credentials) {
 				_, err := s.GetSessionGoogleURL(context.Background(), 1, "userID", "http://example.com")
@@ -160,7 +164,11 @@ func TestService(t *testing.T) {
 					UserID:        "userID",
 					Scopes:        "scopes",
 					RedirectURL:   "http://example.com",
+					CodeVerifier:  "verifier",
 				}
+				sessState.CreatedAt = time.Now()
+				expiredState := &model.SessionState{CredentialsID: 1, UserID: "userID"}
+				expiredState.CreatedAt = time.Now().Add(-time.Hour)
 				cred := &model.CredentialsGoogle{
 					ClientID:     "clientID",
 					ClientSecret: "clientSecret",
@@ -170,12 +178,16 @@ func TestService(t *testing.T) {
 					RefreshToken: "refresh",
 					Expiry:       time.Now(),
 				}
+				store.EXPECT().Transaction(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, f func(context.Context, st.Store) error) error {
+					return f(ctx, store)
+				}).AnyTimes()
 
 				// 1
 				store.EXPECT().GetSessionState(gomock.Any(), "state").Return(sessState, nil).Times(1)
 				store.EXPECT().GetCredentialsGoogleByID(gomock.Any(), uint(1)).Return(cred, nil).Times(1)
-				oauth.EXPECT().Exchange(gomock.Any(), "code").Return(token, nil).Times(1)
-				store.EXPECT().CreateSessionGoogle(gomock.Any(), "userID", "access", "refresh", "scopes", gomock.Any(), gomock.Any()).Return(nil, nil).Times(1)
+				oauth.EXPECT().Exchange(gomock.Any(), "code", oauth2.VerifierOption("verifier")).Return(token, nil).Times(1)
+				store.EXPECT().RemoveSessionState(gomock.Any(), "state").Return(nil).Times(1)
+				store.EXPECT().CreateSessionGoogle(gomock.Any(), "userID", "channel", "Channel", "access", "refresh", "scopes", gomock.Any(), gomock.Any()).Return(nil, nil).Times(1)
 
 				// 2
 				store.EXPECT().GetSessionState(gomock.Any(), "state").Return(nil, retErr).Times(1)
@@ -187,13 +199,38 @@ func TestService(t *testing.T) {
 				// 4
 				store.EXPECT().GetSessionState(gomock.Any(), "state").Return(sessState, nil).Times(1)
 				store.EXPECT().GetCredentialsGoogleByID(gomock.Any(), uint(1)).Return(cred, nil).Times(1)
-				oauth.EXPECT().Exchange(gomock.Any(), "code").Return(nil, retErr).Times(1)
+				oauth.EXPECT().Exchange(gomock.Any(), "code", oauth2.VerifierOption("verifier")).Return(nil, retErr).Times(1)
 
 				// 5
 				store.EXPECT().GetSessionState(gomock.Any(), "state").Return(sessState, nil).Times(1)
 				store.EXPECT().GetCredentialsGoogleByID(gomock.Any(), uint(1)).Return(cred, nil).Times(1)
-				oauth.EXPECT().Exchange(gomock.Any(), "code").Return(token, nil).Times(1)
-				store.EXPECT().CreateSessionGoogle(gomock.Any(), "userID", "access", "refresh", "scopes", gomock.Any(), gomock.Any()).Return(nil, retErr).Times(1)
+				oauth.EXPECT().Exchange(gomock.Any(), "code", oauth2.VerifierOption("verifier")).Return(token, nil).Times(1)
+				store.EXPECT().RemoveSessionState(gomock.Any(), "state").Return(nil).Times(1)
+				store.EXPECT().CreateSessionGoogle(gomock.Any(), "userID", "channel", "Channel", "access", "refresh", "scopes", gomock.Any(), gomock.Any()).Return(nil, retErr).Times(1)
+
+				// 6 - unknown state
+				store.EXPECT().GetSessionState(gomock.Any(), "state").Return(nil, errs.NotFound).Times(1)
+
+				// 7 - expired state
+				store.EXPECT().GetSessionState(gomock.Any(), "state").Return(expiredState, nil).Times(1)
+
+				// 8 - state used by a concurrent callback
+				store.EXPECT().GetSessionState(gomock.Any(), "state").Return(sessState, nil).Times(1)
+				store.EXPECT().GetCredentialsGoogleByID(gomock.Any(), uint(1)).Return(cred, nil).Times(1)
+				oauth.EXPECT().Exchange(gomock.Any(), "code", oauth2.VerifierOption("verifier")).Return(token, nil).Times(1)
+				store.EXPECT().RemoveSessionState(gomock.Any(), "state").Return(errs.NotFound).Times(1)
+
+				// 9 - credentials removed before the session was created
+				store.EXPECT().GetSessionState(gomock.Any(), "state").Return(sessState, nil).Times(1)
+				store.EXPECT().GetCredentialsGoogleByID(gomock.Any(), uint(1)).Return(cred, nil).Times(1)
+				oauth.EXPECT().Exchange(gomock.Any(), "code", oauth2.VerifierOption("verifier")).Return(token, nil).Times(1)
+				store.EXPECT().RemoveSessionState(gomock.Any(), "state").Return(nil).Times(1)
+				store.EXPECT().CreateSessionGoogle(gomock.Any(), "userID", "channel", "Channel", "access", "refresh", "scopes", gomock.Any(), gomock.Any()).Return(nil, errs.NotFound).Times(1)
+
+				// 10 - no refresh token
+				store.EXPECT().GetSessionState(gomock.Any(), "state").Return(sessState, nil).Times(1)
+				store.EXPECT().GetCredentialsGoogleByID(gomock.Any(), uint(1)).Return(cred, nil).Times(1)
+				oauth.EXPECT().Exchange(gomock.Any(), "code", oauth2.VerifierOption("verifier")).Return(&oauth2.Token{AccessToken: "access"}, nil).Times(1)
 			},
 			test: func(c *qt.C, s credentials.Credentials) {
 				url, err := s.CreateSessionGoogle(context.Background(), "state", "code")
@@ -212,6 +249,14 @@ func TestService(t *testing.T) {
 				url, err = s.CreateSessionGoogle(context.Background(), "state", "code")
 				c.Assert(err, qt.Equals, retErr)
 
+				for range 4 {
+					_, err = s.CreateSessionGoogle(context.Background(), "state", "code")
+					c.Assert(err, qt.Equals, errs.InvalidInput)
+				}
+
+				_, err = s.CreateSessionGoogle(context.Background(), "state", "code")
+				c.Assert(err, qt.Equals, credentials.ErrNoRefreshToken)
+
 				url, err = s.CreateSessionGoogle(context.Background(), "", "")
 				c.Assert(err, qt.Equals, errs.InvalidInput)
 			},
@@ -219,6 +264,7 @@ func TestService(t *testing.T) {
 		{
 			name: "RemoveSessionGoogle",
 			setupMock: func(store *mock.MockStore, oauth *mock.MockOAuth2Config, mockTranslation *mock.MockTranslator) {
+				store.EXPECT().GetSessionGoogle(gomock.Any(), "userID", uint(1)).Return(&model.SessionGoogle{Model: gorm.Model{ID: 1}, RefreshToken: "refresh"}, nil).Times(2)
 				store.EXPECT().RemoveSessionGoogle(gomock.Any(), "userID", uint(1)).Return(nil).Times(1)
 				store.EXPECT().RemoveSessionGoogle(gomock.Any(), "userID", uint(1)).Return(retErr).Times(1)
 			},
@@ -260,14 +306,152 @@ func TestService(t *testing.T) {
 			oauthGoogle := mock.NewMockOAuth2Config(ctrl)
 			oauth := mock.NewMockConfigs(ctrl)
 			oauth.EXPECT().GetGoogle(gomock.Any(), gomock.Any()).Return(oauthGoogle, "scopes").AnyTimes()
+			oauth.EXPECT().RevokeGoogle(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+			oauthGoogle.EXPECT().Client(gomock.Any(), gomock.Any()).Return(http.DefaultClient).AnyTimes()
+
+			yt := mock.NewMockYoutube(ctrl)
+			yt.EXPECT().GetAuthorizedChannel(gomock.Any(), gomock.Any()).Return(&youtube.Channel{ID: "channel", Title: "Channel"}, nil).AnyTimes()
 
 			store := mock.NewMockStore(ctrl)
 			translation := mock.NewMockTranslator(ctrl)
 
 			tc.setupMock(store, oauthGoogle, translation)
 
-			s := credentials.New(store, oauth, translation)
+			s := credentials.New(store, oauth, translation, yt)
 			tc.test(c, s)
 		})
 	}
 }
+
+func TestJanitor(t *testing.T) {
+	c := qt.New(t)
+
+	ctrl := gomock.NewController(c)
+	store := mock.NewMockStore(ctrl)
+
+	removed := make(chan time.Time, 1)
+	store.EXPECT().RemoveSessionStatesBefore(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, before time.Time) (int, error) {
+		select {
+		case removed <- before:
+		default:
+		}
+		return 1, nil
+	}).MinTimes(1)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	credentials.New(store, mock.NewMockConfigs(ctrl), mock.NewMockTranslator(ctrl), mock.NewMockYoutube(ctrl)).StartJanitor(ctx, time.Millisecond)
+
+	select {
+	case before := <-removed:
+		c.Assert(before.Before(time.Now().Add(-time.Minute)), qt.IsTrue)
+	case <-time.After(time.Second):
+		c.Fatal("expired session states weren't removed")
+	}
+	cancel()
+}
+
+func TestRemoveSessionGoogleRevoke(t *testing.T) {
+	c := qt.New(t)
+
+	testCases := []struct {
+		name      string
+		session   *model.SessionGoogle
+		token     string
+		revokeErr error
+	}{
+		{
+			name:    "refresh token",
+			session: &model.SessionGoogle{AccessToken: "access", RefreshToken: "refresh"},
+			token:   "refresh",
+		},
+		{
+			name:    "access token",
+			session: &model.SessionGoogle{AccessToken: "access"},
+			token:   "access",
+		},
+		{
+			name:      "revoke failed",
+			session:   &model.SessionGoogle{RefreshToken: "refresh"},
+			token:     "refresh",
+			revokeErr: errors.New("error"),
+		},
+	}
+
+	for _, tc := range testCases {
+		c.Run(tc.name, func(c *qt.C) {
+			ctrl := gomock.NewController(c)
+			store := mock.NewMockStore(ctrl)
+			oauth := mock.NewMockConfigs(ctrl)
+
+			tc.session.ID = 1
+			store.EXPECT().GetSessionGoogle(gomock.Any(), "userID", uint(1)).Return(tc.session, nil).Times(1)
+			oauth.EXPECT().RevokeGoogle(gomock.Any(), tc.token).Return(tc.revokeErr).Times(1)
+			store.EXPECT().RemoveSessionGoogle(gomock.Any(), "userID", uint(1)).Return(nil).Times(1)
+
+			s := credentials.New(store, oauth, mock.NewMockTranslator(ctrl), mock.NewMockYoutube(ctrl))
+			err := s.RemoveSessionGoogle(context.Background(), "userID", 1)
+			c.Assert(err, qt.IsNil)
+		})
+	}
+}
+
+func TestCreateSessionGoogleChannel(t *testing.T) {
+	c := qt.New(t)
+
+	retErr := errors.New("error")
+
+	testCases := []struct {
+		name       string
+		channel    *youtube.Channel
+		channelErr error
+		err        error
+	}{
+		{
+			name:    "channel",
+			channel: &youtube.Channel{ID: "channel", Title: "Channel"},
+		},
+		{
+			name:       "no channel",
+			channelErr: errs.NotFound,
+			err:        credentials.ErrNoChannel,
+		},
+		{
+			name:       "failed",
+			channelErr: retErr,
+			err:        retErr,
+		},
+	}
+
+	for _, tc := range testCases {
+		c.Run(tc.name, func(c *qt.C) {
+			ctrl := gomock.NewController(c)
+			store := mock.NewMockStore(ctrl)
+			oauthGoogle := mock.NewMockOAuth2Config(ctrl)
+			oauth := mock.NewMockConfigs(ctrl)
+			yt := mock.NewMockYoutube(ctrl)
+
+			sessState := &model.SessionState{CredentialsID: 1, UserID: "userID", RedirectURL: "http://example.com"}
+			sessState.CreatedAt = time.Now()
+			token := &oauth2.Token{AccessToken: "access", RefreshToken: "refresh"}
+
+			store.EXPECT().GetSessionState(gomock.Any(), "state").Return(sessState, nil).Times(1)
+			store.EXPECT().GetCredentialsGoogleByID(gomock.Any(), uint(1)).Return(&model.CredentialsGoogle{}, nil).Times(1)
+			oauth.EXPECT().GetGoogle(gomock.Any(), gomock.Any()).Return(oauthGoogle, "scopes").Times(1)
+			oauthGoogle.EXPECT().Exchange(gomock.Any(), "code", gomock.Any()).Return(token, nil).Times(1)
+			oauthGoogle.EXPECT().Client(gomock.Any(), token).Return(http.DefaultClient).Times(1)
+			yt.EXPECT().GetAuthorizedChannel(gomock.Any(), http.DefaultClient).Return(tc.channel, tc.channelErr).Times(1)
+			if tc.channel != nil {
+				store.EXPECT().Transaction(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, f func(context.Context, st.Store) error) error {
+					return f(ctx, store)
+				}).Times(1)
+				store.EXPECT().RemoveSessionState(gomock.Any(), "state").Return(nil).Times(1)
+				store.EXPECT().CreateSessionGoogle(gomock.Any(), "userID", tc.channel.ID, tc.channel.Title, "access", "refresh", gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, nil).Times(1)
+			}
+
+			s := credentials.New(store, oauth, mock.NewMockTranslator(ctrl), yt)
+			_, err := s.CreateSessionGoogle(context.Background(), "state", "code")
+			c.Assert(err, qt.Equals, tc.err)
+		})
+	}
+}
@@ -9,4 +9,6 @@ var (
 	InvalidInput = errors.New("autocc: invalid input")
 	// SourceClosedCaptionsNotFound is returned when the source closed captions are not found.
 	SourceClosedCaptionsNotFound = errors.New("autocc: source closed captions not found")
+	// VideoLanguageNotSet is returned when the video doesn't have a default language to translate from.
+	VideoLanguageNotSet = errors.New("autocc: video language not set")
 )
@@ -0,0 +1,447 @@
+package fake
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"maps"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"sync"
+	"unicode/utf8"
+)
+
+const (
+	// StatusQuotaExceeded is the status code DeepL responds with when the character limit of the key is reached.
+	StatusQuotaExceeded = 456
+
+	// DeepLMaxTexts is the maximum number of texts in a single translation request.
+	DeepLMaxTexts = 50
+	// DeepLMaxRequestSize is the maximum size of a translation request body.
+	DeepLMaxRequestSize = 128 * 1024
+)
+
+// Glossary is a glossary stored by the fake DeepL server.
+type Glossary struct {
+	ID             string
+	Name           string
+	SourceLanguage string
+	TargetLanguage string
+	Entries        map[string]string
+
+	key string
+}
+
+// DeepL is a fake of the subset of the DeepL API v2 used by AutoCC.
+//
+// Texts are translated by prefixing them with the target language, see PseudoTranslate.
+// Each API key has its own character limit and glossaries, usage is counted in characters of the source texts.
+type DeepL struct {
+	server *httptest.Server
+
+	mu         sync.Mutex
+	languages  []string
+	keys       map[string]*deeplKey
+	glossaries map[string]*Glossary
+	failures   []int
+	nextID     int
+	detected   string
+}
+
+type deeplKey struct {
+	count uint
+	limit uint
+}
+
+// NewDeepL starts a fake DeepL server, it has to be closed by the caller.
+func NewDeepL() *DeepL {
+	f := &DeepL{
+		languages:  []string{"DE", "EN", "ES", "FR", "IT", "JA", "NB", "PL"},
+		keys:       make(map[string]*deeplKey),
+		glossaries: make(map[string]*Glossary),
+		detected:   "EN",
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /v2/languages", f.handleLanguages)
+	mux.HandleFunc("POST /v2/translate", f.handleTranslate)
+	mux.HandleFunc("GET /v2/usage", f.handleUsage)
+	mux.HandleFunc("GET /v2/glossaries", f.handleGlossariesList)
+	mux.HandleFunc("POST /v2/glossaries", f.handleGlossariesCreate)
+	mux.HandleFunc("GET /v2/glossaries/{id}", f.handleGlossariesGet)
+	mux.HandleFunc("DELETE /v2/glossaries/{id}", f.handleGlossariesDelete)
+	f.server = httptest.NewServer(f.intercept(mux))
+
+	return f
+}
+
+// Close shuts down the server.
+func (f *DeepL) Close() {
+	f.server.Close()
+}
+
+// URL returns the base URL of the API, it's meant to be passed to translation.New.
+func (f *DeepL) URL() string {
+	return f.server.URL + "/v2/"
+}
+
+// SetLanguages replaces the supported languages, codes are upper case like in the DeepL API.
+func (f *DeepL) SetLanguages(languages ...string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.languages = slices.Clone(languages)
+}
+
+// SetDetectedLanguage sets the language detected in text translated without a source language, it's EN by default.
+func (f *DeepL) SetDetectedLanguage(language string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.detected = strings.ToUpper(language)
+}
+
+// AddKey adds an API key which can translate up to the limit of characters.
+func (f *DeepL) AddKey(key string, limit uint) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.keys[key] = &deeplKey{limit: limit}
+}
+
+// Usage returns the number of characters translated with the API key.
+func (f *DeepL) Usage(key string) uint {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if k, ok := f.keys[key]; ok {
+		return k.count
+	}
+	return 0
+}
+
+// AddGlossary adds a glossary of the API key and returns its ID.
+func (f *DeepL) AddGlossary(key string, glossary Glossary) string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return f.addGlossary(key, glossary)
+}
+
+func (f *DeepL) addGlossary(key string, glossary Glossary) string {
+	f.nextID++
+	glossary.ID = fmt.Sprintf("glossary%d", f.nextID)
+	glossary.key = key
+	f.glossaries[glossary.ID] = &glossary
+	return glossary.ID
+}
+
+// FailNext makes the next request fail with the status code, failures are used in the order they were added.
+func (f *DeepL) FailNext(code int) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.failures = append(f.failures, code)
+}
+
+// PseudoTranslate returns the translation of the text made by the fake.
+// The text is prefixed with the upper case target language, so XML tags in it are preserved.
+func PseudoTranslate(text, targetLanguage string) string {
+	return "[" + strings.ToUpper(targetLanguage) + "] " + text
+}
+
+func writeDeepLError(w http.ResponseWriter, code int, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	_ = json.NewEncoder(w).Encode(map[string]string{"message": message})
+}
+
+type keyKey struct{}
+
+// intercept authorizes requests and injects failures before they're handled.
+func (f *DeepL) intercept(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		key, found := strings.CutPrefix(r.Header.Get("Authorization"), "DeepL-Auth-Key ")
+
+		f.mu.Lock()
+		_, ok := f.keys[key]
+		var failure int
+		if len(f.failures) > 0 {
+			failure = f.failures[0]
+			f.failures = f.failures[1:]
+		}
+		f.mu.Unlock()
+
+		switch {
+		case !found || !ok:
+			writeDeepLError(w, http.StatusForbidden, "Authorization failed. Please supply a valid auth_key parameter.")
+		case failure != 0:
+			writeDeepLError(w, failure, "injected failure")
+		default:
+			next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), keyKey{}, key)))
+		}
+	})
+}
+
+// keyOf returns the API key the request was authorized with.
+func keyOf(r *http.Request) string {
+	key, _ := r.Context().Value(keyKey{}).(string)
+	return key
+}
+
+func (f *DeepL) handleLanguages(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	languages := make([]map[string]string, len(f.languages))
+	for i, l := range f.languages {
+		languages[i] = map[string]string{"language": l, "name": l}
+	}
+	writeJSON(w, languages)
+}
+
+type deeplTranslateRequest struct {
+	Text           []string `json:"text"`
+	SourceLanguage string   `json:"source_lang"`
+	TargetLanguage string   `json:"target_lang"`
+	GlossaryID     string   `json:"glossary_id"`
+	TagHandling    string   `json:"tag_handling"`
+}
+
+// validXML checks that the text is a well-formed XML fragment, as DeepL requires with XML tag handling.
+func validXML(text string) bool {
+	decoder := xml.NewDecoder(strings.NewReader("<text>" + text + "</text>"))
+	for {
+		_, err := decoder.Token()
+		if err == io.EOF {
+			return true
+		}
+		if err != nil {
+			return false
+		}
+	}
+}
+
+func (f *DeepL) handleTranslate(w http.ResponseWriter, r *http.Request) {
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, DeepLMaxRequestSize))
+	if err != nil {
+		writeDeepLError(w, http.StatusRequestEntityTooLarge, "Request Entity Too Large")
+		return
+	}
+	var req deeplTranslateRequest
+	if err := json.Unmarshal(body, &req); err != nil {
+		writeDeepLError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	switch {
+	case len(req.Text) == 0:
+		writeDeepLError(w, http.StatusBadRequest, "Parameter 'text' not specified.")
+		return
+	case len(req.Text) > DeepLMaxTexts:
+		writeDeepLError(w, http.StatusBadRequest, "Too many texts.")
+		return
+	case req.TargetLanguage == "":
+		writeDeepLError(w, http.StatusBadRequest, "Parameter 'target_lang' not specified.")
+		return
+	}
+	if req.TagHandling == "xml" {
+		for _, t := range req.Text {
+			if !validXML(t) {
+				writeDeepLError(w, http.StatusBadRequest, "Tag handling parsing failed, please check input.")
+				return
+			}
+		}
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if !f.supports(req.TargetLanguage) || (req.SourceLanguage != "" && !f.supports(req.SourceLanguage)) {
+		writeDeepLError(w, http.StatusBadRequest, "Value for 'target_lang' not supported.")
+		return
+	}
+
+	var glossary *Glossary
+	if req.GlossaryID != "" {
+		var ok bool
+		glossary, ok = f.glossaries[req.GlossaryID]
+		if !ok || glossary.key != keyOf(r) {
+			writeDeepLError(w, http.StatusNotFound, "Glossary not found.")
+			return
+		}
+		if !strings.EqualFold(glossary.SourceLanguage, req.SourceLanguage) || !strings.EqualFold(glossary.TargetLanguage, req.TargetLanguage) {
+			writeDeepLError(w, http.StatusBadRequest, "Glossary languages don't match the request.")
+			return
+		}
+	}
+
+	var characters uint
+	for _, t := range req.Text {
+		characters += uint(utf8.RuneCountInString(t))
+	}
+	key := f.keys[keyOf(r)]
+	if key.count+characters > key.limit {
+		writeDeepLError(w, StatusQuotaExceeded, "Quota exceeded")
+		return
+	}
+	key.count += characters
+
+	detected := strings.ToUpper(req.SourceLanguage)
+	if detected == "" {
+		detected = f.detected
+	}
+	type translation struct {
+		DetectedSourceLanguage string `json:"detected_source_language"`
+		Text                   string `json:"text"`
+	}
+	translations := make([]translation, len(req.Text))
+	for i, t := range req.Text {
+		t = glossary.apply(t)
+		translations[i] = translation{DetectedSourceLanguage: detected, Text: PseudoTranslate(t, req.TargetLanguage)}
+	}
+	writeJSON(w, map[string]any{"translations": translations})
+}
+
+// apply replaces the source terms of the glossary in the text, longer terms are replaced first.
+func (g *Glossary) apply(text string) string {
+	if g == nil {
+		return text
+	}
+	terms := slices.Collect(maps.Keys(g.Entries))
+	slices.SortFunc(terms, func(a, b string) int {
+		return cmp.Or(len(b)-len(a), strings.Compare(a, b))
+	})
+	for _, term := range terms {
+		text = strings.ReplaceAll(text, term, g.Entries[term])
+	}
+	return text
+}
+
+func (f *DeepL) supports(language string) bool {
+	return slices.ContainsFunc(f.languages, func(l string) bool {
+		return strings.EqualFold(l, language)
+	})
+}
+
+func (f *DeepL) handleUsage(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	key := f.keys[keyOf(r)]
+	writeJSON(w, map[string]uint{"character_count": key.count, "character_limit": key.limit})
+}
+
+func (g *Glossary) toAPI() map[string]any {
+	return map[string]any{
+		"glossary_id": g.ID,
+		"name":        g.Name,
+		"ready":       true,
+		"source_lang": strings.ToLower(g.SourceLanguage),
+		"target_lang": strings.ToLower(g.TargetLanguage),
+		"entry_count": len(g.Entries),
+	}
+}
+
+// ownedGlossary returns the glossary if it belongs to the API key of the request, otherwise it writes an error.
+func (f *DeepL) ownedGlossary(w http.ResponseWriter, r *http.Request) (*Glossary, bool) {
+	glossary, ok := f.glossaries[r.PathValue("id")]
+	if !ok || glossary.key != keyOf(r) {
+		writeDeepLError(w, http.StatusNotFound, "Glossary not found.")
+		return nil, false
+	}
+	return glossary, true
+}
+
+func (f *DeepL) handleGlossariesList(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	glossaries := []map[string]any{}
+	for _, g := range f.glossaries {
+		if g.key == keyOf(r) {
+			glossaries = append(glossaries, g.toAPI())
+		}
+	}
+	slices.SortFunc(glossaries, func(a, b map[string]any) int {
+		return strings.Compare(a["glossary_id"].(string), b["glossary_id"].(string))
+	})
+	writeJSON(w, map[string]any{"glossaries": glossaries})
+}
+
+func (f *DeepL) handleGlossariesCreate(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		Name           string `json:"name"`
+		SourceLanguage string `json:"source_lang"`
+		TargetLanguage string `json:"target_lang"`
+		Entries        string `json:"entries"`
+		EntriesFormat  string `json:"entries_format"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeDeepLError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+	if req.Name == "" || req.SourceLanguage == "" || req.TargetLanguage == "" {
+		writeDeepLError(w, http.StatusBadRequest, "Missing required parameters.")
+		return
+	}
+	if req.EntriesFormat != "tsv" {
+		writeDeepLError(w, http.StatusBadRequest, "Value for 'entries_format' not supported.")
+		return
+	}
+
+	entries := make(map[string]string)
+	for _, line := range strings.Split(strings.TrimSpace(req.Entries), "\n") {
+		source, target, ok := strings.Cut(line, "\t")
+		if !ok || source == "" || target == "" {
+			writeDeepLError(w, http.StatusBadRequest, "Invalid glossary entries.")
+			return
+		}
+		entries[source] = target
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if !f.supports(req.SourceLanguage) || !f.supports(req.TargetLanguage) {
+		writeDeepLError(w, http.StatusBadRequest, "Unsupported glossary languages.")
+		return
+	}
+	id := f.addGlossary(keyOf(r), Glossary{
+		Name:           req.Name,
+		SourceLanguage: req.SourceLanguage,
+		TargetLanguage: req.TargetLanguage,
+		Entries:        entries,
+	})
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	_ = json.NewEncoder(w).Encode(f.glossaries[id].toAPI())
+}
+
+func (f *DeepL) handleGlossariesGet(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	glossary, ok := f.ownedGlossary(w, r)
+	if !ok {
+		return
+	}
+	writeJSON(w, glossary.toAPI())
+}
+
+func (f *DeepL) handleGlossariesDelete(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	glossary, ok := f.ownedGlossary(w, r)
+	if !ok {
+		return
+	}
+	delete(f.glossaries, glossary.ID)
+	w.WriteHeader(http.StatusNoContent)
+}
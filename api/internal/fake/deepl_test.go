@@ -0,0 +1,132 @@
+package fake_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+
+	"github.com/pkulik0/autocc/api/internal/fake"
+)
+
+// deeplRequest makes a request to the fake DeepL server with the API key and decodes the response into v.
+func deeplRequest(c *qt.C, f *fake.DeepL, key, method, path string, body any, v any) int {
+	var data strings.Builder
+	if body != nil {
+		c.Assert(json.NewEncoder(&data).Encode(body), qt.IsNil)
+	}
+	req, err := http.NewRequest(method, f.URL()+path, strings.NewReader(data.String()))
+	c.Assert(err, qt.IsNil)
+	req.Header.Set("Authorization", "DeepL-Auth-Key "+key)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := http.DefaultClient.Do(req)
+	c.Assert(err, qt.IsNil)
+	defer resp.Body.Close()
+	if v != nil && resp.StatusCode < 300 {
+		c.Assert(json.NewDecoder(resp.Body).Decode(v), qt.IsNil)
+	}
+	return resp.StatusCode
+}
+
+type translations struct {
+	Translations []struct {
+		Text string `json:"text"`
+	} `json:"translations"`
+}
+
+func TestDeepLTranslate(t *testing.T) {
+	c := qt.New(t)
+	f := fake.NewDeepL()
+	defer f.Close()
+	f.AddKey("key", 20)
+
+	var resp translations
+	code := deeplRequest(c, f, "key", "POST", "translate", map[string]any{"text": []string{"Hello", "World"}, "source_lang": "en", "target_lang": "de"}, &resp)
+	c.Assert(code, qt.Equals, http.StatusOK)
+	c.Assert(resp.Translations, qt.HasLen, 2)
+	c.Assert(resp.Translations[0].Text, qt.Equals, "[DE] Hello")
+	c.Assert(resp.Translations[1].Text, qt.Equals, fake.PseudoTranslate("World", "de"))
+	c.Assert(f.Usage("key"), qt.Equals, uint(10))
+
+	code = deeplRequest(c, f, "key", "POST", "translate", map[string]any{"text": []string{"Hello, World!"}, "target_lang": "de"}, nil)
+	c.Assert(code, qt.Equals, fake.StatusQuotaExceeded)
+	c.Assert(f.Usage("key"), qt.Equals, uint(10))
+
+	code = deeplRequest(c, f, "key", "POST", "translate", map[string]any{"text": make([]string, fake.DeepLMaxTexts+1), "target_lang": "de"}, nil)
+	c.Assert(code, qt.Equals, http.StatusBadRequest)
+
+	code = deeplRequest(c, f, "key", "POST", "translate", map[string]any{"text": []string{strings.Repeat("a", fake.DeepLMaxRequestSize)}, "target_lang": "de"}, nil)
+	c.Assert(code, qt.Equals, http.StatusRequestEntityTooLarge)
+
+	code = deeplRequest(c, f, "key", "POST", "translate", map[string]any{"text": []string{"Hello"}, "target_lang": "xx"}, nil)
+	c.Assert(code, qt.Equals, http.StatusBadRequest)
+}
+
+func TestDeepLGlossaries(t *testing.T) {
+	c := qt.New(t)
+	f := fake.NewDeepL()
+	defer f.Close()
+	f.AddKey("key", 1000)
+	f.AddKey("other", 1000)
+
+	var glossary struct {
+		ID string `json:"glossary_id"`
+	}
+	code := deeplRequest(c, f, "key", "POST", "glossaries", map[string]any{
+		"name":           "names",
+		"source_lang":    "en",
+		"target_lang":    "de",
+		"entries":        "World\tWelt\nHello World\tHallo Welt",
+		"entries_format": "tsv",
+	}, &glossary)
+	c.Assert(code, qt.Equals, http.StatusCreated)
+
+	var resp translations
+	code = deeplRequest(c, f, "key", "POST", "translate", map[string]any{"text": []string{"Hello World, World"}, "source_lang": "en", "target_lang": "de", "glossary_id": glossary.ID}, &resp)
+	c.Assert(code, qt.Equals, http.StatusOK)
+	c.Assert(resp.Translations[0].Text, qt.Equals, "[DE] Hallo Welt, Welt")
+
+	// Glossaries of other keys can't be accessed.
+	code = deeplRequest(c, f, "other", "GET", "glossaries/"+glossary.ID, nil, nil)
+	c.Assert(code, qt.Equals, http.StatusNotFound)
+	code = deeplRequest(c, f, "other", "POST", "translate", map[string]any{"text": []string{"Hello"}, "source_lang": "en", "target_lang": "de", "glossary_id": glossary.ID}, nil)
+	c.Assert(code, qt.Equals, http.StatusNotFound)
+
+	var list struct {
+		Glossaries []map[string]any `json:"glossaries"`
+	}
+	code = deeplRequest(c, f, "key", "GET", "glossaries", nil, &list)
+	c.Assert(code, qt.Equals, http.StatusOK)
+	c.Assert(list.Glossaries, qt.HasLen, 1)
+	c.Assert(list.Glossaries[0]["entry_count"], qt.Equals, float64(2))
+
+	code = deeplRequest(c, f, "key", "DELETE", "glossaries/"+glossary.ID, nil, nil)
+	c.Assert(code, qt.Equals, http.StatusNoContent)
+	code = deeplRequest(c, f, "key", "GET", "glossaries/"+glossary.ID, nil, nil)
+	c.Assert(code, qt.Equals, http.StatusNotFound)
+}
+
+func TestDeepLFailures(t *testing.T) {
+	c := qt.New(t)
+	f := fake.NewDeepL()
+	defer f.Close()
+	f.AddKey("key", 1000)
+
+	code := deeplRequest(c, f, "invalid", "GET", "usage", nil, nil)
+	c.Assert(code, qt.Equals, http.StatusForbidden)
+
+	f.FailNext(http.StatusTooManyRequests)
+	f.FailNext(http.StatusServiceUnavailable)
+	c.Assert(deeplRequest(c, f, "key", "GET", "usage", nil, nil), qt.Equals, http.StatusTooManyRequests)
+	c.Assert(deeplRequest(c, f, "key", "GET", "usage", nil, nil), qt.Equals, http.StatusServiceUnavailable)
+
+	var usage struct {
+		Count uint `json:"character_count"`
+		Limit uint `json:"character_limit"`
+	}
+	c.Assert(deeplRequest(c, f, "key", "GET", "usage", nil, &usage), qt.Equals, http.StatusOK)
+	c.Assert(usage.Limit, qt.Equals, uint(1000))
+}
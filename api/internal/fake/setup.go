@@ -0,0 +1,62 @@
+package fake
+
+import (
+	"context"
+	"path/filepath"
+	"time"
+
+	qt "github.com/frankban/quicktest"
+	"go.uber.org/mock/gomock"
+
+	"github.com/pkulik0/autocc/api/internal/mock"
+	"github.com/pkulik0/autocc/api/internal/model"
+	"github.com/pkulik0/autocc/api/internal/store"
+)
+
+// Srt is closed captions with two cues, "Hello" and "World".
+const Srt = `1
+00:00:01,000 --> 00:00:02,000
+Hello
+
+2
+00:00:03,000 --> 00:00:04,000
+World
+`
+
+// NewStore creates a store backed by an SQLite database in a temporary directory of the test.
+func NewStore(c *qt.C) store.Store {
+	s, err := store.NewSQLite(filepath.Join(c.TempDir(), "autocc.db"), nil)
+	c.Assert(err, qt.IsNil)
+	return s
+}
+
+// Session adds a session of the user for the channel to the store using new credentials.
+// The access token of the session is authorized for the channel, which must have been added with AddChannel.
+func (f *Youtube) Session(c *qt.C, s store.Store, userID, channelID, token string) *model.CredentialsGoogle {
+	f.mu.Lock()
+	_, ok := f.channels[channelID]
+	f.tokens[token] = channelID
+	f.mu.Unlock()
+	c.Assert(ok, qt.IsTrue, qt.Commentf("channel %q not added", channelID))
+
+	credentials, err := s.AddCredentialsGoogle(context.Background(), "client-"+token, "secret")
+	c.Assert(err, qt.IsNil)
+	_, err = s.CreateSessionGoogle(context.Background(), userID, channelID, "Channel", token, "refresh", "", time.Now().Add(time.Hour), *credentials)
+	c.Assert(err, qt.IsNil)
+	return credentials
+}
+
+// PrefixTranslator returns a translator supporting the languages, translations prefix the text with the target language, e.g. "[de] Hello".
+// Further expectations can be added to it.
+func PrefixTranslator(c *qt.C, languages ...string) *mock.MockTranslator {
+	translator := mock.NewMockTranslator(gomock.NewController(c))
+	translator.EXPECT().GetLanguages(gomock.Any()).Return(languages, nil).AnyTimes()
+	translator.EXPECT().Translate(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, text []string, sourceLanguage, targetLanguage string) ([]string, error) {
+		translated := make([]string, len(text))
+		for i, t := range text {
+			translated[i] = "[" + targetLanguage + "] " + t
+		}
+		return translated, nil
+	}).AnyTimes()
+	return translator
+}
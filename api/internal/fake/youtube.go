@@ -0,0 +1,813 @@
+// Package fake implements in-memory fakes of the external APIs used by AutoCC, so it can be tested without network access.
+package fake
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"google.golang.org/api/option"
+	yt "google.golang.org/api/youtube/v3"
+)
+
+// Caption is a closed captions track stored by the fake YouTube server.
+type Caption struct {
+	ID       string
+	VideoID  string
+	Language string
+	Name     string
+	Body     string
+	// TrackKind is "standard" for uploaded closed captions and "asr" for the ones generated by YouTube.
+	TrackKind   string
+	LastUpdated time.Time
+}
+
+// Youtube is a fake of the subset of the YouTube Data API v3 used by AutoCC.
+//
+// Each access token is authorized for a single channel and requests only see resources of that channel.
+// State is kept in memory for the lifetime of the server.
+type Youtube struct {
+	server *httptest.Server
+
+	mu            sync.Mutex
+	channels      map[string]*yt.Channel
+	tokens        map[string]string
+	videos        map[string]*yt.Video
+	captions      map[string]*Caption
+	playlists     map[string]*yt.Playlist
+	playlistItems map[string][]string
+	exceeded      map[string]bool
+	failures      []apiError
+	lost          []apiError
+	nextID        int
+}
+
+type apiError struct {
+	code   int
+	reason string
+}
+
+// NewYoutube starts a fake YouTube server, it has to be closed by the caller.
+func NewYoutube() *Youtube {
+	f := &Youtube{
+		channels:      make(map[string]*yt.Channel),
+		tokens:        make(map[string]string),
+		videos:        make(map[string]*yt.Video),
+		captions:      make(map[string]*Caption),
+		playlists:     make(map[string]*yt.Playlist),
+		playlistItems: make(map[string][]string),
+		exceeded:      make(map[string]bool),
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /youtube/v3/channels", f.handleChannelsList)
+	mux.HandleFunc("PUT /youtube/v3/channels", f.handleChannelsUpdate)
+	mux.HandleFunc("GET /youtube/v3/search", f.handleSearchList)
+	mux.HandleFunc("GET /youtube/v3/videos", f.handleVideosList)
+	mux.HandleFunc("PUT /youtube/v3/videos", f.handleVideosUpdate)
+	mux.HandleFunc("GET /youtube/v3/captions", f.handleCaptionsList)
+	mux.HandleFunc("GET /youtube/v3/captions/{id}", f.handleCaptionsDownload)
+	mux.HandleFunc("POST /upload/youtube/v3/captions", f.handleCaptionsInsert)
+	mux.HandleFunc("PUT /youtube/v3/captions", f.handleCaptionsUpdate)
+	mux.HandleFunc("PUT /upload/youtube/v3/captions", f.handleCaptionsUpdate)
+	mux.HandleFunc("DELETE /youtube/v3/captions", f.handleCaptionsDelete)
+	mux.HandleFunc("GET /youtube/v3/playlists", f.handlePlaylistsList)
+	mux.HandleFunc("PUT /youtube/v3/playlists", f.handlePlaylistsUpdate)
+	mux.HandleFunc("GET /youtube/v3/playlistItems", f.handlePlaylistItemsList)
+	f.server = httptest.NewServer(f.intercept(mux))
+
+	return f
+}
+
+// Close shuts down the server.
+func (f *Youtube) Close() {
+	f.server.Close()
+}
+
+// URL returns the base URL of the server.
+func (f *Youtube) URL() string {
+	return f.server.URL + "/"
+}
+
+// Options returns client options making YouTube API clients use the server.
+func (f *Youtube) Options() []option.ClientOption {
+	return []option.ClientOption{option.WithEndpoint(f.URL())}
+}
+
+// Client returns an HTTP client authorized with the access token.
+func (f *Youtube) Client(token string) *http.Client {
+	return &http.Client{Transport: &bearerTransport{token: token}}
+}
+
+type bearerTransport struct {
+	token string
+}
+
+func (t *bearerTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	r = r.Clone(r.Context())
+	r.Header.Set("Authorization", "Bearer "+t.token)
+	return http.DefaultTransport.RoundTrip(r)
+}
+
+func (f *Youtube) newID(prefix string) string {
+	f.nextID++
+	return fmt.Sprintf("%s%d", prefix, f.nextID)
+}
+
+// AddChannel adds a channel which can be accessed with the access token.
+func (f *Youtube) AddChannel(id, title, token string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.channels[id] = &yt.Channel{
+		Id:      id,
+		Kind:    "youtube#channel",
+		Snippet: &yt.ChannelSnippet{Title: title},
+		ContentDetails: &yt.ChannelContentDetails{
+			RelatedPlaylists: &yt.ChannelContentDetailsRelatedPlaylists{Uploads: UploadsPlaylistID(id)},
+		},
+	}
+	f.tokens[token] = id
+}
+
+// UploadsPlaylistID returns the ID of the playlist with the uploads of the channel.
+func UploadsPlaylistID(channelID string) string {
+	return "UU" + channelID
+}
+
+// Channel returns a copy of the channel or nil if it doesn't exist.
+func (f *Youtube) Channel(id string) *yt.Channel {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	channel, ok := f.channels[id]
+	if !ok {
+		return nil
+	}
+	return clone(channel)
+}
+
+// AddVideo adds a video to the channel and returns its ID, an ID is generated if the video doesn't have one.
+func (f *Youtube) AddVideo(channelID string, video *yt.Video) string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	video = clone(video)
+	if video.Id == "" {
+		video.Id = f.newID("video")
+	}
+	if video.Snippet == nil {
+		video.Snippet = &yt.VideoSnippet{}
+	}
+	video.Kind = "youtube#video"
+	video.Snippet.ChannelId = channelID
+	f.videos[video.Id] = video
+	return video.Id
+}
+
+// Video returns a copy of the video or nil if it doesn't exist.
+func (f *Youtube) Video(id string) *yt.Video {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	video, ok := f.videos[id]
+	if !ok {
+		return nil
+	}
+	return clone(video)
+}
+
+// AddCaption adds closed captions to the video and returns their ID.
+func (f *Youtube) AddCaption(videoID, language, name, body string) string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	caption := &Caption{
+		ID:          f.newID("caption"),
+		VideoID:     videoID,
+		Language:    language,
+		Name:        name,
+		Body:        body,
+		TrackKind:   "standard",
+		LastUpdated: time.Now(),
+	}
+	f.captions[caption.ID] = caption
+	return caption.ID
+}
+
+// AddASRCaption adds closed captions generated by YouTube's speech recognition to the video and returns their ID.
+func (f *Youtube) AddASRCaption(videoID, language, body string) string {
+	id := f.AddCaption(videoID, language, "", body)
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.captions[id].TrackKind = "asr"
+	return id
+}
+
+// EditCaption replaces the body of the closed captions as if they were edited on YouTube at the given time.
+func (f *Youtube) EditCaption(id, body string, at time.Time) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if caption, ok := f.captions[id]; ok {
+		caption.Body = body
+		caption.LastUpdated = at
+	}
+}
+
+// Captions returns copies of closed captions of the video ordered by ID.
+func (f *Youtube) Captions(videoID string) []Caption {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	var captions []Caption
+	for _, caption := range f.captions {
+		if caption.VideoID == videoID {
+			captions = append(captions, *caption)
+		}
+	}
+	slices.SortFunc(captions, func(a, b Caption) int {
+		return strings.Compare(a.ID, b.ID)
+	})
+	return captions
+}
+
+// ExceedQuota makes all following requests made with the access token fail with `quotaExceeded`.
+func (f *Youtube) ExceedQuota(token string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.exceeded[token] = true
+}
+
+// FailNext makes the next request fail with the status code and reason, failures are used in the order they were added.
+func (f *Youtube) FailNext(code int, reason string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.failures = append(f.failures, apiError{code: code, reason: reason})
+}
+
+// LoseNextResponse makes the next request succeed, but its response is replaced with an error with the status code and reason.
+// It's what a client sees when the server fails after handling the request.
+func (f *Youtube) LoseNextResponse(code int, reason string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.lost = append(f.lost, apiError{code: code, reason: reason})
+}
+
+func clone[T any](v *T) *T {
+	data, err := json.Marshal(v)
+	if err != nil {
+		panic(err)
+	}
+	var c T
+	if err := json.Unmarshal(data, &c); err != nil {
+		panic(err)
+	}
+	return &c
+}
+
+func writeError(w http.ResponseWriter, code int, reason, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	_ = json.NewEncoder(w).Encode(map[string]any{
+		"error": map[string]any{
+			"code":    code,
+			"message": message,
+			"errors": []map[string]string{{
+				"reason":  reason,
+				"message": message,
+			}},
+		},
+	})
+}
+
+func writeJSON(w http.ResponseWriter, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+type channelKey struct{}
+
+// intercept authorizes requests and injects failures before they're handled.
+func (f *Youtube) intercept(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		token := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+
+		f.mu.Lock()
+		channelID, ok := f.tokens[token]
+		exceeded := f.exceeded[token]
+		var failure, lost *apiError
+		if len(f.failures) > 0 {
+			failure = &f.failures[0]
+			f.failures = f.failures[1:]
+		} else if ok && !exceeded && len(f.lost) > 0 {
+			lost = &f.lost[0]
+			f.lost = f.lost[1:]
+		}
+		f.mu.Unlock()
+
+		switch {
+		case !ok:
+			writeError(w, http.StatusUnauthorized, "authError", "invalid credentials")
+		case failure != nil:
+			writeError(w, failure.code, failure.reason, "injected failure")
+		case exceeded:
+			writeError(w, http.StatusForbidden, "quotaExceeded", "quota exceeded")
+		case lost != nil:
+			next.ServeHTTP(httptest.NewRecorder(), r.WithContext(context.WithValue(r.Context(), channelKey{}, channelID)))
+			writeError(w, lost.code, lost.reason, "injected failure after handling the request")
+		default:
+			next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), channelKey{}, channelID)))
+		}
+	})
+}
+
+// channelOf returns the ID of the channel the request was authorized for.
+func channelOf(r *http.Request) string {
+	channelID, _ := r.Context().Value(channelKey{}).(string)
+	return channelID
+}
+
+func (f *Youtube) handleChannelsList(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+
+	var ids []string
+	switch {
+	case query.Get("mine") == "true":
+		ids = []string{channelOf(r)}
+	case query.Get("id") != "":
+		ids = listParam(r, "id")
+	default:
+		writeError(w, http.StatusBadRequest, "missingRequiredParameter", "no filter selected")
+		return
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	resp := &yt.ChannelListResponse{Kind: "youtube#channelListResponse", Items: []*yt.Channel{}}
+	for _, id := range ids {
+		if channel, ok := f.channels[id]; ok {
+			resp.Items = append(resp.Items, clone(channel))
+		}
+	}
+	writeJSON(w, resp)
+}
+
+func (f *Youtube) handleChannelsUpdate(w http.ResponseWriter, r *http.Request) {
+	var update yt.Channel
+	if err := json.NewDecoder(r.Body).Decode(&update); err != nil {
+		writeError(w, http.StatusBadRequest, "parseError", err.Error())
+		return
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	channel, ok := f.channels[update.Id]
+	if !ok {
+		writeError(w, http.StatusNotFound, "channelNotFound", "channel not found")
+		return
+	}
+	if channel.Id != channelOf(r) {
+		writeError(w, http.StatusForbidden, "channelForbidden", "channel of another account")
+		return
+	}
+
+	updated := clone(channel)
+	for _, part := range parts(r) {
+		switch part {
+		case "brandingSettings":
+			updated.BrandingSettings = update.BrandingSettings
+		case "localizations":
+			updated.Localizations = update.Localizations
+		default:
+			writeError(w, http.StatusBadRequest, "invalidPart", "unsupported part "+part)
+			return
+		}
+	}
+	if len(updated.Localizations) > 0 && (updated.BrandingSettings == nil || updated.BrandingSettings.Channel == nil || updated.BrandingSettings.Channel.DefaultLanguage == "") {
+		writeError(w, http.StatusBadRequest, "defaultLanguageNotSet", "localizations require the default language")
+		return
+	}
+
+	f.channels[channel.Id] = updated
+	writeJSON(w, clone(updated))
+}
+
+// page returns the items of the page and the token of the next one, page tokens are offsets.
+func page[T any](items []T, r *http.Request) ([]T, string, error) {
+	query := r.URL.Query()
+
+	maxResults := 5
+	if query.Get("maxResults") != "" {
+		var err error
+		maxResults, err = strconv.Atoi(query.Get("maxResults"))
+		if err != nil || maxResults < 0 || maxResults > 50 {
+			return nil, "", fmt.Errorf("invalid maxResults")
+		}
+	}
+	offset := 0
+	if query.Get("pageToken") != "" {
+		var err error
+		offset, err = strconv.Atoi(query.Get("pageToken"))
+		if err != nil || offset < 0 || offset > len(items) {
+			return nil, "", fmt.Errorf("invalid pageToken")
+		}
+	}
+
+	end := min(offset+maxResults, len(items))
+	next := ""
+	if end < len(items) {
+		next = strconv.Itoa(end)
+	}
+	return items[offset:end], next, nil
+}
+
+// channelVideos returns videos of the channel, the newest first.
+func (f *Youtube) channelVideos(channelID string) []*yt.Video {
+	var videos []*yt.Video
+	for _, video := range f.videos {
+		if video.Snippet.ChannelId == channelID {
+			videos = append(videos, video)
+		}
+	}
+	slices.SortFunc(videos, func(a, b *yt.Video) int {
+		if c := strings.Compare(b.Snippet.PublishedAt, a.Snippet.PublishedAt); c != 0 {
+			return c
+		}
+		return strings.Compare(a.Id, b.Id)
+	})
+	return videos
+}
+
+func (f *Youtube) handleSearchList(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	if query.Get("forMine") != "true" || query.Get("type") != "video" {
+		writeError(w, http.StatusBadRequest, "invalidSearchFilter", "only searching videos of the channel is supported")
+		return
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	videos, next, err := page(f.channelVideos(channelOf(r)), r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalidParameter", err.Error())
+		return
+	}
+
+	resp := &yt.SearchListResponse{Kind: "youtube#searchListResponse", Items: []*yt.SearchResult{}, NextPageToken: next}
+	for _, video := range videos {
+		resp.Items = append(resp.Items, &yt.SearchResult{
+			Kind: "youtube#searchResult",
+			Id:   &yt.ResourceId{Kind: "youtube#video", VideoId: video.Id},
+			Snippet: &yt.SearchResultSnippet{
+				ChannelId:   video.Snippet.ChannelId,
+				Title:       video.Snippet.Title,
+				Description: video.Snippet.Description,
+				PublishedAt: video.Snippet.PublishedAt,
+				Thumbnails:  video.Snippet.Thumbnails,
+			},
+		})
+	}
+	writeJSON(w, resp)
+}
+
+// filterParts returns a copy of the video with only the requested parts.
+func filterParts(video *yt.Video, parts []string) *yt.Video {
+	filtered := &yt.Video{Id: video.Id, Kind: video.Kind}
+	for _, part := range parts {
+		switch part {
+		case "snippet":
+			filtered.Snippet = video.Snippet
+		case "localizations":
+			filtered.Localizations = video.Localizations
+		case "status":
+			filtered.Status = video.Status
+		case "contentDetails":
+			filtered.ContentDetails = video.ContentDetails
+		}
+	}
+	return clone(filtered)
+}
+
+// parts returns the requested parts, they can be passed as separate parameters or separated by commas.
+func parts(r *http.Request) []string {
+	return listParam(r, "part")
+}
+
+// listParam returns the values of a list parameter, which can be repeated or comma separated.
+func listParam(r *http.Request, name string) []string {
+	var values []string
+	for _, value := range r.URL.Query()[name] {
+		values = append(values, strings.Split(value, ",")...)
+	}
+	return values
+}
+
+func (f *Youtube) handleVideosList(w http.ResponseWriter, r *http.Request) {
+	ids := listParam(r, "id")
+	if len(ids) == 0 {
+		writeError(w, http.StatusBadRequest, "missingRequiredParameter", "no filter selected")
+		return
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	resp := &yt.VideoListResponse{Kind: "youtube#videoListResponse", Items: []*yt.Video{}}
+	for _, id := range ids {
+		video, ok := f.videos[id]
+		if !ok {
+			continue
+		}
+		filtered := filterParts(video, parts(r))
+		if slices.Contains(parts(r), "contentDetails") {
+			if filtered.ContentDetails == nil {
+				filtered.ContentDetails = &yt.VideoContentDetails{}
+			}
+			filtered.ContentDetails.Caption = strconv.FormatBool(f.hasCaptions(id))
+		}
+		resp.Items = append(resp.Items, filtered)
+	}
+	writeJSON(w, resp)
+}
+
+func (f *Youtube) hasCaptions(videoID string) bool {
+	for _, caption := range f.captions {
+		if caption.VideoID == videoID {
+			return true
+		}
+	}
+	return false
+}
+
+// ownedVideo returns the video if it belongs to the channel of the request, otherwise it writes an error.
+func (f *Youtube) ownedVideo(w http.ResponseWriter, r *http.Request, id string) (*yt.Video, bool) {
+	video, ok := f.videos[id]
+	if !ok {
+		writeError(w, http.StatusNotFound, "videoNotFound", "video not found")
+		return nil, false
+	}
+	if video.Snippet.ChannelId != channelOf(r) {
+		writeError(w, http.StatusForbidden, "forbidden", "video of another channel")
+		return nil, false
+	}
+	return video, true
+}
+
+func (f *Youtube) handleVideosUpdate(w http.ResponseWriter, r *http.Request) {
+	var update yt.Video
+	if err := json.NewDecoder(r.Body).Decode(&update); err != nil {
+		writeError(w, http.StatusBadRequest, "parseError", err.Error())
+		return
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	video, ok := f.ownedVideo(w, r, update.Id)
+	if !ok {
+		return
+	}
+
+	// Updated parts are replaced as a whole, like in the real API, read-only fields are kept.
+	updated := clone(video)
+	for _, part := range parts(r) {
+		switch part {
+		case "snippet":
+			if update.Snippet == nil || update.Snippet.Title == "" {
+				writeError(w, http.StatusBadRequest, "invalidTitle", "title is required")
+				return
+			}
+			if update.Snippet.CategoryId == "" {
+				writeError(w, http.StatusBadRequest, "invalidCategoryId", "category is required")
+				return
+			}
+			update.Snippet.ChannelId = video.Snippet.ChannelId
+			update.Snippet.PublishedAt = video.Snippet.PublishedAt
+			update.Snippet.Thumbnails = video.Snippet.Thumbnails
+			updated.Snippet = update.Snippet
+		case "localizations":
+			updated.Localizations = update.Localizations
+		case "status":
+			updated.Status = update.Status
+		default:
+			writeError(w, http.StatusBadRequest, "invalidPart", "unsupported part "+part)
+			return
+		}
+	}
+	if len(updated.Localizations) > 0 && updated.Snippet.DefaultLanguage == "" {
+		writeError(w, http.StatusBadRequest, "defaultLanguageNotSet", "localizations require the default language")
+		return
+	}
+
+	f.videos[video.Id] = updated
+	writeJSON(w, filterParts(updated, parts(r)))
+}
+
+func (c *Caption) toAPI() *yt.Caption {
+	return &yt.Caption{
+		Id:   c.ID,
+		Kind: "youtube#caption",
+		Snippet: &yt.CaptionSnippet{
+			VideoId:     c.VideoID,
+			Language:    c.Language,
+			Name:        c.Name,
+			TrackKind:   c.TrackKind,
+			LastUpdated: c.LastUpdated.Format(time.RFC3339Nano),
+		},
+	}
+}
+
+func (f *Youtube) handleCaptionsList(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	videoID := r.URL.Query().Get("videoId")
+	if _, ok := f.ownedVideo(w, r, videoID); !ok {
+		return
+	}
+
+	resp := &yt.CaptionListResponse{Kind: "youtube#captionListResponse", Items: []*yt.Caption{}}
+	for _, caption := range f.captions {
+		if caption.VideoID == videoID {
+			resp.Items = append(resp.Items, caption.toAPI())
+		}
+	}
+	slices.SortFunc(resp.Items, func(a, b *yt.Caption) int {
+		return strings.Compare(a.Id, b.Id)
+	})
+	writeJSON(w, resp)
+}
+
+// ownedCaption returns the closed captions if their video belongs to the channel of the request, otherwise it writes an error.
+func (f *Youtube) ownedCaption(w http.ResponseWriter, r *http.Request, id string) (*Caption, bool) {
+	caption, ok := f.captions[id]
+	if !ok {
+		writeError(w, http.StatusNotFound, "captionNotFound", "caption not found")
+		return nil, false
+	}
+	if _, ok := f.ownedVideo(w, r, caption.VideoID); !ok {
+		return nil, false
+	}
+	return caption, true
+}
+
+func (f *Youtube) handleCaptionsDownload(w http.ResponseWriter, r *http.Request) {
+	if tfmt := r.URL.Query().Get("tfmt"); tfmt != "" && tfmt != "srt" {
+		writeError(w, http.StatusBadRequest, "invalidValue", "only srt is supported")
+		return
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	caption, ok := f.ownedCaption(w, r, r.PathValue("id"))
+	if !ok {
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/plain")
+	_, _ = io.WriteString(w, caption.Body)
+}
+
+// readUpload returns the metadata and the media of a multipart upload, the media is nil for metadata only requests.
+func readUpload(r *http.Request) ([]byte, []byte, error) {
+	mediaType, params, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if err != nil {
+		return nil, nil, err
+	}
+	if !strings.HasPrefix(mediaType, "multipart/") {
+		metadata, err := io.ReadAll(r.Body)
+		return metadata, nil, err
+	}
+
+	reader := multipart.NewReader(r.Body, params["boundary"])
+	var parts [][]byte
+	for {
+		part, err := reader.NextPart()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, nil, err
+		}
+		data, err := io.ReadAll(part)
+		if err != nil {
+			return nil, nil, err
+		}
+		parts = append(parts, data)
+	}
+	if len(parts) != 2 {
+		return nil, nil, fmt.Errorf("expected metadata and media, got %d parts", len(parts))
+	}
+	return parts[0], parts[1], nil
+}
+
+// captionMetadata is decoded separately from yt.Caption to check which fields were sent.
+type captionMetadata struct {
+	ID      string         `json:"id"`
+	Snippet map[string]any `json:"snippet"`
+}
+
+func (f *Youtube) handleCaptionsInsert(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Query().Get("uploadType") != "multipart" {
+		writeError(w, http.StatusBadRequest, "invalidUploadType", "only multipart uploads are supported")
+		return
+	}
+	data, media, err := readUpload(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "parseError", err.Error())
+		return
+	}
+	var metadata captionMetadata
+	if err := json.Unmarshal(data, &metadata); err != nil {
+		writeError(w, http.StatusBadRequest, "parseError", err.Error())
+		return
+	}
+
+	videoID, _ := metadata.Snippet["videoId"].(string)
+	language, _ := metadata.Snippet["language"].(string)
+	name, hasName := metadata.Snippet["name"].(string)
+	if !hasName {
+		writeError(w, http.StatusBadRequest, "nameRequired", "snippet.name is required")
+		return
+	}
+	if language == "" {
+		writeError(w, http.StatusBadRequest, "invalidValue", "snippet.language is required")
+		return
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if _, ok := f.ownedVideo(w, r, videoID); !ok {
+		return
+	}
+
+	caption := &Caption{
+		ID:          f.newID("caption"),
+		VideoID:     videoID,
+		Language:    language,
+		Name:        name,
+		Body:        string(media),
+		TrackKind:   "standard",
+		LastUpdated: time.Now(),
+	}
+	f.captions[caption.ID] = caption
+	writeJSON(w, caption.toAPI())
+}
+
+func (f *Youtube) handleCaptionsUpdate(w http.ResponseWriter, r *http.Request) {
+	data, media, err := readUpload(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "parseError", err.Error())
+		return
+	}
+	var metadata captionMetadata
+	if err := json.Unmarshal(data, &metadata); err != nil {
+		writeError(w, http.StatusBadRequest, "parseError", err.Error())
+		return
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	caption, ok := f.ownedCaption(w, r, metadata.ID)
+	if !ok {
+		return
+	}
+	if media != nil {
+		caption.Body = string(media)
+		caption.LastUpdated = time.Now()
+	}
+	writeJSON(w, caption.toAPI())
+}
+
+func (f *Youtube) handleCaptionsDelete(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	id := r.URL.Query().Get("id")
+	if _, ok := f.ownedCaption(w, r, id); !ok {
+		return
+	}
+	delete(f.captions, id)
+	w.WriteHeader(http.StatusNoContent)
+}
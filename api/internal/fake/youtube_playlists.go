@@ -0,0 +1,215 @@
+package fake
+
+import (
+	"encoding/json"
+	"net/http"
+	"slices"
+	"strings"
+
+	yt "google.golang.org/api/youtube/v3"
+)
+
+// AddPlaylist adds a playlist of the channel containing the videos and returns its ID, an ID is generated if the playlist doesn't have one.
+// Playlists without a status are public.
+func (f *Youtube) AddPlaylist(channelID string, playlist *yt.Playlist, videoIDs ...string) string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	playlist = clone(playlist)
+	if playlist.Id == "" {
+		playlist.Id = f.newID("playlist")
+	}
+	if playlist.Snippet == nil {
+		playlist.Snippet = &yt.PlaylistSnippet{}
+	}
+	playlist.Kind = "youtube#playlist"
+	playlist.Snippet.ChannelId = channelID
+	f.playlists[playlist.Id] = playlist
+	f.playlistItems[playlist.Id] = slices.Clone(videoIDs)
+	return playlist.Id
+}
+
+// Playlist returns a copy of the playlist or nil if it doesn't exist.
+func (f *Youtube) Playlist(id string) *yt.Playlist {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	playlist, ok := f.playlists[id]
+	if !ok {
+		return nil
+	}
+	return clone(playlist)
+}
+
+func (f *Youtube) handlePlaylistsList(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	var playlists []*yt.Playlist
+	switch {
+	case query.Get("mine") == "true" || query.Get("channelId") != "":
+		// Only the owner of the channel lists its private and unlisted playlists.
+		channelID, mine := query.Get("channelId"), true
+		if channelID == "" {
+			channelID = channelOf(r)
+		} else {
+			mine = false
+		}
+		for _, playlist := range f.playlists {
+			public := playlist.Status == nil || playlist.Status.PrivacyStatus == "public"
+			if playlist.Snippet.ChannelId == channelID && (mine || public) {
+				playlists = append(playlists, playlist)
+			}
+		}
+		slices.SortFunc(playlists, func(a, b *yt.Playlist) int {
+			return strings.Compare(a.Id, b.Id)
+		})
+	case query.Get("id") != "":
+		for _, id := range listParam(r, "id") {
+			if playlist, ok := f.playlists[id]; ok {
+				playlists = append(playlists, playlist)
+			}
+		}
+	default:
+		writeError(w, http.StatusBadRequest, "missingRequiredParameter", "no filter selected")
+		return
+	}
+
+	playlists, next, err := page(playlists, r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalidParameter", err.Error())
+		return
+	}
+
+	resp := &yt.PlaylistListResponse{Kind: "youtube#playlistListResponse", Items: []*yt.Playlist{}, NextPageToken: next}
+	for _, playlist := range playlists {
+		resp.Items = append(resp.Items, clone(playlist))
+	}
+	writeJSON(w, resp)
+}
+
+func (f *Youtube) handlePlaylistsUpdate(w http.ResponseWriter, r *http.Request) {
+	var update yt.Playlist
+	if err := json.NewDecoder(r.Body).Decode(&update); err != nil {
+		writeError(w, http.StatusBadRequest, "parseError", err.Error())
+		return
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	playlist, ok := f.playlists[update.Id]
+	if !ok {
+		writeError(w, http.StatusNotFound, "playlistNotFound", "playlist not found")
+		return
+	}
+	if playlist.Snippet.ChannelId != channelOf(r) {
+		writeError(w, http.StatusForbidden, "playlistForbidden", "playlist of another channel")
+		return
+	}
+
+	// Updated parts are replaced as a whole, like in the real API, read-only fields are kept.
+	updated := clone(playlist)
+	for _, part := range parts(r) {
+		switch part {
+		case "snippet":
+			if update.Snippet == nil || update.Snippet.Title == "" {
+				writeError(w, http.StatusBadRequest, "playlistTitleRequired", "title is required")
+				return
+			}
+			update.Snippet.ChannelId = playlist.Snippet.ChannelId
+			update.Snippet.PublishedAt = playlist.Snippet.PublishedAt
+			update.Snippet.Thumbnails = playlist.Snippet.Thumbnails
+			updated.Snippet = update.Snippet
+		case "localizations":
+			updated.Localizations = update.Localizations
+		case "status":
+			updated.Status = update.Status
+		default:
+			writeError(w, http.StatusBadRequest, "invalidPart", "unsupported part "+part)
+			return
+		}
+	}
+	if len(updated.Localizations) > 0 && updated.Snippet.DefaultLanguage == "" {
+		writeError(w, http.StatusBadRequest, "defaultLanguageNotSet", "localizations require the default language")
+		return
+	}
+
+	f.playlists[playlist.Id] = updated
+	writeJSON(w, clone(updated))
+}
+
+func (f *Youtube) handlePlaylistItemsList(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	playlistID := query.Get("playlistId")
+	if playlistID == "" {
+		writeError(w, http.StatusBadRequest, "missingRequiredParameter", "no filter selected")
+		return
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	channelID, videoIDs, ok := f.playlistVideos(playlistID)
+	if !ok {
+		writeError(w, http.StatusNotFound, "playlistNotFound", "playlist not found")
+		return
+	}
+
+	var items []*yt.PlaylistItem
+	for i, videoID := range videoIDs {
+		video, ok := f.videos[videoID]
+		if !ok || (query.Get("videoId") != "" && query.Get("videoId") != videoID) {
+			continue
+		}
+		items = append(items, &yt.PlaylistItem{
+			Id:   playlistID + "-" + videoID,
+			Kind: "youtube#playlistItem",
+			Snippet: &yt.PlaylistItemSnippet{
+				PlaylistId:  playlistID,
+				ChannelId:   channelID,
+				Title:       video.Snippet.Title,
+				Description: video.Snippet.Description,
+				PublishedAt: video.Snippet.PublishedAt,
+				Thumbnails:  video.Snippet.Thumbnails,
+				Position:    int64(i),
+				ResourceId:  &yt.ResourceId{Kind: "youtube#video", VideoId: videoID},
+			},
+			ContentDetails: &yt.PlaylistItemContentDetails{
+				VideoId:          videoID,
+				VideoPublishedAt: video.Snippet.PublishedAt,
+			},
+		})
+	}
+
+	items, next, err := page(items, r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalidParameter", err.Error())
+		return
+	}
+
+	resp := &yt.PlaylistItemListResponse{Kind: "youtube#playlistItemListResponse", Items: []*yt.PlaylistItem{}, NextPageToken: next}
+	for _, item := range items {
+		resp.Items = append(resp.Items, clone(item))
+	}
+	writeJSON(w, resp)
+}
+
+// playlistVideos returns the channel and the videos of the playlist, the uploads playlist of a channel has all of its videos.
+func (f *Youtube) playlistVideos(playlistID string) (string, []string, bool) {
+	if playlist, ok := f.playlists[playlistID]; ok {
+		return playlist.Snippet.ChannelId, f.playlistItems[playlistID], true
+	}
+
+	channelID, ok := strings.CutPrefix(playlistID, UploadsPlaylistID(""))
+	if _, exists := f.channels[channelID]; !ok || !exists {
+		return "", nil, false
+	}
+	var videoIDs []string
+	for _, video := range f.channelVideos(channelID) {
+		videoIDs = append(videoIDs, video.Id)
+	}
+	return channelID, videoIDs, true
+}
@@ -0,0 +1,95 @@
+package fake_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+	"google.golang.org/api/googleapi"
+	"google.golang.org/api/option"
+	yt "google.golang.org/api/youtube/v3"
+
+	"github.com/pkulik0/autocc/api/internal/fake"
+)
+
+func newService(c *qt.C, f *fake.Youtube, token string) *yt.Service {
+	service, err := yt.NewService(context.Background(), append(f.Options(), option.WithHTTPClient(f.Client(token)))...)
+	c.Assert(err, qt.IsNil)
+	return service
+}
+
+func assertAPIError(c *qt.C, err error, code int, reason string) {
+	var apiErr *googleapi.Error
+	c.Assert(errors.As(err, &apiErr), qt.IsTrue, qt.Commentf("%v", err))
+	c.Assert(apiErr.Code, qt.Equals, code)
+	c.Assert(apiErr.Errors, qt.HasLen, 1)
+	c.Assert(apiErr.Errors[0].Reason, qt.Equals, reason)
+}
+
+func TestYoutubeCaptions(t *testing.T) {
+	c := qt.New(t)
+	f := fake.NewYoutube()
+	defer f.Close()
+
+	f.AddChannel("channel", "Channel", "token")
+	f.AddChannel("other", "Other", "other")
+	videoID := f.AddVideo("channel", &yt.Video{})
+	service := newService(c, f, "token")
+
+	caption, err := service.Captions.Insert([]string{"snippet"}, &yt.Caption{Snippet: &yt.CaptionSnippet{
+		VideoId:         videoID,
+		Language:        "en",
+		ForceSendFields: []string{"Name"},
+	}}).Media(strings.NewReader("first")).Do()
+	c.Assert(err, qt.IsNil)
+
+	_, err = service.Captions.Update([]string{"snippet"}, &yt.Caption{Id: caption.Id}).Media(strings.NewReader("second")).Do()
+	c.Assert(err, qt.IsNil)
+	c.Assert(f.Captions(videoID)[0].Body, qt.Equals, "second")
+
+	// The name is required, even if it's empty.
+	_, err = service.Captions.Insert([]string{"snippet"}, &yt.Caption{Snippet: &yt.CaptionSnippet{
+		VideoId:  videoID,
+		Language: "en",
+	}}).Media(strings.NewReader("data")).Do()
+	assertAPIError(c, err, http.StatusBadRequest, "nameRequired")
+
+	// Captions of other channels can't be accessed.
+	err = newService(c, f, "other").Captions.Delete(caption.Id).Do()
+	assertAPIError(c, err, http.StatusForbidden, "forbidden")
+
+	err = service.Captions.Delete(caption.Id).Do()
+	c.Assert(err, qt.IsNil)
+	c.Assert(f.Captions(videoID), qt.HasLen, 0)
+
+	err = service.Captions.Delete(caption.Id).Do()
+	assertAPIError(c, err, http.StatusNotFound, "captionNotFound")
+}
+
+func TestYoutubeFailures(t *testing.T) {
+	c := qt.New(t)
+	f := fake.NewYoutube()
+	defer f.Close()
+
+	f.AddChannel("channel", "Channel", "token")
+	service := newService(c, f, "token")
+
+	_, err := newService(c, f, "invalid").Channels.List([]string{"snippet"}).Mine(true).Do()
+	assertAPIError(c, err, http.StatusUnauthorized, "authError")
+
+	f.FailNext(http.StatusInternalServerError, "backendError")
+	_, err = service.Channels.List([]string{"snippet"}).Mine(true).Do()
+	assertAPIError(c, err, http.StatusInternalServerError, "backendError")
+
+	resp, err := service.Channels.List([]string{"snippet"}).Mine(true).Do()
+	c.Assert(err, qt.IsNil)
+	c.Assert(resp.Items, qt.HasLen, 1)
+	c.Assert(resp.Items[0].Snippet.Title, qt.Equals, "Channel")
+
+	f.ExceedQuota("token")
+	_, err = service.Channels.List([]string{"snippet"}).Mine(true).Do()
+	assertAPIError(c, err, http.StatusForbidden, "quotaExceeded")
+}
@@ -35,6 +35,10 @@ func ErrLog(w http.ResponseWriter, err error, message string, status int) {
 	switch status {
 	case http.StatusBadRequest:
 		http.Error(w, "Bad request", status)
+	case http.StatusNotFound:
+		http.Error(w, "Not found", status)
+	case http.StatusConflict:
+		http.Error(w, "Conflict", status)
 	case http.StatusInternalServerError:
 		http.Error(w, "Internal server error", status)
 	default:
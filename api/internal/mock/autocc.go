@@ -0,0 +1,161 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/pkulik0/autocc/api/internal/autocc (interfaces: AutoCC)
+//
+// Generated by this command:
+//
+//	mockgen -destination=../mock/autocc.go -package=mock . AutoCC
+//
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	autocc "github.com/pkulik0/autocc/api/internal/autocc"
+	pb "github.com/pkulik0/autocc/api/internal/pb"
+	srt "github.com/pkulik0/autocc/api/internal/srt"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockAutoCC is a mock of AutoCC interface.
+type MockAutoCC struct {
+	ctrl     *gomock.Controller
+	recorder *MockAutoCCMockRecorder
+	isgomock struct{}
+}
+
+// MockAutoCCMockRecorder is the mock recorder for MockAutoCC.
+type MockAutoCCMockRecorder struct {
+	mock *MockAutoCC
+}
+
+// NewMockAutoCC creates a new mock instance.
+func NewMockAutoCC(ctrl *gomock.Controller) *MockAutoCC {
+	mock := &MockAutoCC{ctrl: ctrl}
+	mock.recorder = &MockAutoCCMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAutoCC) EXPECT() *MockAutoCCMockRecorder {
+	return m.recorder
+}
+
+// AdjustSourceTiming mocks base method.
+func (m *MockAutoCC) AdjustSourceTiming(ctx context.Context, userID, videoID string, timing srt.Timing) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AdjustSourceTiming", ctx, userID, videoID, timing)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AdjustSourceTiming indicates an expected call of AdjustSourceTiming.
+func (mr *MockAutoCCMockRecorder) AdjustSourceTiming(ctx, userID, videoID, timing any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AdjustSourceTiming", reflect.TypeOf((*MockAutoCC)(nil).AdjustSourceTiming), ctx, userID, videoID, timing)
+}
+
+// DetectLanguage mocks base method.
+func (m *MockAutoCC) DetectLanguage(ctx context.Context, userID, channelID, videoID string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DetectLanguage", ctx, userID, channelID, videoID)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DetectLanguage indicates an expected call of DetectLanguage.
+func (mr *MockAutoCCMockRecorder) DetectLanguage(ctx, userID, channelID, videoID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DetectLanguage", reflect.TypeOf((*MockAutoCC)(nil).DetectLanguage), ctx, userID, channelID, videoID)
+}
+
+// ExportCC mocks base method.
+func (m *MockAutoCC) ExportCC(ctx context.Context, userID, channelID, videoID string, format srt.Format) ([]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExportCC", ctx, userID, channelID, videoID, format)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExportCC indicates an expected call of ExportCC.
+func (mr *MockAutoCCMockRecorder) ExportCC(ctx, userID, channelID, videoID, format any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExportCC", reflect.TypeOf((*MockAutoCC)(nil).ExportCC), ctx, userID, channelID, videoID, format)
+}
+
+// ListVideos mocks base method.
+func (m *MockAutoCC) ListVideos(ctx context.Context, userID, channelID, nextPageToken string, options autocc.ListOptions) ([]*pb.Video, string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListVideos", ctx, userID, channelID, nextPageToken, options)
+	ret0, _ := ret[0].([]*pb.Video)
+	ret1, _ := ret[1].(string)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ListVideos indicates an expected call of ListVideos.
+func (mr *MockAutoCCMockRecorder) ListVideos(ctx, userID, channelID, nextPageToken, options any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListVideos", reflect.TypeOf((*MockAutoCC)(nil).ListVideos), ctx, userID, channelID, nextPageToken, options)
+}
+
+// Process mocks base method.
+func (m *MockAutoCC) Process(ctx context.Context, userID, channelID, videoID string, options autocc.Options) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Process", ctx, userID, channelID, videoID, options)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Process indicates an expected call of Process.
+func (mr *MockAutoCCMockRecorder) Process(ctx, userID, channelID, videoID, options any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Process", reflect.TypeOf((*MockAutoCC)(nil).Process), ctx, userID, channelID, videoID, options)
+}
+
+// RemoveSourceCC mocks base method.
+func (m *MockAutoCC) RemoveSourceCC(ctx context.Context, userID, videoID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveSourceCC", ctx, userID, videoID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveSourceCC indicates an expected call of RemoveSourceCC.
+func (mr *MockAutoCCMockRecorder) RemoveSourceCC(ctx, userID, videoID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveSourceCC", reflect.TypeOf((*MockAutoCC)(nil).RemoveSourceCC), ctx, userID, videoID)
+}
+
+// RemoveTranslationMemory mocks base method.
+func (m *MockAutoCC) RemoveTranslationMemory(ctx context.Context, language string) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveTranslationMemory", ctx, language)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RemoveTranslationMemory indicates an expected call of RemoveTranslationMemory.
+func (mr *MockAutoCCMockRecorder) RemoveTranslationMemory(ctx, language any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveTranslationMemory", reflect.TypeOf((*MockAutoCC)(nil).RemoveTranslationMemory), ctx, language)
+}
+
+// SetSourceCC mocks base method.
+func (m *MockAutoCC) SetSourceCC(ctx context.Context, userID, videoID, language string, format srt.Format, data string, uploadOriginal bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetSourceCC", ctx, userID, videoID, language, format, data, uploadOriginal)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetSourceCC indicates an expected call of SetSourceCC.
+func (mr *MockAutoCCMockRecorder) SetSourceCC(ctx, userID, videoID, language, format, data, uploadOriginal any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetSourceCC", reflect.TypeOf((*MockAutoCC)(nil).SetSourceCC), ctx, userID, videoID, language, format, data, uploadOriginal)
+}
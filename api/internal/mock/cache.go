@@ -14,6 +14,7 @@ import (
 	reflect "reflect"
 	time "time"
 
+	cache "github.com/pkulik0/autocc/api/internal/cache"
 	gomock "go.uber.org/mock/gomock"
 )
 
@@ -85,6 +86,51 @@ func (mr *MockCacheMockRecorder) GetList(ctx, key any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetList", reflect.TypeOf((*MockCache)(nil).GetList), ctx, key)
 }
 
+// Invalidate mocks base method.
+func (m *MockCache) Invalidate(ctx context.Context, tag string) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Invalidate", ctx, tag)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Invalidate indicates an expected call of Invalidate.
+func (mr *MockCacheMockRecorder) Invalidate(ctx, tag any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Invalidate", reflect.TypeOf((*MockCache)(nil).Invalidate), ctx, tag)
+}
+
+// Keys mocks base method.
+func (m *MockCache) Keys(ctx context.Context, namespace cache.Namespace) ([]cache.KeyInfo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Keys", ctx, namespace)
+	ret0, _ := ret[0].([]cache.KeyInfo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Keys indicates an expected call of Keys.
+func (mr *MockCacheMockRecorder) Keys(ctx, namespace any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Keys", reflect.TypeOf((*MockCache)(nil).Keys), ctx, namespace)
+}
+
+// Lock mocks base method.
+func (m *MockCache) Lock(ctx context.Context, key string, expiration time.Duration) (func() error, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Lock", ctx, key, expiration)
+	ret0, _ := ret[0].(func() error)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Lock indicates an expected call of Lock.
+func (mr *MockCacheMockRecorder) Lock(ctx, key, expiration any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Lock", reflect.TypeOf((*MockCache)(nil).Lock), ctx, key, expiration)
+}
+
 // Set mocks base method.
 func (m *MockCache) Set(ctx context.Context, key, value string, expiration time.Duration) error {
 	m.ctrl.T.Helper()
@@ -112,3 +158,32 @@ func (mr *MockCacheMockRecorder) SetList(ctx, key, value, expiration any) *gomoc
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetList", reflect.TypeOf((*MockCache)(nil).SetList), ctx, key, value, expiration)
 }
+
+// Stats mocks base method.
+func (m *MockCache) Stats(ctx context.Context) (map[cache.Namespace]cache.Stats, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Stats", ctx)
+	ret0, _ := ret[0].(map[cache.Namespace]cache.Stats)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Stats indicates an expected call of Stats.
+func (mr *MockCacheMockRecorder) Stats(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Stats", reflect.TypeOf((*MockCache)(nil).Stats), ctx)
+}
+
+// Tag mocks base method.
+func (m *MockCache) Tag(ctx context.Context, key string, tags []string, expiration time.Duration) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Tag", ctx, key, tags, expiration)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Tag indicates an expected call of Tag.
+func (mr *MockCacheMockRecorder) Tag(ctx, key, tags, expiration any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Tag", reflect.TypeOf((*MockCache)(nil).Tag), ctx, key, tags, expiration)
+}
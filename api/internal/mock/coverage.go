@@ -0,0 +1,74 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/pkulik0/autocc/api/internal/coverage (interfaces: Coverage)
+//
+// Generated by this command:
+//
+//	mockgen -destination=../mock/coverage.go -package=mock . Coverage
+//
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	pb "github.com/pkulik0/autocc/api/internal/pb"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockCoverage is a mock of Coverage interface.
+type MockCoverage struct {
+	ctrl     *gomock.Controller
+	recorder *MockCoverageMockRecorder
+	isgomock struct{}
+}
+
+// MockCoverageMockRecorder is the mock recorder for MockCoverage.
+type MockCoverageMockRecorder struct {
+	mock *MockCoverage
+}
+
+// NewMockCoverage creates a new mock instance.
+func NewMockCoverage(ctrl *gomock.Controller) *MockCoverage {
+	mock := &MockCoverage{ctrl: ctrl}
+	mock.recorder = &MockCoverageMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCoverage) EXPECT() *MockCoverageMockRecorder {
+	return m.recorder
+}
+
+// GetChannel mocks base method.
+func (m *MockCoverage) GetChannel(ctx context.Context, userID, channelID, nextPageToken string) ([]*pb.VideoCoverage, []*pb.LanguageSummary, string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetChannel", ctx, userID, channelID, nextPageToken)
+	ret0, _ := ret[0].([]*pb.VideoCoverage)
+	ret1, _ := ret[1].([]*pb.LanguageSummary)
+	ret2, _ := ret[2].(string)
+	ret3, _ := ret[3].(error)
+	return ret0, ret1, ret2, ret3
+}
+
+// GetChannel indicates an expected call of GetChannel.
+func (mr *MockCoverageMockRecorder) GetChannel(ctx, userID, channelID, nextPageToken any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetChannel", reflect.TypeOf((*MockCoverage)(nil).GetChannel), ctx, userID, channelID, nextPageToken)
+}
+
+// GetVideo mocks base method.
+func (m *MockCoverage) GetVideo(ctx context.Context, userID, channelID, videoID string) (*pb.VideoCoverage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetVideo", ctx, userID, channelID, videoID)
+	ret0, _ := ret[0].(*pb.VideoCoverage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetVideo indicates an expected call of GetVideo.
+func (mr *MockCoverageMockRecorder) GetVideo(ctx, userID, channelID, videoID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetVideo", reflect.TypeOf((*MockCoverage)(nil).GetVideo), ctx, userID, channelID, videoID)
+}
@@ -161,15 +161,15 @@ func (mr *MockCredentialsMockRecorder) RemoveCredentialsGoogle(ctx, id any) *gom
 }
 
 // RemoveSessionGoogle mocks base method.
-func (m *MockCredentials) RemoveSessionGoogle(ctx context.Context, userID string, credentialsID uint) error {
+func (m *MockCredentials) RemoveSessionGoogle(ctx context.Context, userID string, sessionID uint) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "RemoveSessionGoogle", ctx, userID, credentialsID)
+	ret := m.ctrl.Call(m, "RemoveSessionGoogle", ctx, userID, sessionID)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // RemoveSessionGoogle indicates an expected call of RemoveSessionGoogle.
-func (mr *MockCredentialsMockRecorder) RemoveSessionGoogle(ctx, userID, credentialsID any) *gomock.Call {
+func (mr *MockCredentialsMockRecorder) RemoveSessionGoogle(ctx, userID, sessionID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveSessionGoogle", reflect.TypeOf((*MockCredentials)(nil).RemoveSessionGoogle), ctx, userID, credentialsID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveSessionGoogle", reflect.TypeOf((*MockCredentials)(nil).RemoveSessionGoogle), ctx, userID, sessionID)
 }
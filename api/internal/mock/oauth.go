@@ -58,6 +58,20 @@ func (mr *MockConfigsMockRecorder) GetGoogle(clientID, clientSecret any) *gomock
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGoogle", reflect.TypeOf((*MockConfigs)(nil).GetGoogle), clientID, clientSecret)
 }
 
+// RevokeGoogle mocks base method.
+func (m *MockConfigs) RevokeGoogle(ctx context.Context, token string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeGoogle", ctx, token)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeGoogle indicates an expected call of RevokeGoogle.
+func (mr *MockConfigsMockRecorder) RevokeGoogle(ctx, token any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeGoogle", reflect.TypeOf((*MockConfigs)(nil).RevokeGoogle), ctx, token)
+}
+
 // MockOAuth2Config is a mock of OAuth2Config interface.
 type MockOAuth2Config struct {
 	ctrl     *gomock.Controller
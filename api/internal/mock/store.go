@@ -74,18 +74,33 @@ func (mr *MockStoreMockRecorder) AddCredentialsGoogle(ctx, clientID, clientSecre
 }
 
 // CreateSessionGoogle mocks base method.
-func (m *MockStore) CreateSessionGoogle(ctx context.Context, userID, accessToken, refreshToken, scopes string, expiry time.Time, credentials model.CredentialsGoogle) (*model.SessionGoogle, error) {
+func (m *MockStore) CreateSessionGoogle(ctx context.Context, userID, channelID, channelTitle, accessToken, refreshToken, scopes string, expiry time.Time, credentials model.CredentialsGoogle) (*model.SessionGoogle, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateSessionGoogle", ctx, userID, accessToken, refreshToken, scopes, expiry, credentials)
+	ret := m.ctrl.Call(m, "CreateSessionGoogle", ctx, userID, channelID, channelTitle, accessToken, refreshToken, scopes, expiry, credentials)
 	ret0, _ := ret[0].(*model.SessionGoogle)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // CreateSessionGoogle indicates an expected call of CreateSessionGoogle.
-func (mr *MockStoreMockRecorder) CreateSessionGoogle(ctx, userID, accessToken, refreshToken, scopes, expiry, credentials any) *gomock.Call {
+func (mr *MockStoreMockRecorder) CreateSessionGoogle(ctx, userID, channelID, channelTitle, accessToken, refreshToken, scopes, expiry, credentials any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSessionGoogle", reflect.TypeOf((*MockStore)(nil).CreateSessionGoogle), ctx, userID, accessToken, refreshToken, scopes, expiry, credentials)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSessionGoogle", reflect.TypeOf((*MockStore)(nil).CreateSessionGoogle), ctx, userID, channelID, channelTitle, accessToken, refreshToken, scopes, expiry, credentials)
+}
+
+// GetAddedTags mocks base method.
+func (m *MockStore) GetAddedTags(ctx context.Context, videoID string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAddedTags", ctx, videoID)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAddedTags indicates an expected call of GetAddedTags.
+func (mr *MockStoreMockRecorder) GetAddedTags(ctx, videoID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAddedTags", reflect.TypeOf((*MockStore)(nil).GetAddedTags), ctx, videoID)
 }
 
 // GetCredentialsDeepLAll mocks base method.
@@ -164,6 +179,41 @@ func (mr *MockStoreMockRecorder) GetCredentialsGoogleByID(ctx, id any) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCredentialsGoogleByID", reflect.TypeOf((*MockStore)(nil).GetCredentialsGoogleByID), ctx, id)
 }
 
+// GetLocalizations mocks base method.
+func (m *MockStore) GetLocalizations(ctx context.Context, kind string, resourceIDs ...string) ([]model.Localization, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, kind}
+	for _, a := range resourceIDs {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetLocalizations", varargs...)
+	ret0, _ := ret[0].([]model.Localization)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLocalizations indicates an expected call of GetLocalizations.
+func (mr *MockStoreMockRecorder) GetLocalizations(ctx, kind any, resourceIDs ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, kind}, resourceIDs...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLocalizations", reflect.TypeOf((*MockStore)(nil).GetLocalizations), varargs...)
+}
+
+// GetSessionGoogle mocks base method.
+func (m *MockStore) GetSessionGoogle(ctx context.Context, userID string, id uint) (*model.SessionGoogle, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSessionGoogle", ctx, userID, id)
+	ret0, _ := ret[0].(*model.SessionGoogle)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSessionGoogle indicates an expected call of GetSessionGoogle.
+func (mr *MockStoreMockRecorder) GetSessionGoogle(ctx, userID, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSessionGoogle", reflect.TypeOf((*MockStore)(nil).GetSessionGoogle), ctx, userID, id)
+}
+
 // GetSessionGoogleAll mocks base method.
 func (m *MockStore) GetSessionGoogleAll(ctx context.Context, userID string) ([]model.SessionGoogle, error) {
 	m.ctrl.T.Helper()
@@ -180,9 +230,9 @@ func (mr *MockStoreMockRecorder) GetSessionGoogleAll(ctx, userID any) *gomock.Ca
 }
 
 // GetSessionGoogleByAvailableCost mocks base method.
-func (m *MockStore) GetSessionGoogleByAvailableCost(ctx context.Context, userID string, cost uint) (*model.SessionGoogle, func() error, error) {
+func (m *MockStore) GetSessionGoogleByAvailableCost(ctx context.Context, userID, channelID string, cost uint) (*model.SessionGoogle, func() error, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetSessionGoogleByAvailableCost", ctx, userID, cost)
+	ret := m.ctrl.Call(m, "GetSessionGoogleByAvailableCost", ctx, userID, channelID, cost)
 	ret0, _ := ret[0].(*model.SessionGoogle)
 	ret1, _ := ret[1].(func() error)
 	ret2, _ := ret[2].(error)
@@ -190,39 +240,117 @@ func (m *MockStore) GetSessionGoogleByAvailableCost(ctx context.Context, userID
 }
 
 // GetSessionGoogleByAvailableCost indicates an expected call of GetSessionGoogleByAvailableCost.
-func (mr *MockStoreMockRecorder) GetSessionGoogleByAvailableCost(ctx, userID, cost any) *gomock.Call {
+func (mr *MockStoreMockRecorder) GetSessionGoogleByAvailableCost(ctx, userID, channelID, cost any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSessionGoogleByAvailableCost", reflect.TypeOf((*MockStore)(nil).GetSessionGoogleByAvailableCost), ctx, userID, cost)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSessionGoogleByAvailableCost", reflect.TypeOf((*MockStore)(nil).GetSessionGoogleByAvailableCost), ctx, userID, channelID, cost)
 }
 
-// GetSessionGoogleByCredentialsID mocks base method.
-func (m *MockStore) GetSessionGoogleByCredentialsID(ctx context.Context, credentialsID uint, userID string) (*model.SessionGoogle, error) {
+// GetSessionState mocks base method.
+func (m *MockStore) GetSessionState(ctx context.Context, state string) (*model.SessionState, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetSessionGoogleByCredentialsID", ctx, credentialsID, userID)
-	ret0, _ := ret[0].(*model.SessionGoogle)
+	ret := m.ctrl.Call(m, "GetSessionState", ctx, state)
+	ret0, _ := ret[0].(*model.SessionState)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetSessionGoogleByCredentialsID indicates an expected call of GetSessionGoogleByCredentialsID.
-func (mr *MockStoreMockRecorder) GetSessionGoogleByCredentialsID(ctx, credentialsID, userID any) *gomock.Call {
+// GetSessionState indicates an expected call of GetSessionState.
+func (mr *MockStoreMockRecorder) GetSessionState(ctx, state any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSessionGoogleByCredentialsID", reflect.TypeOf((*MockStore)(nil).GetSessionGoogleByCredentialsID), ctx, credentialsID, userID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSessionState", reflect.TypeOf((*MockStore)(nil).GetSessionState), ctx, state)
 }
 
-// GetSessionState mocks base method.
-func (m *MockStore) GetSessionState(ctx context.Context, state string) (*model.SessionState, error) {
+// GetSourceCC mocks base method.
+func (m *MockStore) GetSourceCC(ctx context.Context, userID, videoID string) (*model.SourceCC, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetSessionState", ctx, state)
-	ret0, _ := ret[0].(*model.SessionState)
+	ret := m.ctrl.Call(m, "GetSourceCC", ctx, userID, videoID)
+	ret0, _ := ret[0].(*model.SourceCC)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetSessionState indicates an expected call of GetSessionState.
-func (mr *MockStoreMockRecorder) GetSessionState(ctx, state any) *gomock.Call {
+// GetSourceCC indicates an expected call of GetSourceCC.
+func (mr *MockStoreMockRecorder) GetSourceCC(ctx, userID, videoID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSessionState", reflect.TypeOf((*MockStore)(nil).GetSessionState), ctx, state)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSourceCC", reflect.TypeOf((*MockStore)(nil).GetSourceCC), ctx, userID, videoID)
+}
+
+// GetTranslatedCC mocks base method.
+func (m *MockStore) GetTranslatedCC(ctx context.Context, videoIDs ...string) ([]model.TranslatedCC, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx}
+	for _, a := range videoIDs {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetTranslatedCC", varargs...)
+	ret0, _ := ret[0].([]model.TranslatedCC)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTranslatedCC indicates an expected call of GetTranslatedCC.
+func (mr *MockStoreMockRecorder) GetTranslatedCC(ctx any, videoIDs ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx}, videoIDs...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTranslatedCC", reflect.TypeOf((*MockStore)(nil).GetTranslatedCC), varargs...)
+}
+
+// GetTranslationMemory mocks base method.
+func (m *MockStore) GetTranslationMemory(ctx context.Context, sourceLanguage, targetLanguage string, text []string) (map[string]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTranslationMemory", ctx, sourceLanguage, targetLanguage, text)
+	ret0, _ := ret[0].(map[string]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTranslationMemory indicates an expected call of GetTranslationMemory.
+func (mr *MockStoreMockRecorder) GetTranslationMemory(ctx, sourceLanguage, targetLanguage, text any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTranslationMemory", reflect.TypeOf((*MockStore)(nil).GetTranslationMemory), ctx, sourceLanguage, targetLanguage, text)
+}
+
+// MarkCredentialsGoogleExhausted mocks base method.
+func (m *MockStore) MarkCredentialsGoogleExhausted(ctx context.Context, id uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkCredentialsGoogleExhausted", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkCredentialsGoogleExhausted indicates an expected call of MarkCredentialsGoogleExhausted.
+func (mr *MockStoreMockRecorder) MarkCredentialsGoogleExhausted(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkCredentialsGoogleExhausted", reflect.TypeOf((*MockStore)(nil).MarkCredentialsGoogleExhausted), ctx, id)
+}
+
+// MarkSessionGoogleBroken mocks base method.
+func (m *MockStore) MarkSessionGoogleBroken(ctx context.Context, id uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkSessionGoogleBroken", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkSessionGoogleBroken indicates an expected call of MarkSessionGoogleBroken.
+func (mr *MockStoreMockRecorder) MarkSessionGoogleBroken(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkSessionGoogleBroken", reflect.TypeOf((*MockStore)(nil).MarkSessionGoogleBroken), ctx, id)
+}
+
+// ReencryptSecrets mocks base method.
+func (m *MockStore) ReencryptSecrets(ctx context.Context) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReencryptSecrets", ctx)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReencryptSecrets indicates an expected call of ReencryptSecrets.
+func (mr *MockStoreMockRecorder) ReencryptSecrets(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReencryptSecrets", reflect.TypeOf((*MockStore)(nil).ReencryptSecrets), ctx)
 }
 
 // RemoveCredentialsDeepL mocks base method.
@@ -254,31 +382,160 @@ func (mr *MockStoreMockRecorder) RemoveCredentialsGoogle(ctx, id any) *gomock.Ca
 }
 
 // RemoveSessionGoogle mocks base method.
-func (m *MockStore) RemoveSessionGoogle(ctx context.Context, userID string, credentialsID uint) error {
+func (m *MockStore) RemoveSessionGoogle(ctx context.Context, userID string, id uint) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "RemoveSessionGoogle", ctx, userID, credentialsID)
+	ret := m.ctrl.Call(m, "RemoveSessionGoogle", ctx, userID, id)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // RemoveSessionGoogle indicates an expected call of RemoveSessionGoogle.
-func (mr *MockStoreMockRecorder) RemoveSessionGoogle(ctx, userID, credentialsID any) *gomock.Call {
+func (mr *MockStoreMockRecorder) RemoveSessionGoogle(ctx, userID, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveSessionGoogle", reflect.TypeOf((*MockStore)(nil).RemoveSessionGoogle), ctx, userID, id)
+}
+
+// RemoveSessionState mocks base method.
+func (m *MockStore) RemoveSessionState(ctx context.Context, state string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveSessionState", ctx, state)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveSessionState indicates an expected call of RemoveSessionState.
+func (mr *MockStoreMockRecorder) RemoveSessionState(ctx, state any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveSessionState", reflect.TypeOf((*MockStore)(nil).RemoveSessionState), ctx, state)
+}
+
+// RemoveSessionStatesBefore mocks base method.
+func (m *MockStore) RemoveSessionStatesBefore(ctx context.Context, before time.Time) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveSessionStatesBefore", ctx, before)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RemoveSessionStatesBefore indicates an expected call of RemoveSessionStatesBefore.
+func (mr *MockStoreMockRecorder) RemoveSessionStatesBefore(ctx, before any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveSessionStatesBefore", reflect.TypeOf((*MockStore)(nil).RemoveSessionStatesBefore), ctx, before)
+}
+
+// RemoveSourceCC mocks base method.
+func (m *MockStore) RemoveSourceCC(ctx context.Context, userID, videoID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveSourceCC", ctx, userID, videoID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveSourceCC indicates an expected call of RemoveSourceCC.
+func (mr *MockStoreMockRecorder) RemoveSourceCC(ctx, userID, videoID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveSourceCC", reflect.TypeOf((*MockStore)(nil).RemoveSourceCC), ctx, userID, videoID)
+}
+
+// RemoveTranslationMemory mocks base method.
+func (m *MockStore) RemoveTranslationMemory(ctx context.Context, sourceLanguage, targetLanguage string) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveTranslationMemory", ctx, sourceLanguage, targetLanguage)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RemoveTranslationMemory indicates an expected call of RemoveTranslationMemory.
+func (mr *MockStoreMockRecorder) RemoveTranslationMemory(ctx, sourceLanguage, targetLanguage any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveTranslationMemory", reflect.TypeOf((*MockStore)(nil).RemoveTranslationMemory), ctx, sourceLanguage, targetLanguage)
+}
+
+// SaveAddedTags mocks base method.
+func (m *MockStore) SaveAddedTags(ctx context.Context, videoID string, tags []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveAddedTags", ctx, videoID, tags)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveAddedTags indicates an expected call of SaveAddedTags.
+func (mr *MockStoreMockRecorder) SaveAddedTags(ctx, videoID, tags any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveSessionGoogle", reflect.TypeOf((*MockStore)(nil).RemoveSessionGoogle), ctx, userID, credentialsID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveAddedTags", reflect.TypeOf((*MockStore)(nil).SaveAddedTags), ctx, videoID, tags)
+}
+
+// SaveLocalizations mocks base method.
+func (m *MockStore) SaveLocalizations(ctx context.Context, localizations []model.Localization) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveLocalizations", ctx, localizations)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveLocalizations indicates an expected call of SaveLocalizations.
+func (mr *MockStoreMockRecorder) SaveLocalizations(ctx, localizations any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveLocalizations", reflect.TypeOf((*MockStore)(nil).SaveLocalizations), ctx, localizations)
 }
 
 // SaveSessionState mocks base method.
-func (m *MockStore) SaveSessionState(ctx context.Context, credentialsID uint, userID, state, scopes, redirectURL string) error {
+func (m *MockStore) SaveSessionState(ctx context.Context, credentialsID uint, userID, state, codeVerifier, scopes, redirectURL string) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "SaveSessionState", ctx, credentialsID, userID, state, scopes, redirectURL)
+	ret := m.ctrl.Call(m, "SaveSessionState", ctx, credentialsID, userID, state, codeVerifier, scopes, redirectURL)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // SaveSessionState indicates an expected call of SaveSessionState.
-func (mr *MockStoreMockRecorder) SaveSessionState(ctx, credentialsID, userID, state, scopes, redirectURL any) *gomock.Call {
+func (mr *MockStoreMockRecorder) SaveSessionState(ctx, credentialsID, userID, state, codeVerifier, scopes, redirectURL any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveSessionState", reflect.TypeOf((*MockStore)(nil).SaveSessionState), ctx, credentialsID, userID, state, codeVerifier, scopes, redirectURL)
+}
+
+// SaveSourceCC mocks base method.
+func (m *MockStore) SaveSourceCC(ctx context.Context, userID, videoID, language, srt string, uploadOriginal bool) (*model.SourceCC, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveSourceCC", ctx, userID, videoID, language, srt, uploadOriginal)
+	ret0, _ := ret[0].(*model.SourceCC)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SaveSourceCC indicates an expected call of SaveSourceCC.
+func (mr *MockStoreMockRecorder) SaveSourceCC(ctx, userID, videoID, language, srt, uploadOriginal any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveSourceCC", reflect.TypeOf((*MockStore)(nil).SaveSourceCC), ctx, userID, videoID, language, srt, uploadOriginal)
+}
+
+// SaveTranslatedCC mocks base method.
+func (m *MockStore) SaveTranslatedCC(ctx context.Context, translatedCC []model.TranslatedCC) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveTranslatedCC", ctx, translatedCC)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveTranslatedCC indicates an expected call of SaveTranslatedCC.
+func (mr *MockStoreMockRecorder) SaveTranslatedCC(ctx, translatedCC any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveTranslatedCC", reflect.TypeOf((*MockStore)(nil).SaveTranslatedCC), ctx, translatedCC)
+}
+
+// SaveTranslationMemory mocks base method.
+func (m *MockStore) SaveTranslationMemory(ctx context.Context, sourceLanguage, targetLanguage string, translations map[string]string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveTranslationMemory", ctx, sourceLanguage, targetLanguage, translations)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveTranslationMemory indicates an expected call of SaveTranslationMemory.
+func (mr *MockStoreMockRecorder) SaveTranslationMemory(ctx, sourceLanguage, targetLanguage, translations any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveSessionState", reflect.TypeOf((*MockStore)(nil).SaveSessionState), ctx, credentialsID, userID, state, scopes, redirectURL)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveTranslationMemory", reflect.TypeOf((*MockStore)(nil).SaveTranslationMemory), ctx, sourceLanguage, targetLanguage, translations)
 }
 
 // Transaction mocks base method.
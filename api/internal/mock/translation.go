@@ -40,6 +40,21 @@ func (m *MockTranslator) EXPECT() *MockTranslatorMockRecorder {
 	return m.recorder
 }
 
+// DetectLanguage mocks base method.
+func (m *MockTranslator) DetectLanguage(ctx context.Context, text []string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DetectLanguage", ctx, text)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DetectLanguage indicates an expected call of DetectLanguage.
+func (mr *MockTranslatorMockRecorder) DetectLanguage(ctx, text any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DetectLanguage", reflect.TypeOf((*MockTranslator)(nil).DetectLanguage), ctx, text)
+}
+
 // GetLanguages mocks base method.
 func (m *MockTranslator) GetLanguages(ctx context.Context) ([]string, error) {
 	m.ctrl.T.Helper()
@@ -11,6 +11,7 @@ package mock
 
 import (
 	context "context"
+	http "net/http"
 	reflect "reflect"
 
 	pb "github.com/pkulik0/autocc/api/internal/pb"
@@ -44,54 +45,134 @@ func (m *MockYoutube) EXPECT() *MockYoutubeMockRecorder {
 }
 
 // DownloadCC mocks base method.
-func (m *MockYoutube) DownloadCC(ctx context.Context, userID, ccID string) (*srt.Srt, error) {
+func (m *MockYoutube) DownloadCC(ctx context.Context, userID, channelID, ccID string) (*srt.Srt, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DownloadCC", ctx, userID, ccID)
+	ret := m.ctrl.Call(m, "DownloadCC", ctx, userID, channelID, ccID)
 	ret0, _ := ret[0].(*srt.Srt)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // DownloadCC indicates an expected call of DownloadCC.
-func (mr *MockYoutubeMockRecorder) DownloadCC(ctx, userID, ccID any) *gomock.Call {
+func (mr *MockYoutubeMockRecorder) DownloadCC(ctx, userID, channelID, ccID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DownloadCC", reflect.TypeOf((*MockYoutube)(nil).DownloadCC), ctx, userID, ccID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DownloadCC", reflect.TypeOf((*MockYoutube)(nil).DownloadCC), ctx, userID, channelID, ccID)
+}
+
+// GetAuthorizedChannel mocks base method.
+func (m *MockYoutube) GetAuthorizedChannel(ctx context.Context, client *http.Client) (*youtube.Channel, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAuthorizedChannel", ctx, client)
+	ret0, _ := ret[0].(*youtube.Channel)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAuthorizedChannel indicates an expected call of GetAuthorizedChannel.
+func (mr *MockYoutubeMockRecorder) GetAuthorizedChannel(ctx, client any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAuthorizedChannel", reflect.TypeOf((*MockYoutube)(nil).GetAuthorizedChannel), ctx, client)
 }
 
 // GetCC mocks base method.
-func (m *MockYoutube) GetCC(ctx context.Context, userID, videoID string) ([]*youtube.CC, error) {
+func (m *MockYoutube) GetCC(ctx context.Context, userID, channelID, videoID string) ([]*youtube.CC, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetCC", ctx, userID, videoID)
+	ret := m.ctrl.Call(m, "GetCC", ctx, userID, channelID, videoID)
 	ret0, _ := ret[0].([]*youtube.CC)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetCC indicates an expected call of GetCC.
-func (mr *MockYoutubeMockRecorder) GetCC(ctx, userID, videoID any) *gomock.Call {
+func (mr *MockYoutubeMockRecorder) GetCC(ctx, userID, channelID, videoID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCC", reflect.TypeOf((*MockYoutube)(nil).GetCC), ctx, userID, videoID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCC", reflect.TypeOf((*MockYoutube)(nil).GetCC), ctx, userID, channelID, videoID)
+}
+
+// GetChannelMetadata mocks base method.
+func (m *MockYoutube) GetChannelMetadata(ctx context.Context, userID, channelID string) (*youtube.Metadata, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetChannelMetadata", ctx, userID, channelID)
+	ret0, _ := ret[0].(*youtube.Metadata)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetChannelMetadata indicates an expected call of GetChannelMetadata.
+func (mr *MockYoutubeMockRecorder) GetChannelMetadata(ctx, userID, channelID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetChannelMetadata", reflect.TypeOf((*MockYoutube)(nil).GetChannelMetadata), ctx, userID, channelID)
+}
+
+// GetChannels mocks base method.
+func (m *MockYoutube) GetChannels(ctx context.Context, userID string) ([]*youtube.Channel, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetChannels", ctx, userID)
+	ret0, _ := ret[0].([]*youtube.Channel)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetChannels indicates an expected call of GetChannels.
+func (mr *MockYoutubeMockRecorder) GetChannels(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetChannels", reflect.TypeOf((*MockYoutube)(nil).GetChannels), ctx, userID)
+}
+
+// GetLocalizationStates mocks base method.
+func (m *MockYoutube) GetLocalizationStates(ctx context.Context, userID, channelID string, videoIDs ...string) (map[string][]youtube.LocalizationState, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, userID, channelID}
+	for _, a := range videoIDs {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetLocalizationStates", varargs...)
+	ret0, _ := ret[0].(map[string][]youtube.LocalizationState)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLocalizationStates indicates an expected call of GetLocalizationStates.
+func (mr *MockYoutubeMockRecorder) GetLocalizationStates(ctx, userID, channelID any, videoIDs ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, userID, channelID}, videoIDs...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLocalizationStates", reflect.TypeOf((*MockYoutube)(nil).GetLocalizationStates), varargs...)
 }
 
 // GetMetadata mocks base method.
-func (m *MockYoutube) GetMetadata(ctx context.Context, userID, videoID string) (*youtube.Metadata, error) {
+func (m *MockYoutube) GetMetadata(ctx context.Context, userID, channelID, videoID string) (*youtube.Metadata, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetMetadata", ctx, userID, videoID)
+	ret := m.ctrl.Call(m, "GetMetadata", ctx, userID, channelID, videoID)
 	ret0, _ := ret[0].(*youtube.Metadata)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetMetadata indicates an expected call of GetMetadata.
-func (mr *MockYoutubeMockRecorder) GetMetadata(ctx, userID, videoID any) *gomock.Call {
+func (mr *MockYoutubeMockRecorder) GetMetadata(ctx, userID, channelID, videoID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMetadata", reflect.TypeOf((*MockYoutube)(nil).GetMetadata), ctx, userID, videoID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMetadata", reflect.TypeOf((*MockYoutube)(nil).GetMetadata), ctx, userID, channelID, videoID)
+}
+
+// GetPlaylists mocks base method.
+func (m *MockYoutube) GetPlaylists(ctx context.Context, userID, channelID, videoID string) ([]*youtube.Playlist, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPlaylists", ctx, userID, channelID, videoID)
+	ret0, _ := ret[0].([]*youtube.Playlist)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPlaylists indicates an expected call of GetPlaylists.
+func (mr *MockYoutubeMockRecorder) GetPlaylists(ctx, userID, channelID, videoID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPlaylists", reflect.TypeOf((*MockYoutube)(nil).GetPlaylists), ctx, userID, channelID, videoID)
 }
 
 // GetVideos mocks base method.
-func (m *MockYoutube) GetVideos(ctx context.Context, userID, nextPageToken string) ([]*pb.Video, string, error) {
+func (m *MockYoutube) GetVideos(ctx context.Context, userID, channelID, nextPageToken string) ([]*pb.Video, string, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetVideos", ctx, userID, nextPageToken)
+	ret := m.ctrl.Call(m, "GetVideos", ctx, userID, channelID, nextPageToken)
 	ret0, _ := ret[0].([]*pb.Video)
 	ret1, _ := ret[1].(string)
 	ret2, _ := ret[2].(error)
@@ -99,36 +180,93 @@ func (m *MockYoutube) GetVideos(ctx context.Context, userID, nextPageToken strin
 }
 
 // GetVideos indicates an expected call of GetVideos.
-func (mr *MockYoutubeMockRecorder) GetVideos(ctx, userID, nextPageToken any) *gomock.Call {
+func (mr *MockYoutubeMockRecorder) GetVideos(ctx, userID, channelID, nextPageToken any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetVideos", reflect.TypeOf((*MockYoutube)(nil).GetVideos), ctx, userID, channelID, nextPageToken)
+}
+
+// ListCaptionLanguages mocks base method.
+func (m *MockYoutube) ListCaptionLanguages(ctx context.Context, userID, channelID string, videos []*pb.Video, limit int) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListCaptionLanguages", ctx, userID, channelID, videos, limit)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListCaptionLanguages indicates an expected call of ListCaptionLanguages.
+func (mr *MockYoutubeMockRecorder) ListCaptionLanguages(ctx, userID, channelID, videos, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListCaptionLanguages", reflect.TypeOf((*MockYoutube)(nil).ListCaptionLanguages), ctx, userID, channelID, videos, limit)
+}
+
+// SetLanguage mocks base method.
+func (m *MockYoutube) SetLanguage(ctx context.Context, userID, channelID, videoID, language string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetLanguage", ctx, userID, channelID, videoID, language)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetLanguage indicates an expected call of SetLanguage.
+func (mr *MockYoutubeMockRecorder) SetLanguage(ctx, userID, channelID, videoID, language any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetLanguage", reflect.TypeOf((*MockYoutube)(nil).SetLanguage), ctx, userID, channelID, videoID, language)
+}
+
+// UpdateChannelMetadata mocks base method.
+func (m *MockYoutube) UpdateChannelMetadata(ctx context.Context, userID, channelID string, source *youtube.Metadata, metadata map[string]*youtube.Metadata, policy youtube.MergePolicy) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateChannelMetadata", ctx, userID, channelID, source, metadata, policy)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateChannelMetadata indicates an expected call of UpdateChannelMetadata.
+func (mr *MockYoutubeMockRecorder) UpdateChannelMetadata(ctx, userID, channelID, source, metadata, policy any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetVideos", reflect.TypeOf((*MockYoutube)(nil).GetVideos), ctx, userID, nextPageToken)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateChannelMetadata", reflect.TypeOf((*MockYoutube)(nil).UpdateChannelMetadata), ctx, userID, channelID, source, metadata, policy)
 }
 
 // UpdateMetadata mocks base method.
-func (m *MockYoutube) UpdateMetadata(ctx context.Context, userID, videoID string, metadata map[string]*youtube.Metadata) error {
+func (m *MockYoutube) UpdateMetadata(ctx context.Context, userID, channelID, videoID string, source *youtube.Metadata, metadata map[string]*youtube.Metadata, policy youtube.MergePolicy) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateMetadata", ctx, userID, videoID, metadata)
+	ret := m.ctrl.Call(m, "UpdateMetadata", ctx, userID, channelID, videoID, source, metadata, policy)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // UpdateMetadata indicates an expected call of UpdateMetadata.
-func (mr *MockYoutubeMockRecorder) UpdateMetadata(ctx, userID, videoID, metadata any) *gomock.Call {
+func (mr *MockYoutubeMockRecorder) UpdateMetadata(ctx, userID, channelID, videoID, source, metadata, policy any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateMetadata", reflect.TypeOf((*MockYoutube)(nil).UpdateMetadata), ctx, userID, channelID, videoID, source, metadata, policy)
+}
+
+// UpdatePlaylistMetadata mocks base method.
+func (m *MockYoutube) UpdatePlaylistMetadata(ctx context.Context, userID, channelID string, playlist *youtube.Playlist, metadata map[string]*youtube.Metadata, policy youtube.MergePolicy) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdatePlaylistMetadata", ctx, userID, channelID, playlist, metadata, policy)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdatePlaylistMetadata indicates an expected call of UpdatePlaylistMetadata.
+func (mr *MockYoutubeMockRecorder) UpdatePlaylistMetadata(ctx, userID, channelID, playlist, metadata, policy any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateMetadata", reflect.TypeOf((*MockYoutube)(nil).UpdateMetadata), ctx, userID, videoID, metadata)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePlaylistMetadata", reflect.TypeOf((*MockYoutube)(nil).UpdatePlaylistMetadata), ctx, userID, channelID, playlist, metadata, policy)
 }
 
 // UploadCC mocks base method.
-func (m *MockYoutube) UploadCC(ctx context.Context, userID, videoID, language string, srt *srt.Srt) (string, error) {
+func (m *MockYoutube) UploadCC(ctx context.Context, userID, channelID, videoID, language string, srt *srt.Srt) (string, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UploadCC", ctx, userID, videoID, language, srt)
+	ret := m.ctrl.Call(m, "UploadCC", ctx, userID, channelID, videoID, language, srt)
 	ret0, _ := ret[0].(string)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // UploadCC indicates an expected call of UploadCC.
-func (mr *MockYoutubeMockRecorder) UploadCC(ctx, userID, videoID, language, srt any) *gomock.Call {
+func (mr *MockYoutubeMockRecorder) UploadCC(ctx, userID, channelID, videoID, language, srt any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UploadCC", reflect.TypeOf((*MockYoutube)(nil).UploadCC), ctx, userID, videoID, language, srt)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UploadCC", reflect.TypeOf((*MockYoutube)(nil).UploadCC), ctx, userID, channelID, videoID, language, srt)
 }
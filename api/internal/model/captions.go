@@ -0,0 +1,35 @@
+package model
+
+import (
+	"gorm.io/gorm"
+)
+
+// SourceCC is a model for storing closed captions uploaded by a user as the translation source of a video.
+type SourceCC struct {
+	gorm.Model
+	UserID         string `gorm:"uniqueIndex:idx_source_cc_user_video"`
+	VideoID        string `gorm:"uniqueIndex:idx_source_cc_user_video"`
+	Language       string
+	Srt            string
+	UploadOriginal bool
+}
+
+// TableName returns the table name for the model.
+func (s *SourceCC) TableName() string {
+	return "source_cc"
+}
+
+// TranslatedCC is a model for storing closed captions translated and uploaded by AutoCC, so they can be told apart from the ones uploaded by hand.
+// UpdatedAt is when the closed captions were translated, they're stale if their source changed afterwards.
+type TranslatedCC struct {
+	gorm.Model
+	VideoID  string `gorm:"uniqueIndex:idx_translated_cc_video_language"`
+	Language string `gorm:"uniqueIndex:idx_translated_cc_video_language"`
+	// TrackID is the ID of the uploaded closed captions on YouTube.
+	TrackID string
+}
+
+// TableName returns the table name for the model.
+func (t *TranslatedCC) TableName() string {
+	return "translated_cc"
+}
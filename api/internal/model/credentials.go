@@ -2,6 +2,7 @@ package model
 
 import (
 	"strings"
+	"time"
 
 	"github.com/pkulik0/autocc/api/internal/pb"
 	"gorm.io/gorm"
@@ -16,7 +17,7 @@ func secureSecret(s string) string {
 // CredentialsDeepL is a model for storing DeepL API credentials.
 type CredentialsDeepL struct {
 	gorm.Model
-	Key   string
+	Key   string `gorm:"serializer:encrypted"`
 	Usage uint
 }
 
@@ -38,8 +39,10 @@ func (c *CredentialsDeepL) ToProto() *pb.CredentialsDeepL {
 type CredentialsGoogle struct {
 	gorm.Model
 	ClientID     string
-	ClientSecret string
+	ClientSecret string `gorm:"serializer:encrypted"`
+	// Usage is the quota used since UsageResetAt, the start of the quota day, see quota.GoogleDayStart.
 	Usage        uint
+	UsageResetAt *time.Time
 }
 
 // TableName returns the table name for the model.
@@ -0,0 +1,31 @@
+package model
+
+import (
+	"gorm.io/gorm"
+)
+
+const (
+	// LocalizationKindVideo is the kind of localizations of a video's title and description.
+	LocalizationKindVideo = "video"
+	// LocalizationKindPlaylist is the kind of localizations of a playlist's title and description.
+	LocalizationKindPlaylist = "playlist"
+	// LocalizationKindChannel is the kind of localizations of a channel's name and description.
+	LocalizationKindChannel = "channel"
+)
+
+// Localization is a model for storing localizations authored by AutoCC, so they can be told apart from the ones written by hand.
+type Localization struct {
+	gorm.Model
+	Kind       string `gorm:"uniqueIndex:idx_localizations_resource"`
+	ResourceID string `gorm:"uniqueIndex:idx_localizations_resource"`
+	Language   string `gorm:"uniqueIndex:idx_localizations_resource"`
+	// Hash is the SHA-256 of the authored localization, it doesn't match if the localization was edited afterwards.
+	Hash string
+	// SourceHash is the SHA-256 of the metadata the localization was translated from.
+	SourceHash string
+}
+
+// TableName returns the table name for the model.
+func (l *Localization) TableName() string {
+	return "localizations"
+}
@@ -8,18 +8,23 @@ import (
 	"gorm.io/gorm"
 
 	"github.com/pkulik0/autocc/api/internal/oauth"
+	"github.com/pkulik0/autocc/api/internal/pb"
 )
 
 // SessionGoogle is a model for storing Google API sessions.
 type SessionGoogle struct {
 	gorm.Model
 	UserID        string
-	AccessToken   string
-	RefreshToken  string
+	ChannelID     string `gorm:"index:idx_sessions_google_user_channel"`
+	ChannelTitle  string
+	AccessToken   string `gorm:"serializer:encrypted"`
+	RefreshToken  string `gorm:"serializer:encrypted"`
 	Expiry        time.Time
 	CredentialsID uint
 	Credentials   CredentialsGoogle `gorm:"foreignKey:CredentialsID"`
 	Scopes        string
+	// Broken is set when the refresh token was revoked or expired, the user has to authenticate again.
+	Broken bool
 }
 
 // TableName returns the table name for the model.
@@ -27,6 +32,18 @@ func (s *SessionGoogle) TableName() string {
 	return "sessions_google"
 }
 
+// ToProto converts the model to a protobuf message, the tokens are left out.
+// Sessions created before channels were recorded have to be authenticated again, so they're reported as broken.
+func (s *SessionGoogle) ToProto() *pb.SessionGoogle {
+	return &pb.SessionGoogle{
+		Id:            uint64(s.ID),
+		CredentialsId: uint64(s.CredentialsID),
+		ChannelId:     s.ChannelID,
+		ChannelTitle:  s.ChannelTitle,
+		Broken:        s.Broken || s.ChannelID == "",
+	}
+}
+
 // GetTokenSource returns a token source for the session.
 func (s *SessionGoogle) GetTokenSource(ctx context.Context, onChange func(*oauth2.Token)) (oauth2.TokenSource, error) {
 	t := &oauth2.Token{
@@ -47,7 +64,8 @@ func (s *SessionGoogle) GetTokenSource(ctx context.Context, onChange func(*oauth
 type SessionState struct {
 	gorm.Model
 	UserID        string
-	State         string
+	State         string `gorm:"uniqueIndex:idx_sessions_state_state"`
+	CodeVerifier  string
 	CredentialsID uint
 	Credentials   CredentialsGoogle `gorm:"foreignKey:CredentialsID"`
 	Scopes        string
@@ -0,0 +1,18 @@
+package model
+
+import (
+	"gorm.io/gorm"
+)
+
+// AddedTag is a model for storing tags AutoCC added to a video, so they can be told apart from the ones added by hand.
+type AddedTag struct {
+	gorm.Model
+	VideoID string `gorm:"uniqueIndex:idx_added_tags_video_tag"`
+	// Tag is stored lowercase, YouTube compares tags case-insensitively.
+	Tag string `gorm:"uniqueIndex:idx_added_tags_video_tag"`
+}
+
+// TableName returns the table name for the model.
+func (a *AddedTag) TableName() string {
+	return "added_tags"
+}
@@ -0,0 +1,21 @@
+package model
+
+import (
+	"gorm.io/gorm"
+)
+
+// TranslationMemory is a model for storing translations of single segments, reused across documents.
+type TranslationMemory struct {
+	gorm.Model
+	SourceLanguage string `gorm:"uniqueIndex:idx_translation_memory_segment"`
+	TargetLanguage string `gorm:"uniqueIndex:idx_translation_memory_segment"`
+	// SourceHash is the SHA-256 of the source text, the text itself can be too long to be indexed.
+	SourceHash     string `gorm:"uniqueIndex:idx_translation_memory_segment"`
+	SourceText     string
+	TranslatedText string
+}
+
+// TableName returns the table name for the model.
+func (t *TranslationMemory) TableName() string {
+	return "translation_memory"
+}
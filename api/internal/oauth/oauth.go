@@ -2,7 +2,10 @@ package oauth
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"net/http"
+	"net/url"
 	"strings"
 
 	"golang.org/x/oauth2"
@@ -28,10 +31,15 @@ type OAuth2Config interface {
 type Configs interface {
 	// GetGoogle returns Google OAuth2 configurations.
 	GetGoogle(clientID, clientSecret string) (OAuth2Config, string)
+	// RevokeGoogle revokes a Google access or refresh token.
+	RevokeGoogle(ctx context.Context, token string) error
 }
 
 var _ Configs = &configs{}
 
+// googleRevokeURL is the endpoint revoking Google tokens, revoking a refresh token also revokes its access tokens.
+const googleRevokeURL = "https://oauth2.googleapis.com/revoke"
+
 type configs struct {
 	googleCallbackURL string
 }
@@ -55,6 +63,31 @@ func (o *configs) GetGoogle(clientID, clientSecret string) (OAuth2Config, string
 	}, strings.Join(scopes, ";")
 }
 
+func (o *configs) RevokeGoogle(ctx context.Context, token string) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, googleRevokeURL, strings.NewReader(url.Values{"token": {token}}.Encode()))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("failed to revoke token: %s", resp.Status)
+	}
+	return nil
+}
+
+// IsInvalidGrant returns true if the error was caused by a token which was revoked or expired.
+func IsInvalidGrant(err error) bool {
+	var retrieveErr *oauth2.RetrieveError
+	return errors.As(err, &retrieveErr) && retrieveErr.ErrorCode == "invalid_grant"
+}
+
 var _ oauth2.TokenSource = &reactiveTokenSource{}
 
 type reactiveTokenSource struct {
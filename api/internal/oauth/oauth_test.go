@@ -0,0 +1,93 @@
+package oauth_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+	"golang.org/x/oauth2"
+
+	"github.com/pkulik0/autocc/api/internal/oauth"
+)
+
+// refreshErr returns the error of refreshing a token at a token endpoint which responds with the status and body.
+func refreshErr(c *qt.C, status int, body string) error {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status)
+		fmt.Fprint(w, body)
+	}))
+	c.Cleanup(server.Close)
+
+	config := &oauth2.Config{
+		ClientID:     "client",
+		ClientSecret: "secret",
+		Endpoint:     oauth2.Endpoint{TokenURL: server.URL, AuthStyle: oauth2.AuthStyleInParams},
+	}
+	_, err := config.TokenSource(context.Background(), &oauth2.Token{RefreshToken: "refresh"}).Token()
+	c.Assert(err, qt.IsNotNil)
+	return err
+}
+
+func TestIsInvalidGrant(t *testing.T) {
+	c := qt.New(t)
+
+	tt := []struct {
+		name         string
+		err          func(c *qt.C) error
+		invalidGrant bool
+	}{
+		{
+			name: "invalid grant",
+			err: func(c *qt.C) error {
+				return refreshErr(c, http.StatusBadRequest, `{"error": "invalid_grant", "error_description": "Token has been expired or revoked."}`)
+			},
+			invalidGrant: true,
+		},
+		{
+			name: "wrapped invalid grant",
+			err: func(c *qt.C) error {
+				return fmt.Errorf("failed to refresh token: %w", refreshErr(c, http.StatusBadRequest, `{"error": "invalid_grant"}`))
+			},
+			invalidGrant: true,
+		},
+		{
+			name: "invalid client",
+			err: func(c *qt.C) error {
+				return refreshErr(c, http.StatusUnauthorized, `{"error": "invalid_client"}`)
+			},
+			invalidGrant: false,
+		},
+		{
+			name: "server error",
+			err: func(c *qt.C) error {
+				return refreshErr(c, http.StatusInternalServerError, `Internal Server Error`)
+			},
+			invalidGrant: false,
+		},
+		{
+			name: "other error",
+			err: func(c *qt.C) error {
+				return errors.New("invalid_grant")
+			},
+			invalidGrant: false,
+		},
+		{
+			name: "nil",
+			err: func(c *qt.C) error {
+				return nil
+			},
+			invalidGrant: false,
+		},
+	}
+
+	for _, tc := range tt {
+		c.Run(tc.name, func(c *qt.C) {
+			c.Assert(oauth.IsInvalidGrant(tc.err(c)), qt.Equals, tc.invalidGrant)
+		})
+	}
+}
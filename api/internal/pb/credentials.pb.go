@@ -436,17 +436,95 @@ func (x *GetSessionGoogleURLResponse) GetUrl() string {
 	return ""
 }
 
+type SessionGoogle struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id            uint64 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	CredentialsId uint64 `protobuf:"varint,2,opt,name=credentials_id,json=credentialsId,proto3" json:"credentials_id,omitempty"`
+	ChannelId     string `protobuf:"bytes,3,opt,name=channel_id,json=channelId,proto3" json:"channel_id,omitempty"`
+	ChannelTitle  string `protobuf:"bytes,4,opt,name=channel_title,json=channelTitle,proto3" json:"channel_title,omitempty"`
+	// The session needs to be authenticated again.
+	Broken bool `protobuf:"varint,5,opt,name=broken,proto3" json:"broken,omitempty"`
+}
+
+func (x *SessionGoogle) Reset() {
+	*x = SessionGoogle{}
+	mi := &file_pb_credentials_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SessionGoogle) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SessionGoogle) ProtoMessage() {}
+
+func (x *SessionGoogle) ProtoReflect() protoreflect.Message {
+	mi := &file_pb_credentials_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SessionGoogle.ProtoReflect.Descriptor instead.
+func (*SessionGoogle) Descriptor() ([]byte, []int) {
+	return file_pb_credentials_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *SessionGoogle) GetId() uint64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *SessionGoogle) GetCredentialsId() uint64 {
+	if x != nil {
+		return x.CredentialsId
+	}
+	return 0
+}
+
+func (x *SessionGoogle) GetChannelId() string {
+	if x != nil {
+		return x.ChannelId
+	}
+	return ""
+}
+
+func (x *SessionGoogle) GetChannelTitle() string {
+	if x != nil {
+		return x.ChannelTitle
+	}
+	return ""
+}
+
+func (x *SessionGoogle) GetBroken() bool {
+	if x != nil {
+		return x.Broken
+	}
+	return false
+}
+
 type GetUserSessionsGoogleResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	CredentialIds []uint64 `protobuf:"varint,1,rep,packed,name=credential_ids,json=credentialIds,proto3" json:"credential_ids,omitempty"`
+	Sessions []*SessionGoogle `protobuf:"bytes,3,rep,name=sessions,proto3" json:"sessions,omitempty"`
 }
 
 func (x *GetUserSessionsGoogleResponse) Reset() {
 	*x = GetUserSessionsGoogleResponse{}
-	mi := &file_pb_credentials_proto_msgTypes[8]
+	mi := &file_pb_credentials_proto_msgTypes[9]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -458,7 +536,7 @@ func (x *GetUserSessionsGoogleResponse) String() string {
 func (*GetUserSessionsGoogleResponse) ProtoMessage() {}
 
 func (x *GetUserSessionsGoogleResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_pb_credentials_proto_msgTypes[8]
+	mi := &file_pb_credentials_proto_msgTypes[9]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -471,12 +549,12 @@ func (x *GetUserSessionsGoogleResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetUserSessionsGoogleResponse.ProtoReflect.Descriptor instead.
 func (*GetUserSessionsGoogleResponse) Descriptor() ([]byte, []int) {
-	return file_pb_credentials_proto_rawDescGZIP(), []int{8}
+	return file_pb_credentials_proto_rawDescGZIP(), []int{9}
 }
 
-func (x *GetUserSessionsGoogleResponse) GetCredentialIds() []uint64 {
+func (x *GetUserSessionsGoogleResponse) GetSessions() []*SessionGoogle {
 	if x != nil {
-		return x.CredentialIds
+		return x.Sessions
 	}
 	return nil
 }
@@ -528,18 +606,30 @@ var file_pb_credentials_proto_rawDesc = []byte{
 	0x70, 0x4c, 0x52, 0x05, 0x64, 0x65, 0x65, 0x70, 0x6c, 0x22, 0x2f, 0x0a, 0x1b, 0x47, 0x65, 0x74,
 	0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x47, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x55, 0x52, 0x4c,
 	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x75, 0x72, 0x6c, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x75, 0x72, 0x6c, 0x22, 0x46, 0x0a, 0x1d, 0x47, 0x65,
-	0x74, 0x55, 0x73, 0x65, 0x72, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x47, 0x6f, 0x6f,
-	0x67, 0x6c, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x25, 0x0a, 0x0e, 0x63,
-	0x72, 0x65, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x61, 0x6c, 0x5f, 0x69, 0x64, 0x73, 0x18, 0x01, 0x20,
-	0x03, 0x28, 0x04, 0x52, 0x0d, 0x63, 0x72, 0x65, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x61, 0x6c, 0x49,
-	0x64, 0x73, 0x42, 0x64, 0x0a, 0x06, 0x63, 0x6f, 0x6d, 0x2e, 0x70, 0x62, 0x42, 0x10, 0x43, 0x72,
-	0x65, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x61, 0x6c, 0x73, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x50, 0x01,
-	0x5a, 0x20, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x70, 0x6b, 0x75,
-	0x6c, 0x69, 0x6b, 0x30, 0x2f, 0x61, 0x75, 0x74, 0x6f, 0x63, 0x63, 0x2f, 0x61, 0x70, 0x69, 0x2f,
-	0x70, 0x62, 0xa2, 0x02, 0x03, 0x50, 0x58, 0x58, 0xaa, 0x02, 0x02, 0x50, 0x62, 0xca, 0x02, 0x02,
-	0x50, 0x62, 0xe2, 0x02, 0x0e, 0x50, 0x62, 0x5c, 0x47, 0x50, 0x42, 0x4d, 0x65, 0x74, 0x61, 0x64,
-	0x61, 0x74, 0x61, 0xea, 0x02, 0x02, 0x50, 0x62, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x75, 0x72, 0x6c, 0x22, 0xa2, 0x01, 0x0a, 0x0d, 0x53,
+	0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x47, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x12, 0x0e, 0x0a, 0x02,
+	0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x02, 0x69, 0x64, 0x12, 0x25, 0x0a, 0x0e,
+	0x63, 0x72, 0x65, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x61, 0x6c, 0x73, 0x5f, 0x69, 0x64, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x04, 0x52, 0x0d, 0x63, 0x72, 0x65, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x61, 0x6c,
+	0x73, 0x49, 0x64, 0x12, 0x1d, 0x0a, 0x0a, 0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x5f, 0x69,
+	0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c,
+	0x49, 0x64, 0x12, 0x23, 0x0a, 0x0d, 0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x5f, 0x74, 0x69,
+	0x74, 0x6c, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x63, 0x68, 0x61, 0x6e, 0x6e,
+	0x65, 0x6c, 0x54, 0x69, 0x74, 0x6c, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x62, 0x72, 0x6f, 0x6b, 0x65,
+	0x6e, 0x18, 0x05, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x62, 0x72, 0x6f, 0x6b, 0x65, 0x6e, 0x22,
+	0x5a, 0x0a, 0x1d, 0x47, 0x65, 0x74, 0x55, 0x73, 0x65, 0x72, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f,
+	0x6e, 0x73, 0x47, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x12, 0x2d, 0x0a, 0x08, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x03, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x11, 0x2e, 0x70, 0x62, 0x2e, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x47,
+	0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x52, 0x08, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x4a,
+	0x04, 0x08, 0x01, 0x10, 0x02, 0x4a, 0x04, 0x08, 0x02, 0x10, 0x03, 0x42, 0x64, 0x0a, 0x06, 0x63,
+	0x6f, 0x6d, 0x2e, 0x70, 0x62, 0x42, 0x10, 0x43, 0x72, 0x65, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x61,
+	0x6c, 0x73, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x50, 0x01, 0x5a, 0x20, 0x67, 0x69, 0x74, 0x68, 0x75,
+	0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x70, 0x6b, 0x75, 0x6c, 0x69, 0x6b, 0x30, 0x2f, 0x61, 0x75,
+	0x74, 0x6f, 0x63, 0x63, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x70, 0x62, 0xa2, 0x02, 0x03, 0x50, 0x58,
+	0x58, 0xaa, 0x02, 0x02, 0x50, 0x62, 0xca, 0x02, 0x02, 0x50, 0x62, 0xe2, 0x02, 0x0e, 0x50, 0x62,
+	0x5c, 0x47, 0x50, 0x42, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0xea, 0x02, 0x02, 0x50,
+	0x62, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -554,7 +644,7 @@ func file_pb_credentials_proto_rawDescGZIP() []byte {
 	return file_pb_credentials_proto_rawDescData
 }
 
-var file_pb_credentials_proto_msgTypes = make([]protoimpl.MessageInfo, 9)
+var file_pb_credentials_proto_msgTypes = make([]protoimpl.MessageInfo, 10)
 var file_pb_credentials_proto_goTypes = []any{
 	(*AddCredentialsGoogleRequest)(nil),   // 0: pb.AddCredentialsGoogleRequest
 	(*CredentialsGoogle)(nil),             // 1: pb.CredentialsGoogle
@@ -564,18 +654,20 @@ var file_pb_credentials_proto_goTypes = []any{
 	(*AddCredentialsDeepLResponse)(nil),   // 5: pb.AddCredentialsDeepLResponse
 	(*GetCredentialsResponse)(nil),        // 6: pb.GetCredentialsResponse
 	(*GetSessionGoogleURLResponse)(nil),   // 7: pb.GetSessionGoogleURLResponse
-	(*GetUserSessionsGoogleResponse)(nil), // 8: pb.GetUserSessionsGoogleResponse
+	(*SessionGoogle)(nil),                 // 8: pb.SessionGoogle
+	(*GetUserSessionsGoogleResponse)(nil), // 9: pb.GetUserSessionsGoogleResponse
 }
 var file_pb_credentials_proto_depIdxs = []int32{
 	1, // 0: pb.AddCredentialsGoogleResponse.credentials:type_name -> pb.CredentialsGoogle
 	4, // 1: pb.AddCredentialsDeepLResponse.credentials:type_name -> pb.CredentialsDeepL
 	1, // 2: pb.GetCredentialsResponse.google:type_name -> pb.CredentialsGoogle
 	4, // 3: pb.GetCredentialsResponse.deepl:type_name -> pb.CredentialsDeepL
-	4, // [4:4] is the sub-list for method output_type
-	4, // [4:4] is the sub-list for method input_type
-	4, // [4:4] is the sub-list for extension type_name
-	4, // [4:4] is the sub-list for extension extendee
-	0, // [0:4] is the sub-list for field type_name
+	8, // 4: pb.GetUserSessionsGoogleResponse.sessions:type_name -> pb.SessionGoogle
+	5, // [5:5] is the sub-list for method output_type
+	5, // [5:5] is the sub-list for method input_type
+	5, // [5:5] is the sub-list for extension type_name
+	5, // [5:5] is the sub-list for extension extendee
+	0, // [0:5] is the sub-list for field type_name
 }
 
 func init() { file_pb_credentials_proto_init() }
@@ -589,7 +681,7 @@ func file_pb_credentials_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_pb_credentials_proto_rawDesc,
 			NumEnums:      0,
-			NumMessages:   9,
+			NumMessages:   10,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
@@ -21,16 +21,140 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+// Decides which existing localizations are replaced by translated ones.
+// Localizations which AutoCC didn't author, or which were edited afterwards, are considered manual.
+type MergePolicy int32
+
+const (
+	// Manual localizations are kept, the ones authored by AutoCC are replaced if their source changed.
+	MergePolicy_MERGE_POLICY_KEEP_MANUAL MergePolicy = 0
+	// Manual localizations are kept, all of the ones authored by AutoCC are replaced.
+	MergePolicy_MERGE_POLICY_OVERWRITE_AUTO MergePolicy = 1
+	// All localizations are replaced.
+	MergePolicy_MERGE_POLICY_OVERWRITE_ALL MergePolicy = 2
+)
+
+// Enum value maps for MergePolicy.
+var (
+	MergePolicy_name = map[int32]string{
+		0: "MERGE_POLICY_KEEP_MANUAL",
+		1: "MERGE_POLICY_OVERWRITE_AUTO",
+		2: "MERGE_POLICY_OVERWRITE_ALL",
+	}
+	MergePolicy_value = map[string]int32{
+		"MERGE_POLICY_KEEP_MANUAL":    0,
+		"MERGE_POLICY_OVERWRITE_AUTO": 1,
+		"MERGE_POLICY_OVERWRITE_ALL":  2,
+	}
+)
+
+func (x MergePolicy) Enum() *MergePolicy {
+	p := new(MergePolicy)
+	*p = x
+	return p
+}
+
+func (x MergePolicy) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (MergePolicy) Descriptor() protoreflect.EnumDescriptor {
+	return file_pb_youtube_proto_enumTypes[0].Descriptor()
+}
+
+func (MergePolicy) Type() protoreflect.EnumType {
+	return &file_pb_youtube_proto_enumTypes[0]
+}
+
+func (x MergePolicy) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use MergePolicy.Descriptor instead.
+func (MergePolicy) EnumDescriptor() ([]byte, []int) {
+	return file_pb_youtube_proto_rawDescGZIP(), []int{0}
+}
+
+// Tells who made the closed captions or the localized metadata of a video in a language.
+type Author int32
+
+const (
+	// There's nothing in the language.
+	Author_AUTHOR_NONE Author = 0
+	// Uploaded or written by hand, or authored by AutoCC and edited afterwards.
+	Author_AUTHOR_HUMAN  Author = 1
+	Author_AUTHOR_AUTOCC Author = 2
+	// Generated by YouTube's speech recognition, only closed captions can have it.
+	Author_AUTHOR_YOUTUBE Author = 3
+)
+
+// Enum value maps for Author.
+var (
+	Author_name = map[int32]string{
+		0: "AUTHOR_NONE",
+		1: "AUTHOR_HUMAN",
+		2: "AUTHOR_AUTOCC",
+		3: "AUTHOR_YOUTUBE",
+	}
+	Author_value = map[string]int32{
+		"AUTHOR_NONE":    0,
+		"AUTHOR_HUMAN":   1,
+		"AUTHOR_AUTOCC":  2,
+		"AUTHOR_YOUTUBE": 3,
+	}
+)
+
+func (x Author) Enum() *Author {
+	p := new(Author)
+	*p = x
+	return p
+}
+
+func (x Author) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Author) Descriptor() protoreflect.EnumDescriptor {
+	return file_pb_youtube_proto_enumTypes[1].Descriptor()
+}
+
+func (Author) Type() protoreflect.EnumType {
+	return &file_pb_youtube_proto_enumTypes[1]
+}
+
+func (x Author) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Author.Descriptor instead.
+func (Author) EnumDescriptor() ([]byte, []int) {
+	return file_pb_youtube_proto_rawDescGZIP(), []int{1}
+}
+
 type Video struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Id           string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	Title        string                 `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
-	ThumbnailUrl string                 `protobuf:"bytes,3,opt,name=thumbnail_url,json=thumbnailUrl,proto3" json:"thumbnail_url,omitempty"`
-	Description  string                 `protobuf:"bytes,4,opt,name=description,proto3" json:"description,omitempty"`
-	PublishedAt  *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=published_at,json=publishedAt,proto3" json:"published_at,omitempty"`
+	Id           string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Title        string `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
+	ThumbnailUrl string `protobuf:"bytes,3,opt,name=thumbnail_url,json=thumbnailUrl,proto3" json:"thumbnail_url,omitempty"`
+	Description  string `protobuf:"bytes,4,opt,name=description,proto3" json:"description,omitempty"`
+	// Not set if YouTube returned an invalid publish date.
+	PublishedAt *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=published_at,json=publishedAt,proto3" json:"published_at,omitempty"`
+	// One of public, unlisted or private.
+	PrivacyStatus   string `protobuf:"bytes,6,opt,name=privacy_status,json=privacyStatus,proto3" json:"privacy_status,omitempty"`
+	DurationSeconds uint32 `protobuf:"varint,7,opt,name=duration_seconds,json=durationSeconds,proto3" json:"duration_seconds,omitempty"`
+	// Default language of the video, empty if it's not set.
+	Language string `protobuf:"bytes,8,opt,name=language,proto3" json:"language,omitempty"`
+	// Languages of the closed captions on YouTube, empty if the video has closed captions but they weren't listed.
+	CaptionLanguages []string `protobuf:"bytes,9,rep,name=caption_languages,json=captionLanguages,proto3" json:"caption_languages,omitempty"`
+	// Set if AutoCC authored localizations of the video.
+	Processed bool `protobuf:"varint,10,opt,name=processed,proto3" json:"processed,omitempty"`
+	// Languages AutoCC can translate to without closed captions on YouTube, empty if the caption languages are unknown.
+	MissingLanguages []string `protobuf:"bytes,11,rep,name=missing_languages,json=missingLanguages,proto3" json:"missing_languages,omitempty"`
+	// Set if the video has closed captions on YouTube.
+	HasCaptions bool `protobuf:"varint,12,opt,name=has_captions,json=hasCaptions,proto3" json:"has_captions,omitempty"`
 }
 
 func (x *Video) Reset() {
@@ -98,6 +222,55 @@ func (x *Video) GetPublishedAt() *timestamppb.Timestamp {
 	return nil
 }
 
+func (x *Video) GetPrivacyStatus() string {
+	if x != nil {
+		return x.PrivacyStatus
+	}
+	return ""
+}
+
+func (x *Video) GetDurationSeconds() uint32 {
+	if x != nil {
+		return x.DurationSeconds
+	}
+	return 0
+}
+
+func (x *Video) GetLanguage() string {
+	if x != nil {
+		return x.Language
+	}
+	return ""
+}
+
+func (x *Video) GetCaptionLanguages() []string {
+	if x != nil {
+		return x.CaptionLanguages
+	}
+	return nil
+}
+
+func (x *Video) GetProcessed() bool {
+	if x != nil {
+		return x.Processed
+	}
+	return false
+}
+
+func (x *Video) GetMissingLanguages() []string {
+	if x != nil {
+		return x.MissingLanguages
+	}
+	return nil
+}
+
+func (x *Video) GetHasCaptions() bool {
+	if x != nil {
+		return x.HasCaptions
+	}
+	return false
+}
+
 type GetYoutubeVideosResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -151,13 +324,894 @@ func (x *GetYoutubeVideosResponse) GetVideos() []*Video {
 	return nil
 }
 
+type Channel struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id    string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Title string `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
+	// Set if the user has to authenticate again to use the channel.
+	Broken bool `protobuf:"varint,3,opt,name=broken,proto3" json:"broken,omitempty"`
+}
+
+func (x *Channel) Reset() {
+	*x = Channel{}
+	mi := &file_pb_youtube_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Channel) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Channel) ProtoMessage() {}
+
+func (x *Channel) ProtoReflect() protoreflect.Message {
+	mi := &file_pb_youtube_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Channel.ProtoReflect.Descriptor instead.
+func (*Channel) Descriptor() ([]byte, []int) {
+	return file_pb_youtube_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *Channel) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Channel) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *Channel) GetBroken() bool {
+	if x != nil {
+		return x.Broken
+	}
+	return false
+}
+
+type GetYoutubeChannelsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Channels []*Channel `protobuf:"bytes,1,rep,name=channels,proto3" json:"channels,omitempty"`
+}
+
+func (x *GetYoutubeChannelsResponse) Reset() {
+	*x = GetYoutubeChannelsResponse{}
+	mi := &file_pb_youtube_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetYoutubeChannelsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetYoutubeChannelsResponse) ProtoMessage() {}
+
+func (x *GetYoutubeChannelsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pb_youtube_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetYoutubeChannelsResponse.ProtoReflect.Descriptor instead.
+func (*GetYoutubeChannelsResponse) Descriptor() ([]byte, []int) {
+	return file_pb_youtube_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *GetYoutubeChannelsResponse) GetChannels() []*Channel {
+	if x != nil {
+		return x.Channels
+	}
+	return nil
+}
+
+// Selects what's localized besides the closed captions, title and description of the video.
+type ProcessVideoRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Translated tags are added to the tags of the video.
+	Tags bool `protobuf:"varint,1,opt,name=tags,proto3" json:"tags,omitempty"`
+	// Playlists containing the video are localized.
+	Playlists bool `protobuf:"varint,2,opt,name=playlists,proto3" json:"playlists,omitempty"`
+	// The name and description of the channel are localized.
+	Channel bool        `protobuf:"varint,3,opt,name=channel,proto3" json:"channel,omitempty"`
+	Policy  MergePolicy `protobuf:"varint,4,opt,name=policy,proto3,enum=pb.MergePolicy" json:"policy,omitempty"`
+	// Set as the default language of the video before it's processed, if it's not empty.
+	Language string `protobuf:"bytes,5,opt,name=language,proto3" json:"language,omitempty"`
+}
+
+func (x *ProcessVideoRequest) Reset() {
+	*x = ProcessVideoRequest{}
+	mi := &file_pb_youtube_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ProcessVideoRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProcessVideoRequest) ProtoMessage() {}
+
+func (x *ProcessVideoRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pb_youtube_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProcessVideoRequest.ProtoReflect.Descriptor instead.
+func (*ProcessVideoRequest) Descriptor() ([]byte, []int) {
+	return file_pb_youtube_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *ProcessVideoRequest) GetTags() bool {
+	if x != nil {
+		return x.Tags
+	}
+	return false
+}
+
+func (x *ProcessVideoRequest) GetPlaylists() bool {
+	if x != nil {
+		return x.Playlists
+	}
+	return false
+}
+
+func (x *ProcessVideoRequest) GetChannel() bool {
+	if x != nil {
+		return x.Channel
+	}
+	return false
+}
+
+func (x *ProcessVideoRequest) GetPolicy() MergePolicy {
+	if x != nil {
+		return x.Policy
+	}
+	return MergePolicy_MERGE_POLICY_KEEP_MANUAL
+}
+
+func (x *ProcessVideoRequest) GetLanguage() string {
+	if x != nil {
+		return x.Language
+	}
+	return ""
+}
+
+type DetectVideoLanguageResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Language string `protobuf:"bytes,1,opt,name=language,proto3" json:"language,omitempty"`
+}
+
+func (x *DetectVideoLanguageResponse) Reset() {
+	*x = DetectVideoLanguageResponse{}
+	mi := &file_pb_youtube_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DetectVideoLanguageResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DetectVideoLanguageResponse) ProtoMessage() {}
+
+func (x *DetectVideoLanguageResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pb_youtube_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DetectVideoLanguageResponse.ProtoReflect.Descriptor instead.
+func (*DetectVideoLanguageResponse) Descriptor() ([]byte, []int) {
+	return file_pb_youtube_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *DetectVideoLanguageResponse) GetLanguage() string {
+	if x != nil {
+		return x.Language
+	}
+	return ""
+}
+
+type ClosedCaptions struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id       string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Language string `protobuf:"bytes,2,opt,name=language,proto3" json:"language,omitempty"`
+}
+
+func (x *ClosedCaptions) Reset() {
+	*x = ClosedCaptions{}
+	mi := &file_pb_youtube_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ClosedCaptions) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ClosedCaptions) ProtoMessage() {}
+
+func (x *ClosedCaptions) ProtoReflect() protoreflect.Message {
+	mi := &file_pb_youtube_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ClosedCaptions.ProtoReflect.Descriptor instead.
+func (*ClosedCaptions) Descriptor() ([]byte, []int) {
+	return file_pb_youtube_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *ClosedCaptions) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *ClosedCaptions) GetLanguage() string {
+	if x != nil {
+		return x.Language
+	}
+	return ""
+}
+
+type GetYoutubeClosedCaptionsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ClosedCaptions []*ClosedCaptions `protobuf:"bytes,1,rep,name=closed_captions,json=closedCaptions,proto3" json:"closed_captions,omitempty"`
+}
+
+func (x *GetYoutubeClosedCaptionsResponse) Reset() {
+	*x = GetYoutubeClosedCaptionsResponse{}
+	mi := &file_pb_youtube_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetYoutubeClosedCaptionsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetYoutubeClosedCaptionsResponse) ProtoMessage() {}
+
+func (x *GetYoutubeClosedCaptionsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pb_youtube_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetYoutubeClosedCaptionsResponse.ProtoReflect.Descriptor instead.
+func (*GetYoutubeClosedCaptionsResponse) Descriptor() ([]byte, []int) {
+	return file_pb_youtube_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *GetYoutubeClosedCaptionsResponse) GetClosedCaptions() []*ClosedCaptions {
+	if x != nil {
+		return x.ClosedCaptions
+	}
+	return nil
+}
+
+type SyncPoint struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	FromMs int64 `protobuf:"varint,1,opt,name=from_ms,json=fromMs,proto3" json:"from_ms,omitempty"`
+	ToMs   int64 `protobuf:"varint,2,opt,name=to_ms,json=toMs,proto3" json:"to_ms,omitempty"`
+}
+
+func (x *SyncPoint) Reset() {
+	*x = SyncPoint{}
+	mi := &file_pb_youtube_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SyncPoint) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SyncPoint) ProtoMessage() {}
+
+func (x *SyncPoint) ProtoReflect() protoreflect.Message {
+	mi := &file_pb_youtube_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SyncPoint.ProtoReflect.Descriptor instead.
+func (*SyncPoint) Descriptor() ([]byte, []int) {
+	return file_pb_youtube_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *SyncPoint) GetFromMs() int64 {
+	if x != nil {
+		return x.FromMs
+	}
+	return 0
+}
+
+func (x *SyncPoint) GetToMs() int64 {
+	if x != nil {
+		return x.ToMs
+	}
+	return 0
+}
+
+type AdjustSourceTimingRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	SyncPoints    []*SyncPoint `protobuf:"bytes,1,rep,name=sync_points,json=syncPoints,proto3" json:"sync_points,omitempty"`
+	FramerateFrom float64      `protobuf:"fixed64,2,opt,name=framerate_from,json=framerateFrom,proto3" json:"framerate_from,omitempty"`
+	FramerateTo   float64      `protobuf:"fixed64,3,opt,name=framerate_to,json=framerateTo,proto3" json:"framerate_to,omitempty"`
+	ShiftMs       int64        `protobuf:"varint,4,opt,name=shift_ms,json=shiftMs,proto3" json:"shift_ms,omitempty"`
+	MinDurationMs int64        `protobuf:"varint,5,opt,name=min_duration_ms,json=minDurationMs,proto3" json:"min_duration_ms,omitempty"`
+	MaxDurationMs int64        `protobuf:"varint,6,opt,name=max_duration_ms,json=maxDurationMs,proto3" json:"max_duration_ms,omitempty"`
+}
+
+func (x *AdjustSourceTimingRequest) Reset() {
+	*x = AdjustSourceTimingRequest{}
+	mi := &file_pb_youtube_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AdjustSourceTimingRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AdjustSourceTimingRequest) ProtoMessage() {}
+
+func (x *AdjustSourceTimingRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pb_youtube_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AdjustSourceTimingRequest.ProtoReflect.Descriptor instead.
+func (*AdjustSourceTimingRequest) Descriptor() ([]byte, []int) {
+	return file_pb_youtube_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *AdjustSourceTimingRequest) GetSyncPoints() []*SyncPoint {
+	if x != nil {
+		return x.SyncPoints
+	}
+	return nil
+}
+
+func (x *AdjustSourceTimingRequest) GetFramerateFrom() float64 {
+	if x != nil {
+		return x.FramerateFrom
+	}
+	return 0
+}
+
+func (x *AdjustSourceTimingRequest) GetFramerateTo() float64 {
+	if x != nil {
+		return x.FramerateTo
+	}
+	return 0
+}
+
+func (x *AdjustSourceTimingRequest) GetShiftMs() int64 {
+	if x != nil {
+		return x.ShiftMs
+	}
+	return 0
+}
+
+func (x *AdjustSourceTimingRequest) GetMinDurationMs() int64 {
+	if x != nil {
+		return x.MinDurationMs
+	}
+	return 0
+}
+
+func (x *AdjustSourceTimingRequest) GetMaxDurationMs() int64 {
+	if x != nil {
+		return x.MaxDurationMs
+	}
+	return 0
+}
+
+type TranslationState struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Author Author `protobuf:"varint,1,opt,name=author,proto3,enum=pb.Author" json:"author,omitempty"`
+	// Set if AutoCC translated it from a source which changed since.
+	Stale bool `protobuf:"varint,2,opt,name=stale,proto3" json:"stale,omitempty"`
+}
+
+func (x *TranslationState) Reset() {
+	*x = TranslationState{}
+	mi := &file_pb_youtube_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TranslationState) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TranslationState) ProtoMessage() {}
+
+func (x *TranslationState) ProtoReflect() protoreflect.Message {
+	mi := &file_pb_youtube_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TranslationState.ProtoReflect.Descriptor instead.
+func (*TranslationState) Descriptor() ([]byte, []int) {
+	return file_pb_youtube_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *TranslationState) GetAuthor() Author {
+	if x != nil {
+		return x.Author
+	}
+	return Author_AUTHOR_NONE
+}
+
+func (x *TranslationState) GetStale() bool {
+	if x != nil {
+		return x.Stale
+	}
+	return false
+}
+
+type LanguageCoverage struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Language string            `protobuf:"bytes,1,opt,name=language,proto3" json:"language,omitempty"`
+	Captions *TranslationState `protobuf:"bytes,2,opt,name=captions,proto3" json:"captions,omitempty"`
+	Metadata *TranslationState `protobuf:"bytes,3,opt,name=metadata,proto3" json:"metadata,omitempty"`
+}
+
+func (x *LanguageCoverage) Reset() {
+	*x = LanguageCoverage{}
+	mi := &file_pb_youtube_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LanguageCoverage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LanguageCoverage) ProtoMessage() {}
+
+func (x *LanguageCoverage) ProtoReflect() protoreflect.Message {
+	mi := &file_pb_youtube_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LanguageCoverage.ProtoReflect.Descriptor instead.
+func (*LanguageCoverage) Descriptor() ([]byte, []int) {
+	return file_pb_youtube_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *LanguageCoverage) GetLanguage() string {
+	if x != nil {
+		return x.Language
+	}
+	return ""
+}
+
+func (x *LanguageCoverage) GetCaptions() *TranslationState {
+	if x != nil {
+		return x.Captions
+	}
+	return nil
+}
+
+func (x *LanguageCoverage) GetMetadata() *TranslationState {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+type VideoCoverage struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	VideoId string `protobuf:"bytes,1,opt,name=video_id,json=videoId,proto3" json:"video_id,omitempty"`
+	Title   string `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
+	// Default language of the video, it's not listed in the languages.
+	Language string `protobuf:"bytes,3,opt,name=language,proto3" json:"language,omitempty"`
+	// Languages AutoCC translates to and the ones the video has translations in, ordered by language.
+	Languages []*LanguageCoverage `protobuf:"bytes,4,rep,name=languages,proto3" json:"languages,omitempty"`
+}
+
+func (x *VideoCoverage) Reset() {
+	*x = VideoCoverage{}
+	mi := &file_pb_youtube_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VideoCoverage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VideoCoverage) ProtoMessage() {}
+
+func (x *VideoCoverage) ProtoReflect() protoreflect.Message {
+	mi := &file_pb_youtube_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VideoCoverage.ProtoReflect.Descriptor instead.
+func (*VideoCoverage) Descriptor() ([]byte, []int) {
+	return file_pb_youtube_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *VideoCoverage) GetVideoId() string {
+	if x != nil {
+		return x.VideoId
+	}
+	return ""
+}
+
+func (x *VideoCoverage) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *VideoCoverage) GetLanguage() string {
+	if x != nil {
+		return x.Language
+	}
+	return ""
+}
+
+func (x *VideoCoverage) GetLanguages() []*LanguageCoverage {
+	if x != nil {
+		return x.Languages
+	}
+	return nil
+}
+
+type GetVideoCoverageResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Coverage *VideoCoverage `protobuf:"bytes,1,opt,name=coverage,proto3" json:"coverage,omitempty"`
+}
+
+func (x *GetVideoCoverageResponse) Reset() {
+	*x = GetVideoCoverageResponse{}
+	mi := &file_pb_youtube_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetVideoCoverageResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetVideoCoverageResponse) ProtoMessage() {}
+
+func (x *GetVideoCoverageResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pb_youtube_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetVideoCoverageResponse.ProtoReflect.Descriptor instead.
+func (*GetVideoCoverageResponse) Descriptor() ([]byte, []int) {
+	return file_pb_youtube_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *GetVideoCoverageResponse) GetCoverage() *VideoCoverage {
+	if x != nil {
+		return x.Coverage
+	}
+	return nil
+}
+
+// Counts videos by the state of their translations in a language.
+type LanguageSummary struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Language        string `protobuf:"bytes,1,opt,name=language,proto3" json:"language,omitempty"`
+	CaptionsMissing uint32 `protobuf:"varint,2,opt,name=captions_missing,json=captionsMissing,proto3" json:"captions_missing,omitempty"`
+	CaptionsHuman   uint32 `protobuf:"varint,3,opt,name=captions_human,json=captionsHuman,proto3" json:"captions_human,omitempty"`
+	CaptionsAutocc  uint32 `protobuf:"varint,4,opt,name=captions_autocc,json=captionsAutocc,proto3" json:"captions_autocc,omitempty"`
+	CaptionsYoutube uint32 `protobuf:"varint,5,opt,name=captions_youtube,json=captionsYoutube,proto3" json:"captions_youtube,omitempty"`
+	CaptionsStale   uint32 `protobuf:"varint,6,opt,name=captions_stale,json=captionsStale,proto3" json:"captions_stale,omitempty"`
+	MetadataMissing uint32 `protobuf:"varint,7,opt,name=metadata_missing,json=metadataMissing,proto3" json:"metadata_missing,omitempty"`
+	MetadataHuman   uint32 `protobuf:"varint,8,opt,name=metadata_human,json=metadataHuman,proto3" json:"metadata_human,omitempty"`
+	MetadataAutocc  uint32 `protobuf:"varint,9,opt,name=metadata_autocc,json=metadataAutocc,proto3" json:"metadata_autocc,omitempty"`
+	MetadataStale   uint32 `protobuf:"varint,10,opt,name=metadata_stale,json=metadataStale,proto3" json:"metadata_stale,omitempty"`
+}
+
+func (x *LanguageSummary) Reset() {
+	*x = LanguageSummary{}
+	mi := &file_pb_youtube_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LanguageSummary) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LanguageSummary) ProtoMessage() {}
+
+func (x *LanguageSummary) ProtoReflect() protoreflect.Message {
+	mi := &file_pb_youtube_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LanguageSummary.ProtoReflect.Descriptor instead.
+func (*LanguageSummary) Descriptor() ([]byte, []int) {
+	return file_pb_youtube_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *LanguageSummary) GetLanguage() string {
+	if x != nil {
+		return x.Language
+	}
+	return ""
+}
+
+func (x *LanguageSummary) GetCaptionsMissing() uint32 {
+	if x != nil {
+		return x.CaptionsMissing
+	}
+	return 0
+}
+
+func (x *LanguageSummary) GetCaptionsHuman() uint32 {
+	if x != nil {
+		return x.CaptionsHuman
+	}
+	return 0
+}
+
+func (x *LanguageSummary) GetCaptionsAutocc() uint32 {
+	if x != nil {
+		return x.CaptionsAutocc
+	}
+	return 0
+}
+
+func (x *LanguageSummary) GetCaptionsYoutube() uint32 {
+	if x != nil {
+		return x.CaptionsYoutube
+	}
+	return 0
+}
+
+func (x *LanguageSummary) GetCaptionsStale() uint32 {
+	if x != nil {
+		return x.CaptionsStale
+	}
+	return 0
+}
+
+func (x *LanguageSummary) GetMetadataMissing() uint32 {
+	if x != nil {
+		return x.MetadataMissing
+	}
+	return 0
+}
+
+func (x *LanguageSummary) GetMetadataHuman() uint32 {
+	if x != nil {
+		return x.MetadataHuman
+	}
+	return 0
+}
+
+func (x *LanguageSummary) GetMetadataAutocc() uint32 {
+	if x != nil {
+		return x.MetadataAutocc
+	}
+	return 0
+}
+
+func (x *LanguageSummary) GetMetadataStale() uint32 {
+	if x != nil {
+		return x.MetadataStale
+	}
+	return 0
+}
+
+// Coverage of a page of videos of the channel, summaries of the pages can be added together.
+type GetChannelCoverageResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	NextPageToken string             `protobuf:"bytes,1,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	Videos        []*VideoCoverage   `protobuf:"bytes,2,rep,name=videos,proto3" json:"videos,omitempty"`
+	Languages     []*LanguageSummary `protobuf:"bytes,3,rep,name=languages,proto3" json:"languages,omitempty"`
+}
+
+func (x *GetChannelCoverageResponse) Reset() {
+	*x = GetChannelCoverageResponse{}
+	mi := &file_pb_youtube_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetChannelCoverageResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetChannelCoverageResponse) ProtoMessage() {}
+
+func (x *GetChannelCoverageResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pb_youtube_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetChannelCoverageResponse.ProtoReflect.Descriptor instead.
+func (*GetChannelCoverageResponse) Descriptor() ([]byte, []int) {
+	return file_pb_youtube_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *GetChannelCoverageResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+func (x *GetChannelCoverageResponse) GetVideos() []*VideoCoverage {
+	if x != nil {
+		return x.Videos
+	}
+	return nil
+}
+
+func (x *GetChannelCoverageResponse) GetLanguages() []*LanguageSummary {
+	if x != nil {
+		return x.Languages
+	}
+	return nil
+}
+
 var File_pb_youtube_proto protoreflect.FileDescriptor
 
 var file_pb_youtube_proto_rawDesc = []byte{
 	0x0a, 0x10, 0x70, 0x62, 0x2f, 0x79, 0x6f, 0x75, 0x74, 0x75, 0x62, 0x65, 0x2e, 0x70, 0x72, 0x6f,
 	0x74, 0x6f, 0x12, 0x02, 0x70, 0x62, 0x1a, 0x1f, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70,
 	0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d,
-	0x70, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0xb3, 0x01, 0x0a, 0x05, 0x56, 0x69, 0x64, 0x65,
+	0x70, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0xbc, 0x03, 0x0a, 0x05, 0x56, 0x69, 0x64, 0x65,
 	0x6f, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69,
 	0x64, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x69, 0x74, 0x6c, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
 	0x52, 0x05, 0x74, 0x69, 0x74, 0x6c, 0x65, 0x12, 0x23, 0x0a, 0x0d, 0x74, 0x68, 0x75, 0x6d, 0x62,
@@ -168,20 +1222,166 @@ var file_pb_youtube_proto_rawDesc = []byte{
 	0x0a, 0x0c, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x73, 0x68, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18, 0x05,
 	0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72,
 	0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70,
-	0x52, 0x0b, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x73, 0x68, 0x65, 0x64, 0x41, 0x74, 0x22, 0x65, 0x0a,
-	0x18, 0x47, 0x65, 0x74, 0x59, 0x6f, 0x75, 0x74, 0x75, 0x62, 0x65, 0x56, 0x69, 0x64, 0x65, 0x6f,
-	0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x26, 0x0a, 0x0f, 0x6e, 0x65, 0x78,
-	0x74, 0x5f, 0x70, 0x61, 0x67, 0x65, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x01, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x0d, 0x6e, 0x65, 0x78, 0x74, 0x50, 0x61, 0x67, 0x65, 0x54, 0x6f, 0x6b, 0x65,
-	0x6e, 0x12, 0x21, 0x0a, 0x06, 0x76, 0x69, 0x64, 0x65, 0x6f, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28,
-	0x0b, 0x32, 0x09, 0x2e, 0x70, 0x62, 0x2e, 0x56, 0x69, 0x64, 0x65, 0x6f, 0x52, 0x06, 0x76, 0x69,
-	0x64, 0x65, 0x6f, 0x73, 0x42, 0x60, 0x0a, 0x06, 0x63, 0x6f, 0x6d, 0x2e, 0x70, 0x62, 0x42, 0x0c,
-	0x59, 0x6f, 0x75, 0x74, 0x75, 0x62, 0x65, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x50, 0x01, 0x5a, 0x20,
-	0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x70, 0x6b, 0x75, 0x6c, 0x69,
-	0x6b, 0x30, 0x2f, 0x61, 0x75, 0x74, 0x6f, 0x63, 0x63, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x70, 0x62,
-	0xa2, 0x02, 0x03, 0x50, 0x58, 0x58, 0xaa, 0x02, 0x02, 0x50, 0x62, 0xca, 0x02, 0x02, 0x50, 0x62,
-	0xe2, 0x02, 0x0e, 0x50, 0x62, 0x5c, 0x47, 0x50, 0x42, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74,
-	0x61, 0xea, 0x02, 0x02, 0x50, 0x62, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x52, 0x0b, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x73, 0x68, 0x65, 0x64, 0x41, 0x74, 0x12, 0x25, 0x0a,
+	0x0e, 0x70, 0x72, 0x69, 0x76, 0x61, 0x63, 0x79, 0x5f, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18,
+	0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x70, 0x72, 0x69, 0x76, 0x61, 0x63, 0x79, 0x53, 0x74,
+	0x61, 0x74, 0x75, 0x73, 0x12, 0x29, 0x0a, 0x10, 0x64, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x5f, 0x73, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0f,
+	0x64, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x53, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73, 0x12,
+	0x1a, 0x0a, 0x08, 0x6c, 0x61, 0x6e, 0x67, 0x75, 0x61, 0x67, 0x65, 0x18, 0x08, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x08, 0x6c, 0x61, 0x6e, 0x67, 0x75, 0x61, 0x67, 0x65, 0x12, 0x2b, 0x0a, 0x11, 0x63,
+	0x61, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x6c, 0x61, 0x6e, 0x67, 0x75, 0x61, 0x67, 0x65, 0x73,
+	0x18, 0x09, 0x20, 0x03, 0x28, 0x09, 0x52, 0x10, 0x63, 0x61, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x4c,
+	0x61, 0x6e, 0x67, 0x75, 0x61, 0x67, 0x65, 0x73, 0x12, 0x1c, 0x0a, 0x09, 0x70, 0x72, 0x6f, 0x63,
+	0x65, 0x73, 0x73, 0x65, 0x64, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x08, 0x52, 0x09, 0x70, 0x72, 0x6f,
+	0x63, 0x65, 0x73, 0x73, 0x65, 0x64, 0x12, 0x2b, 0x0a, 0x11, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6e,
+	0x67, 0x5f, 0x6c, 0x61, 0x6e, 0x67, 0x75, 0x61, 0x67, 0x65, 0x73, 0x18, 0x0b, 0x20, 0x03, 0x28,
+	0x09, 0x52, 0x10, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6e, 0x67, 0x4c, 0x61, 0x6e, 0x67, 0x75, 0x61,
+	0x67, 0x65, 0x73, 0x12, 0x21, 0x0a, 0x0c, 0x68, 0x61, 0x73, 0x5f, 0x63, 0x61, 0x70, 0x74, 0x69,
+	0x6f, 0x6e, 0x73, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0b, 0x68, 0x61, 0x73, 0x43, 0x61,
+	0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x22, 0x65, 0x0a, 0x18, 0x47, 0x65, 0x74, 0x59, 0x6f, 0x75,
+	0x74, 0x75, 0x62, 0x65, 0x56, 0x69, 0x64, 0x65, 0x6f, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x12, 0x26, 0x0a, 0x0f, 0x6e, 0x65, 0x78, 0x74, 0x5f, 0x70, 0x61, 0x67, 0x65, 0x5f,
+	0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x6e, 0x65, 0x78,
+	0x74, 0x50, 0x61, 0x67, 0x65, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x12, 0x21, 0x0a, 0x06, 0x76, 0x69,
+	0x64, 0x65, 0x6f, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x09, 0x2e, 0x70, 0x62, 0x2e,
+	0x56, 0x69, 0x64, 0x65, 0x6f, 0x52, 0x06, 0x76, 0x69, 0x64, 0x65, 0x6f, 0x73, 0x22, 0x47, 0x0a,
+	0x07, 0x43, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x69, 0x74, 0x6c,
+	0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x74, 0x69, 0x74, 0x6c, 0x65, 0x12, 0x16,
+	0x0a, 0x06, 0x62, 0x72, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06,
+	0x62, 0x72, 0x6f, 0x6b, 0x65, 0x6e, 0x22, 0x45, 0x0a, 0x1a, 0x47, 0x65, 0x74, 0x59, 0x6f, 0x75,
+	0x74, 0x75, 0x62, 0x65, 0x43, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x73, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x27, 0x0a, 0x08, 0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x73,
+	0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0b, 0x2e, 0x70, 0x62, 0x2e, 0x43, 0x68, 0x61, 0x6e,
+	0x6e, 0x65, 0x6c, 0x52, 0x08, 0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x73, 0x22, 0xa6, 0x01,
+	0x0a, 0x13, 0x50, 0x72, 0x6f, 0x63, 0x65, 0x73, 0x73, 0x56, 0x69, 0x64, 0x65, 0x6f, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x61, 0x67, 0x73, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x08, 0x52, 0x04, 0x74, 0x61, 0x67, 0x73, 0x12, 0x1c, 0x0a, 0x09, 0x70, 0x6c, 0x61,
+	0x79, 0x6c, 0x69, 0x73, 0x74, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x09, 0x70, 0x6c,
+	0x61, 0x79, 0x6c, 0x69, 0x73, 0x74, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x63, 0x68, 0x61, 0x6e, 0x6e,
+	0x65, 0x6c, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65,
+	0x6c, 0x12, 0x27, 0x0a, 0x06, 0x70, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x0e, 0x32, 0x0f, 0x2e, 0x70, 0x62, 0x2e, 0x4d, 0x65, 0x72, 0x67, 0x65, 0x50, 0x6f, 0x6c, 0x69,
+	0x63, 0x79, 0x52, 0x06, 0x70, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x12, 0x1a, 0x0a, 0x08, 0x6c, 0x61,
+	0x6e, 0x67, 0x75, 0x61, 0x67, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x6c, 0x61,
+	0x6e, 0x67, 0x75, 0x61, 0x67, 0x65, 0x22, 0x39, 0x0a, 0x1b, 0x44, 0x65, 0x74, 0x65, 0x63, 0x74,
+	0x56, 0x69, 0x64, 0x65, 0x6f, 0x4c, 0x61, 0x6e, 0x67, 0x75, 0x61, 0x67, 0x65, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x6c, 0x61, 0x6e, 0x67, 0x75, 0x61, 0x67,
+	0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x6c, 0x61, 0x6e, 0x67, 0x75, 0x61, 0x67,
+	0x65, 0x22, 0x3c, 0x0a, 0x0e, 0x43, 0x6c, 0x6f, 0x73, 0x65, 0x64, 0x43, 0x61, 0x70, 0x74, 0x69,
+	0x6f, 0x6e, 0x73, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x02, 0x69, 0x64, 0x12, 0x1a, 0x0a, 0x08, 0x6c, 0x61, 0x6e, 0x67, 0x75, 0x61, 0x67, 0x65, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x6c, 0x61, 0x6e, 0x67, 0x75, 0x61, 0x67, 0x65, 0x22,
+	0x5f, 0x0a, 0x20, 0x47, 0x65, 0x74, 0x59, 0x6f, 0x75, 0x74, 0x75, 0x62, 0x65, 0x43, 0x6c, 0x6f,
+	0x73, 0x65, 0x64, 0x43, 0x61, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x12, 0x3b, 0x0a, 0x0f, 0x63, 0x6c, 0x6f, 0x73, 0x65, 0x64, 0x5f, 0x63, 0x61,
+	0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x70,
+	0x62, 0x2e, 0x43, 0x6c, 0x6f, 0x73, 0x65, 0x64, 0x43, 0x61, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73,
+	0x52, 0x0e, 0x63, 0x6c, 0x6f, 0x73, 0x65, 0x64, 0x43, 0x61, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73,
+	0x22, 0x39, 0x0a, 0x09, 0x53, 0x79, 0x6e, 0x63, 0x50, 0x6f, 0x69, 0x6e, 0x74, 0x12, 0x17, 0x0a,
+	0x07, 0x66, 0x72, 0x6f, 0x6d, 0x5f, 0x6d, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06,
+	0x66, 0x72, 0x6f, 0x6d, 0x4d, 0x73, 0x12, 0x13, 0x0a, 0x05, 0x74, 0x6f, 0x5f, 0x6d, 0x73, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x04, 0x74, 0x6f, 0x4d, 0x73, 0x22, 0x80, 0x02, 0x0a, 0x19,
+	0x41, 0x64, 0x6a, 0x75, 0x73, 0x74, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x54, 0x69, 0x6d, 0x69,
+	0x6e, 0x67, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x2e, 0x0a, 0x0b, 0x73, 0x79, 0x6e,
+	0x63, 0x5f, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0d,
+	0x2e, 0x70, 0x62, 0x2e, 0x53, 0x79, 0x6e, 0x63, 0x50, 0x6f, 0x69, 0x6e, 0x74, 0x52, 0x0a, 0x73,
+	0x79, 0x6e, 0x63, 0x50, 0x6f, 0x69, 0x6e, 0x74, 0x73, 0x12, 0x25, 0x0a, 0x0e, 0x66, 0x72, 0x61,
+	0x6d, 0x65, 0x72, 0x61, 0x74, 0x65, 0x5f, 0x66, 0x72, 0x6f, 0x6d, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x01, 0x52, 0x0d, 0x66, 0x72, 0x61, 0x6d, 0x65, 0x72, 0x61, 0x74, 0x65, 0x46, 0x72, 0x6f, 0x6d,
+	0x12, 0x21, 0x0a, 0x0c, 0x66, 0x72, 0x61, 0x6d, 0x65, 0x72, 0x61, 0x74, 0x65, 0x5f, 0x74, 0x6f,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0b, 0x66, 0x72, 0x61, 0x6d, 0x65, 0x72, 0x61, 0x74,
+	0x65, 0x54, 0x6f, 0x12, 0x19, 0x0a, 0x08, 0x73, 0x68, 0x69, 0x66, 0x74, 0x5f, 0x6d, 0x73, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x73, 0x68, 0x69, 0x66, 0x74, 0x4d, 0x73, 0x12, 0x26,
+	0x0a, 0x0f, 0x6d, 0x69, 0x6e, 0x5f, 0x64, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x6d,
+	0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0d, 0x6d, 0x69, 0x6e, 0x44, 0x75, 0x72, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x4d, 0x73, 0x12, 0x26, 0x0a, 0x0f, 0x6d, 0x61, 0x78, 0x5f, 0x64, 0x75,
+	0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x6d, 0x73, 0x18, 0x06, 0x20, 0x01, 0x28, 0x03, 0x52,
+	0x0d, 0x6d, 0x61, 0x78, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x4d, 0x73, 0x22, 0x4c,
+	0x0a, 0x10, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x53, 0x74, 0x61,
+	0x74, 0x65, 0x12, 0x22, 0x0a, 0x06, 0x61, 0x75, 0x74, 0x68, 0x6f, 0x72, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x0e, 0x32, 0x0a, 0x2e, 0x70, 0x62, 0x2e, 0x41, 0x75, 0x74, 0x68, 0x6f, 0x72, 0x52, 0x06,
+	0x61, 0x75, 0x74, 0x68, 0x6f, 0x72, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x74, 0x61, 0x6c, 0x65, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x05, 0x73, 0x74, 0x61, 0x6c, 0x65, 0x22, 0x92, 0x01, 0x0a,
+	0x10, 0x4c, 0x61, 0x6e, 0x67, 0x75, 0x61, 0x67, 0x65, 0x43, 0x6f, 0x76, 0x65, 0x72, 0x61, 0x67,
+	0x65, 0x12, 0x1a, 0x0a, 0x08, 0x6c, 0x61, 0x6e, 0x67, 0x75, 0x61, 0x67, 0x65, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x08, 0x6c, 0x61, 0x6e, 0x67, 0x75, 0x61, 0x67, 0x65, 0x12, 0x30, 0x0a,
+	0x08, 0x63, 0x61, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x14, 0x2e, 0x70, 0x62, 0x2e, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x53, 0x74, 0x61, 0x74, 0x65, 0x52, 0x08, 0x63, 0x61, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12,
+	0x30, 0x0a, 0x08, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x14, 0x2e, 0x70, 0x62, 0x2e, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x6c, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x53, 0x74, 0x61, 0x74, 0x65, 0x52, 0x08, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74,
+	0x61, 0x22, 0x90, 0x01, 0x0a, 0x0d, 0x56, 0x69, 0x64, 0x65, 0x6f, 0x43, 0x6f, 0x76, 0x65, 0x72,
+	0x61, 0x67, 0x65, 0x12, 0x19, 0x0a, 0x08, 0x76, 0x69, 0x64, 0x65, 0x6f, 0x5f, 0x69, 0x64, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x76, 0x69, 0x64, 0x65, 0x6f, 0x49, 0x64, 0x12, 0x14,
+	0x0a, 0x05, 0x74, 0x69, 0x74, 0x6c, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x74,
+	0x69, 0x74, 0x6c, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x6c, 0x61, 0x6e, 0x67, 0x75, 0x61, 0x67, 0x65,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x6c, 0x61, 0x6e, 0x67, 0x75, 0x61, 0x67, 0x65,
+	0x12, 0x32, 0x0a, 0x09, 0x6c, 0x61, 0x6e, 0x67, 0x75, 0x61, 0x67, 0x65, 0x73, 0x18, 0x04, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x70, 0x62, 0x2e, 0x4c, 0x61, 0x6e, 0x67, 0x75, 0x61, 0x67,
+	0x65, 0x43, 0x6f, 0x76, 0x65, 0x72, 0x61, 0x67, 0x65, 0x52, 0x09, 0x6c, 0x61, 0x6e, 0x67, 0x75,
+	0x61, 0x67, 0x65, 0x73, 0x22, 0x49, 0x0a, 0x18, 0x47, 0x65, 0x74, 0x56, 0x69, 0x64, 0x65, 0x6f,
+	0x43, 0x6f, 0x76, 0x65, 0x72, 0x61, 0x67, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x12, 0x2d, 0x0a, 0x08, 0x63, 0x6f, 0x76, 0x65, 0x72, 0x61, 0x67, 0x65, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x11, 0x2e, 0x70, 0x62, 0x2e, 0x56, 0x69, 0x64, 0x65, 0x6f, 0x43, 0x6f, 0x76,
+	0x65, 0x72, 0x61, 0x67, 0x65, 0x52, 0x08, 0x63, 0x6f, 0x76, 0x65, 0x72, 0x61, 0x67, 0x65, 0x22,
+	0x9c, 0x03, 0x0a, 0x0f, 0x4c, 0x61, 0x6e, 0x67, 0x75, 0x61, 0x67, 0x65, 0x53, 0x75, 0x6d, 0x6d,
+	0x61, 0x72, 0x79, 0x12, 0x1a, 0x0a, 0x08, 0x6c, 0x61, 0x6e, 0x67, 0x75, 0x61, 0x67, 0x65, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x6c, 0x61, 0x6e, 0x67, 0x75, 0x61, 0x67, 0x65, 0x12,
+	0x29, 0x0a, 0x10, 0x63, 0x61, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x5f, 0x6d, 0x69, 0x73, 0x73,
+	0x69, 0x6e, 0x67, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0f, 0x63, 0x61, 0x70, 0x74, 0x69,
+	0x6f, 0x6e, 0x73, 0x4d, 0x69, 0x73, 0x73, 0x69, 0x6e, 0x67, 0x12, 0x25, 0x0a, 0x0e, 0x63, 0x61,
+	0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x5f, 0x68, 0x75, 0x6d, 0x61, 0x6e, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x0d, 0x52, 0x0d, 0x63, 0x61, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x48, 0x75, 0x6d, 0x61,
+	0x6e, 0x12, 0x27, 0x0a, 0x0f, 0x63, 0x61, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x5f, 0x61, 0x75,
+	0x74, 0x6f, 0x63, 0x63, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0e, 0x63, 0x61, 0x70, 0x74,
+	0x69, 0x6f, 0x6e, 0x73, 0x41, 0x75, 0x74, 0x6f, 0x63, 0x63, 0x12, 0x29, 0x0a, 0x10, 0x63, 0x61,
+	0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x5f, 0x79, 0x6f, 0x75, 0x74, 0x75, 0x62, 0x65, 0x18, 0x05,
+	0x20, 0x01, 0x28, 0x0d, 0x52, 0x0f, 0x63, 0x61, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x59, 0x6f,
+	0x75, 0x74, 0x75, 0x62, 0x65, 0x12, 0x25, 0x0a, 0x0e, 0x63, 0x61, 0x70, 0x74, 0x69, 0x6f, 0x6e,
+	0x73, 0x5f, 0x73, 0x74, 0x61, 0x6c, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0d, 0x63,
+	0x61, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x53, 0x74, 0x61, 0x6c, 0x65, 0x12, 0x29, 0x0a, 0x10,
+	0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x5f, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6e, 0x67,
+	0x18, 0x07, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0f, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61,
+	0x4d, 0x69, 0x73, 0x73, 0x69, 0x6e, 0x67, 0x12, 0x25, 0x0a, 0x0e, 0x6d, 0x65, 0x74, 0x61, 0x64,
+	0x61, 0x74, 0x61, 0x5f, 0x68, 0x75, 0x6d, 0x61, 0x6e, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0d, 0x52,
+	0x0d, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x48, 0x75, 0x6d, 0x61, 0x6e, 0x12, 0x27,
+	0x0a, 0x0f, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x5f, 0x61, 0x75, 0x74, 0x6f, 0x63,
+	0x63, 0x18, 0x09, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0e, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74,
+	0x61, 0x41, 0x75, 0x74, 0x6f, 0x63, 0x63, 0x12, 0x25, 0x0a, 0x0e, 0x6d, 0x65, 0x74, 0x61, 0x64,
+	0x61, 0x74, 0x61, 0x5f, 0x73, 0x74, 0x61, 0x6c, 0x65, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x0d, 0x52,
+	0x0d, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x53, 0x74, 0x61, 0x6c, 0x65, 0x22, 0xa2,
+	0x01, 0x0a, 0x1a, 0x47, 0x65, 0x74, 0x43, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x43, 0x6f, 0x76,
+	0x65, 0x72, 0x61, 0x67, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x26, 0x0a,
+	0x0f, 0x6e, 0x65, 0x78, 0x74, 0x5f, 0x70, 0x61, 0x67, 0x65, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x6e, 0x65, 0x78, 0x74, 0x50, 0x61, 0x67, 0x65,
+	0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x12, 0x29, 0x0a, 0x06, 0x76, 0x69, 0x64, 0x65, 0x6f, 0x73, 0x18,
+	0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x70, 0x62, 0x2e, 0x56, 0x69, 0x64, 0x65, 0x6f,
+	0x43, 0x6f, 0x76, 0x65, 0x72, 0x61, 0x67, 0x65, 0x52, 0x06, 0x76, 0x69, 0x64, 0x65, 0x6f, 0x73,
+	0x12, 0x31, 0x0a, 0x09, 0x6c, 0x61, 0x6e, 0x67, 0x75, 0x61, 0x67, 0x65, 0x73, 0x18, 0x03, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x70, 0x62, 0x2e, 0x4c, 0x61, 0x6e, 0x67, 0x75, 0x61, 0x67,
+	0x65, 0x53, 0x75, 0x6d, 0x6d, 0x61, 0x72, 0x79, 0x52, 0x09, 0x6c, 0x61, 0x6e, 0x67, 0x75, 0x61,
+	0x67, 0x65, 0x73, 0x2a, 0x6c, 0x0a, 0x0b, 0x4d, 0x65, 0x72, 0x67, 0x65, 0x50, 0x6f, 0x6c, 0x69,
+	0x63, 0x79, 0x12, 0x1c, 0x0a, 0x18, 0x4d, 0x45, 0x52, 0x47, 0x45, 0x5f, 0x50, 0x4f, 0x4c, 0x49,
+	0x43, 0x59, 0x5f, 0x4b, 0x45, 0x45, 0x50, 0x5f, 0x4d, 0x41, 0x4e, 0x55, 0x41, 0x4c, 0x10, 0x00,
+	0x12, 0x1f, 0x0a, 0x1b, 0x4d, 0x45, 0x52, 0x47, 0x45, 0x5f, 0x50, 0x4f, 0x4c, 0x49, 0x43, 0x59,
+	0x5f, 0x4f, 0x56, 0x45, 0x52, 0x57, 0x52, 0x49, 0x54, 0x45, 0x5f, 0x41, 0x55, 0x54, 0x4f, 0x10,
+	0x01, 0x12, 0x1e, 0x0a, 0x1a, 0x4d, 0x45, 0x52, 0x47, 0x45, 0x5f, 0x50, 0x4f, 0x4c, 0x49, 0x43,
+	0x59, 0x5f, 0x4f, 0x56, 0x45, 0x52, 0x57, 0x52, 0x49, 0x54, 0x45, 0x5f, 0x41, 0x4c, 0x4c, 0x10,
+	0x02, 0x2a, 0x52, 0x0a, 0x06, 0x41, 0x75, 0x74, 0x68, 0x6f, 0x72, 0x12, 0x0f, 0x0a, 0x0b, 0x41,
+	0x55, 0x54, 0x48, 0x4f, 0x52, 0x5f, 0x4e, 0x4f, 0x4e, 0x45, 0x10, 0x00, 0x12, 0x10, 0x0a, 0x0c,
+	0x41, 0x55, 0x54, 0x48, 0x4f, 0x52, 0x5f, 0x48, 0x55, 0x4d, 0x41, 0x4e, 0x10, 0x01, 0x12, 0x11,
+	0x0a, 0x0d, 0x41, 0x55, 0x54, 0x48, 0x4f, 0x52, 0x5f, 0x41, 0x55, 0x54, 0x4f, 0x43, 0x43, 0x10,
+	0x02, 0x12, 0x12, 0x0a, 0x0e, 0x41, 0x55, 0x54, 0x48, 0x4f, 0x52, 0x5f, 0x59, 0x4f, 0x55, 0x54,
+	0x55, 0x42, 0x45, 0x10, 0x03, 0x42, 0x60, 0x0a, 0x06, 0x63, 0x6f, 0x6d, 0x2e, 0x70, 0x62, 0x42,
+	0x0c, 0x59, 0x6f, 0x75, 0x74, 0x75, 0x62, 0x65, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x50, 0x01, 0x5a,
+	0x20, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x70, 0x6b, 0x75, 0x6c,
+	0x69, 0x6b, 0x30, 0x2f, 0x61, 0x75, 0x74, 0x6f, 0x63, 0x63, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x70,
+	0x62, 0xa2, 0x02, 0x03, 0x50, 0x58, 0x58, 0xaa, 0x02, 0x02, 0x50, 0x62, 0xca, 0x02, 0x02, 0x50,
+	0x62, 0xe2, 0x02, 0x0e, 0x50, 0x62, 0x5c, 0x47, 0x50, 0x42, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61,
+	0x74, 0x61, 0xea, 0x02, 0x02, 0x50, 0x62, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -196,20 +1396,48 @@ func file_pb_youtube_proto_rawDescGZIP() []byte {
 	return file_pb_youtube_proto_rawDescData
 }
 
-var file_pb_youtube_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_pb_youtube_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
+var file_pb_youtube_proto_msgTypes = make([]protoimpl.MessageInfo, 16)
 var file_pb_youtube_proto_goTypes = []any{
-	(*Video)(nil),                    // 0: pb.Video
-	(*GetYoutubeVideosResponse)(nil), // 1: pb.GetYoutubeVideosResponse
-	(*timestamppb.Timestamp)(nil),    // 2: google.protobuf.Timestamp
+	(MergePolicy)(0),                         // 0: pb.MergePolicy
+	(Author)(0),                              // 1: pb.Author
+	(*Video)(nil),                            // 2: pb.Video
+	(*GetYoutubeVideosResponse)(nil),         // 3: pb.GetYoutubeVideosResponse
+	(*Channel)(nil),                          // 4: pb.Channel
+	(*GetYoutubeChannelsResponse)(nil),       // 5: pb.GetYoutubeChannelsResponse
+	(*ProcessVideoRequest)(nil),              // 6: pb.ProcessVideoRequest
+	(*DetectVideoLanguageResponse)(nil),      // 7: pb.DetectVideoLanguageResponse
+	(*ClosedCaptions)(nil),                   // 8: pb.ClosedCaptions
+	(*GetYoutubeClosedCaptionsResponse)(nil), // 9: pb.GetYoutubeClosedCaptionsResponse
+	(*SyncPoint)(nil),                        // 10: pb.SyncPoint
+	(*AdjustSourceTimingRequest)(nil),        // 11: pb.AdjustSourceTimingRequest
+	(*TranslationState)(nil),                 // 12: pb.TranslationState
+	(*LanguageCoverage)(nil),                 // 13: pb.LanguageCoverage
+	(*VideoCoverage)(nil),                    // 14: pb.VideoCoverage
+	(*GetVideoCoverageResponse)(nil),         // 15: pb.GetVideoCoverageResponse
+	(*LanguageSummary)(nil),                  // 16: pb.LanguageSummary
+	(*GetChannelCoverageResponse)(nil),       // 17: pb.GetChannelCoverageResponse
+	(*timestamppb.Timestamp)(nil),            // 18: google.protobuf.Timestamp
 }
 var file_pb_youtube_proto_depIdxs = []int32{
-	2, // 0: pb.Video.published_at:type_name -> google.protobuf.Timestamp
-	0, // 1: pb.GetYoutubeVideosResponse.videos:type_name -> pb.Video
-	2, // [2:2] is the sub-list for method output_type
-	2, // [2:2] is the sub-list for method input_type
-	2, // [2:2] is the sub-list for extension type_name
-	2, // [2:2] is the sub-list for extension extendee
-	0, // [0:2] is the sub-list for field type_name
+	18, // 0: pb.Video.published_at:type_name -> google.protobuf.Timestamp
+	2,  // 1: pb.GetYoutubeVideosResponse.videos:type_name -> pb.Video
+	4,  // 2: pb.GetYoutubeChannelsResponse.channels:type_name -> pb.Channel
+	0,  // 3: pb.ProcessVideoRequest.policy:type_name -> pb.MergePolicy
+	8,  // 4: pb.GetYoutubeClosedCaptionsResponse.closed_captions:type_name -> pb.ClosedCaptions
+	10, // 5: pb.AdjustSourceTimingRequest.sync_points:type_name -> pb.SyncPoint
+	1,  // 6: pb.TranslationState.author:type_name -> pb.Author
+	12, // 7: pb.LanguageCoverage.captions:type_name -> pb.TranslationState
+	12, // 8: pb.LanguageCoverage.metadata:type_name -> pb.TranslationState
+	13, // 9: pb.VideoCoverage.languages:type_name -> pb.LanguageCoverage
+	14, // 10: pb.GetVideoCoverageResponse.coverage:type_name -> pb.VideoCoverage
+	14, // 11: pb.GetChannelCoverageResponse.videos:type_name -> pb.VideoCoverage
+	16, // 12: pb.GetChannelCoverageResponse.languages:type_name -> pb.LanguageSummary
+	13, // [13:13] is the sub-list for method output_type
+	13, // [13:13] is the sub-list for method input_type
+	13, // [13:13] is the sub-list for extension type_name
+	13, // [13:13] is the sub-list for extension extendee
+	0,  // [0:13] is the sub-list for field type_name
 }
 
 func init() { file_pb_youtube_proto_init() }
@@ -222,13 +1450,14 @@ func file_pb_youtube_proto_init() {
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_pb_youtube_proto_rawDesc,
-			NumEnums:      0,
-			NumMessages:   2,
+			NumEnums:      2,
+			NumMessages:   16,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
 		GoTypes:           file_pb_youtube_proto_goTypes,
 		DependencyIndexes: file_pb_youtube_proto_depIdxs,
+		EnumInfos:         file_pb_youtube_proto_enumTypes,
 		MessageInfos:      file_pb_youtube_proto_msgTypes,
 	}.Build()
 	File_pb_youtube_proto = out.File
@@ -1,15 +1,43 @@
 package quota
 
+import (
+	"time"
+	// The time zone of Google's quota days must be known even if the system has no time zone database.
+	_ "time/tzdata"
+)
+
 const (
 	Google = 10_000
 	DeepL  = 500_000
 )
 
 const (
-	YoutubeSearchList       = 100
-	YoutubeVideosList       = 1
-	YoutubeVideosUpdate     = 50
-	YoutubeCaptionsList     = 50
-	YoutubeCaptionsDownload = 200
-	YoutubeCaptionsUpload   = 400
+	YoutubeSearchList        = 100
+	YoutubeVideosList        = 1
+	YoutubeVideosUpdate      = 50
+	YoutubeCaptionsList      = 50
+	YoutubeCaptionsDownload  = 200
+	YoutubeCaptionsUpload    = 400
+	YoutubePlaylistsList     = 1
+	YoutubePlaylistsUpdate   = 50
+	YoutubePlaylistItemsList = 1
+	YoutubeChannelsList      = 1
+	YoutubeChannelsUpdate    = 50
 )
+
+// googleLocation is the time zone in which the quota of Google APIs is reset at midnight.
+var googleLocation = mustLoadLocation("America/Los_Angeles")
+
+func mustLoadLocation(name string) *time.Location {
+	location, err := time.LoadLocation(name)
+	if err != nil {
+		panic(err)
+	}
+	return location
+}
+
+// GoogleDayStart returns when the day of the quota of Google APIs containing t started, it's in UTC.
+func GoogleDayStart(t time.Time) time.Time {
+	t = t.In(googleLocation)
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, googleLocation).UTC()
+}
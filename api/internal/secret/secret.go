@@ -0,0 +1,185 @@
+package secret
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+const (
+	// KeySize is the size of master keys in bytes.
+	KeySize = 32
+	// Prefix marks encrypted values, it's followed by the ID of the master key.
+	Prefix = "enc:v1:"
+)
+
+var (
+	// ErrInvalidKey is returned when a master key has an invalid size or encoding.
+	ErrInvalidKey = errors.New("secret: invalid master key")
+	// ErrUnknownKey is returned when a value was encrypted with a master key missing from the keyring.
+	ErrUnknownKey = errors.New("secret: unknown master key")
+	// ErrMalformed is returned when an encrypted value can't be parsed.
+	ErrMalformed = errors.New("secret: malformed encrypted value")
+)
+
+// Keyring encrypts values with the current master key and decrypts values encrypted with any of its keys.
+//
+// Values are encrypted with envelope encryption: each value gets a random data key used with AES-GCM,
+// and the data key is stored next to it, encrypted with the master key.
+// Rotating the master key doesn't require re-encrypting values right away, the previous key stays in the keyring
+// until all values are rewritten with the current one, which decrypts and encrypts each whole value again.
+type Keyring struct {
+	currentID string
+	keys      map[string]cipher.AEAD
+}
+
+func keyID(key []byte) string {
+	hash := sha256.Sum256(key)
+	return hex.EncodeToString(hash[:4])
+}
+
+func newAEAD(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// NewKeyring creates a keyring encrypting with the current key.
+// Previous keys are only used to decrypt values which weren't encrypted with the current key yet.
+func NewKeyring(current []byte, previous ...[]byte) (*Keyring, error) {
+	k := &Keyring{
+		currentID: keyID(current),
+		keys:      make(map[string]cipher.AEAD),
+	}
+	for _, key := range append([][]byte{current}, previous...) {
+		if len(key) != KeySize {
+			return nil, ErrInvalidKey
+		}
+		aead, err := newAEAD(key)
+		if err != nil {
+			return nil, err
+		}
+		k.keys[keyID(key)] = aead
+	}
+	return k, nil
+}
+
+// ParseKeys parses base64 encoded master keys separated by commas or newlines, the first one is the current key.
+func ParseKeys(s string) ([][]byte, error) {
+	var keys [][]byte
+	for _, field := range strings.FieldsFunc(s, func(r rune) bool { return r == ',' || r == '\n' || r == '\r' }) {
+		field = strings.TrimSpace(field)
+		if field == "" {
+			continue
+		}
+		key, err := base64.StdEncoding.DecodeString(field)
+		if err != nil || len(key) != KeySize {
+			return nil, ErrInvalidKey
+		}
+		keys = append(keys, key)
+	}
+	if len(keys) == 0 {
+		return nil, ErrInvalidKey
+	}
+	return keys, nil
+}
+
+// CurrentPrefix returns the prefix of values encrypted with the current key.
+func (k *Keyring) CurrentPrefix() string {
+	return Prefix + k.currentID + ":"
+}
+
+// IsEncrypted returns true if the value was encrypted by a keyring.
+func IsEncrypted(value string) bool {
+	return strings.HasPrefix(value, Prefix)
+}
+
+func seal(aead cipher.AEAD, plaintext []byte) ([]byte, error) {
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+	return aead.Seal(nonce, nonce, plaintext, nil), nil
+}
+
+func open(aead cipher.AEAD, ciphertext []byte) ([]byte, error) {
+	if len(ciphertext) < aead.NonceSize() {
+		return nil, ErrMalformed
+	}
+	return aead.Open(nil, ciphertext[:aead.NonceSize()], ciphertext[aead.NonceSize():], nil)
+}
+
+// Encrypt encrypts the value with the current master key. Empty values are kept empty.
+func (k *Keyring) Encrypt(value string) (string, error) {
+	if value == "" {
+		return "", nil
+	}
+
+	dataKey := make([]byte, KeySize)
+	if _, err := rand.Read(dataKey); err != nil {
+		return "", err
+	}
+	dataAEAD, err := newAEAD(dataKey)
+	if err != nil {
+		return "", err
+	}
+
+	encryptedKey, err := seal(k.keys[k.currentID], dataKey)
+	if err != nil {
+		return "", err
+	}
+	encryptedValue, err := seal(dataAEAD, []byte(value))
+	if err != nil {
+		return "", err
+	}
+
+	return k.CurrentPrefix() + base64.RawStdEncoding.EncodeToString(encryptedKey) + ":" + base64.RawStdEncoding.EncodeToString(encryptedValue), nil
+}
+
+// Decrypt decrypts the value with the master key it was encrypted with.
+// Values which aren't encrypted are returned as they are, so plaintext written before encryption was enabled stays readable.
+func (k *Keyring) Decrypt(value string) (string, error) {
+	if !IsEncrypted(value) {
+		return value, nil
+	}
+
+	parts := strings.Split(strings.TrimPrefix(value, Prefix), ":")
+	if len(parts) != 3 {
+		return "", ErrMalformed
+	}
+	masterAEAD, ok := k.keys[parts[0]]
+	if !ok {
+		return "", ErrUnknownKey
+	}
+	encryptedKey, err := base64.RawStdEncoding.DecodeString(parts[1])
+	if err != nil {
+		return "", ErrMalformed
+	}
+	encryptedValue, err := base64.RawStdEncoding.DecodeString(parts[2])
+	if err != nil {
+		return "", ErrMalformed
+	}
+
+	dataKey, err := open(masterAEAD, encryptedKey)
+	if err != nil {
+		return "", fmt.Errorf("failed to decrypt data key: %w", err)
+	}
+	dataAEAD, err := newAEAD(dataKey)
+	if err != nil {
+		return "", err
+	}
+	plaintext, err := open(dataAEAD, encryptedValue)
+	if err != nil {
+		return "", fmt.Errorf("failed to decrypt value: %w", err)
+	}
+
+	return string(plaintext), nil
+}
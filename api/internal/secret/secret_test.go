@@ -0,0 +1,101 @@
+package secret_test
+
+import (
+	"bytes"
+	"encoding/base64"
+	"strings"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+
+	"github.com/pkulik0/autocc/api/internal/secret"
+)
+
+func TestKeyring(t *testing.T) {
+	c := qt.New(t)
+
+	oldKey, newKey := bytes.Repeat([]byte{1}, secret.KeySize), bytes.Repeat([]byte{2}, secret.KeySize)
+	oldKeyring, err := secret.NewKeyring(oldKey)
+	c.Assert(err, qt.IsNil)
+	newKeyring, err := secret.NewKeyring(newKey, oldKey)
+	c.Assert(err, qt.IsNil)
+
+	encrypted, err := oldKeyring.Encrypt("secret")
+	c.Assert(err, qt.IsNil)
+	c.Assert(secret.IsEncrypted(encrypted), qt.IsTrue)
+	c.Assert(strings.HasPrefix(encrypted, oldKeyring.CurrentPrefix()), qt.IsTrue)
+	c.Assert(strings.Contains(encrypted, "secret"), qt.IsFalse)
+
+	again, err := oldKeyring.Encrypt("secret")
+	c.Assert(err, qt.IsNil)
+	c.Assert(again, qt.Not(qt.Equals), encrypted)
+
+	decrypted, err := oldKeyring.Decrypt(encrypted)
+	c.Assert(err, qt.IsNil)
+	c.Assert(decrypted, qt.Equals, "secret")
+
+	// Values encrypted with previous keys can be decrypted.
+	decrypted, err = newKeyring.Decrypt(encrypted)
+	c.Assert(err, qt.IsNil)
+	c.Assert(decrypted, qt.Equals, "secret")
+
+	reencrypted, err := newKeyring.Encrypt(decrypted)
+	c.Assert(err, qt.IsNil)
+	c.Assert(strings.HasPrefix(reencrypted, newKeyring.CurrentPrefix()), qt.IsTrue)
+	_, err = oldKeyring.Decrypt(reencrypted)
+	c.Assert(err, qt.Equals, secret.ErrUnknownKey)
+
+	// Plaintext is passed through, empty values stay empty.
+	decrypted, err = oldKeyring.Decrypt("plaintext")
+	c.Assert(err, qt.IsNil)
+	c.Assert(decrypted, qt.Equals, "plaintext")
+	empty, err := oldKeyring.Encrypt("")
+	c.Assert(err, qt.IsNil)
+	c.Assert(empty, qt.Equals, "")
+
+	// Tampered values are rejected.
+	tampered := encrypted[:len(encrypted)-2] + "AA"
+	if tampered == encrypted {
+		tampered = encrypted[:len(encrypted)-2] + "BB"
+	}
+	_, err = oldKeyring.Decrypt(tampered)
+	c.Assert(err, qt.IsNotNil)
+	_, err = oldKeyring.Decrypt(oldKeyring.CurrentPrefix() + "invalid")
+	c.Assert(err, qt.Equals, secret.ErrMalformed)
+
+	_, err = secret.NewKeyring([]byte("short"))
+	c.Assert(err, qt.Equals, secret.ErrInvalidKey)
+}
+
+func TestParseKeys(t *testing.T) {
+	c := qt.New(t)
+
+	key1, key2 := bytes.Repeat([]byte{1}, secret.KeySize), bytes.Repeat([]byte{2}, secret.KeySize)
+	encoded1, encoded2 := base64.StdEncoding.EncodeToString(key1), base64.StdEncoding.EncodeToString(key2)
+
+	tt := []struct {
+		name string
+		keys string
+		want [][]byte
+		err  bool
+	}{
+		{name: "single", keys: encoded1, want: [][]byte{key1}},
+		{name: "comma separated", keys: encoded1 + ", " + encoded2, want: [][]byte{key1, key2}},
+		{name: "lines", keys: encoded2 + "\n" + encoded1 + "\n", want: [][]byte{key2, key1}},
+		{name: "empty", keys: " \n", err: true},
+		{name: "invalid base64", keys: "not-base64!", err: true},
+		{name: "invalid size", keys: base64.StdEncoding.EncodeToString([]byte("short")), err: true},
+	}
+
+	for _, tc := range tt {
+		c.Run(tc.name, func(c *qt.C) {
+			keys, err := secret.ParseKeys(tc.keys)
+			if tc.err {
+				c.Assert(err, qt.Equals, secret.ErrInvalidKey)
+				return
+			}
+			c.Assert(err, qt.IsNil)
+			c.Assert(keys, qt.DeepEquals, tc.want)
+		})
+	}
+}
@@ -1,10 +1,14 @@
 package server
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 	"net/http"
+	"slices"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/rs/cors"
@@ -13,11 +17,13 @@ import (
 	"github.com/pkulik0/autocc/api/internal/auth"
 	"github.com/pkulik0/autocc/api/internal/autocc"
 	"github.com/pkulik0/autocc/api/internal/cache"
+	"github.com/pkulik0/autocc/api/internal/coverage"
 	"github.com/pkulik0/autocc/api/internal/credentials"
 	"github.com/pkulik0/autocc/api/internal/errs"
 	"github.com/pkulik0/autocc/api/internal/helpers"
 	"github.com/pkulik0/autocc/api/internal/middleware"
 	"github.com/pkulik0/autocc/api/internal/pb"
+	"github.com/pkulik0/autocc/api/internal/srt"
 	"github.com/pkulik0/autocc/api/internal/version"
 	"github.com/pkulik0/autocc/api/internal/youtube"
 )
@@ -28,15 +34,17 @@ type server struct {
 	auth        auth.Auth
 	youtube     youtube.Youtube
 	autocc      autocc.AutoCC
+	coverage    coverage.Coverage
 }
 
-func New(cache cache.Cache, credentials credentials.Credentials, auth auth.Auth, youtube youtube.Youtube, autocc autocc.AutoCC) *server {
+func New(cache cache.Cache, credentials credentials.Credentials, auth auth.Auth, youtube youtube.Youtube, autocc autocc.AutoCC, coverage coverage.Coverage) *server {
 	return &server{
 		cache:       cache,
 		credentials: credentials,
 		auth:        auth,
 		youtube:     youtube,
 		autocc:      autocc,
+		coverage:    coverage,
 	}
 }
 
@@ -234,13 +242,13 @@ func (s *server) handlerUserSessionsGoogle(w http.ResponseWriter, r *http.Reques
 
 	var resp pb.GetUserSessionsGoogleResponse
 	for _, s := range sessions {
-		resp.CredentialIds = append(resp.CredentialIds, uint64(s.CredentialsID))
+		resp.Sessions = append(resp.Sessions, s.ToProto())
 	}
 	helpers.WritePb(w, &resp)
 }
 
 func (s *server) handlerRemoveSessionGoogle(w http.ResponseWriter, r *http.Request) {
-	credentialsID, err := parsePathID(r)
+	sessionID, err := parsePathID(r)
 	if err != nil {
 		helpers.ErrLog(w, err, "failed to parse id", http.StatusBadRequest)
 		return
@@ -252,12 +260,15 @@ func (s *server) handlerRemoveSessionGoogle(w http.ResponseWriter, r *http.Reque
 		return
 	}
 
-	err = s.credentials.RemoveSessionGoogle(r.Context(), userID, uint(credentialsID))
+	err = s.credentials.RemoveSessionGoogle(r.Context(), userID, uint(sessionID))
 	switch err {
 	case nil:
 	case errs.InvalidInput:
 		helpers.ErrLog(w, err, "invalid input", http.StatusBadRequest)
 		return
+	case errs.NotFound:
+		helpers.ErrLog(w, err, "google session not found", http.StatusNotFound)
+		return
 	default:
 		helpers.ErrLog(w, err, "failed to remove google session", http.StatusInternalServerError)
 		return
@@ -266,6 +277,31 @@ func (s *server) handlerRemoveSessionGoogle(w http.ResponseWriter, r *http.Reque
 	w.WriteHeader(http.StatusNoContent)
 }
 
+func (s *server) handlerYoutubeChannels(w http.ResponseWriter, r *http.Request) {
+	userID, _, ok := auth.UserFromContext(r.Context())
+	if !ok {
+		helpers.ErrLog(w, nil, "failed to get user from context", http.StatusInternalServerError)
+		return
+	}
+
+	channels, err := s.youtube.GetChannels(r.Context(), userID)
+	switch err {
+	case nil:
+	case errs.InvalidInput:
+		helpers.ErrLog(w, err, "invalid input", http.StatusBadRequest)
+		return
+	default:
+		helpers.ErrLog(w, err, "failed to get channels", http.StatusInternalServerError)
+		return
+	}
+
+	var resp pb.GetYoutubeChannelsResponse
+	for _, c := range channels {
+		resp.Channels = append(resp.Channels, c.ToProto())
+	}
+	helpers.WritePb(w, &resp)
+}
+
 func (s *server) handlerYoutubeVideos(w http.ResponseWriter, r *http.Request) {
 	userID, _, ok := auth.UserFromContext(r.Context())
 	if !ok {
@@ -273,8 +309,18 @@ func (s *server) handlerYoutubeVideos(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	nextPageToken := r.URL.Query().Get("next_page_token")
-	videos, nextPageToken, err := s.youtube.GetVideos(r.Context(), userID, nextPageToken)
+	query := r.URL.Query()
+	options := autocc.ListOptions{
+		Unprocessed:      query.Get("unprocessed") == "true",
+		MissingLanguages: query.Get("missing_languages") == "true",
+		Sort:             autocc.VideoSort(query.Get("sort")),
+	}
+	if options.Sort != "" && !options.Sort.IsValid() {
+		helpers.ErrLog(w, nil, "invalid sort", http.StatusBadRequest)
+		return
+	}
+
+	videos, nextPageToken, err := s.autocc.ListVideos(r.Context(), userID, r.PathValue("channel"), query.Get("next_page_token"), options)
 	switch err {
 	case nil:
 	case errs.NotFound:
@@ -293,6 +339,12 @@ func (s *server) handlerYoutubeVideos(w http.ResponseWriter, r *http.Request) {
 	helpers.WritePb(w, &resp)
 }
 
+var mergePolicies = map[pb.MergePolicy]youtube.MergePolicy{
+	pb.MergePolicy_MERGE_POLICY_KEEP_MANUAL:    youtube.MergeKeepManual,
+	pb.MergePolicy_MERGE_POLICY_OVERWRITE_AUTO: youtube.MergeOverwriteAuto,
+	pb.MergePolicy_MERGE_POLICY_OVERWRITE_ALL:  youtube.MergeOverwriteAll,
+}
+
 func (s *server) handlerProcess(w http.ResponseWriter, r *http.Request) {
 	userID, _, ok := auth.UserFromContext(r.Context())
 	if !ok {
@@ -300,14 +352,35 @@ func (s *server) handlerProcess(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	videoID := r.PathValue("id")
+	var req pb.ProcessVideoRequest
+	err := helpers.ReadPb(r, &req)
+	if err != nil {
+		helpers.ErrLog(w, err, "failed to decode request", http.StatusBadRequest)
+		return
+	}
 
-	err := s.autocc.Process(r.Context(), userID, videoID)
+	policy, ok := mergePolicies[req.Policy]
+	if !ok {
+		helpers.ErrLog(w, fmt.Errorf("invalid merge policy: %d", req.Policy), "invalid merge policy", http.StatusBadRequest)
+		return
+	}
+
+	videoID := r.PathValue("id")
+	err = s.autocc.Process(r.Context(), userID, r.PathValue("channel"), videoID, autocc.Options{
+		Tags:      req.Tags,
+		Playlists: req.Playlists,
+		Channel:   req.Channel,
+		Policy:    policy,
+		Language:  req.Language,
+	})
 	switch err {
 	case nil:
 	case errs.InvalidInput:
 		helpers.ErrLog(w, err, "invalid input", http.StatusBadRequest)
 		return
+	case errs.VideoLanguageNotSet:
+		helpers.ErrLog(w, err, "video language not set", http.StatusConflict)
+		return
 	case errs.SourceClosedCaptionsNotFound:
 		helpers.ErrLog(w, err, "source closed captions not found", http.StatusNotFound)
 		return
@@ -323,21 +396,456 @@ func (s *server) handlerProcess(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusNoContent)
 }
 
+func (s *server) handlerDetectLanguage(w http.ResponseWriter, r *http.Request) {
+	userID, _, ok := auth.UserFromContext(r.Context())
+	if !ok {
+		helpers.ErrLog(w, nil, "failed to get user from context", http.StatusInternalServerError)
+		return
+	}
+
+	language, err := s.autocc.DetectLanguage(r.Context(), userID, r.PathValue("channel"), r.PathValue("id"))
+	switch err {
+	case nil:
+	case errs.InvalidInput:
+		helpers.ErrLog(w, err, "invalid input", http.StatusBadRequest)
+		return
+	case errs.NotFound:
+		helpers.ErrLog(w, err, "not found", http.StatusNotFound)
+		return
+	default:
+		helpers.ErrLog(w, err, "failed to detect language", http.StatusInternalServerError)
+		return
+	}
+
+	helpers.WritePb(w, &pb.DetectVideoLanguageResponse{Language: language})
+}
+
+func (s *server) handlerVideoCoverage(w http.ResponseWriter, r *http.Request) {
+	userID, _, ok := auth.UserFromContext(r.Context())
+	if !ok {
+		helpers.ErrLog(w, nil, "failed to get user from context", http.StatusInternalServerError)
+		return
+	}
+
+	coverage, err := s.coverage.GetVideo(r.Context(), userID, r.PathValue("channel"), r.PathValue("id"))
+	switch err {
+	case nil:
+	case errs.InvalidInput:
+		helpers.ErrLog(w, err, "invalid input", http.StatusBadRequest)
+		return
+	case errs.NotFound:
+		helpers.ErrLog(w, err, "not found", http.StatusNotFound)
+		return
+	default:
+		helpers.ErrLog(w, err, "failed to get coverage", http.StatusInternalServerError)
+		return
+	}
+
+	helpers.WritePb(w, &pb.GetVideoCoverageResponse{Coverage: coverage})
+}
+
+func (s *server) handlerChannelCoverage(w http.ResponseWriter, r *http.Request) {
+	userID, _, ok := auth.UserFromContext(r.Context())
+	if !ok {
+		helpers.ErrLog(w, nil, "failed to get user from context", http.StatusInternalServerError)
+		return
+	}
+
+	nextPageToken := r.URL.Query().Get("next_page_token")
+	videos, languages, nextPageToken, err := s.coverage.GetChannel(r.Context(), userID, r.PathValue("channel"), nextPageToken)
+	switch err {
+	case nil:
+	case errs.NotFound:
+	case errs.InvalidInput:
+		helpers.ErrLog(w, err, "invalid input", http.StatusBadRequest)
+		return
+	default:
+		helpers.ErrLog(w, err, "failed to get coverage", http.StatusInternalServerError)
+		return
+	}
+
+	var resp pb.GetChannelCoverageResponse
+	resp.Videos = videos
+	resp.Languages = languages
+	resp.NextPageToken = nextPageToken
+
+	helpers.WritePb(w, &resp)
+}
+
+const (
+	maxSourceCCSize = 10 << 20
+)
+
+// checkVideoOfChannel returns errs.NotFound if the video doesn't belong to the channel of the user.
+// Closed captions can be listed only for videos of the channel, like in handlerDownloadCC.
+func (s *server) checkVideoOfChannel(ctx context.Context, userID, channelID, videoID string) error {
+	_, err := s.youtube.GetCC(ctx, userID, channelID, videoID)
+	return err
+}
+
+func (s *server) handlerSetSourceCC(w http.ResponseWriter, r *http.Request) {
+	userID, _, ok := auth.UserFromContext(r.Context())
+	if !ok {
+		helpers.ErrLog(w, nil, "failed to get user from context", http.StatusInternalServerError)
+		return
+	}
+
+	videoID := r.PathValue("id")
+	query := r.URL.Query()
+
+	format := srt.Format(query.Get("format"))
+	if format == "" {
+		format = srt.FormatSrt
+	}
+	uploadOriginal := query.Get("upload_original") == "true"
+
+	data, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxSourceCCSize))
+	if err != nil {
+		helpers.ErrLog(w, err, "failed to read request", http.StatusBadRequest)
+		return
+	}
+
+	err = s.checkVideoOfChannel(r.Context(), userID, r.PathValue("channel"), videoID)
+	if err == nil {
+		err = s.autocc.SetSourceCC(r.Context(), userID, videoID, query.Get("language"), format, string(data), uploadOriginal)
+	}
+	switch err {
+	case nil:
+	case errs.InvalidInput:
+		helpers.ErrLog(w, err, "invalid input", http.StatusBadRequest)
+		return
+	case errs.NotFound:
+		helpers.ErrLog(w, err, "not found", http.StatusNotFound)
+		return
+	default:
+		helpers.ErrLog(w, err, "failed to set source cc", http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *server) handlerRemoveSourceCC(w http.ResponseWriter, r *http.Request) {
+	userID, _, ok := auth.UserFromContext(r.Context())
+	if !ok {
+		helpers.ErrLog(w, nil, "failed to get user from context", http.StatusInternalServerError)
+		return
+	}
+
+	videoID := r.PathValue("id")
+	err := s.checkVideoOfChannel(r.Context(), userID, r.PathValue("channel"), videoID)
+	if err == nil {
+		err = s.autocc.RemoveSourceCC(r.Context(), userID, videoID)
+	}
+	switch err {
+	case nil:
+	case errs.InvalidInput:
+		helpers.ErrLog(w, err, "invalid input", http.StatusBadRequest)
+		return
+	case errs.NotFound:
+		helpers.ErrLog(w, err, "not found", http.StatusNotFound)
+		return
+	default:
+		helpers.ErrLog(w, err, "failed to remove source cc", http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *server) handlerAdjustSourceTiming(w http.ResponseWriter, r *http.Request) {
+	userID, _, ok := auth.UserFromContext(r.Context())
+	if !ok {
+		helpers.ErrLog(w, nil, "failed to get user from context", http.StatusInternalServerError)
+		return
+	}
+
+	var req pb.AdjustSourceTimingRequest
+	err := helpers.ReadPb(r, &req)
+	if err != nil {
+		helpers.ErrLog(w, err, "failed to decode request", http.StatusBadRequest)
+		return
+	}
+
+	timing := srt.Timing{
+		FramerateFrom: req.FramerateFrom,
+		FramerateTo:   req.FramerateTo,
+		Shift:         time.Duration(req.ShiftMs) * time.Millisecond,
+		MinDuration:   time.Duration(req.MinDurationMs) * time.Millisecond,
+		MaxDuration:   time.Duration(req.MaxDurationMs) * time.Millisecond,
+	}
+	for _, p := range req.SyncPoints {
+		timing.SyncPoints = append(timing.SyncPoints, srt.SyncPoint{
+			From: time.Duration(p.FromMs) * time.Millisecond,
+			To:   time.Duration(p.ToMs) * time.Millisecond,
+		})
+	}
+
+	videoID := r.PathValue("id")
+	err = s.checkVideoOfChannel(r.Context(), userID, r.PathValue("channel"), videoID)
+	if err == nil {
+		err = s.autocc.AdjustSourceTiming(r.Context(), userID, videoID, timing)
+	}
+	switch err {
+	case nil:
+	case errs.InvalidInput:
+		helpers.ErrLog(w, err, "invalid input", http.StatusBadRequest)
+		return
+	case errs.NotFound:
+		helpers.ErrLog(w, err, "not found", http.StatusNotFound)
+		return
+	default:
+		helpers.ErrLog(w, err, "failed to adjust source cc timing", http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// parseFormat returns the subtitles format from the query, defaulting to SRT.
+func parseFormat(r *http.Request) (srt.Format, error) {
+	format := srt.Format(r.URL.Query().Get("format"))
+	if format == "" {
+		return srt.FormatSrt, nil
+	}
+	if !format.IsValid() {
+		return "", fmt.Errorf("invalid format: %s", format)
+	}
+	return format, nil
+}
+
+func (s *server) handlerYoutubeCC(w http.ResponseWriter, r *http.Request) {
+	userID, _, ok := auth.UserFromContext(r.Context())
+	if !ok {
+		helpers.ErrLog(w, nil, "failed to get user from context", http.StatusInternalServerError)
+		return
+	}
+
+	allCC, err := s.youtube.GetCC(r.Context(), userID, r.PathValue("channel"), r.PathValue("id"))
+	switch err {
+	case nil:
+	case errs.InvalidInput:
+		helpers.ErrLog(w, err, "invalid input", http.StatusBadRequest)
+		return
+	case errs.NotFound:
+		helpers.ErrLog(w, err, "not found", http.StatusNotFound)
+		return
+	default:
+		helpers.ErrLog(w, err, "failed to get cc", http.StatusInternalServerError)
+		return
+	}
+
+	var resp pb.GetYoutubeClosedCaptionsResponse
+	for _, cc := range allCC {
+		resp.ClosedCaptions = append(resp.ClosedCaptions, cc.ToProto())
+	}
+	helpers.WritePb(w, &resp)
+}
+
+func (s *server) handlerDownloadCC(w http.ResponseWriter, r *http.Request) {
+	userID, _, ok := auth.UserFromContext(r.Context())
+	if !ok {
+		helpers.ErrLog(w, nil, "failed to get user from context", http.StatusInternalServerError)
+		return
+	}
+
+	format, err := parseFormat(r)
+	if err != nil {
+		helpers.ErrLog(w, err, "failed to parse format", http.StatusBadRequest)
+		return
+	}
+
+	channelID := r.PathValue("channel")
+	videoID := r.PathValue("id")
+	trackID := r.PathValue("trackId")
+
+	// The track is served under the name of the video, so it has to belong to it.
+	allCC, err := s.youtube.GetCC(r.Context(), userID, channelID, videoID)
+	if err == nil && !slices.ContainsFunc(allCC, func(cc *youtube.CC) bool { return cc.Id == trackID }) {
+		err = errs.NotFound
+	}
+	var cc *srt.Srt
+	if err == nil {
+		cc, err = s.youtube.DownloadCC(r.Context(), userID, channelID, trackID)
+	}
+	switch err {
+	case nil:
+	case errs.InvalidInput:
+		helpers.ErrLog(w, err, "invalid input", http.StatusBadRequest)
+		return
+	case errs.NotFound:
+		helpers.ErrLog(w, err, "not found", http.StatusNotFound)
+		return
+	default:
+		helpers.ErrLog(w, err, "failed to download cc", http.StatusInternalServerError)
+		return
+	}
+
+	data, err := cc.Encode(format)
+	if err != nil {
+		helpers.ErrLog(w, err, "failed to encode cc", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", format.ContentType())
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="%s.%s.%s"`, videoID, trackID, format))
+	helpers.WriteOrLog(w, []byte(data))
+}
+
+func (s *server) handlerExportCC(w http.ResponseWriter, r *http.Request) {
+	userID, _, ok := auth.UserFromContext(r.Context())
+	if !ok {
+		helpers.ErrLog(w, nil, "failed to get user from context", http.StatusInternalServerError)
+		return
+	}
+
+	format, err := parseFormat(r)
+	if err != nil {
+		helpers.ErrLog(w, err, "failed to parse format", http.StatusBadRequest)
+		return
+	}
+
+	videoID := r.PathValue("id")
+
+	data, err := s.autocc.ExportCC(r.Context(), userID, r.PathValue("channel"), videoID, format)
+	switch err {
+	case nil:
+	case errs.InvalidInput:
+		helpers.ErrLog(w, err, "invalid input", http.StatusBadRequest)
+		return
+	case errs.NotFound:
+		helpers.ErrLog(w, err, "not found", http.StatusNotFound)
+		return
+	default:
+		helpers.ErrLog(w, err, "failed to export cc", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/zip")
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="%s.zip"`, videoID))
+	helpers.WriteOrLog(w, data)
+}
+
+func (s *server) handlerCacheStats(w http.ResponseWriter, r *http.Request) {
+	stats, err := s.cache.Stats(r.Context())
+	if err != nil {
+		helpers.ErrLog(w, err, "failed to get cache stats", http.StatusInternalServerError)
+		return
+	}
+
+	var resp pb.GetCacheStatsResponse
+	for namespace, s := range stats {
+		resp.Stats = append(resp.Stats, &pb.CacheStats{
+			Namespace: string(namespace),
+			Hits:      s.Hits,
+			Misses:    s.Misses,
+		})
+	}
+	slices.SortFunc(resp.Stats, func(a, b *pb.CacheStats) int {
+		return strings.Compare(a.Namespace, b.Namespace)
+	})
+
+	helpers.WritePb(w, &resp)
+}
+
+func (s *server) handlerCacheKeys(w http.ResponseWriter, r *http.Request) {
+	namespace := cache.Namespace(r.PathValue("namespace"))
+	if !namespace.IsValid() {
+		helpers.ErrLog(w, errs.InvalidInput, "invalid namespace", http.StatusBadRequest)
+		return
+	}
+
+	keys, err := s.cache.Keys(r.Context(), namespace)
+	if err != nil {
+		helpers.ErrLog(w, err, "failed to get cache keys", http.StatusInternalServerError)
+		return
+	}
+
+	var resp pb.GetCacheKeysResponse
+	for _, k := range keys {
+		resp.Keys = append(resp.Keys, &pb.CacheKey{
+			Key:        k.Key,
+			TtlSeconds: int64(k.TTL.Seconds()),
+		})
+	}
+
+	helpers.WritePb(w, &resp)
+}
+
+func (s *server) handlerInvalidateCache(name string, tag func(string) string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		value := r.PathValue(name)
+		if value == "" {
+			helpers.ErrLog(w, errs.InvalidInput, "missing "+name, http.StatusBadRequest)
+			return
+		}
+
+		removed, err := s.cache.Invalidate(r.Context(), tag(value))
+		if err != nil {
+			helpers.ErrLog(w, err, "failed to invalidate cache", http.StatusInternalServerError)
+			return
+		}
+
+		log.Info().Str("tag", tag(value)).Int("removed", removed).Msg("invalidated cache")
+		helpers.WritePb(w, &pb.InvalidateCacheResponse{Removed: uint64(removed)})
+	}
+}
+
+// handlerInvalidateLanguage invalidates the cache of the language and removes its translation memory,
+// otherwise the same translations would be reused from the store.
+func (s *server) handlerInvalidateLanguage(w http.ResponseWriter, r *http.Request) {
+	language := r.PathValue("language")
+	if language == "" {
+		helpers.ErrLog(w, errs.InvalidInput, "missing language", http.StatusBadRequest)
+		return
+	}
+
+	removed, err := s.cache.Invalidate(r.Context(), cache.TagLanguage(language))
+	if err != nil {
+		helpers.ErrLog(w, err, "failed to invalidate cache", http.StatusInternalServerError)
+		return
+	}
+	removedSegments, err := s.autocc.RemoveTranslationMemory(r.Context(), language)
+	if err != nil {
+		helpers.ErrLog(w, err, "failed to remove translation memory", http.StatusInternalServerError)
+		return
+	}
+
+	log.Info().Str("language", language).Int("removed", removed).Int("removed_segments", removedSegments).Msg("invalidated language")
+	helpers.WritePb(w, &pb.InvalidateCacheResponse{Removed: uint64(removed), RemovedSegments: uint64(removedSegments)})
+}
+
 func (s *server) getMux() *http.ServeMux {
 	superuserMux := http.NewServeMux()
 	superuserMux.HandleFunc("POST /credentials/google", s.handlerAddCredentialsGoogle)
 	superuserMux.HandleFunc("POST /credentials/deepl", s.handlerAddCredentialsDeepL)
 	superuserMux.HandleFunc("DELETE /credentials/google/{id}", s.handlerRemoveCredentialsGoogle)
 	superuserMux.HandleFunc("DELETE /credentials/deepl/{id}", s.handlerRemoveCredentialsDeepL)
+	superuserMux.HandleFunc("GET /cache/stats", s.handlerCacheStats)
+	superuserMux.HandleFunc("GET /cache/keys/{namespace}", s.handlerCacheKeys)
+	superuserMux.HandleFunc("DELETE /cache/videos/{id}", s.handlerInvalidateCache("id", cache.TagVideo))
+	superuserMux.HandleFunc("DELETE /cache/users/{id}", s.handlerInvalidateCache("id", cache.TagUser))
+	superuserMux.HandleFunc("DELETE /cache/languages/{language}", s.handlerInvalidateLanguage)
 
 	ytMux := http.NewServeMux()
-	ytMux.HandleFunc("GET /videos", s.handlerYoutubeVideos)
-	ytMux.HandleFunc("POST /videos/{id}", s.handlerProcess)
+	ytMux.HandleFunc("GET /channels", s.handlerYoutubeChannels)
+	ytMux.HandleFunc("GET /channels/{channel}/videos", s.handlerYoutubeVideos)
+	ytMux.HandleFunc("POST /channels/{channel}/videos/{id}", s.handlerProcess)
+	ytMux.HandleFunc("GET /channels/{channel}/videos/{id}/language", s.handlerDetectLanguage)
+	ytMux.HandleFunc("GET /channels/{channel}/videos/{id}/coverage", s.handlerVideoCoverage)
+	ytMux.HandleFunc("GET /channels/{channel}/coverage", s.handlerChannelCoverage)
+	ytMux.HandleFunc("PUT /channels/{channel}/videos/{id}/source", s.handlerSetSourceCC)
+	ytMux.HandleFunc("DELETE /channels/{channel}/videos/{id}/source", s.handlerRemoveSourceCC)
+	ytMux.HandleFunc("POST /channels/{channel}/videos/{id}/source/timing", s.handlerAdjustSourceTiming)
+	ytMux.HandleFunc("GET /channels/{channel}/videos/{id}/captions", s.handlerYoutubeCC)
+	ytMux.HandleFunc("GET /channels/{channel}/videos/{id}/captions/{trackId}", s.handlerDownloadCC)
+	ytMux.HandleFunc("GET /channels/{channel}/videos/{id}/export", s.handlerExportCC)
 
 	authMux := http.NewServeMux()
 	authMux.Handle("/", middleware.Superuser(superuserMux))
 	authMux.HandleFunc("GET /credentials", s.handlerCredentials)
 	authMux.HandleFunc("GET /sessions/google", s.handlerUserSessionsGoogle)
+	// Authentication URLs are requested for credentials, sessions are removed by their own ID.
 	authMux.HandleFunc("GET /sessions/google/{id}", s.handlerSessionGoogleURL)
 	authMux.HandleFunc("DELETE /sessions/google/{id}", s.handlerRemoveSessionGoogle)
 	authMux.Handle("/youtube/", http.StripPrefix("/youtube", ytMux))
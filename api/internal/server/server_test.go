@@ -8,17 +8,23 @@ import (
 	"net/http/httptest"
 	"strings"
 	"testing"
+	"time"
 
 	qt "github.com/frankban/quicktest"
 	"go.uber.org/mock/gomock"
 	"google.golang.org/protobuf/proto"
+	"gorm.io/gorm"
 
 	"github.com/pkulik0/autocc/api/internal/auth"
+	"github.com/pkulik0/autocc/api/internal/autocc"
+	"github.com/pkulik0/autocc/api/internal/cache"
 	"github.com/pkulik0/autocc/api/internal/errs"
 	"github.com/pkulik0/autocc/api/internal/middleware"
 	"github.com/pkulik0/autocc/api/internal/mock"
 	"github.com/pkulik0/autocc/api/internal/model"
 	"github.com/pkulik0/autocc/api/internal/pb"
+	"github.com/pkulik0/autocc/api/internal/srt"
+	"github.com/pkulik0/autocc/api/internal/youtube"
 )
 
 func TestHandlerRoot(t *testing.T) {
@@ -27,7 +33,7 @@ func TestHandlerRoot(t *testing.T) {
 	w := httptest.NewRecorder()
 	r := httptest.NewRequest("GET", "/", nil)
 
-	s := New(nil, nil, nil, nil, nil)
+	s := New(nil, nil, nil, nil, nil, nil)
 	s.handlerRoot(w, r)
 
 	c.Assert(w.Code, qt.Equals, http.StatusOK)
@@ -106,7 +112,7 @@ func TestHandlerCredentials(t *testing.T) {
 			service := mock.NewMockCredentials(ctrl)
 			tc.setupMocks(service)
 
-			s := New(nil, service, nil, nil, nil)
+			s := New(nil, service, nil, nil, nil, nil)
 			tc.test(c, s)
 		})
 	}
@@ -197,7 +203,7 @@ func TestAddCredentialsGoogle(t *testing.T) {
 			service := mock.NewMockCredentials(ctrl)
 			tc.setupMocks(service)
 
-			s := New(nil, service, nil, nil, nil)
+			s := New(nil, service, nil, nil, nil, nil)
 			tc.test(c, s)
 		})
 	}
@@ -287,7 +293,7 @@ func TestAddCredentialsDeepL(t *testing.T) {
 			service := mock.NewMockCredentials(ctrl)
 			tc.setupMocks(service)
 
-			s := New(nil, service, nil, nil, nil)
+			s := New(nil, service, nil, nil, nil, nil)
 			tc.test(c, s)
 		})
 	}
@@ -353,7 +359,7 @@ func TestRemoveCredentialsGoogle(t *testing.T) {
 			service := mock.NewMockCredentials(ctrl)
 			tc.setupMocks(service)
 
-			s := New(nil, service, nil, nil, nil)
+			s := New(nil, service, nil, nil, nil, nil)
 			tc.test(c, s)
 		})
 	}
@@ -419,7 +425,7 @@ func TestRemoveCredentialsDeepL(t *testing.T) {
 			service := mock.NewMockCredentials(ctrl)
 			tc.setupMocks(service)
 
-			s := New(nil, service, nil, nil, nil)
+			s := New(nil, service, nil, nil, nil, nil)
 			tc.test(c, s)
 		})
 	}
@@ -522,7 +528,7 @@ func TestHandlerSessionGoogleURL(t *testing.T) {
 			service := mock.NewMockCredentials(ctrl)
 			tc.setupMocks(service)
 
-			s := New(nil, service, nil, nil, nil)
+			s := New(nil, service, nil, nil, nil, nil)
 			tc.test(c, s)
 		})
 	}
@@ -600,7 +606,7 @@ func TestHandlerSessionGoogleCallback(t *testing.T) {
 			service := mock.NewMockCredentials(ctrl)
 			tc.setupMocks(service)
 
-			s := New(nil, service, nil, nil, nil)
+			s := New(nil, service, nil, nil, nil, nil)
 			tc.test(c, s)
 		})
 	}
@@ -617,7 +623,7 @@ func TestHandlerUserSessionsGoogle(t *testing.T) {
 		{
 			name: "success",
 			setupMocks: func(service *mock.MockCredentials) {
-				service.EXPECT().GetSessionsGoogleByUser(gomock.Any(), "userID").Return([]model.SessionGoogle{{CredentialsID: 123}}, nil)
+				service.EXPECT().GetSessionsGoogleByUser(gomock.Any(), "userID").Return([]model.SessionGoogle{{Model: gorm.Model{ID: 1}, CredentialsID: 123, ChannelID: "channel", ChannelTitle: "Channel"}}, nil)
 			},
 			test: func(c *qt.C, server *server) {
 				w := httptest.NewRecorder()
@@ -631,8 +637,44 @@ func TestHandlerUserSessionsGoogle(t *testing.T) {
 				err := proto.Unmarshal(w.Body.Bytes(), &resp)
 				c.Assert(err, qt.IsNil)
 
-				c.Assert(resp.CredentialIds, qt.HasLen, 1)
-				c.Assert(resp.CredentialIds[0], qt.Equals, uint64(123))
+				c.Assert(resp.Sessions, qt.HasLen, 1)
+				c.Assert(resp.Sessions[0].Id, qt.Equals, uint64(1))
+				c.Assert(resp.Sessions[0].CredentialsId, qt.Equals, uint64(123))
+				c.Assert(resp.Sessions[0].ChannelId, qt.Equals, "channel")
+				c.Assert(resp.Sessions[0].ChannelTitle, qt.Equals, "Channel")
+				c.Assert(resp.Sessions[0].Broken, qt.IsFalse)
+			},
+		},
+		{
+			name: "broken",
+			setupMocks: func(service *mock.MockCredentials) {
+				service.EXPECT().GetSessionsGoogleByUser(gomock.Any(), "userID").Return([]model.SessionGoogle{
+					{Model: gorm.Model{ID: 1}, CredentialsID: 123, ChannelID: "channel"},
+					{Model: gorm.Model{ID: 2}, CredentialsID: 123, ChannelID: "other", Broken: true},
+					{Model: gorm.Model{ID: 3}, CredentialsID: 789},
+				}, nil)
+			},
+			test: func(c *qt.C, server *server) {
+				w := httptest.NewRecorder()
+				r := httptest.NewRequest("GET", "/sessions/google", nil)
+				r = r.WithContext(auth.ContextWithUser(r.Context(), "userID", false))
+
+				server.handlerUserSessionsGoogle(w, r)
+
+				c.Assert(w.Code, qt.Equals, http.StatusOK)
+				var resp pb.GetUserSessionsGoogleResponse
+				err := proto.Unmarshal(w.Body.Bytes(), &resp)
+				c.Assert(err, qt.IsNil)
+
+				// Sessions of channels authenticated with the same credentials are listed separately.
+				var ids []uint64
+				var broken []bool
+				for _, session := range resp.Sessions {
+					ids = append(ids, session.Id)
+					broken = append(broken, session.Broken)
+				}
+				c.Assert(ids, qt.DeepEquals, []uint64{1, 2, 3})
+				c.Assert(broken, qt.DeepEquals, []bool{false, true, true})
 			},
 		},
 		{
@@ -686,7 +728,7 @@ func TestHandlerUserSessionsGoogle(t *testing.T) {
 			service := mock.NewMockCredentials(ctrl)
 			tc.setupMocks(service)
 
-			s := New(nil, service, nil, nil, nil)
+			s := New(nil, service, nil, nil, nil, nil)
 			tc.test(c, s)
 		})
 	}
@@ -732,6 +774,22 @@ func TestHandlerRemoveSessionGoogle(t *testing.T) {
 				c.Assert(w.Code, qt.Equals, http.StatusInternalServerError)
 			},
 		},
+		{
+			name: "not found",
+			setupMocks: func(service *mock.MockCredentials) {
+				service.EXPECT().RemoveSessionGoogle(gomock.Any(), "userID", uint(1)).Return(errs.NotFound)
+			},
+			test: func(c *qt.C, server *server) {
+				w := httptest.NewRecorder()
+				r := httptest.NewRequest("DELETE", "/sessions/google/1", nil)
+				r.SetPathValue("id", "1")
+				r = r.WithContext(auth.ContextWithUser(r.Context(), "userID", false))
+
+				server.handlerRemoveSessionGoogle(w, r)
+
+				c.Assert(w.Code, qt.Equals, http.StatusNotFound)
+			},
+		},
 		{
 			name: "invalid input",
 			setupMocks: func(s *mock.MockCredentials) {
@@ -784,7 +842,1499 @@ func TestHandlerRemoveSessionGoogle(t *testing.T) {
 			service := mock.NewMockCredentials(ctrl)
 			tc.setupMocks(service)
 
-			s := New(nil, service, nil, nil, nil)
+			s := New(nil, service, nil, nil, nil, nil)
+			tc.test(c, s)
+		})
+	}
+}
+
+func TestHandlerSetSourceCC(t *testing.T) {
+	c := qt.New(t)
+
+	const data = "1\n00:00:01,000 --> 00:00:02,000\nHello\n\n"
+
+	testCases := []struct {
+		name       string
+		setupMocks func(yt *mock.MockYoutube, service *mock.MockAutoCC)
+		test       func(c *qt.C, s *server)
+	}{
+		{
+			name: "success",
+			setupMocks: func(yt *mock.MockYoutube, service *mock.MockAutoCC) {
+				yt.EXPECT().GetCC(gomock.Any(), "userID", "channelID", "videoID").Return(nil, nil)
+				service.EXPECT().SetSourceCC(gomock.Any(), "userID", "videoID", "en", srt.FormatVtt, data, true).Return(nil)
+			},
+			test: func(c *qt.C, server *server) {
+				w := httptest.NewRecorder()
+				r := httptest.NewRequest("PUT", "/youtube/channels/channelID/videos/videoID/source?language=en&format=vtt&upload_original=true", strings.NewReader(data))
+				r.SetPathValue("channel", "channelID")
+				r.SetPathValue("id", "videoID")
+				r = r.WithContext(auth.ContextWithUser(r.Context(), "userID", false))
+
+				server.handlerSetSourceCC(w, r)
+
+				c.Assert(w.Code, qt.Equals, http.StatusNoContent)
+			},
+		},
+		{
+			name: "default format",
+			setupMocks: func(yt *mock.MockYoutube, service *mock.MockAutoCC) {
+				yt.EXPECT().GetCC(gomock.Any(), "userID", "channelID", "videoID").Return(nil, nil)
+				service.EXPECT().SetSourceCC(gomock.Any(), "userID", "videoID", "", srt.FormatSrt, data, false).Return(nil)
+			},
+			test: func(c *qt.C, server *server) {
+				w := httptest.NewRecorder()
+				r := httptest.NewRequest("PUT", "/youtube/channels/channelID/videos/videoID/source", strings.NewReader(data))
+				r.SetPathValue("channel", "channelID")
+				r.SetPathValue("id", "videoID")
+				r = r.WithContext(auth.ContextWithUser(r.Context(), "userID", false))
+
+				server.handlerSetSourceCC(w, r)
+
+				c.Assert(w.Code, qt.Equals, http.StatusNoContent)
+			},
+		},
+		{
+			name: "error",
+			setupMocks: func(yt *mock.MockYoutube, service *mock.MockAutoCC) {
+				yt.EXPECT().GetCC(gomock.Any(), "userID", "channelID", "videoID").Return(nil, nil)
+				service.EXPECT().SetSourceCC(gomock.Any(), "userID", "videoID", "", srt.FormatSrt, data, false).Return(errors.New("error"))
+			},
+			test: func(c *qt.C, server *server) {
+				w := httptest.NewRecorder()
+				r := httptest.NewRequest("PUT", "/youtube/channels/channelID/videos/videoID/source", strings.NewReader(data))
+				r.SetPathValue("channel", "channelID")
+				r.SetPathValue("id", "videoID")
+				r = r.WithContext(auth.ContextWithUser(r.Context(), "userID", false))
+
+				server.handlerSetSourceCC(w, r)
+
+				c.Assert(w.Code, qt.Equals, http.StatusInternalServerError)
+			},
+		},
+		{
+			name: "invalid input",
+			setupMocks: func(yt *mock.MockYoutube, service *mock.MockAutoCC) {
+				yt.EXPECT().GetCC(gomock.Any(), "userID", "channelID", "videoID").Return(nil, nil)
+				service.EXPECT().SetSourceCC(gomock.Any(), "userID", "videoID", "", srt.FormatSrt, "invalid", false).Return(errs.InvalidInput)
+			},
+			test: func(c *qt.C, server *server) {
+				w := httptest.NewRecorder()
+				r := httptest.NewRequest("PUT", "/youtube/channels/channelID/videos/videoID/source", strings.NewReader("invalid"))
+				r.SetPathValue("channel", "channelID")
+				r.SetPathValue("id", "videoID")
+				r = r.WithContext(auth.ContextWithUser(r.Context(), "userID", false))
+
+				server.handlerSetSourceCC(w, r)
+
+				c.Assert(w.Code, qt.Equals, http.StatusBadRequest)
+			},
+		},
+		{
+			name: "video of another channel",
+			setupMocks: func(yt *mock.MockYoutube, service *mock.MockAutoCC) {
+				yt.EXPECT().GetCC(gomock.Any(), "userID", "channelID", "videoID").Return(nil, errs.NotFound)
+			},
+			test: func(c *qt.C, server *server) {
+				w := httptest.NewRecorder()
+				r := httptest.NewRequest("PUT", "/youtube/channels/channelID/videos/videoID/source", strings.NewReader(data))
+				r.SetPathValue("channel", "channelID")
+				r.SetPathValue("id", "videoID")
+				r = r.WithContext(auth.ContextWithUser(r.Context(), "userID", false))
+
+				server.handlerSetSourceCC(w, r)
+
+				c.Assert(w.Code, qt.Equals, http.StatusNotFound)
+			},
+		},
+		{
+			name:       "no user",
+			setupMocks: func(yt *mock.MockYoutube, service *mock.MockAutoCC) {},
+			test: func(c *qt.C, server *server) {
+				w := httptest.NewRecorder()
+				r := httptest.NewRequest("PUT", "/youtube/channels/channelID/videos/videoID/source", strings.NewReader(data))
+				r.SetPathValue("channel", "channelID")
+				r.SetPathValue("id", "videoID")
+
+				server.handlerSetSourceCC(w, r)
+
+				c.Assert(w.Code, qt.Equals, http.StatusInternalServerError)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		c.Run(tc.name, func(c *qt.C) {
+			ctrl := gomock.NewController(c)
+
+			yt := mock.NewMockYoutube(ctrl)
+			service := mock.NewMockAutoCC(ctrl)
+			tc.setupMocks(yt, service)
+
+			s := New(nil, nil, nil, yt, service, nil)
+			tc.test(c, s)
+		})
+	}
+}
+
+func TestHandlerRemoveSourceCC(t *testing.T) {
+	c := qt.New(t)
+
+	testCases := []struct {
+		name       string
+		setupMocks func(yt *mock.MockYoutube, service *mock.MockAutoCC)
+		test       func(c *qt.C, s *server)
+	}{
+		{
+			name: "success",
+			setupMocks: func(yt *mock.MockYoutube, service *mock.MockAutoCC) {
+				yt.EXPECT().GetCC(gomock.Any(), "userID", "channelID", "videoID").Return(nil, nil)
+				service.EXPECT().RemoveSourceCC(gomock.Any(), "userID", "videoID").Return(nil)
+			},
+			test: func(c *qt.C, server *server) {
+				w := httptest.NewRecorder()
+				r := httptest.NewRequest("DELETE", "/youtube/channels/channelID/videos/videoID/source", nil)
+				r.SetPathValue("channel", "channelID")
+				r.SetPathValue("id", "videoID")
+				r = r.WithContext(auth.ContextWithUser(r.Context(), "userID", false))
+
+				server.handlerRemoveSourceCC(w, r)
+
+				c.Assert(w.Code, qt.Equals, http.StatusNoContent)
+			},
+		},
+		{
+			name: "error",
+			setupMocks: func(yt *mock.MockYoutube, service *mock.MockAutoCC) {
+				yt.EXPECT().GetCC(gomock.Any(), "userID", "channelID", "videoID").Return(nil, nil)
+				service.EXPECT().RemoveSourceCC(gomock.Any(), "userID", "videoID").Return(errors.New("error"))
+			},
+			test: func(c *qt.C, server *server) {
+				w := httptest.NewRecorder()
+				r := httptest.NewRequest("DELETE", "/youtube/channels/channelID/videos/videoID/source", nil)
+				r.SetPathValue("channel", "channelID")
+				r.SetPathValue("id", "videoID")
+				r = r.WithContext(auth.ContextWithUser(r.Context(), "userID", false))
+
+				server.handlerRemoveSourceCC(w, r)
+
+				c.Assert(w.Code, qt.Equals, http.StatusInternalServerError)
+			},
+		},
+		{
+			name: "video of another channel",
+			setupMocks: func(yt *mock.MockYoutube, service *mock.MockAutoCC) {
+				yt.EXPECT().GetCC(gomock.Any(), "userID", "channelID", "videoID").Return(nil, errs.NotFound)
+			},
+			test: func(c *qt.C, server *server) {
+				w := httptest.NewRecorder()
+				r := httptest.NewRequest("DELETE", "/youtube/channels/channelID/videos/videoID/source", nil)
+				r.SetPathValue("channel", "channelID")
+				r.SetPathValue("id", "videoID")
+				r = r.WithContext(auth.ContextWithUser(r.Context(), "userID", false))
+
+				server.handlerRemoveSourceCC(w, r)
+
+				c.Assert(w.Code, qt.Equals, http.StatusNotFound)
+			},
+		},
+		{
+			name:       "no user",
+			setupMocks: func(yt *mock.MockYoutube, service *mock.MockAutoCC) {},
+			test: func(c *qt.C, server *server) {
+				w := httptest.NewRecorder()
+				r := httptest.NewRequest("DELETE", "/youtube/channels/channelID/videos/videoID/source", nil)
+				r.SetPathValue("channel", "channelID")
+				r.SetPathValue("id", "videoID")
+
+				server.handlerRemoveSourceCC(w, r)
+
+				c.Assert(w.Code, qt.Equals, http.StatusInternalServerError)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		c.Run(tc.name, func(c *qt.C) {
+			ctrl := gomock.NewController(c)
+
+			yt := mock.NewMockYoutube(ctrl)
+			service := mock.NewMockAutoCC(ctrl)
+			tc.setupMocks(yt, service)
+
+			s := New(nil, nil, nil, yt, service, nil)
+			tc.test(c, s)
+		})
+	}
+}
+
+func TestHandlerAdjustSourceTiming(t *testing.T) {
+	c := qt.New(t)
+
+	testCases := []struct {
+		name       string
+		setupMocks func(yt *mock.MockYoutube, service *mock.MockAutoCC)
+		test       func(c *qt.C, s *server)
+	}{
+		{
+			name: "success",
+			setupMocks: func(yt *mock.MockYoutube, service *mock.MockAutoCC) {
+				yt.EXPECT().GetCC(gomock.Any(), "userID", "channelID", "videoID").Return(nil, nil)
+				service.EXPECT().AdjustSourceTiming(gomock.Any(), "userID", "videoID", srt.Timing{
+					SyncPoints:  []srt.SyncPoint{{From: time.Second, To: 2 * time.Second}, {From: 3 * time.Second, To: 5 * time.Second}},
+					Shift:       -500 * time.Millisecond,
+					MinDuration: time.Second,
+				}).Return(nil)
+			},
+			test: func(c *qt.C, server *server) {
+				data, err := proto.Marshal(&pb.AdjustSourceTimingRequest{
+					SyncPoints:    []*pb.SyncPoint{{FromMs: 1000, ToMs: 2000}, {FromMs: 3000, ToMs: 5000}},
+					ShiftMs:       -500,
+					MinDurationMs: 1000,
+				})
+				c.Assert(err, qt.IsNil)
+
+				w := httptest.NewRecorder()
+				r := httptest.NewRequest("POST", "/youtube/channels/channelID/videos/videoID/source/timing", bytes.NewReader(data))
+				r.SetPathValue("channel", "channelID")
+				r.SetPathValue("id", "videoID")
+				r = r.WithContext(auth.ContextWithUser(r.Context(), "userID", false))
+
+				server.handlerAdjustSourceTiming(w, r)
+
+				c.Assert(w.Code, qt.Equals, http.StatusNoContent)
+			},
+		},
+		{
+			name: "not found",
+			setupMocks: func(yt *mock.MockYoutube, service *mock.MockAutoCC) {
+				yt.EXPECT().GetCC(gomock.Any(), "userID", "channelID", "videoID").Return(nil, nil)
+				service.EXPECT().AdjustSourceTiming(gomock.Any(), "userID", "videoID", gomock.Any()).Return(errs.NotFound)
+			},
+			test: func(c *qt.C, server *server) {
+				w := httptest.NewRecorder()
+				r := httptest.NewRequest("POST", "/youtube/channels/channelID/videos/videoID/source/timing", nil)
+				r.SetPathValue("channel", "channelID")
+				r.SetPathValue("id", "videoID")
+				r = r.WithContext(auth.ContextWithUser(r.Context(), "userID", false))
+
+				server.handlerAdjustSourceTiming(w, r)
+
+				c.Assert(w.Code, qt.Equals, http.StatusNotFound)
+			},
+		},
+		{
+			name: "invalid input",
+			setupMocks: func(yt *mock.MockYoutube, service *mock.MockAutoCC) {
+				yt.EXPECT().GetCC(gomock.Any(), "userID", "channelID", "videoID").Return(nil, nil)
+				service.EXPECT().AdjustSourceTiming(gomock.Any(), "userID", "videoID", gomock.Any()).Return(errs.InvalidInput)
+			},
+			test: func(c *qt.C, server *server) {
+				w := httptest.NewRecorder()
+				r := httptest.NewRequest("POST", "/youtube/channels/channelID/videos/videoID/source/timing", nil)
+				r.SetPathValue("channel", "channelID")
+				r.SetPathValue("id", "videoID")
+				r = r.WithContext(auth.ContextWithUser(r.Context(), "userID", false))
+
+				server.handlerAdjustSourceTiming(w, r)
+
+				c.Assert(w.Code, qt.Equals, http.StatusBadRequest)
+			},
+		},
+		{
+			name:       "invalid body",
+			setupMocks: func(yt *mock.MockYoutube, service *mock.MockAutoCC) {},
+			test: func(c *qt.C, server *server) {
+				w := httptest.NewRecorder()
+				r := httptest.NewRequest("POST", "/youtube/channels/channelID/videos/videoID/source/timing", strings.NewReader("invalid"))
+				r.SetPathValue("channel", "channelID")
+				r.SetPathValue("id", "videoID")
+				r = r.WithContext(auth.ContextWithUser(r.Context(), "userID", false))
+
+				server.handlerAdjustSourceTiming(w, r)
+
+				c.Assert(w.Code, qt.Equals, http.StatusBadRequest)
+			},
+		},
+		{
+			name: "video of another channel",
+			setupMocks: func(yt *mock.MockYoutube, service *mock.MockAutoCC) {
+				yt.EXPECT().GetCC(gomock.Any(), "userID", "channelID", "videoID").Return(nil, errs.NotFound)
+			},
+			test: func(c *qt.C, server *server) {
+				w := httptest.NewRecorder()
+				r := httptest.NewRequest("POST", "/youtube/channels/channelID/videos/videoID/source/timing", nil)
+				r.SetPathValue("channel", "channelID")
+				r.SetPathValue("id", "videoID")
+				r = r.WithContext(auth.ContextWithUser(r.Context(), "userID", false))
+
+				server.handlerAdjustSourceTiming(w, r)
+
+				c.Assert(w.Code, qt.Equals, http.StatusNotFound)
+			},
+		},
+		{
+			name:       "no user",
+			setupMocks: func(yt *mock.MockYoutube, service *mock.MockAutoCC) {},
+			test: func(c *qt.C, server *server) {
+				w := httptest.NewRecorder()
+				r := httptest.NewRequest("POST", "/youtube/channels/channelID/videos/videoID/source/timing", nil)
+				r.SetPathValue("channel", "channelID")
+				r.SetPathValue("id", "videoID")
+
+				server.handlerAdjustSourceTiming(w, r)
+
+				c.Assert(w.Code, qt.Equals, http.StatusInternalServerError)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		c.Run(tc.name, func(c *qt.C) {
+			ctrl := gomock.NewController(c)
+
+			yt := mock.NewMockYoutube(ctrl)
+			service := mock.NewMockAutoCC(ctrl)
+			tc.setupMocks(yt, service)
+
+			s := New(nil, nil, nil, yt, service, nil)
+			tc.test(c, s)
+		})
+	}
+}
+
+func TestHandlerProcess(t *testing.T) {
+	c := qt.New(t)
+
+	testCases := []struct {
+		name       string
+		setupMocks func(service *mock.MockAutoCC)
+		test       func(c *qt.C, s *server)
+	}{
+		{
+			name: "success",
+			setupMocks: func(service *mock.MockAutoCC) {
+				service.EXPECT().Process(gomock.Any(), "userID", "channelID", "videoID", autocc.Options{Tags: true, Channel: true, Policy: youtube.MergeOverwriteAuto}).Return(nil)
+			},
+			test: func(c *qt.C, server *server) {
+				data, err := proto.Marshal(&pb.ProcessVideoRequest{Tags: true, Channel: true, Policy: pb.MergePolicy_MERGE_POLICY_OVERWRITE_AUTO})
+				c.Assert(err, qt.IsNil)
+
+				w := httptest.NewRecorder()
+				r := httptest.NewRequest("POST", "/youtube/channels/channelID/videos/videoID", bytes.NewReader(data))
+				r.SetPathValue("channel", "channelID")
+				r.SetPathValue("id", "videoID")
+				r = r.WithContext(auth.ContextWithUser(r.Context(), "userID", false))
+
+				server.handlerProcess(w, r)
+
+				c.Assert(w.Code, qt.Equals, http.StatusNoContent)
+			},
+		},
+		{
+			name: "no options",
+			setupMocks: func(service *mock.MockAutoCC) {
+				service.EXPECT().Process(gomock.Any(), "userID", "channelID", "videoID", autocc.Options{}).Return(nil)
+			},
+			test: func(c *qt.C, server *server) {
+				w := httptest.NewRecorder()
+				r := httptest.NewRequest("POST", "/youtube/channels/channelID/videos/videoID", nil)
+				r.SetPathValue("channel", "channelID")
+				r.SetPathValue("id", "videoID")
+				r = r.WithContext(auth.ContextWithUser(r.Context(), "userID", false))
+
+				server.handlerProcess(w, r)
+
+				c.Assert(w.Code, qt.Equals, http.StatusNoContent)
+			},
+		},
+		{
+			name: "source not found",
+			setupMocks: func(service *mock.MockAutoCC) {
+				service.EXPECT().Process(gomock.Any(), "userID", "channelID", "videoID", gomock.Any()).Return(errs.SourceClosedCaptionsNotFound)
+			},
+			test: func(c *qt.C, server *server) {
+				w := httptest.NewRecorder()
+				r := httptest.NewRequest("POST", "/youtube/channels/channelID/videos/videoID", nil)
+				r.SetPathValue("channel", "channelID")
+				r.SetPathValue("id", "videoID")
+				r = r.WithContext(auth.ContextWithUser(r.Context(), "userID", false))
+
+				server.handlerProcess(w, r)
+
+				c.Assert(w.Code, qt.Equals, http.StatusNotFound)
+			},
+		},
+		{
+			name: "language",
+			setupMocks: func(service *mock.MockAutoCC) {
+				service.EXPECT().Process(gomock.Any(), "userID", "channelID", "videoID", autocc.Options{Language: "pl"}).Return(nil)
+			},
+			test: func(c *qt.C, server *server) {
+				data, err := proto.Marshal(&pb.ProcessVideoRequest{Language: "pl"})
+				c.Assert(err, qt.IsNil)
+
+				w := httptest.NewRecorder()
+				r := httptest.NewRequest("POST", "/youtube/channels/channelID/videos/videoID", bytes.NewReader(data))
+				r.SetPathValue("channel", "channelID")
+				r.SetPathValue("id", "videoID")
+				r = r.WithContext(auth.ContextWithUser(r.Context(), "userID", false))
+
+				server.handlerProcess(w, r)
+
+				c.Assert(w.Code, qt.Equals, http.StatusNoContent)
+			},
+		},
+		{
+			name: "language not set",
+			setupMocks: func(service *mock.MockAutoCC) {
+				service.EXPECT().Process(gomock.Any(), "userID", "channelID", "videoID", gomock.Any()).Return(errs.VideoLanguageNotSet)
+			},
+			test: func(c *qt.C, server *server) {
+				w := httptest.NewRecorder()
+				r := httptest.NewRequest("POST", "/youtube/channels/channelID/videos/videoID", nil)
+				r.SetPathValue("channel", "channelID")
+				r.SetPathValue("id", "videoID")
+				r = r.WithContext(auth.ContextWithUser(r.Context(), "userID", false))
+
+				server.handlerProcess(w, r)
+
+				c.Assert(w.Code, qt.Equals, http.StatusConflict)
+			},
+		},
+		{
+			name:       "invalid policy",
+			setupMocks: func(service *mock.MockAutoCC) {},
+			test: func(c *qt.C, server *server) {
+				data, err := proto.Marshal(&pb.ProcessVideoRequest{Policy: pb.MergePolicy(42)})
+				c.Assert(err, qt.IsNil)
+
+				w := httptest.NewRecorder()
+				r := httptest.NewRequest("POST", "/youtube/channels/channelID/videos/videoID", bytes.NewReader(data))
+				r.SetPathValue("channel", "channelID")
+				r.SetPathValue("id", "videoID")
+				r = r.WithContext(auth.ContextWithUser(r.Context(), "userID", false))
+
+				server.handlerProcess(w, r)
+
+				c.Assert(w.Code, qt.Equals, http.StatusBadRequest)
+			},
+		},
+		{
+			name:       "invalid body",
+			setupMocks: func(service *mock.MockAutoCC) {},
+			test: func(c *qt.C, server *server) {
+				w := httptest.NewRecorder()
+				r := httptest.NewRequest("POST", "/youtube/channels/channelID/videos/videoID", strings.NewReader("invalid"))
+				r.SetPathValue("channel", "channelID")
+				r.SetPathValue("id", "videoID")
+				r = r.WithContext(auth.ContextWithUser(r.Context(), "userID", false))
+
+				server.handlerProcess(w, r)
+
+				c.Assert(w.Code, qt.Equals, http.StatusBadRequest)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		c.Run(tc.name, func(c *qt.C) {
+			ctrl := gomock.NewController(c)
+
+			service := mock.NewMockAutoCC(ctrl)
+			tc.setupMocks(service)
+
+			s := New(nil, nil, nil, nil, service, nil)
+			tc.test(c, s)
+		})
+	}
+}
+
+func TestHandlerDetectLanguage(t *testing.T) {
+	c := qt.New(t)
+
+	testCases := []struct {
+		name       string
+		setupMocks func(service *mock.MockAutoCC)
+		test       func(c *qt.C, s *server)
+	}{
+		{
+			name: "success",
+			setupMocks: func(service *mock.MockAutoCC) {
+				service.EXPECT().DetectLanguage(gomock.Any(), "userID", "channelID", "videoID").Return("pl", nil)
+			},
+			test: func(c *qt.C, server *server) {
+				w := httptest.NewRecorder()
+				r := httptest.NewRequest("GET", "/youtube/channels/channelID/videos/videoID/language", nil)
+				r.SetPathValue("channel", "channelID")
+				r.SetPathValue("id", "videoID")
+				r = r.WithContext(auth.ContextWithUser(r.Context(), "userID", false))
+
+				server.handlerDetectLanguage(w, r)
+
+				c.Assert(w.Code, qt.Equals, http.StatusOK)
+				var resp pb.DetectVideoLanguageResponse
+				err := proto.Unmarshal(w.Body.Bytes(), &resp)
+				c.Assert(err, qt.IsNil)
+				c.Assert(resp.Language, qt.Equals, "pl")
+			},
+		},
+		{
+			name: "not found",
+			setupMocks: func(service *mock.MockAutoCC) {
+				service.EXPECT().DetectLanguage(gomock.Any(), "userID", "channelID", "videoID").Return("", errs.NotFound)
+			},
+			test: func(c *qt.C, server *server) {
+				w := httptest.NewRecorder()
+				r := httptest.NewRequest("GET", "/youtube/channels/channelID/videos/videoID/language", nil)
+				r.SetPathValue("channel", "channelID")
+				r.SetPathValue("id", "videoID")
+				r = r.WithContext(auth.ContextWithUser(r.Context(), "userID", false))
+
+				server.handlerDetectLanguage(w, r)
+
+				c.Assert(w.Code, qt.Equals, http.StatusNotFound)
+			},
+		},
+		{
+			name:       "no user",
+			setupMocks: func(service *mock.MockAutoCC) {},
+			test: func(c *qt.C, server *server) {
+				w := httptest.NewRecorder()
+				r := httptest.NewRequest("GET", "/youtube/channels/channelID/videos/videoID/language", nil)
+				r.SetPathValue("channel", "channelID")
+				r.SetPathValue("id", "videoID")
+
+				server.handlerDetectLanguage(w, r)
+
+				c.Assert(w.Code, qt.Equals, http.StatusInternalServerError)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		c.Run(tc.name, func(c *qt.C) {
+			ctrl := gomock.NewController(c)
+
+			service := mock.NewMockAutoCC(ctrl)
+			tc.setupMocks(service)
+
+			s := New(nil, nil, nil, nil, service, nil)
+			tc.test(c, s)
+		})
+	}
+}
+
+func TestHandlerVideoCoverage(t *testing.T) {
+	c := qt.New(t)
+
+	testCases := []struct {
+		name       string
+		setupMocks func(service *mock.MockCoverage)
+		test       func(c *qt.C, s *server)
+	}{
+		{
+			name: "success",
+			setupMocks: func(service *mock.MockCoverage) {
+				service.EXPECT().GetVideo(gomock.Any(), "userID", "channelID", "videoID").Return(&pb.VideoCoverage{
+					VideoId:   "videoID",
+					Languages: []*pb.LanguageCoverage{{Language: "de", Captions: &pb.TranslationState{Author: pb.Author_AUTHOR_AUTOCC, Stale: true}}},
+				}, nil)
+			},
+			test: func(c *qt.C, server *server) {
+				w := httptest.NewRecorder()
+				r := httptest.NewRequest("GET", "/youtube/channels/channelID/videos/videoID/coverage", nil)
+				r.SetPathValue("channel", "channelID")
+				r.SetPathValue("id", "videoID")
+				r = r.WithContext(auth.ContextWithUser(r.Context(), "userID", false))
+
+				server.handlerVideoCoverage(w, r)
+
+				c.Assert(w.Code, qt.Equals, http.StatusOK)
+				var resp pb.GetVideoCoverageResponse
+				err := proto.Unmarshal(w.Body.Bytes(), &resp)
+				c.Assert(err, qt.IsNil)
+				c.Assert(resp.Coverage.VideoId, qt.Equals, "videoID")
+				c.Assert(resp.Coverage.Languages, qt.HasLen, 1)
+				c.Assert(resp.Coverage.Languages[0].Captions.Author, qt.Equals, pb.Author_AUTHOR_AUTOCC)
+				c.Assert(resp.Coverage.Languages[0].Captions.Stale, qt.IsTrue)
+			},
+		},
+		{
+			name: "not found",
+			setupMocks: func(service *mock.MockCoverage) {
+				service.EXPECT().GetVideo(gomock.Any(), "userID", "channelID", "videoID").Return(nil, errs.NotFound)
+			},
+			test: func(c *qt.C, server *server) {
+				w := httptest.NewRecorder()
+				r := httptest.NewRequest("GET", "/youtube/channels/channelID/videos/videoID/coverage", nil)
+				r.SetPathValue("channel", "channelID")
+				r.SetPathValue("id", "videoID")
+				r = r.WithContext(auth.ContextWithUser(r.Context(), "userID", false))
+
+				server.handlerVideoCoverage(w, r)
+
+				c.Assert(w.Code, qt.Equals, http.StatusNotFound)
+			},
+		},
+		{
+			name:       "no user",
+			setupMocks: func(service *mock.MockCoverage) {},
+			test: func(c *qt.C, server *server) {
+				w := httptest.NewRecorder()
+				r := httptest.NewRequest("GET", "/youtube/channels/channelID/videos/videoID/coverage", nil)
+				r.SetPathValue("channel", "channelID")
+				r.SetPathValue("id", "videoID")
+
+				server.handlerVideoCoverage(w, r)
+
+				c.Assert(w.Code, qt.Equals, http.StatusInternalServerError)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		c.Run(tc.name, func(c *qt.C) {
+			ctrl := gomock.NewController(c)
+
+			service := mock.NewMockCoverage(ctrl)
+			tc.setupMocks(service)
+
+			s := New(nil, nil, nil, nil, nil, service)
+			tc.test(c, s)
+		})
+	}
+}
+
+func TestHandlerChannelCoverage(t *testing.T) {
+	c := qt.New(t)
+
+	testCases := []struct {
+		name       string
+		setupMocks func(service *mock.MockCoverage)
+		test       func(c *qt.C, s *server)
+	}{
+		{
+			name: "success",
+			setupMocks: func(service *mock.MockCoverage) {
+				service.EXPECT().GetChannel(gomock.Any(), "userID", "channelID", "token").Return(
+					[]*pb.VideoCoverage{{VideoId: "videoID"}},
+					[]*pb.LanguageSummary{{Language: "de", CaptionsMissing: 1}},
+					"next", nil,
+				)
+			},
+			test: func(c *qt.C, server *server) {
+				w := httptest.NewRecorder()
+				r := httptest.NewRequest("GET", "/youtube/channels/channelID/coverage?next_page_token=token", nil)
+				r.SetPathValue("channel", "channelID")
+				r = r.WithContext(auth.ContextWithUser(r.Context(), "userID", false))
+
+				server.handlerChannelCoverage(w, r)
+
+				c.Assert(w.Code, qt.Equals, http.StatusOK)
+				var resp pb.GetChannelCoverageResponse
+				err := proto.Unmarshal(w.Body.Bytes(), &resp)
+				c.Assert(err, qt.IsNil)
+				c.Assert(resp.Videos, qt.HasLen, 1)
+				c.Assert(resp.Languages, qt.HasLen, 1)
+				c.Assert(resp.Languages[0].CaptionsMissing, qt.Equals, uint32(1))
+				c.Assert(resp.NextPageToken, qt.Equals, "next")
+			},
+		},
+		{
+			name: "error",
+			setupMocks: func(service *mock.MockCoverage) {
+				service.EXPECT().GetChannel(gomock.Any(), "userID", "channelID", "").Return(nil, nil, "", errors.New("error"))
+			},
+			test: func(c *qt.C, server *server) {
+				w := httptest.NewRecorder()
+				r := httptest.NewRequest("GET", "/youtube/channels/channelID/coverage", nil)
+				r.SetPathValue("channel", "channelID")
+				r = r.WithContext(auth.ContextWithUser(r.Context(), "userID", false))
+
+				server.handlerChannelCoverage(w, r)
+
+				c.Assert(w.Code, qt.Equals, http.StatusInternalServerError)
+			},
+		},
+		{
+			name:       "no user",
+			setupMocks: func(service *mock.MockCoverage) {},
+			test: func(c *qt.C, server *server) {
+				w := httptest.NewRecorder()
+				r := httptest.NewRequest("GET", "/youtube/channels/channelID/coverage", nil)
+				r.SetPathValue("channel", "channelID")
+
+				server.handlerChannelCoverage(w, r)
+
+				c.Assert(w.Code, qt.Equals, http.StatusInternalServerError)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		c.Run(tc.name, func(c *qt.C) {
+			ctrl := gomock.NewController(c)
+
+			service := mock.NewMockCoverage(ctrl)
+			tc.setupMocks(service)
+
+			s := New(nil, nil, nil, nil, nil, service)
+			tc.test(c, s)
+		})
+	}
+}
+
+func TestHandlerYoutubeVideos(t *testing.T) {
+	c := qt.New(t)
+
+	testCases := []struct {
+		name       string
+		setupMocks func(service *mock.MockAutoCC)
+		test       func(c *qt.C, s *server)
+	}{
+		{
+			name: "success",
+			setupMocks: func(service *mock.MockAutoCC) {
+				options := autocc.ListOptions{Unprocessed: true, MissingLanguages: true, Sort: autocc.VideoSortTitle}
+				service.EXPECT().ListVideos(gomock.Any(), "userID", "channelID", "token", options).Return([]*pb.Video{{Id: "videoID", MissingLanguages: []string{"de"}}}, "next", nil)
+			},
+			test: func(c *qt.C, server *server) {
+				w := httptest.NewRecorder()
+				r := httptest.NewRequest("GET", "/youtube/channels/channelID/videos?next_page_token=token&unprocessed=true&missing_languages=true&sort=title", nil)
+				r.SetPathValue("channel", "channelID")
+				r = r.WithContext(auth.ContextWithUser(r.Context(), "userID", false))
+
+				server.handlerYoutubeVideos(w, r)
+
+				c.Assert(w.Code, qt.Equals, http.StatusOK)
+				var resp pb.GetYoutubeVideosResponse
+				err := proto.Unmarshal(w.Body.Bytes(), &resp)
+				c.Assert(err, qt.IsNil)
+				c.Assert(resp.Videos, qt.HasLen, 1)
+				c.Assert(resp.Videos[0].MissingLanguages, qt.DeepEquals, []string{"de"})
+				c.Assert(resp.NextPageToken, qt.Equals, "next")
+			},
+		},
+		{
+			name: "not found",
+			setupMocks: func(service *mock.MockAutoCC) {
+				service.EXPECT().ListVideos(gomock.Any(), "userID", "channelID", "", autocc.ListOptions{}).Return(nil, "", errs.NotFound)
+			},
+			test: func(c *qt.C, server *server) {
+				w := httptest.NewRecorder()
+				r := httptest.NewRequest("GET", "/youtube/channels/channelID/videos", nil)
+				r.SetPathValue("channel", "channelID")
+				r = r.WithContext(auth.ContextWithUser(r.Context(), "userID", false))
+
+				server.handlerYoutubeVideos(w, r)
+
+				c.Assert(w.Code, qt.Equals, http.StatusOK)
+				var resp pb.GetYoutubeVideosResponse
+				err := proto.Unmarshal(w.Body.Bytes(), &resp)
+				c.Assert(err, qt.IsNil)
+				c.Assert(resp.Videos, qt.HasLen, 0)
+			},
+		},
+		{
+			name:       "invalid sort",
+			setupMocks: func(service *mock.MockAutoCC) {},
+			test: func(c *qt.C, server *server) {
+				w := httptest.NewRecorder()
+				r := httptest.NewRequest("GET", "/youtube/channels/channelID/videos?sort=views", nil)
+				r.SetPathValue("channel", "channelID")
+				r = r.WithContext(auth.ContextWithUser(r.Context(), "userID", false))
+
+				server.handlerYoutubeVideos(w, r)
+
+				c.Assert(w.Code, qt.Equals, http.StatusBadRequest)
+			},
+		},
+		{
+			name:       "no user",
+			setupMocks: func(service *mock.MockAutoCC) {},
+			test: func(c *qt.C, server *server) {
+				w := httptest.NewRecorder()
+				r := httptest.NewRequest("GET", "/youtube/channels/channelID/videos", nil)
+				r.SetPathValue("channel", "channelID")
+
+				server.handlerYoutubeVideos(w, r)
+
+				c.Assert(w.Code, qt.Equals, http.StatusInternalServerError)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		c.Run(tc.name, func(c *qt.C) {
+			ctrl := gomock.NewController(c)
+
+			service := mock.NewMockAutoCC(ctrl)
+			tc.setupMocks(service)
+
+			s := New(nil, nil, nil, nil, service, nil)
+			tc.test(c, s)
+		})
+	}
+}
+
+func TestHandlerYoutubeChannels(t *testing.T) {
+	c := qt.New(t)
+
+	testCases := []struct {
+		name       string
+		setupMocks func(yt *mock.MockYoutube)
+		test       func(c *qt.C, s *server)
+	}{
+		{
+			name: "success",
+			setupMocks: func(yt *mock.MockYoutube) {
+				yt.EXPECT().GetChannels(gomock.Any(), "userID").Return([]*youtube.Channel{{ID: "channelID", Title: "Channel"}, {ID: "brokenID", Title: "Broken", Broken: true}}, nil)
+			},
+			test: func(c *qt.C, server *server) {
+				w := httptest.NewRecorder()
+				r := httptest.NewRequest("GET", "/youtube/channels", nil)
+				r = r.WithContext(auth.ContextWithUser(r.Context(), "userID", false))
+
+				server.handlerYoutubeChannels(w, r)
+
+				c.Assert(w.Code, qt.Equals, http.StatusOK)
+				var resp pb.GetYoutubeChannelsResponse
+				err := proto.Unmarshal(w.Body.Bytes(), &resp)
+				c.Assert(err, qt.IsNil)
+				c.Assert(resp.Channels, qt.HasLen, 2)
+				c.Assert(resp.Channels[0].Id, qt.Equals, "channelID")
+				c.Assert(resp.Channels[0].Title, qt.Equals, "Channel")
+				c.Assert(resp.Channels[0].Broken, qt.IsFalse)
+				c.Assert(resp.Channels[1].Broken, qt.IsTrue)
+			},
+		},
+		{
+			name: "error",
+			setupMocks: func(yt *mock.MockYoutube) {
+				yt.EXPECT().GetChannels(gomock.Any(), "userID").Return(nil, errors.New("error"))
+			},
+			test: func(c *qt.C, server *server) {
+				w := httptest.NewRecorder()
+				r := httptest.NewRequest("GET", "/youtube/channels", nil)
+				r = r.WithContext(auth.ContextWithUser(r.Context(), "userID", false))
+
+				server.handlerYoutubeChannels(w, r)
+
+				c.Assert(w.Code, qt.Equals, http.StatusInternalServerError)
+			},
+		},
+		{
+			name:       "no user",
+			setupMocks: func(yt *mock.MockYoutube) {},
+			test: func(c *qt.C, server *server) {
+				w := httptest.NewRecorder()
+				r := httptest.NewRequest("GET", "/youtube/channels", nil)
+
+				server.handlerYoutubeChannels(w, r)
+
+				c.Assert(w.Code, qt.Equals, http.StatusInternalServerError)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		c.Run(tc.name, func(c *qt.C) {
+			ctrl := gomock.NewController(c)
+
+			yt := mock.NewMockYoutube(ctrl)
+			tc.setupMocks(yt)
+
+			s := New(nil, nil, nil, yt, nil, nil)
+			tc.test(c, s)
+		})
+	}
+}
+
+func TestHandlerYoutubeCC(t *testing.T) {
+	c := qt.New(t)
+
+	testCases := []struct {
+		name       string
+		setupMocks func(yt *mock.MockYoutube)
+		test       func(c *qt.C, s *server)
+	}{
+		{
+			name: "success",
+			setupMocks: func(yt *mock.MockYoutube) {
+				yt.EXPECT().GetCC(gomock.Any(), "userID", "channelID", "videoID").Return([]*youtube.CC{{Id: "ccID", Language: "en"}}, nil)
+			},
+			test: func(c *qt.C, server *server) {
+				w := httptest.NewRecorder()
+				r := httptest.NewRequest("GET", "/youtube/channels/channelID/videos/videoID/captions", nil)
+				r.SetPathValue("channel", "channelID")
+				r.SetPathValue("id", "videoID")
+				r = r.WithContext(auth.ContextWithUser(r.Context(), "userID", false))
+
+				server.handlerYoutubeCC(w, r)
+
+				c.Assert(w.Code, qt.Equals, http.StatusOK)
+				var resp pb.GetYoutubeClosedCaptionsResponse
+				err := proto.Unmarshal(w.Body.Bytes(), &resp)
+				c.Assert(err, qt.IsNil)
+				c.Assert(resp.ClosedCaptions, qt.HasLen, 1)
+				c.Assert(resp.ClosedCaptions[0].Id, qt.Equals, "ccID")
+				c.Assert(resp.ClosedCaptions[0].Language, qt.Equals, "en")
+			},
+		},
+		{
+			name: "error",
+			setupMocks: func(yt *mock.MockYoutube) {
+				yt.EXPECT().GetCC(gomock.Any(), "userID", "channelID", "videoID").Return(nil, errors.New("error"))
+			},
+			test: func(c *qt.C, server *server) {
+				w := httptest.NewRecorder()
+				r := httptest.NewRequest("GET", "/youtube/channels/channelID/videos/videoID/captions", nil)
+				r.SetPathValue("channel", "channelID")
+				r.SetPathValue("id", "videoID")
+				r = r.WithContext(auth.ContextWithUser(r.Context(), "userID", false))
+
+				server.handlerYoutubeCC(w, r)
+
+				c.Assert(w.Code, qt.Equals, http.StatusInternalServerError)
+			},
+		},
+		{
+			name:       "no user",
+			setupMocks: func(yt *mock.MockYoutube) {},
+			test: func(c *qt.C, server *server) {
+				w := httptest.NewRecorder()
+				r := httptest.NewRequest("GET", "/youtube/channels/channelID/videos/videoID/captions", nil)
+				r.SetPathValue("channel", "channelID")
+				r.SetPathValue("id", "videoID")
+
+				server.handlerYoutubeCC(w, r)
+
+				c.Assert(w.Code, qt.Equals, http.StatusInternalServerError)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		c.Run(tc.name, func(c *qt.C) {
+			ctrl := gomock.NewController(c)
+
+			yt := mock.NewMockYoutube(ctrl)
+			tc.setupMocks(yt)
+
+			s := New(nil, nil, nil, yt, nil, nil)
+			tc.test(c, s)
+		})
+	}
+}
+
+func TestHandlerDownloadCC(t *testing.T) {
+	c := qt.New(t)
+
+	cc, err := srt.Parse("1\n00:00:01,000 --> 00:00:02,000\nHello\n")
+	c.Assert(err, qt.IsNil)
+
+	testCases := []struct {
+		name       string
+		setupMocks func(yt *mock.MockYoutube)
+		test       func(c *qt.C, s *server)
+	}{
+		{
+			name: "srt",
+			setupMocks: func(yt *mock.MockYoutube) {
+				yt.EXPECT().GetCC(gomock.Any(), "userID", "channelID", "videoID").Return([]*youtube.CC{{Id: "ccID", Language: "en"}}, nil)
+				yt.EXPECT().DownloadCC(gomock.Any(), "userID", "channelID", "ccID").Return(cc, nil)
+			},
+			test: func(c *qt.C, server *server) {
+				w := httptest.NewRecorder()
+				r := httptest.NewRequest("GET", "/youtube/channels/channelID/videos/videoID/captions/ccID", nil)
+				r.SetPathValue("channel", "channelID")
+				r.SetPathValue("id", "videoID")
+				r.SetPathValue("trackId", "ccID")
+				r = r.WithContext(auth.ContextWithUser(r.Context(), "userID", false))
+
+				server.handlerDownloadCC(w, r)
+
+				c.Assert(w.Code, qt.Equals, http.StatusOK)
+				c.Assert(w.Header().Get("Content-Type"), qt.Equals, srt.FormatSrt.ContentType())
+				c.Assert(w.Header().Get("Content-Disposition"), qt.Contains, "videoID.ccID.srt")
+				c.Assert(w.Body.String(), qt.Equals, cc.String())
+			},
+		},
+		{
+			name: "vtt",
+			setupMocks: func(yt *mock.MockYoutube) {
+				yt.EXPECT().GetCC(gomock.Any(), "userID", "channelID", "videoID").Return([]*youtube.CC{{Id: "ccID", Language: "en"}}, nil)
+				yt.EXPECT().DownloadCC(gomock.Any(), "userID", "channelID", "ccID").Return(cc, nil)
+			},
+			test: func(c *qt.C, server *server) {
+				w := httptest.NewRecorder()
+				r := httptest.NewRequest("GET", "/youtube/channels/channelID/videos/videoID/captions/ccID?format=vtt", nil)
+				r.SetPathValue("channel", "channelID")
+				r.SetPathValue("id", "videoID")
+				r.SetPathValue("trackId", "ccID")
+				r = r.WithContext(auth.ContextWithUser(r.Context(), "userID", false))
+
+				server.handlerDownloadCC(w, r)
+
+				c.Assert(w.Code, qt.Equals, http.StatusOK)
+				c.Assert(w.Header().Get("Content-Type"), qt.Equals, srt.FormatVtt.ContentType())
+				c.Assert(w.Body.String(), qt.Equals, cc.Vtt())
+			},
+		},
+		{
+			name:       "invalid format",
+			setupMocks: func(yt *mock.MockYoutube) {},
+			test: func(c *qt.C, server *server) {
+				w := httptest.NewRecorder()
+				r := httptest.NewRequest("GET", "/youtube/channels/channelID/videos/videoID/captions/ccID?format=ass", nil)
+				r.SetPathValue("channel", "channelID")
+				r.SetPathValue("id", "videoID")
+				r.SetPathValue("trackId", "ccID")
+				r = r.WithContext(auth.ContextWithUser(r.Context(), "userID", false))
+
+				server.handlerDownloadCC(w, r)
+
+				c.Assert(w.Code, qt.Equals, http.StatusBadRequest)
+			},
+		},
+		{
+			name: "not found",
+			setupMocks: func(yt *mock.MockYoutube) {
+				yt.EXPECT().GetCC(gomock.Any(), "userID", "channelID", "videoID").Return([]*youtube.CC{{Id: "ccID", Language: "en"}}, nil)
+				yt.EXPECT().DownloadCC(gomock.Any(), "userID", "channelID", "ccID").Return(nil, errs.NotFound)
+			},
+			test: func(c *qt.C, server *server) {
+				w := httptest.NewRecorder()
+				r := httptest.NewRequest("GET", "/youtube/channels/channelID/videos/videoID/captions/ccID", nil)
+				r.SetPathValue("channel", "channelID")
+				r.SetPathValue("id", "videoID")
+				r.SetPathValue("trackId", "ccID")
+				r = r.WithContext(auth.ContextWithUser(r.Context(), "userID", false))
+
+				server.handlerDownloadCC(w, r)
+
+				c.Assert(w.Code, qt.Equals, http.StatusNotFound)
+			},
+		},
+		{
+			name: "track of another video",
+			setupMocks: func(yt *mock.MockYoutube) {
+				yt.EXPECT().GetCC(gomock.Any(), "userID", "channelID", "videoID").Return([]*youtube.CC{{Id: "otherID", Language: "en"}}, nil)
+			},
+			test: func(c *qt.C, server *server) {
+				w := httptest.NewRecorder()
+				r := httptest.NewRequest("GET", "/youtube/channels/channelID/videos/videoID/captions/ccID", nil)
+				r.SetPathValue("channel", "channelID")
+				r.SetPathValue("id", "videoID")
+				r.SetPathValue("trackId", "ccID")
+				r = r.WithContext(auth.ContextWithUser(r.Context(), "userID", false))
+
+				server.handlerDownloadCC(w, r)
+
+				c.Assert(w.Code, qt.Equals, http.StatusNotFound)
+			},
+		},
+		{
+			name: "error",
+			setupMocks: func(yt *mock.MockYoutube) {
+				yt.EXPECT().GetCC(gomock.Any(), "userID", "channelID", "videoID").Return([]*youtube.CC{{Id: "ccID", Language: "en"}}, nil)
+				yt.EXPECT().DownloadCC(gomock.Any(), "userID", "channelID", "ccID").Return(nil, errors.New("error"))
+			},
+			test: func(c *qt.C, server *server) {
+				w := httptest.NewRecorder()
+				r := httptest.NewRequest("GET", "/youtube/channels/channelID/videos/videoID/captions/ccID", nil)
+				r.SetPathValue("channel", "channelID")
+				r.SetPathValue("id", "videoID")
+				r.SetPathValue("trackId", "ccID")
+				r = r.WithContext(auth.ContextWithUser(r.Context(), "userID", false))
+
+				server.handlerDownloadCC(w, r)
+
+				c.Assert(w.Code, qt.Equals, http.StatusInternalServerError)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		c.Run(tc.name, func(c *qt.C) {
+			ctrl := gomock.NewController(c)
+
+			yt := mock.NewMockYoutube(ctrl)
+			tc.setupMocks(yt)
+
+			s := New(nil, nil, nil, yt, nil, nil)
+			tc.test(c, s)
+		})
+	}
+}
+
+func TestHandlerExportCC(t *testing.T) {
+	c := qt.New(t)
+
+	testCases := []struct {
+		name       string
+		setupMocks func(service *mock.MockAutoCC)
+		test       func(c *qt.C, s *server)
+	}{
+		{
+			name: "success",
+			setupMocks: func(service *mock.MockAutoCC) {
+				service.EXPECT().ExportCC(gomock.Any(), "userID", "channelID", "videoID", srt.FormatTxt).Return([]byte("zip"), nil)
+			},
+			test: func(c *qt.C, server *server) {
+				w := httptest.NewRecorder()
+				r := httptest.NewRequest("GET", "/youtube/channels/channelID/videos/videoID/export?format=txt", nil)
+				r.SetPathValue("channel", "channelID")
+				r.SetPathValue("id", "videoID")
+				r = r.WithContext(auth.ContextWithUser(r.Context(), "userID", false))
+
+				server.handlerExportCC(w, r)
+
+				c.Assert(w.Code, qt.Equals, http.StatusOK)
+				c.Assert(w.Header().Get("Content-Type"), qt.Equals, "application/zip")
+				c.Assert(w.Body.String(), qt.Equals, "zip")
+			},
+		},
+		{
+			name: "not found",
+			setupMocks: func(service *mock.MockAutoCC) {
+				service.EXPECT().ExportCC(gomock.Any(), "userID", "channelID", "videoID", srt.FormatSrt).Return(nil, errs.NotFound)
+			},
+			test: func(c *qt.C, server *server) {
+				w := httptest.NewRecorder()
+				r := httptest.NewRequest("GET", "/youtube/channels/channelID/videos/videoID/export", nil)
+				r.SetPathValue("channel", "channelID")
+				r.SetPathValue("id", "videoID")
+				r = r.WithContext(auth.ContextWithUser(r.Context(), "userID", false))
+
+				server.handlerExportCC(w, r)
+
+				c.Assert(w.Code, qt.Equals, http.StatusNotFound)
+			},
+		},
+		{
+			name:       "invalid format",
+			setupMocks: func(service *mock.MockAutoCC) {},
+			test: func(c *qt.C, server *server) {
+				w := httptest.NewRecorder()
+				r := httptest.NewRequest("GET", "/youtube/channels/channelID/videos/videoID/export?format=ass", nil)
+				r.SetPathValue("channel", "channelID")
+				r.SetPathValue("id", "videoID")
+				r = r.WithContext(auth.ContextWithUser(r.Context(), "userID", false))
+
+				server.handlerExportCC(w, r)
+
+				c.Assert(w.Code, qt.Equals, http.StatusBadRequest)
+			},
+		},
+		{
+			name: "error",
+			setupMocks: func(service *mock.MockAutoCC) {
+				service.EXPECT().ExportCC(gomock.Any(), "userID", "channelID", "videoID", srt.FormatSrt).Return(nil, errors.New("error"))
+			},
+			test: func(c *qt.C, server *server) {
+				w := httptest.NewRecorder()
+				r := httptest.NewRequest("GET", "/youtube/channels/channelID/videos/videoID/export", nil)
+				r.SetPathValue("channel", "channelID")
+				r.SetPathValue("id", "videoID")
+				r = r.WithContext(auth.ContextWithUser(r.Context(), "userID", false))
+
+				server.handlerExportCC(w, r)
+
+				c.Assert(w.Code, qt.Equals, http.StatusInternalServerError)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		c.Run(tc.name, func(c *qt.C) {
+			ctrl := gomock.NewController(c)
+
+			service := mock.NewMockAutoCC(ctrl)
+			tc.setupMocks(service)
+
+			s := New(nil, nil, nil, nil, service, nil)
+			tc.test(c, s)
+		})
+	}
+}
+
+func TestHandlerCacheStats(t *testing.T) {
+	c := qt.New(t)
+
+	testCases := []struct {
+		name       string
+		setupMocks func(cache *mock.MockCache)
+		test       func(c *qt.C, s *server)
+	}{
+		{
+			name: "success",
+			setupMocks: func(mockCache *mock.MockCache) {
+				mockCache.EXPECT().Stats(gomock.Any()).Return(map[cache.Namespace]cache.Stats{
+					cache.NamespaceUpload:      {Hits: 1, Misses: 2},
+					cache.NamespaceTranslation: {Hits: 3, Misses: 4},
+				}, nil)
+			},
+			test: func(c *qt.C, server *server) {
+				w := httptest.NewRecorder()
+				r := httptest.NewRequest("GET", "/cache/stats", nil)
+
+				server.handlerCacheStats(w, r)
+
+				c.Assert(w.Code, qt.Equals, http.StatusOK)
+				var resp pb.GetCacheStatsResponse
+				err := proto.Unmarshal(w.Body.Bytes(), &resp)
+				c.Assert(err, qt.IsNil)
+
+				c.Assert(resp.Stats, qt.HasLen, 2)
+				c.Assert(resp.Stats[0].Namespace, qt.Equals, "translation")
+				c.Assert(resp.Stats[0].Hits, qt.Equals, uint64(3))
+				c.Assert(resp.Stats[0].Misses, qt.Equals, uint64(4))
+				c.Assert(resp.Stats[1].Namespace, qt.Equals, "upload")
+			},
+		},
+		{
+			name: "error",
+			setupMocks: func(mockCache *mock.MockCache) {
+				mockCache.EXPECT().Stats(gomock.Any()).Return(nil, errors.New("error"))
+			},
+			test: func(c *qt.C, server *server) {
+				w := httptest.NewRecorder()
+				r := httptest.NewRequest("GET", "/cache/stats", nil)
+
+				server.handlerCacheStats(w, r)
+
+				c.Assert(w.Code, qt.Equals, http.StatusInternalServerError)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		c.Run(tc.name, func(c *qt.C) {
+			ctrl := gomock.NewController(c)
+
+			mockCache := mock.NewMockCache(ctrl)
+			tc.setupMocks(mockCache)
+
+			s := New(mockCache, nil, nil, nil, nil, nil)
+			tc.test(c, s)
+		})
+	}
+}
+
+func TestHandlerCacheKeys(t *testing.T) {
+	c := qt.New(t)
+
+	testCases := []struct {
+		name       string
+		setupMocks func(cache *mock.MockCache)
+		test       func(c *qt.C, s *server)
+	}{
+		{
+			name: "success",
+			setupMocks: func(mockCache *mock.MockCache) {
+				mockCache.EXPECT().Keys(gomock.Any(), cache.NamespaceTranslation).Return([]cache.KeyInfo{
+					{Key: "translation:v2:a", TTL: time.Hour},
+				}, nil)
+			},
+			test: func(c *qt.C, server *server) {
+				w := httptest.NewRecorder()
+				r := httptest.NewRequest("GET", "/cache/keys/translation", nil)
+				r.SetPathValue("namespace", "translation")
+
+				server.handlerCacheKeys(w, r)
+
+				c.Assert(w.Code, qt.Equals, http.StatusOK)
+				var resp pb.GetCacheKeysResponse
+				err := proto.Unmarshal(w.Body.Bytes(), &resp)
+				c.Assert(err, qt.IsNil)
+
+				c.Assert(resp.Keys, qt.HasLen, 1)
+				c.Assert(resp.Keys[0].Key, qt.Equals, "translation:v2:a")
+				c.Assert(resp.Keys[0].TtlSeconds, qt.Equals, int64(3600))
+			},
+		},
+		{
+			name:       "invalid namespace",
+			setupMocks: func(mockCache *mock.MockCache) {},
+			test: func(c *qt.C, server *server) {
+				w := httptest.NewRecorder()
+				r := httptest.NewRequest("GET", "/cache/keys/lock", nil)
+				r.SetPathValue("namespace", "lock")
+
+				server.handlerCacheKeys(w, r)
+
+				c.Assert(w.Code, qt.Equals, http.StatusBadRequest)
+			},
+		},
+		{
+			name: "error",
+			setupMocks: func(mockCache *mock.MockCache) {
+				mockCache.EXPECT().Keys(gomock.Any(), cache.NamespaceUpload).Return(nil, errors.New("error"))
+			},
+			test: func(c *qt.C, server *server) {
+				w := httptest.NewRecorder()
+				r := httptest.NewRequest("GET", "/cache/keys/upload", nil)
+				r.SetPathValue("namespace", "upload")
+
+				server.handlerCacheKeys(w, r)
+
+				c.Assert(w.Code, qt.Equals, http.StatusInternalServerError)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		c.Run(tc.name, func(c *qt.C) {
+			ctrl := gomock.NewController(c)
+
+			mockCache := mock.NewMockCache(ctrl)
+			tc.setupMocks(mockCache)
+
+			s := New(mockCache, nil, nil, nil, nil, nil)
+			tc.test(c, s)
+		})
+	}
+}
+
+func TestHandlerInvalidateCache(t *testing.T) {
+	c := qt.New(t)
+
+	testCases := []struct {
+		name       string
+		setupMocks func(cache *mock.MockCache)
+		test       func(c *qt.C, s *server)
+	}{
+		{
+			name: "video",
+			setupMocks: func(mockCache *mock.MockCache) {
+				mockCache.EXPECT().Invalidate(gomock.Any(), "video:videoID").Return(2, nil)
+			},
+			test: func(c *qt.C, server *server) {
+				w := httptest.NewRecorder()
+				r := httptest.NewRequest("DELETE", "/cache/videos/videoID", nil)
+				r.SetPathValue("id", "videoID")
+
+				server.handlerInvalidateCache("id", cache.TagVideo)(w, r)
+
+				c.Assert(w.Code, qt.Equals, http.StatusOK)
+				var resp pb.InvalidateCacheResponse
+				err := proto.Unmarshal(w.Body.Bytes(), &resp)
+				c.Assert(err, qt.IsNil)
+				c.Assert(resp.Removed, qt.Equals, uint64(2))
+			},
+		},
+		{
+			name:       "missing value",
+			setupMocks: func(mockCache *mock.MockCache) {},
+			test: func(c *qt.C, server *server) {
+				w := httptest.NewRecorder()
+				r := httptest.NewRequest("DELETE", "/cache/users/", nil)
+
+				server.handlerInvalidateCache("id", cache.TagUser)(w, r)
+
+				c.Assert(w.Code, qt.Equals, http.StatusBadRequest)
+			},
+		},
+		{
+			name: "error",
+			setupMocks: func(mockCache *mock.MockCache) {
+				mockCache.EXPECT().Invalidate(gomock.Any(), "user:userID").Return(0, errors.New("error"))
+			},
+			test: func(c *qt.C, server *server) {
+				w := httptest.NewRecorder()
+				r := httptest.NewRequest("DELETE", "/cache/users/userID", nil)
+				r.SetPathValue("id", "userID")
+
+				server.handlerInvalidateCache("id", cache.TagUser)(w, r)
+
+				c.Assert(w.Code, qt.Equals, http.StatusInternalServerError)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		c.Run(tc.name, func(c *qt.C) {
+			ctrl := gomock.NewController(c)
+
+			mockCache := mock.NewMockCache(ctrl)
+			tc.setupMocks(mockCache)
+
+			s := New(mockCache, nil, nil, nil, nil, nil)
+			tc.test(c, s)
+		})
+	}
+}
+
+func TestHandlerInvalidateLanguage(t *testing.T) {
+	c := qt.New(t)
+
+	testCases := []struct {
+		name       string
+		setupMocks func(cache *mock.MockCache, autocc *mock.MockAutoCC)
+		test       func(c *qt.C, s *server)
+	}{
+		{
+			name: "success",
+			setupMocks: func(mockCache *mock.MockCache, mockAutoCC *mock.MockAutoCC) {
+				mockCache.EXPECT().Invalidate(gomock.Any(), "language:pl").Return(2, nil)
+				mockAutoCC.EXPECT().RemoveTranslationMemory(gomock.Any(), "PL").Return(3, nil)
+			},
+			test: func(c *qt.C, server *server) {
+				w := httptest.NewRecorder()
+				r := httptest.NewRequest("DELETE", "/cache/languages/PL", nil)
+				r.SetPathValue("language", "PL")
+
+				server.handlerInvalidateLanguage(w, r)
+
+				c.Assert(w.Code, qt.Equals, http.StatusOK)
+				var resp pb.InvalidateCacheResponse
+				err := proto.Unmarshal(w.Body.Bytes(), &resp)
+				c.Assert(err, qt.IsNil)
+				c.Assert(resp.Removed, qt.Equals, uint64(2))
+				c.Assert(resp.RemovedSegments, qt.Equals, uint64(3))
+			},
+		},
+		{
+			name:       "missing language",
+			setupMocks: func(mockCache *mock.MockCache, mockAutoCC *mock.MockAutoCC) {},
+			test: func(c *qt.C, server *server) {
+				w := httptest.NewRecorder()
+				r := httptest.NewRequest("DELETE", "/cache/languages/", nil)
+
+				server.handlerInvalidateLanguage(w, r)
+
+				c.Assert(w.Code, qt.Equals, http.StatusBadRequest)
+			},
+		},
+		{
+			name: "translation memory error",
+			setupMocks: func(mockCache *mock.MockCache, mockAutoCC *mock.MockAutoCC) {
+				mockCache.EXPECT().Invalidate(gomock.Any(), "language:pl").Return(0, nil)
+				mockAutoCC.EXPECT().RemoveTranslationMemory(gomock.Any(), "pl").Return(0, errors.New("error"))
+			},
+			test: func(c *qt.C, server *server) {
+				w := httptest.NewRecorder()
+				r := httptest.NewRequest("DELETE", "/cache/languages/pl", nil)
+				r.SetPathValue("language", "pl")
+
+				server.handlerInvalidateLanguage(w, r)
+
+				c.Assert(w.Code, qt.Equals, http.StatusInternalServerError)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		c.Run(tc.name, func(c *qt.C) {
+			ctrl := gomock.NewController(c)
+
+			mockCache := mock.NewMockCache(ctrl)
+			mockAutoCC := mock.NewMockAutoCC(ctrl)
+			tc.setupMocks(mockCache, mockAutoCC)
+
+			s := New(mockCache, nil, nil, nil, mockAutoCC, nil)
 			tc.test(c, s)
 		})
 	}
@@ -1050,7 +2600,7 @@ func TestGetMux(t *testing.T) {
 			service := mock.NewMockCredentials(ctrl)
 			tc.setupMock(service)
 
-			s := New(nil, service, a, nil, nil)
+			s := New(nil, service, a, nil, nil, nil)
 			mux := s.getMux()
 
 			w := httptest.NewRecorder()
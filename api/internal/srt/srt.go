@@ -4,12 +4,13 @@ import (
 	"fmt"
 	"strconv"
 	"strings"
+	"time"
 )
 
 type line struct {
 	Number int
-	Start  string
-	End    string
+	Start  time.Duration
+	End    time.Duration
 	Text   string
 }
 
@@ -18,9 +19,107 @@ type Srt struct {
 	Lines []line
 }
 
+// Format is a subtitles file format.
+type Format string
+
+const (
+	FormatSrt Format = "srt"
+	FormatVtt Format = "vtt"
+	FormatTxt Format = "txt"
+)
+
+// IsValid reports whether the format is supported.
+func (f Format) IsValid() bool {
+	switch f {
+	case FormatSrt, FormatVtt, FormatTxt:
+		return true
+	default:
+		return false
+	}
+}
+
+// ContentType returns the MIME type of the format.
+func (f Format) ContentType() string {
+	switch f {
+	case FormatSrt:
+		return "application/x-subrip"
+	case FormatVtt:
+		return "text/vtt"
+	default:
+		return "text/plain; charset=utf-8"
+	}
+}
+
+// ParseFormat parses subtitles in the given format.
+func ParseFormat(data string, format Format) (*Srt, error) {
+	switch format {
+	case FormatSrt:
+		return Parse(data)
+	case FormatVtt:
+		return ParseVtt(data)
+	default:
+		return nil, fmt.Errorf("unsupported format: %s", format)
+	}
+}
+
+// splitLines splits the data into lines, dropping the BOM and carriage returns.
+func splitLines(data string) []string {
+	data = strings.TrimPrefix(data, "\ufeff")
+	data = strings.ReplaceAll(data, "\r\n", "\n")
+	return strings.Split(data, "\n")
+}
+
+// parseTimestamp parses a `[hh:]mm:ss,ttt` timestamp. The milliseconds can also be separated with a dot.
+// The fraction of a second is scaled by its number of digits, digits after the milliseconds are dropped.
+func parseTimestamp(timestamp string) (time.Duration, error) {
+	timestamp = strings.Replace(strings.TrimSpace(timestamp), ",", ".", 1)
+
+	parts := strings.Split(timestamp, ":")
+	if len(parts) == 2 {
+		parts = append([]string{"0"}, parts...)
+	}
+	if len(parts) != 3 {
+		return 0, fmt.Errorf("invalid timestamp: %s", timestamp)
+	}
+
+	secondsParts := strings.Split(parts[2], ".")
+	if len(secondsParts) != 2 {
+		return 0, fmt.Errorf("invalid timestamp: %s", timestamp)
+	}
+
+	fraction := secondsParts[1]
+	if fraction == "" || len(fraction) > 9 || strings.Trim(fraction, "0123456789") != "" {
+		return 0, fmt.Errorf("invalid timestamp: %s", timestamp)
+	}
+	fraction = (fraction + "00")[:3]
+
+	var values [4]int
+	for i, v := range []string{parts[0], parts[1], secondsParts[0], fraction} {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 0 {
+			return 0, fmt.Errorf("invalid timestamp: %s", timestamp)
+		}
+		values[i] = n
+	}
+
+	return time.Duration(values[0])*time.Hour +
+		time.Duration(values[1])*time.Minute +
+		time.Duration(values[2])*time.Second +
+		time.Duration(values[3])*time.Millisecond, nil
+}
+
+// formatTimestamp formats the duration as a `hh:mm:ss<sep>ttt` timestamp.
+func formatTimestamp(d time.Duration, sep byte) string {
+	if d < 0 {
+		d = 0
+	}
+	ms := d.Milliseconds()
+	return fmt.Sprintf("%02d:%02d:%02d%c%03d", ms/3_600_000, ms/60_000%60, ms/1000%60, sep, ms%1000)
+}
+
 // New creates a new SRT instance.
 func Parse(data string) (*Srt, error) {
-	lines := strings.Split(data, "\n")
+	lines := splitLines(data)
 
 	getLine := func(i int) (string, error) {
 		if i < 0 || i >= len(lines) {
@@ -56,20 +155,35 @@ func Parse(data string) (*Srt, error) {
 			return nil, err
 		}
 
-		timeRange := strings.Split(strings.TrimSpace(lines[i]), " --> ")
+		timeRange := strings.Split(lineText, " --> ")
 		if len(timeRange) != 2 {
 			return nil, fmt.Errorf("invalid time range: %s", lineText)
 		}
-		line.Start = timeRange[0]
-		line.End = timeRange[1]
+		line.Start, err = parseTimestamp(timeRange[0])
+		if err != nil {
+			return nil, err
+		}
+		line.End, err = parseTimestamp(timeRange[1])
+		if err != nil {
+			return nil, err
+		}
 
-		// Parse the text.
+		// Parse the text, which spans until the next empty line.
 		i++
 		lineText, err = getLine(i)
 		if err != nil {
 			return nil, err
 		}
-		line.Text = lineText
+		text := []string{lineText}
+		for i+1 < len(lines) {
+			next, _ := getLine(i + 1)
+			if next == "" {
+				break
+			}
+			text = append(text, next)
+			i++
+		}
+		line.Text = strings.Join(text, "\n")
 
 		srt.Lines = append(srt.Lines, line)
 	}
@@ -83,9 +197,9 @@ func (s *Srt) String() string {
 	for _, l := range s.Lines {
 		sb.WriteString(strconv.Itoa(l.Number))
 		sb.WriteString("\n")
-		sb.WriteString(l.Start)
+		sb.WriteString(formatTimestamp(l.Start, ','))
 		sb.WriteString(" --> ")
-		sb.WriteString(l.End)
+		sb.WriteString(formatTimestamp(l.End, ','))
 		sb.WriteString("\n")
 		sb.WriteString(l.Text)
 		sb.WriteString("\n\n")
@@ -93,6 +207,30 @@ func (s *Srt) String() string {
 	return sb.String()
 }
 
+// PlainText serializes the text of the subtitles without timing information.
+func (s *Srt) PlainText() string {
+	var sb strings.Builder
+	for _, l := range s.Lines {
+		sb.WriteString(l.Text)
+		sb.WriteString("\n")
+	}
+	return sb.String()
+}
+
+// Encode serializes the SRT instance to the given format.
+func (s *Srt) Encode(format Format) (string, error) {
+	switch format {
+	case FormatSrt:
+		return s.String(), nil
+	case FormatVtt:
+		return s.Vtt(), nil
+	case FormatTxt:
+		return s.PlainText(), nil
+	default:
+		return "", fmt.Errorf("unsupported format: %s", format)
+	}
+}
+
 // Text returns the text of the subtitles.
 func (s *Srt) Text() []string {
 	var text []string
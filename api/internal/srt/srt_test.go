@@ -0,0 +1,150 @@
+package srt_test
+
+import (
+	"testing"
+	"time"
+
+	qt "github.com/frankban/quicktest"
+
+	"github.com/pkulik0/autocc/api/internal/srt"
+)
+
+func TestParse(t *testing.T) {
+	c := qt.New(t)
+
+	data := "1\r\n00:00:01,000 --> 00:00:02,500\r\nHello\r\nworld\r\n\r\n2\r\n00:00:03,000 --> 00:00:04,000\r\nBye\r\n"
+
+	s, err := srt.Parse(data)
+	c.Assert(err, qt.IsNil)
+	c.Assert(s.Lines, qt.HasLen, 2)
+	c.Assert(s.Lines[0].Start, qt.Equals, time.Second)
+	c.Assert(s.Lines[0].End, qt.Equals, 2500*time.Millisecond)
+	c.Assert(s.Lines[0].Text, qt.Equals, "Hello\nworld")
+	c.Assert(s.Lines[1].Text, qt.Equals, "Bye")
+
+	reparsed, err := srt.Parse(s.String())
+	c.Assert(err, qt.IsNil)
+	c.Assert(reparsed, qt.DeepEquals, s)
+
+	_, err = srt.Parse("invalid")
+	c.Assert(err, qt.IsNotNil)
+
+	_, err = srt.Parse("1\n00:00:01,000\nHello\n")
+	c.Assert(err, qt.IsNotNil)
+
+	_, err = srt.Parse("1\n00:00:xx,000 --> 00:00:02,000\nHello\n")
+	c.Assert(err, qt.IsNotNil)
+}
+
+func TestParseTimestamp(t *testing.T) {
+	c := qt.New(t)
+
+	testCases := []struct {
+		timestamp string
+		start     time.Duration
+		valid     bool
+	}{
+		{timestamp: "00:00:01,500", start: 1500 * time.Millisecond, valid: true},
+		{timestamp: "00:00:01.500", start: 1500 * time.Millisecond, valid: true},
+		{timestamp: "00:01,500", start: 1500 * time.Millisecond, valid: true},
+		{timestamp: "00:00:01,5", start: 1500 * time.Millisecond, valid: true},
+		{timestamp: "00:00:01,05", start: 1050 * time.Millisecond, valid: true},
+		{timestamp: "00:00:01,5000", start: 1500 * time.Millisecond, valid: true},
+		{timestamp: "00:00:01,123456789", start: 1123 * time.Millisecond, valid: true},
+		{timestamp: "01:02:03,004", start: time.Hour + 2*time.Minute + 3*time.Second + 4*time.Millisecond, valid: true},
+		{timestamp: "00:00:01,", valid: false},
+		{timestamp: "00:00:01,1234567890", valid: false},
+		{timestamp: "00:00:01,+50", valid: false},
+		{timestamp: "00:00:01", valid: false},
+	}
+
+	for _, tc := range testCases {
+		c.Run(tc.timestamp, func(c *qt.C) {
+			s, err := srt.Parse("1\n" + tc.timestamp + " --> 01:00:00,000\nHello\n")
+			if !tc.valid {
+				c.Assert(err, qt.IsNotNil)
+				return
+			}
+			c.Assert(err, qt.IsNil)
+			c.Assert(s.Lines[0].Start, qt.Equals, tc.start)
+		})
+	}
+}
+
+func TestParseVtt(t *testing.T) {
+	c := qt.New(t)
+
+	data := `WEBVTT - Some title
+
+NOTE this is a comment
+
+STYLE
+::cue { color: red }
+
+intro
+00:01.000 --> 00:02.500 align:start position:10%
+Hello
+world
+
+01:00:03.000 --> 01:00:04.000
+Bye
+`
+
+	s, err := srt.ParseVtt(data)
+	c.Assert(err, qt.IsNil)
+	c.Assert(s.Lines, qt.HasLen, 2)
+	c.Assert(s.Lines[0].Number, qt.Equals, 1)
+	c.Assert(s.Lines[0].Start, qt.Equals, time.Second)
+	c.Assert(s.Lines[0].End, qt.Equals, 2500*time.Millisecond)
+	c.Assert(s.Lines[0].Text, qt.Equals, "Hello\nworld")
+	c.Assert(s.Lines[1].Number, qt.Equals, 2)
+	c.Assert(s.Lines[1].Start, qt.Equals, time.Hour+3*time.Second)
+	c.Assert(s.Lines[1].Text, qt.Equals, "Bye")
+
+	_, err = srt.ParseVtt("1\n00:00:01,000 --> 00:00:02,000\nHello\n")
+	c.Assert(err, qt.IsNotNil)
+
+	_, err = srt.ParseVtt("WEBVTT\n\n00:01 --> 00:02\nHello\n")
+	c.Assert(err, qt.IsNotNil)
+}
+
+func TestParseFormat(t *testing.T) {
+	c := qt.New(t)
+
+	s, err := srt.ParseFormat("WEBVTT\n\n00:01.000 --> 00:02.000\nHello\n", srt.FormatVtt)
+	c.Assert(err, qt.IsNil)
+	c.Assert(s.Lines, qt.HasLen, 1)
+
+	s, err = srt.ParseFormat("1\n00:00:01,000 --> 00:00:02,000\nHello\n", srt.FormatSrt)
+	c.Assert(err, qt.IsNil)
+	c.Assert(s.Lines, qt.HasLen, 1)
+
+	_, err = srt.ParseFormat("", "ass")
+	c.Assert(err, qt.IsNotNil)
+}
+
+func TestEncode(t *testing.T) {
+	c := qt.New(t)
+
+	s, err := srt.Parse("1\n00:00:01,000 --> 00:00:02,500\nHello\nworld\n\n2\n00:00:03,000 --> 00:00:04,000\nBye\n")
+	c.Assert(err, qt.IsNil)
+
+	data, err := s.Encode(srt.FormatSrt)
+	c.Assert(err, qt.IsNil)
+	c.Assert(data, qt.Equals, s.String())
+
+	data, err = s.Encode(srt.FormatVtt)
+	c.Assert(err, qt.IsNil)
+	c.Assert(data, qt.Equals, "WEBVTT\n\n00:00:01.000 --> 00:00:02.500\nHello\nworld\n\n00:00:03.000 --> 00:00:04.000\nBye\n\n")
+
+	reparsed, err := srt.ParseVtt(data)
+	c.Assert(err, qt.IsNil)
+	c.Assert(reparsed, qt.DeepEquals, s)
+
+	data, err = s.Encode(srt.FormatTxt)
+	c.Assert(err, qt.IsNil)
+	c.Assert(data, qt.Equals, "Hello\nworld\nBye\n")
+
+	_, err = s.Encode("ass")
+	c.Assert(err, qt.IsNotNil)
+}
@@ -0,0 +1,182 @@
+package srt
+
+import (
+	"fmt"
+	"html"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var (
+	// Matches HTML-like tags (`<i>`, `</b>`, `<font color="red">`) and SSA override codes (`{\an8}`).
+	tagRegex = regexp.MustCompile(`</?[a-zA-Z][^<>]*>|\{\\[^{}]*\}`)
+	// Matches the XML placeholders produced by ProtectTags.
+	placeholderRegex = regexp.MustCompile(`<g\s+id=["'](\d+)["']\s*>|</g\s*>|<x\s+id=["'](\d+)["']\s*/>`)
+)
+
+type tagKind int
+
+const (
+	tagText tagKind = iota
+	tagOpen
+	tagClose
+	tagStandalone
+)
+
+type token struct {
+	kind tagKind
+	raw  string
+	name string
+	// pair is the index of the matching closing token of an opening one.
+	pair int
+}
+
+func tokenize(text string) []token {
+	var tokens []token
+	last := 0
+	for _, loc := range tagRegex.FindAllStringIndex(text, -1) {
+		if loc[0] > last {
+			tokens = append(tokens, token{kind: tagText, raw: text[last:loc[0]]})
+		}
+		last = loc[1]
+
+		raw := text[loc[0]:loc[1]]
+		switch {
+		case strings.HasPrefix(raw, "{"), strings.HasSuffix(raw, "/>"):
+			tokens = append(tokens, token{kind: tagStandalone, raw: raw})
+		case strings.HasPrefix(raw, "</"):
+			tokens = append(tokens, token{kind: tagClose, raw: raw, name: tagName(raw[2:])})
+		default:
+			tokens = append(tokens, token{kind: tagOpen, raw: raw, name: tagName(raw[1:])})
+		}
+	}
+	if last < len(text) {
+		tokens = append(tokens, token{kind: tagText, raw: text[last:]})
+	}
+
+	// Pair opening and closing tags. Anything that can't be paired is kept as a standalone tag.
+	var stack []int
+	for i := range tokens {
+		switch tokens[i].kind {
+		case tagOpen:
+			stack = append(stack, i)
+		case tagClose:
+			if len(stack) == 0 || tokens[stack[len(stack)-1]].name != tokens[i].name {
+				tokens[i].kind = tagStandalone
+				continue
+			}
+			tokens[stack[len(stack)-1]].pair = i
+			stack = stack[:len(stack)-1]
+		}
+	}
+	for _, i := range stack {
+		tokens[i].kind = tagStandalone
+	}
+
+	return tokens
+}
+
+func tagName(s string) string {
+	end := strings.IndexAny(s, " \t>/")
+	if end < 0 {
+		return strings.ToLower(s)
+	}
+	return strings.ToLower(s[:end])
+}
+
+// Markup holds formatting tags of a cue replaced with placeholders by ProtectTags.
+type Markup struct {
+	prefix  string
+	opening map[int]string
+	closing map[int]string
+	single  map[int]string
+}
+
+// ProtectTags replaces formatting tags in the text with XML placeholders which survive translation with XML tag handling.
+// Paired tags become `<g id="N">...</g>` elements so they can follow the words they wrap, other tags become `<x id="N"/>`.
+// Tags at the start of the text, like positioning codes, are removed and restored verbatim.
+func ProtectTags(text string) (string, *Markup) {
+	markup := &Markup{
+		opening: make(map[int]string),
+		closing: make(map[int]string),
+		single:  make(map[int]string),
+	}
+
+	tokens := tokenize(text)
+	i := 0
+	for ; i < len(tokens) && tokens[i].kind == tagStandalone; i++ {
+		markup.prefix += tokens[i].raw
+	}
+
+	var sb strings.Builder
+	id := 0
+	for ; i < len(tokens); i++ {
+		t := tokens[i]
+		switch t.kind {
+		case tagText:
+			sb.WriteString(html.EscapeString(t.raw))
+		case tagOpen:
+			markup.opening[id] = t.raw
+			markup.closing[id] = tokens[t.pair].raw
+			fmt.Fprintf(&sb, `<g id="%d">`, id)
+			id++
+		case tagClose:
+			sb.WriteString("</g>")
+		case tagStandalone:
+			markup.single[id] = t.raw
+			fmt.Fprintf(&sb, `<x id="%d"/>`, id)
+			id++
+		}
+	}
+
+	return sb.String(), markup
+}
+
+// StripTags removes formatting tags from the text.
+func StripTags(text string) string {
+	return tagRegex.ReplaceAllString(text, "")
+}
+
+// Restore replaces the placeholders in the translated text with the original tags.
+func (m *Markup) Restore(text string) (string, error) {
+	var sb strings.Builder
+	sb.WriteString(m.prefix)
+
+	var stack []int
+	last := 0
+	for _, loc := range placeholderRegex.FindAllStringSubmatchIndex(text, -1) {
+		sb.WriteString(html.UnescapeString(text[last:loc[0]]))
+		last = loc[1]
+
+		switch {
+		case loc[2] >= 0:
+			id, _ := strconv.Atoi(text[loc[2]:loc[3]])
+			opening, ok := m.opening[id]
+			if !ok {
+				return "", fmt.Errorf("unknown tag id: %d", id)
+			}
+			sb.WriteString(opening)
+			stack = append(stack, id)
+		case loc[4] >= 0:
+			id, _ := strconv.Atoi(text[loc[4]:loc[5]])
+			single, ok := m.single[id]
+			if !ok {
+				return "", fmt.Errorf("unknown tag id: %d", id)
+			}
+			sb.WriteString(single)
+		default:
+			if len(stack) == 0 {
+				return "", fmt.Errorf("unexpected closing tag")
+			}
+			sb.WriteString(m.closing[stack[len(stack)-1]])
+			stack = stack[:len(stack)-1]
+		}
+	}
+	if len(stack) > 0 {
+		return "", fmt.Errorf("unclosed tag")
+	}
+	sb.WriteString(html.UnescapeString(text[last:]))
+
+	return sb.String(), nil
+}
@@ -0,0 +1,123 @@
+package srt_test
+
+import (
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+
+	"github.com/pkulik0/autocc/api/internal/srt"
+)
+
+func TestProtectTags(t *testing.T) {
+	c := qt.New(t)
+
+	tt := []struct {
+		name      string
+		text      string
+		protected string
+	}{
+		{
+			name:      "plain",
+			text:      "Hello & goodbye",
+			protected: "Hello &amp; goodbye",
+		},
+		{
+			name:      "italic",
+			text:      "<i>Hello</i> world",
+			protected: `<g id="0">Hello</g> world`,
+		},
+		{
+			name:      "nested",
+			text:      `<b>Hello <font color="#ff0000"><i>cruel</i></font></b> world`,
+			protected: `<g id="0">Hello <g id="1"><g id="2">cruel</g></g></g> world`,
+		},
+		{
+			name:      "positioning",
+			text:      `{\an8}<i>Hello</i>`,
+			protected: `<g id="0">Hello</g>`,
+		},
+		{
+			name:      "positioning inside",
+			text:      `Hello {\an8}world`,
+			protected: `Hello <x id="0"/>world`,
+		},
+		{
+			name:      "unmatched",
+			text:      "<i>Hello</b> world",
+			protected: `Hello<x id="0"/> world`,
+		},
+		{
+			name:      "not a tag",
+			text:      "1 < 2 > 0",
+			protected: "1 &lt; 2 &gt; 0",
+		},
+	}
+
+	for _, tc := range tt {
+		c.Run(tc.name, func(c *qt.C) {
+			protected, markup := srt.ProtectTags(tc.text)
+			c.Assert(protected, qt.Equals, tc.protected)
+
+			restored, err := markup.Restore(protected)
+			c.Assert(err, qt.IsNil)
+			c.Assert(restored, qt.Equals, tc.text)
+		})
+	}
+}
+
+func TestStripTags(t *testing.T) {
+	c := qt.New(t)
+
+	c.Assert(srt.StripTags(`{\an8}<b>Hello <font color="#ff0000">cruel</font></b> world & 1 < 2`), qt.Equals, "Hello cruel world & 1 < 2")
+}
+
+func TestRestoreTags(t *testing.T) {
+	c := qt.New(t)
+
+	_, markup := srt.ProtectTags(`{\an8}<b>Hello <i>cruel</i></b> world &`)
+
+	tt := []struct {
+		name       string
+		translated string
+		restored   string
+		err        bool
+	}{
+		{
+			name:       "moved",
+			translated: `Witaj <g id="0">okrutny <g id="1">świecie</g></g> &amp;`,
+			restored:   `{\an8}Witaj <b>okrutny <i>świecie</i></b> &`,
+		},
+		{
+			name:       "dropped",
+			translated: `Witaj świecie`,
+			restored:   `{\an8}Witaj świecie`,
+		},
+		{
+			name:       "unknown",
+			translated: `<g id="5">Witaj</g>`,
+			err:        true,
+		},
+		{
+			name:       "unclosed",
+			translated: `<g id="0">Witaj`,
+			err:        true,
+		},
+		{
+			name:       "unexpected closing",
+			translated: `Witaj</g>`,
+			err:        true,
+		},
+	}
+
+	for _, tc := range tt {
+		c.Run(tc.name, func(c *qt.C) {
+			restored, err := markup.Restore(tc.translated)
+			if tc.err {
+				c.Assert(err, qt.IsNotNil)
+				return
+			}
+			c.Assert(err, qt.IsNil)
+			c.Assert(restored, qt.Equals, tc.restored)
+		})
+	}
+}
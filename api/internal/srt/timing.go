@@ -0,0 +1,127 @@
+package srt
+
+import (
+	"errors"
+	"time"
+)
+
+const (
+	// Framerate23976 is the NTSC film framerate.
+	Framerate23976 = 24000.0 / 1001.0
+	// Framerate25 is the PAL framerate.
+	Framerate25 = 25.0
+)
+
+var (
+	// ErrInvalidTiming is returned when the timing adjustment is invalid.
+	ErrInvalidTiming = errors.New("srt: invalid timing adjustment")
+)
+
+// SyncPoint maps a time in the subtitles to the time it should appear at.
+type SyncPoint struct {
+	From time.Duration
+	To   time.Duration
+}
+
+// Shift moves all cues by the offset. Cues moved before zero are clamped to it.
+func (s *Srt) Shift(offset time.Duration) {
+	for i := range s.Lines {
+		s.Lines[i].Start = max(s.Lines[i].Start+offset, 0)
+		s.Lines[i].End = max(s.Lines[i].End+offset, 0)
+	}
+}
+
+// scale maps every timestamp t to t*factor+offset.
+func (s *Srt) scale(factor float64, offset time.Duration) {
+	apply := func(t time.Duration) time.Duration {
+		return max(time.Duration(float64(t)*factor)+offset, 0)
+	}
+	for i := range s.Lines {
+		s.Lines[i].Start = apply(s.Lines[i].Start)
+		s.Lines[i].End = apply(s.Lines[i].End)
+	}
+}
+
+// Sync linearly scales the timing so that both sync points are met.
+func (s *Srt) Sync(first, second SyncPoint) error {
+	if first.From == second.From {
+		return ErrInvalidTiming
+	}
+
+	factor := float64(second.To-first.To) / float64(second.From-first.From)
+	if factor <= 0 {
+		return ErrInvalidTiming
+	}
+	offset := first.To - time.Duration(float64(first.From)*factor)
+
+	s.scale(factor, offset)
+	return nil
+}
+
+// ConvertFramerate converts the timing of subtitles made for a video with the source framerate to the target one.
+func (s *Srt) ConvertFramerate(from, to float64) error {
+	if from <= 0 || to <= 0 {
+		return ErrInvalidTiming
+	}
+
+	s.scale(from/to, 0)
+	return nil
+}
+
+// Clamp makes every cue last at least minDuration and at most maxDuration.
+// Cues are not extended past the start of the next one. A zero bound is ignored.
+func (s *Srt) Clamp(minDuration, maxDuration time.Duration) error {
+	if minDuration < 0 || maxDuration < 0 || (maxDuration != 0 && minDuration > maxDuration) {
+		return ErrInvalidTiming
+	}
+
+	for i := range s.Lines {
+		l := &s.Lines[i]
+		if maxDuration != 0 && l.End-l.Start > maxDuration {
+			l.End = l.Start + maxDuration
+		}
+		if minDuration != 0 && l.End-l.Start < minDuration {
+			end := l.Start + minDuration
+			if i+1 < len(s.Lines) && s.Lines[i+1].Start < end {
+				end = s.Lines[i+1].Start
+			}
+			if end > l.End {
+				l.End = end
+			}
+		}
+	}
+	return nil
+}
+
+// Timing describes operations adjusting the timing of subtitles.
+// They are applied in order: sync, framerate conversion, shift and clamping. Zero values are skipped.
+type Timing struct {
+	SyncPoints    []SyncPoint
+	FramerateFrom float64
+	FramerateTo   float64
+	Shift         time.Duration
+	MinDuration   time.Duration
+	MaxDuration   time.Duration
+}
+
+// AdjustTiming applies the timing operations to the subtitles.
+func (s *Srt) AdjustTiming(t Timing) error {
+	switch len(t.SyncPoints) {
+	case 0:
+	case 2:
+		if err := s.Sync(t.SyncPoints[0], t.SyncPoints[1]); err != nil {
+			return err
+		}
+	default:
+		return ErrInvalidTiming
+	}
+
+	if t.FramerateFrom != 0 || t.FramerateTo != 0 {
+		if err := s.ConvertFramerate(t.FramerateFrom, t.FramerateTo); err != nil {
+			return err
+		}
+	}
+
+	s.Shift(t.Shift)
+	return s.Clamp(t.MinDuration, t.MaxDuration)
+}
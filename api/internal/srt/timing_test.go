@@ -0,0 +1,97 @@
+package srt_test
+
+import (
+	"testing"
+	"time"
+
+	qt "github.com/frankban/quicktest"
+
+	"github.com/pkulik0/autocc/api/internal/srt"
+)
+
+func parseTiming(c *qt.C) *srt.Srt {
+	s, err := srt.Parse("1\n00:00:10,000 --> 00:00:12,000\nFirst\n\n2\n00:00:20,000 --> 00:00:20,100\nSecond\n\n3\n00:00:20,500 --> 00:00:40,000\nThird\n")
+	c.Assert(err, qt.IsNil)
+	return s
+}
+
+func TestShift(t *testing.T) {
+	c := qt.New(t)
+	s := parseTiming(c)
+
+	s.Shift(1500 * time.Millisecond)
+	c.Assert(s.Lines[0].Start, qt.Equals, 11500*time.Millisecond)
+	c.Assert(s.Lines[2].End, qt.Equals, 41500*time.Millisecond)
+
+	s.Shift(-15 * time.Second)
+	c.Assert(s.Lines[0].Start, qt.Equals, time.Duration(0))
+	c.Assert(s.Lines[0].End, qt.Equals, time.Duration(0))
+	c.Assert(s.Lines[1].Start, qt.Equals, 6500*time.Millisecond)
+}
+
+func TestSync(t *testing.T) {
+	c := qt.New(t)
+	s := parseTiming(c)
+
+	err := s.Sync(srt.SyncPoint{From: 10 * time.Second, To: 11 * time.Second}, srt.SyncPoint{From: 20 * time.Second, To: 31 * time.Second})
+	c.Assert(err, qt.IsNil)
+	c.Assert(s.Lines[0].Start, qt.Equals, 11*time.Second)
+	c.Assert(s.Lines[0].End, qt.Equals, 15*time.Second)
+	c.Assert(s.Lines[1].Start, qt.Equals, 31*time.Second)
+
+	err = s.Sync(srt.SyncPoint{From: time.Second}, srt.SyncPoint{From: time.Second, To: time.Second})
+	c.Assert(err, qt.Equals, srt.ErrInvalidTiming)
+
+	err = s.Sync(srt.SyncPoint{From: time.Second, To: 2 * time.Second}, srt.SyncPoint{From: 2 * time.Second, To: time.Second})
+	c.Assert(err, qt.Equals, srt.ErrInvalidTiming)
+}
+
+func TestConvertFramerate(t *testing.T) {
+	c := qt.New(t)
+	s := parseTiming(c)
+
+	err := s.ConvertFramerate(srt.Framerate25, srt.Framerate23976)
+	c.Assert(err, qt.IsNil)
+	c.Assert(s.Lines[0].Start.Milliseconds(), qt.Equals, int64(10427))
+
+	err = s.ConvertFramerate(srt.Framerate23976, srt.Framerate25)
+	c.Assert(err, qt.IsNil)
+	c.Assert(s.Lines[0].Start.Round(time.Millisecond), qt.Equals, 10*time.Second)
+
+	err = s.ConvertFramerate(0, srt.Framerate25)
+	c.Assert(err, qt.Equals, srt.ErrInvalidTiming)
+}
+
+func TestClamp(t *testing.T) {
+	c := qt.New(t)
+	s := parseTiming(c)
+
+	err := s.Clamp(time.Second, 5*time.Second)
+	c.Assert(err, qt.IsNil)
+	c.Assert(s.Lines[0].End, qt.Equals, 12*time.Second)
+	// Extended only up to the start of the next cue.
+	c.Assert(s.Lines[1].End, qt.Equals, 20500*time.Millisecond)
+	c.Assert(s.Lines[2].End, qt.Equals, 25500*time.Millisecond)
+
+	err = s.Clamp(5*time.Second, time.Second)
+	c.Assert(err, qt.Equals, srt.ErrInvalidTiming)
+}
+
+func TestAdjustTiming(t *testing.T) {
+	c := qt.New(t)
+	s := parseTiming(c)
+
+	err := s.AdjustTiming(srt.Timing{
+		Shift:       time.Second,
+		MaxDuration: 10 * time.Second,
+	})
+	c.Assert(err, qt.IsNil)
+	c.Assert(s.Lines[0].Start, qt.Equals, 11*time.Second)
+	c.Assert(s.Lines[2].End, qt.Equals, 31500*time.Millisecond)
+
+	err = s.AdjustTiming(srt.Timing{SyncPoints: []srt.SyncPoint{{}}})
+	c.Assert(err, qt.Equals, srt.ErrInvalidTiming)
+
+	err = s.AdjustTiming(srt.Timing{FramerateFrom: srt.Framerate25})
+	c.Assert(err, qt.Equals, srt.ErrInvalidTiming)
+}
@@ -0,0 +1,92 @@
+package srt
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	vttHeader = "WEBVTT"
+)
+
+// ParseVtt parses subtitles in the WebVTT format.
+func ParseVtt(data string) (*Srt, error) {
+	lines := splitLines(data)
+	if len(lines) == 0 || !strings.HasPrefix(strings.TrimSpace(lines[0]), vttHeader) {
+		return nil, fmt.Errorf("missing %s header", vttHeader)
+	}
+
+	// Split the file into blocks separated by empty lines, skipping the header block.
+	var blocks [][]string
+	var block []string
+	for _, l := range lines[1:] {
+		l = strings.Trim(l, " \t")
+		if l == "" {
+			if len(block) > 0 {
+				blocks = append(blocks, block)
+			}
+			block = nil
+			continue
+		}
+		block = append(block, l)
+	}
+	if len(block) > 0 {
+		blocks = append(blocks, block)
+	}
+
+	srt := &Srt{}
+	for _, b := range blocks {
+		// Comments, styles and regions are not cues.
+		if strings.HasPrefix(b[0], "NOTE") || b[0] == "STYLE" || b[0] == "REGION" {
+			continue
+		}
+
+		// The cue identifier is optional.
+		if !strings.Contains(b[0], "-->") {
+			b = b[1:]
+		}
+		if len(b) == 0 || !strings.Contains(b[0], "-->") {
+			continue
+		}
+
+		timeRange := strings.Split(b[0], "-->")
+		start, err := parseTimestamp(timeRange[0])
+		if err != nil {
+			return nil, err
+		}
+		// The end timestamp can be followed by cue settings.
+		endFields := strings.Fields(timeRange[1])
+		if len(endFields) == 0 {
+			return nil, fmt.Errorf("invalid time range: %s", b[0])
+		}
+		end, err := parseTimestamp(endFields[0])
+		if err != nil {
+			return nil, err
+		}
+
+		srt.Lines = append(srt.Lines, line{
+			Number: len(srt.Lines) + 1,
+			Start:  start,
+			End:    end,
+			Text:   strings.Join(b[1:], "\n"),
+		})
+	}
+
+	return srt, nil
+}
+
+// Vtt serializes the SRT instance to the WebVTT format.
+func (s *Srt) Vtt() string {
+	var sb strings.Builder
+	sb.WriteString(vttHeader)
+	sb.WriteString("\n\n")
+	for _, l := range s.Lines {
+		sb.WriteString(formatTimestamp(l.Start, '.'))
+		sb.WriteString(" --> ")
+		sb.WriteString(formatTimestamp(l.End, '.'))
+		sb.WriteString("\n")
+		sb.WriteString(l.Text)
+		sb.WriteString("\n\n")
+	}
+	return sb.String()
+}
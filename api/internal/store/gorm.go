@@ -2,9 +2,14 @@ package store
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
+	"reflect"
+	"strings"
 	"time"
 
+	"github.com/glebarez/sqlite"
 	"github.com/rs/zerolog/log"
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
@@ -13,16 +18,17 @@ import (
 	"github.com/pkulik0/autocc/api/internal/errs"
 	"github.com/pkulik0/autocc/api/internal/model"
 	"github.com/pkulik0/autocc/api/internal/quota"
+	"github.com/pkulik0/autocc/api/internal/secret"
 )
 
 var _ Store = &gormStore{}
 
 type gormStore struct {
-	db *gorm.DB
+	db      *gorm.DB
+	keyring *secret.Keyring
 }
 
-// New creates a new store with a connection to the PostgreSQL database.
-func New(host string, port uint16, user, pass, dbName string) (*gormStore, error) {
+func openPostgres(host string, port uint16, user, pass, dbName string) (*gorm.DB, error) {
 	dsn := fmt.Sprintf("host=%s port=%d user=%s password=%s dbname=%s sslmode=disable", host, port, user, pass, dbName)
 
 	db, err := gorm.Open(postgres.New(postgres.Config{
@@ -33,10 +39,119 @@ func New(host string, port uint16, user, pass, dbName string) (*gormStore, error
 	}
 	log.Debug().Str("host", host).Uint16("port", port).Str("user", user).Str("db", dbName).Msg("connected to psql")
 
-	db.AutoMigrate(&model.CredentialsGoogle{}, &model.CredentialsDeepL{}, &model.SessionGoogle{}, &model.SessionState{})
-	log.Debug().Msg("migrated database models")
+	return db, nil
+}
+
+func openSQLite(path string) (*gorm.DB, error) {
+	// Transactions take the write lock when they begin, which serializes usage reservations like `FOR UPDATE` does in PostgreSQL.
+	// The driver is written in pure Go, so the binary is built without cgo. Times are written in the format SQLite understands.
+	dsn := fmt.Sprintf("file:%s?_txlock=immediate&_pragma=busy_timeout(5000)&_pragma=foreign_keys(1)&_time_format=sqlite", path)
 
-	return &gormStore{db: db}, nil
+	db, err := gorm.Open(sqlite.Open(dsn), &gorm.Config{})
+	if err != nil {
+		return nil, err
+	}
+	if err := db.Callback().Query().After("gorm:query").Register("autocc:local_times", localTimes); err != nil {
+		return nil, err
+	}
+	if err := db.Exec("SELECT 1").Error; err != nil {
+		return nil, err
+	}
+	log.Debug().Str("path", path).Msg("opened sqlite database")
+
+	return db, nil
+}
+
+// localTimes converts times read from SQLite to the local time zone, the driver reads them in UTC.
+// The PostgreSQL driver returns local times, so both stores return the same values.
+func localTimes(db *gorm.DB) {
+	if db.Error != nil || db.Statement.Schema == nil {
+		return
+	}
+
+	ctx := db.Statement.Context
+	convert := func(value reflect.Value) {
+		if value.Kind() != reflect.Struct {
+			return
+		}
+		for _, field := range db.Statement.Schema.Fields {
+			v, zero := field.ValueOf(ctx, value)
+			if zero {
+				continue
+			}
+			var err error
+			switch t := v.(type) {
+			case time.Time:
+				err = field.Set(ctx, value, t.Local())
+			case *time.Time:
+				err = field.Set(ctx, value, t.Local())
+			case gorm.DeletedAt:
+				err = field.Set(ctx, value, gorm.DeletedAt{Time: t.Time.Local(), Valid: t.Valid})
+			}
+			if err != nil {
+				log.Error().Err(err).Str("field", field.Name).Msg("failed to convert time")
+			}
+		}
+	}
+
+	switch value := db.Statement.ReflectValue; value.Kind() {
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < value.Len(); i++ {
+			convert(reflect.Indirect(value.Index(i)))
+		}
+	default:
+		convert(value)
+	}
+}
+
+// New creates a new store with a connection to the PostgreSQL database.
+// Pending migrations are applied, it fails if the database schema is newer than supported.
+// Secrets are encrypted with the keyring, if it's nil they are stored in plaintext.
+func New(host string, port uint16, user, pass, dbName string, keyring *secret.Keyring) (*gormStore, error) {
+	db, err := openPostgres(host, port, user, pass, dbName)
+	if err != nil {
+		return nil, err
+	}
+	return newGormStore(db, keyring)
+}
+
+// NewSQLite creates a new store backed by the SQLite database file at the path.
+// Pending migrations are applied, it fails if the database schema is newer than supported.
+// Secrets are encrypted with the keyring, if it's nil they are stored in plaintext.
+func NewSQLite(path string, keyring *secret.Keyring) (*gormStore, error) {
+	db, err := openSQLite(path)
+	if err != nil {
+		return nil, err
+	}
+	return newGormStore(db, keyring)
+}
+
+func newGormStore(db *gorm.DB, keyring *secret.Keyring) (*gormStore, error) {
+	migrator, err := newMigrator(db)
+	if err != nil {
+		return nil, err
+	}
+	count, err := migrator.Up(context.Background())
+	if err != nil {
+		return nil, err
+	}
+	log.Debug().Int("applied", count).Msg("migrated database schema")
+
+	if err := useKeyring(db, keyring); err != nil {
+		return nil, err
+	}
+	if keyring != nil {
+		// Secrets written before encryption was enabled are encrypted once, values encrypted with previous keys are left as they are.
+		count, err := encryptAllSecrets(context.Background(), db, secret.Prefix)
+		if err != nil {
+			return nil, err
+		}
+		if count > 0 {
+			log.Info().Int("count", count).Msg("encrypted plaintext secrets")
+		}
+	}
+
+	return &gormStore{db: db, keyring: keyring}, nil
 }
 
 func (s *gormStore) Transaction(ctx context.Context, f func(ctx context.Context, store Store) error) error {
@@ -45,7 +160,7 @@ func (s *gormStore) Transaction(ctx context.Context, f func(ctx context.Context,
 		return tx.Error
 	}
 
-	err := f(ctx, &gormStore{db: tx})
+	err := f(ctx, &gormStore{db: tx, keyring: s.keyring})
 	if err != nil {
 		tx.Rollback()
 		return err
@@ -138,6 +253,11 @@ func (s *gormStore) RemoveCredentialsGoogle(ctx context.Context, id uint) error
 			return result.Error
 		}
 
+		result = tx.WithContext(ctx).Unscoped().Where("credentials_id = ?", id).Delete(&model.SessionState{})
+		if result.Error != nil {
+			return result.Error
+		}
+
 		return nil
 	})
 }
@@ -150,9 +270,11 @@ func (s *gormStore) RemoveCredentialsDeepL(ctx context.Context, id uint) error {
 	return nil
 }
 
-func (s *gormStore) CreateSessionGoogle(ctx context.Context, userID, accessToken, refreshToken, scopes string, expiry time.Time, credentials model.CredentialsGoogle) (*model.SessionGoogle, error) {
+func (s *gormStore) CreateSessionGoogle(ctx context.Context, userID, channelID, channelTitle, accessToken, refreshToken, scopes string, expiry time.Time, credentials model.CredentialsGoogle) (*model.SessionGoogle, error) {
 	session := &model.SessionGoogle{
 		UserID:        userID,
+		ChannelID:     channelID,
+		ChannelTitle:  channelTitle,
 		AccessToken:   accessToken,
 		RefreshToken:  refreshToken,
 		Expiry:        expiry,
@@ -160,23 +282,45 @@ func (s *gormStore) CreateSessionGoogle(ctx context.Context, userID, accessToken
 		Scopes:        scopes,
 	}
 
-	result := s.db.WithContext(ctx).Create(session)
-	if result.Error != nil {
-		return nil, result.Error
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// The credentials might have been removed while the user was authorizing the session.
+		var count int64
+		result := tx.Model(&model.CredentialsGoogle{}).Where("id = ?", credentials.ID).Count(&count)
+		if result.Error != nil {
+			return result.Error
+		}
+		if count == 0 {
+			return errs.NotFound
+		}
+
+		// Authenticating again replaces previous sessions of the channel, broken ones and ones created before channels were recorded.
+		result = tx.Where("user_id = ? AND credentials_id = ? AND (channel_id = ? OR channel_id = '' OR broken = ?)", userID, credentials.ID, channelID, true).Delete(&model.SessionGoogle{})
+		if result.Error != nil {
+			return result.Error
+		}
+
+		return tx.Create(session).Error
+	})
+	if err != nil {
+		return nil, err
 	}
 
 	session.Credentials = credentials
 	return session, nil
 }
 
-func (s *gormStore) GetSessionGoogleByCredentialsID(ctx context.Context, credentialsID uint, userID string) (*model.SessionGoogle, error) {
+func (s *gormStore) GetSessionGoogle(ctx context.Context, userID string, id uint) (*model.SessionGoogle, error) {
 	var session model.SessionGoogle
 
 	result := s.db.WithContext(ctx).
 		Preload("Credentials").
-		Where("credentials_id = ? AND user_id = ?", credentialsID, userID).
+		Where("id = ? AND user_id = ?", id, userID).
 		First(&session)
-	if result.Error != nil {
+	switch result.Error {
+	case nil:
+	case gorm.ErrRecordNotFound:
+		return nil, errs.NotFound
+	default:
 		return nil, result.Error
 	}
 
@@ -197,51 +341,95 @@ func (s *gormStore) GetSessionGoogleAll(ctx context.Context, userID string) ([]m
 	return sessions, nil
 }
 
-func (s *gormStore) RemoveSessionGoogle(ctx context.Context, userID string, credentialsID uint) error {
-	result := s.db.WithContext(ctx).Where("user_id = ? AND credentials_id = ?", userID, credentialsID).Delete(&model.SessionGoogle{})
+func (s *gormStore) RemoveSessionGoogle(ctx context.Context, userID string, id uint) error {
+	result := s.db.WithContext(ctx).Where("id = ? AND user_id = ?", id, userID).Delete(&model.SessionGoogle{})
 	if result.Error != nil {
 		return result.Error
 	}
+	if result.RowsAffected == 0 {
+		return errs.NotFound
+	}
 	return nil
 }
 
-func (s *gormStore) SaveSessionState(ctx context.Context, credentialsID uint, userID, state, scopes, redirectURL string) error {
+func (s *gormStore) SaveSessionState(ctx context.Context, credentialsID uint, userID, state, codeVerifier, scopes, redirectURL string) error {
 	sessionState := &model.SessionState{
 		UserID:        userID,
 		State:         state,
+		CodeVerifier:  codeVerifier,
 		CredentialsID: credentialsID,
 		Scopes:        scopes,
 		RedirectURL:   redirectURL,
 	}
 
-	result := s.db.WithContext(ctx).Create(sessionState)
-	if result.Error != nil {
-		return result.Error
-	}
+	return s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// A user has at most one pending state per credentials, requesting a new URL invalidates the previous one.
+		result := tx.Unscoped().Where("user_id = ? AND credentials_id = ?", userID, credentialsID).Delete(&model.SessionState{})
+		if result.Error != nil {
+			return result.Error
+		}
 
-	return nil
+		return tx.Create(sessionState).Error
+	})
 }
 
 func (s *gormStore) GetSessionState(ctx context.Context, state string) (*model.SessionState, error) {
 	var sessionState model.SessionState
 
 	result := s.db.WithContext(ctx).Preload("Credentials").Where("state = ?", state).First(&sessionState)
-	if result.Error != nil {
+	switch result.Error {
+	case nil:
+	case gorm.ErrRecordNotFound:
+		return nil, errs.NotFound
+	default:
 		return nil, result.Error
 	}
+	// Removed credentials aren't preloaded, the state can't be used anymore.
+	if sessionState.Credentials.ID == 0 {
+		log.Debug().Uint("credentials_id", sessionState.CredentialsID).Msg("credentials of session state were removed")
+		return nil, errs.NotFound
+	}
 
 	return &sessionState, nil
 }
 
-func (s *gormStore) GetSessionGoogleByAvailableCost(ctx context.Context, userID string, cost uint) (*model.SessionGoogle, func() error, error) {
+func (s *gormStore) RemoveSessionState(ctx context.Context, state string) error {
+	result := s.db.WithContext(ctx).Unscoped().Where("state = ?", state).Delete(&model.SessionState{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return errs.NotFound
+	}
+	return nil
+}
+
+func (s *gormStore) RemoveSessionStatesBefore(ctx context.Context, before time.Time) (int, error) {
+	result := s.db.WithContext(ctx).Unscoped().Where("created_at < ?", before).Delete(&model.SessionState{})
+	if result.Error != nil {
+		return 0, result.Error
+	}
+	return int(result.RowsAffected), nil
+}
+
+func (s *gormStore) GetSessionGoogleByAvailableCost(ctx context.Context, userID, channelID string, cost uint) (*model.SessionGoogle, func() error, error) {
 	var session model.SessionGoogle
 
 	maxUsage := quota.Google - cost
+	dayStart := quota.GoogleDayStart(time.Now())
 
 	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
-		result := tx.Preload("Credentials").
+		// The quota is reset daily, usage counted on a previous day starts over.
+		result := tx.Model(&model.CredentialsGoogle{}).
+			Where("usage_reset_at IS NULL OR usage_reset_at < ?", dayStart).
+			Updates(map[string]any{"usage": 0, "usage_reset_at": dayStart})
+		if result.Error != nil {
+			return result.Error
+		}
+
+		result = tx.Preload("Credentials").
 			Joins("JOIN credentials_google ON credentials_google.id = sessions_google.credentials_id").
-			Where("user_id = ? AND credentials_google.usage < ?", userID, maxUsage).
+			Where("user_id = ? AND channel_id = ? AND sessions_google.broken = ? AND credentials_google.usage < ?", userID, channelID, false, maxUsage).
 			Order("credentials_google.usage").
 			Clauses(clause.Locking{Strength: "UPDATE", Table: clause.Table{Name: "credentials_google"}}).
 			First(&session)
@@ -271,6 +459,30 @@ func (s *gormStore) GetSessionGoogleByAvailableCost(ctx context.Context, userID
 	return &session, revert, nil
 }
 
+func (s *gormStore) MarkSessionGoogleBroken(ctx context.Context, id uint) error {
+	result := s.db.WithContext(ctx).Model(&model.SessionGoogle{}).Where("id = ?", id).Update("broken", true)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return errs.NotFound
+	}
+	return nil
+}
+
+func (s *gormStore) MarkCredentialsGoogleExhausted(ctx context.Context, id uint) error {
+	// The usage is set for the current quota day, so the credentials are used again after the quota is reset.
+	result := s.db.WithContext(ctx).Model(&model.CredentialsGoogle{}).Where("id = ?", id).
+		Updates(map[string]any{"usage": quota.Google, "usage_reset_at": quota.GoogleDayStart(time.Now())})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return errs.NotFound
+	}
+	return nil
+}
+
 func (s *gormStore) UpdateSessionGoogle(ctx context.Context, session *model.SessionGoogle) error {
 	result := s.db.WithContext(ctx).Save(session)
 	if result.Error != nil {
@@ -309,3 +521,193 @@ func (s *gormStore) GetCredentialsDeepLByAvailableCost(ctx context.Context, cost
 	credentials.Usage += cost
 	return &credentials, revert, nil
 }
+
+func (s *gormStore) SaveSourceCC(ctx context.Context, userID, videoID, language, srt string, uploadOriginal bool) (*model.SourceCC, error) {
+	sourceCC := &model.SourceCC{
+		UserID:         userID,
+		VideoID:        videoID,
+		Language:       language,
+		Srt:            srt,
+		UploadOriginal: uploadOriginal,
+	}
+
+	result := s.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "user_id"}, {Name: "video_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"updated_at", "language", "srt", "upload_original"}),
+	}).Create(sourceCC)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	return sourceCC, nil
+}
+
+func (s *gormStore) GetSourceCC(ctx context.Context, userID, videoID string) (*model.SourceCC, error) {
+	var sourceCC model.SourceCC
+
+	result := s.db.WithContext(ctx).Where("user_id = ? AND video_id = ?", userID, videoID).First(&sourceCC)
+	switch result.Error {
+	case nil:
+	case gorm.ErrRecordNotFound:
+		return nil, errs.NotFound
+	default:
+		return nil, result.Error
+	}
+
+	return &sourceCC, nil
+}
+
+func (s *gormStore) RemoveSourceCC(ctx context.Context, userID, videoID string) error {
+	// The row is deleted permanently, a soft deleted one would still occupy the unique index.
+	result := s.db.WithContext(ctx).Unscoped().Where("user_id = ? AND video_id = ?", userID, videoID).Delete(&model.SourceCC{})
+	if result.Error != nil {
+		return result.Error
+	}
+	return nil
+}
+
+// Maximum number of segments in a single translation memory query.
+const translationMemoryBatchSize = 1000
+
+func hashSegment(text string) string {
+	hash := sha256.Sum256([]byte(text))
+	return hex.EncodeToString(hash[:])
+}
+
+func (s *gormStore) GetTranslationMemory(ctx context.Context, sourceLanguage, targetLanguage string, text []string) (map[string]string, error) {
+	hashes := make([]string, 0, len(text))
+	for _, t := range text {
+		hashes = append(hashes, hashSegment(t))
+	}
+
+	translations := make(map[string]string)
+	for start := 0; start < len(hashes); start += translationMemoryBatchSize {
+		end := min(start+translationMemoryBatchSize, len(hashes))
+
+		var segments []model.TranslationMemory
+		result := s.db.WithContext(ctx).Where("source_language = ? AND target_language = ? AND source_hash IN ?", sourceLanguage, targetLanguage, hashes[start:end]).Find(&segments)
+		if result.Error != nil {
+			return nil, result.Error
+		}
+		for _, segment := range segments {
+			translations[segment.SourceText] = segment.TranslatedText
+		}
+	}
+
+	return translations, nil
+}
+
+func (s *gormStore) SaveTranslationMemory(ctx context.Context, sourceLanguage, targetLanguage string, translations map[string]string) error {
+	if len(translations) == 0 {
+		return nil
+	}
+
+	segments := make([]model.TranslationMemory, 0, len(translations))
+	for sourceText, translatedText := range translations {
+		segments = append(segments, model.TranslationMemory{
+			SourceLanguage: sourceLanguage,
+			TargetLanguage: targetLanguage,
+			SourceHash:     hashSegment(sourceText),
+			SourceText:     sourceText,
+			TranslatedText: translatedText,
+		})
+	}
+
+	return s.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "source_language"}, {Name: "target_language"}, {Name: "source_hash"}},
+		DoUpdates: clause.AssignmentColumns([]string{"updated_at", "translated_text"}),
+	}).CreateInBatches(segments, translationMemoryBatchSize).Error
+}
+
+func (s *gormStore) RemoveTranslationMemory(ctx context.Context, sourceLanguage, targetLanguage string) (int, error) {
+	if sourceLanguage == "" && targetLanguage == "" {
+		return 0, errs.InvalidInput
+	}
+
+	query := s.db.WithContext(ctx).Unscoped()
+	if sourceLanguage != "" {
+		query = query.Where("LOWER(source_language) = LOWER(?)", sourceLanguage)
+	}
+	if targetLanguage != "" {
+		query = query.Where("LOWER(target_language) = LOWER(?)", targetLanguage)
+	}
+
+	// Rows are deleted permanently, soft deleted ones would still occupy the unique index.
+	result := query.Delete(&model.TranslationMemory{})
+	if result.Error != nil {
+		return 0, result.Error
+	}
+	return int(result.RowsAffected), nil
+}
+
+func (s *gormStore) GetLocalizations(ctx context.Context, kind string, resourceIDs ...string) ([]model.Localization, error) {
+	var localizations []model.Localization
+	if len(resourceIDs) == 0 {
+		return localizations, nil
+	}
+
+	result := s.db.WithContext(ctx).Where("kind = ? AND resource_id IN ?", kind, resourceIDs).Order("resource_id, language").Find(&localizations)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	return localizations, nil
+}
+
+func (s *gormStore) SaveLocalizations(ctx context.Context, localizations []model.Localization) error {
+	if len(localizations) == 0 {
+		return nil
+	}
+
+	return s.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "kind"}, {Name: "resource_id"}, {Name: "language"}},
+		DoUpdates: clause.AssignmentColumns([]string{"updated_at", "hash", "source_hash"}),
+	}).Create(&localizations).Error
+}
+
+func (s *gormStore) GetTranslatedCC(ctx context.Context, videoIDs ...string) ([]model.TranslatedCC, error) {
+	var translatedCC []model.TranslatedCC
+	if len(videoIDs) == 0 {
+		return translatedCC, nil
+	}
+
+	result := s.db.WithContext(ctx).Where("video_id IN ?", videoIDs).Order("video_id, language").Find(&translatedCC)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	return translatedCC, nil
+}
+
+func (s *gormStore) SaveTranslatedCC(ctx context.Context, translatedCC []model.TranslatedCC) error {
+	if len(translatedCC) == 0 {
+		return nil
+	}
+
+	return s.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "video_id"}, {Name: "language"}},
+		DoUpdates: clause.AssignmentColumns([]string{"updated_at", "track_id"}),
+	}).Create(&translatedCC).Error
+}
+
+func (s *gormStore) GetAddedTags(ctx context.Context, videoID string) ([]string, error) {
+	var tags []string
+	result := s.db.WithContext(ctx).Model(&model.AddedTag{}).Where("video_id = ?", videoID).Order("tag").Pluck("tag", &tags)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	return tags, nil
+}
+
+func (s *gormStore) SaveAddedTags(ctx context.Context, videoID string, tags []string) error {
+	if len(tags) == 0 {
+		return nil
+	}
+
+	added := make([]model.AddedTag, 0, len(tags))
+	for _, tag := range tags {
+		added = append(added, model.AddedTag{VideoID: videoID, Tag: strings.ToLower(tag)})
+	}
+	return s.db.WithContext(ctx).Clauses(clause.OnConflict{DoNothing: true}).Create(&added).Error
+}
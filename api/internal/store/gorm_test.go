@@ -4,12 +4,22 @@ import (
 	"context"
 	"crypto/rand"
 	"encoding/hex"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync/atomic"
 	"testing"
 	"time"
 
 	qt "github.com/frankban/quicktest"
+	"github.com/glebarez/sqlite"
+	"golang.org/x/sync/errgroup"
+	"gorm.io/gorm"
 
+	"github.com/pkulik0/autocc/api/internal/errs"
 	"github.com/pkulik0/autocc/api/internal/model"
+	"github.com/pkulik0/autocc/api/internal/quota"
+	"github.com/pkulik0/autocc/api/internal/secret"
 	"github.com/pkulik0/autocc/api/internal/store"
 )
 
@@ -20,325 +30,833 @@ func randomString(c *qt.C) string {
 	return hex.EncodeToString(s)
 }
 
+func randomKeyring(c *qt.C) *secret.Keyring {
+	key := make([]byte, secret.KeySize)
+	_, err := rand.Read(key)
+	c.Assert(err, qt.IsNil)
+
+	keyring, err := secret.NewKeyring(key)
+	c.Assert(err, qt.IsNil)
+	return keyring
+}
+
 func setupStore(c *qt.C) store.Store {
-	s, err := store.New("localhost", 5432, "autocc", "autocc", "autocc")
+	s, err := store.New("localhost", 5432, "autocc", "autocc", "autocc", randomKeyring(c))
 	c.Assert(err, qt.IsNil)
 	return s
 }
 
+func setupStoreSQLite(c *qt.C) store.Store {
+	s, err := store.NewSQLite(filepath.Join(c.TempDir(), "autocc.db"), randomKeyring(c))
+	c.Assert(err, qt.IsNil)
+	return s
+}
+
+var backends = []struct {
+	name  string
+	setup func(c *qt.C) store.Store
+}{
+	{name: "postgres", setup: setupStore},
+	{name: "sqlite", setup: setupStoreSQLite},
+}
+
+// forEachBackend runs the test against each store backend.
+func forEachBackend(t *testing.T, test func(c *qt.C, s store.Store)) {
+	c := qt.New(t)
+	for _, b := range backends {
+		c.Run(b.name, func(c *qt.C) {
+			test(c, b.setup(c))
+		})
+	}
+}
+
 func TestConnection(t *testing.T) {
 	c := qt.New(t)
 	_ = setupStore(c)
 
-	_, err := store.New("doesnt-exist", 5432, "autocc", "autocc", "autocc")
+	_, err := store.New("doesnt-exist", 5432, "autocc", "autocc", "autocc", nil)
 	c.Assert(err, qt.IsNotNil)
 }
 
-func TestCredentialsGoogle(t *testing.T) {
+func TestConnectionSQLite(t *testing.T) {
 	c := qt.New(t)
-	s := setupStore(c)
+	_ = setupStoreSQLite(c)
 
-	id, secret := randomString(c), randomString(c)
+	_, err := store.NewSQLite(filepath.Join(c.TempDir(), "doesnt-exist", "autocc.db"), nil)
+	c.Assert(err, qt.IsNotNil)
+}
 
-	credentials, err := s.AddCredentialsGoogle(context.Background(), id, secret)
-	c.Assert(err, qt.IsNil)
-	c.Assert(credentials.ClientID, qt.Equals, id)
-	c.Assert(credentials.ClientSecret, qt.Equals, secret)
+func TestCredentialsGoogle(t *testing.T) {
+	forEachBackend(t, func(c *qt.C, s store.Store) {
+		id, secret := randomString(c), randomString(c)
 
-	retrieved, err := s.GetCredentialsGoogleByID(context.Background(), credentials.ID)
-	c.Assert(err, qt.IsNil)
-	c.Assert(retrieved, qt.DeepEquals, credentials)
+		credentials, err := s.AddCredentialsGoogle(context.Background(), id, secret)
+		c.Assert(err, qt.IsNil)
+		c.Assert(credentials.ClientID, qt.Equals, id)
+		c.Assert(credentials.ClientSecret, qt.Equals, secret)
 
-	credentialsAll, err := s.GetCredentialsGoogleAll(context.Background())
-	c.Assert(err, qt.IsNil)
-	c.Assert(credentialsAll, qt.Contains, *credentials)
+		retrieved, err := s.GetCredentialsGoogleByID(context.Background(), credentials.ID)
+		c.Assert(err, qt.IsNil)
+		c.Assert(retrieved, qt.DeepEquals, credentials)
 
-	err = s.RemoveCredentialsGoogle(context.Background(), credentials.ID)
-	c.Assert(err, qt.IsNil)
+		credentialsAll, err := s.GetCredentialsGoogleAll(context.Background())
+		c.Assert(err, qt.IsNil)
+		c.Assert(credentialsAll, qt.Contains, *credentials)
 
-	retrieved, err = s.GetCredentialsGoogleByID(context.Background(), credentials.ID)
-	c.Assert(err, qt.IsNotNil)
+		err = s.RemoveCredentialsGoogle(context.Background(), credentials.ID)
+		c.Assert(err, qt.IsNil)
+
+		retrieved, err = s.GetCredentialsGoogleByID(context.Background(), credentials.ID)
+		c.Assert(err, qt.IsNotNil)
+	})
 }
 
 func TestCredentialsDeepL(t *testing.T) {
-	c := qt.New(t)
-	s := setupStore(c)
+	forEachBackend(t, func(c *qt.C, s store.Store) {
+		key := randomString(c)
 
-	key := randomString(c)
+		credentials, err := s.AddCredentialsDeepL(context.Background(), key, 5)
+		c.Assert(err, qt.IsNil)
+		c.Assert(credentials.Key, qt.Equals, key)
 
-	credentials, err := s.AddCredentialsDeepL(context.Background(), key, 5)
-	c.Assert(err, qt.IsNil)
-	c.Assert(credentials.Key, qt.Equals, key)
+		retrieved, err := s.GetCredentialsDeepLByID(context.Background(), credentials.ID)
+		c.Assert(err, qt.IsNil)
+		c.Assert(retrieved, qt.DeepEquals, credentials)
 
-	retrieved, err := s.GetCredentialsDeepLByID(context.Background(), credentials.ID)
-	c.Assert(err, qt.IsNil)
-	c.Assert(retrieved, qt.DeepEquals, credentials)
+		credentialsAll, err := s.GetCredentialsDeepLAll(context.Background())
+		c.Assert(err, qt.IsNil)
+		c.Assert(credentialsAll, qt.Contains, *credentials)
 
-	credentialsAll, err := s.GetCredentialsDeepLAll(context.Background())
-	c.Assert(err, qt.IsNil)
-	c.Assert(credentialsAll, qt.Contains, *credentials)
+		err = s.RemoveCredentialsDeepL(context.Background(), credentials.ID)
+		c.Assert(err, qt.IsNil)
 
-	err = s.RemoveCredentialsDeepL(context.Background(), credentials.ID)
-	c.Assert(err, qt.IsNil)
-
-	retrieved, err = s.GetCredentialsDeepLByID(context.Background(), credentials.ID)
-	c.Assert(err, qt.IsNotNil)
+		retrieved, err = s.GetCredentialsDeepLByID(context.Background(), credentials.ID)
+		c.Assert(err, qt.IsNotNil)
+	})
 }
 
 func TestSessionState(t *testing.T) {
-	c := qt.New(t)
-	s := setupStore(c)
+	forEachBackend(t, func(c *qt.C, s store.Store) {
+		credentials, err := s.AddCredentialsGoogle(context.Background(), randomString(c), randomString(c))
+		c.Assert(err, qt.IsNil)
+
+		credentialsID, userID, state, scopes, url := credentials.ID, randomString(c), randomString(c), randomString(c), "https://example.com"
+
+		err = s.SaveSessionState(context.Background(), credentialsID, userID, state, "verifier", scopes, url)
+		c.Assert(err, qt.IsNil)
+
+		retrieved, err := s.GetSessionState(context.Background(), state)
+		c.Assert(err, qt.IsNil)
+		c.Assert(retrieved.CredentialsID, qt.Equals, credentialsID)
+		c.Assert(retrieved.UserID, qt.Equals, userID)
+		c.Assert(retrieved.State, qt.Equals, state)
+		c.Assert(retrieved.CodeVerifier, qt.Equals, "verifier")
+		c.Assert(retrieved.Scopes, qt.Equals, scopes)
+
+		_, err = s.GetSessionState(context.Background(), "invalid")
+		c.Assert(err, qt.Equals, errs.NotFound)
+
+		// A new state of the same user and credentials replaces the previous one.
+		newState := randomString(c)
+		err = s.SaveSessionState(context.Background(), credentialsID, userID, newState, "verifier", scopes, url)
+		c.Assert(err, qt.IsNil)
+		_, err = s.GetSessionState(context.Background(), state)
+		c.Assert(err, qt.Equals, errs.NotFound)
+
+		err = s.RemoveSessionState(context.Background(), newState)
+		c.Assert(err, qt.IsNil)
+		err = s.RemoveSessionState(context.Background(), newState)
+		c.Assert(err, qt.Equals, errs.NotFound)
+		_, err = s.GetSessionState(context.Background(), newState)
+		c.Assert(err, qt.Equals, errs.NotFound)
+	})
+}
 
-	credentialsID, userID, state, scopes, url := uint(1), randomString(c), randomString(c), randomString(c), "https://example.com"
+func TestSessionStateRemovedCredentials(t *testing.T) {
+	forEachBackend(t, func(c *qt.C, s store.Store) {
+		credentials, err := s.AddCredentialsGoogle(context.Background(), randomString(c), randomString(c))
+		c.Assert(err, qt.IsNil)
 
-	err := s.SaveSessionState(context.Background(), credentialsID, userID, state, scopes, url)
-	c.Assert(err, qt.IsNil)
+		state, userID := randomString(c), randomString(c)
+		err = s.SaveSessionState(context.Background(), credentials.ID, userID, state, "", "", "https://example.com")
+		c.Assert(err, qt.IsNil)
+		sessionState, err := s.GetSessionState(context.Background(), state)
+		c.Assert(err, qt.IsNil)
 
-	retrieved, err := s.GetSessionState(context.Background(), state)
-	c.Assert(err, qt.IsNil)
-	c.Assert(retrieved.CredentialsID, qt.Equals, credentialsID)
-	c.Assert(retrieved.UserID, qt.Equals, userID)
-	c.Assert(retrieved.State, qt.Equals, state)
-	c.Assert(retrieved.Scopes, qt.Equals, scopes)
+		err = s.RemoveCredentialsGoogle(context.Background(), credentials.ID)
+		c.Assert(err, qt.IsNil)
 
-	_, err = s.GetSessionState(context.Background(), "invalid")
-	c.Assert(err, qt.IsNotNil)
+		_, err = s.GetSessionState(context.Background(), state)
+		c.Assert(err, qt.Equals, errs.NotFound)
+		_, err = s.CreateSessionGoogle(context.Background(), userID, "channel", "Channel", "access", "refresh", "", time.Now(), sessionState.Credentials)
+		c.Assert(err, qt.Equals, errs.NotFound)
+	})
+}
+
+func TestRemoveSessionStatesBefore(t *testing.T) {
+	forEachBackend(t, func(c *qt.C, s store.Store) {
+		credentials, err := s.AddCredentialsGoogle(context.Background(), randomString(c), randomString(c))
+		c.Assert(err, qt.IsNil)
+
+		state := randomString(c)
+		err = s.SaveSessionState(context.Background(), credentials.ID, randomString(c), state, "", "", "https://example.com")
+		c.Assert(err, qt.IsNil)
+
+		count, err := s.RemoveSessionStatesBefore(context.Background(), time.Now().Add(-time.Minute))
+		c.Assert(err, qt.IsNil)
+		c.Assert(count, qt.Equals, 0)
+		_, err = s.GetSessionState(context.Background(), state)
+		c.Assert(err, qt.IsNil)
+
+		count, err = s.RemoveSessionStatesBefore(context.Background(), time.Now().Add(time.Minute))
+		c.Assert(err, qt.IsNil)
+		c.Assert(count >= 1, qt.IsTrue)
+		_, err = s.GetSessionState(context.Background(), state)
+		c.Assert(err, qt.Equals, errs.NotFound)
+	})
 }
 
 func TestSessionGoogle(t *testing.T) {
-	c := qt.New(t)
-	s := setupStore(c)
+	forEachBackend(t, func(c *qt.C, s store.Store) {
+		credentials, err := s.AddCredentialsGoogle(context.Background(), "client", "secret")
+		c.Assert(err, qt.IsNil)
+
+		userID, accessToken, refreshToken, expiry, scopes := randomString(c), randomString(c), randomString(c), time.Now().Round(time.Minute), randomString(c)
+
+		channelID, channelTitle := randomString(c), randomString(c)
+
+		session, err := s.CreateSessionGoogle(context.Background(), userID, channelID, channelTitle, accessToken, refreshToken, scopes, expiry, *credentials)
+		c.Assert(err, qt.IsNil)
+		c.Assert(session.UserID, qt.Equals, userID)
+		c.Assert(session.ChannelID, qt.Equals, channelID)
+		c.Assert(session.ChannelTitle, qt.Equals, channelTitle)
+		c.Assert(session.AccessToken, qt.Equals, accessToken)
+		c.Assert(session.RefreshToken, qt.Equals, refreshToken)
+		c.Assert(session.Expiry, qt.Equals, expiry)
+		c.Assert(session.CredentialsID, qt.Equals, credentials.ID)
+		c.Assert(session.Scopes, qt.Equals, scopes)
+
+		retrieved, err := s.GetSessionGoogle(context.Background(), userID, session.ID)
+		c.Assert(err, qt.IsNil)
+		c.Assert(retrieved, qt.DeepEquals, session)
+
+		err = s.RemoveSessionGoogle(context.Background(), userID, session.ID)
+		c.Assert(err, qt.IsNil)
+
+		_, err = s.GetSessionGoogle(context.Background(), userID, session.ID)
+		c.Assert(err, qt.Equals, errs.NotFound)
+		err = s.RemoveSessionGoogle(context.Background(), userID, session.ID)
+		c.Assert(err, qt.Equals, errs.NotFound)
+	})
+}
 
-	credentials, err := s.AddCredentialsGoogle(context.Background(), "client", "secret")
-	c.Assert(err, qt.IsNil)
+func TestUpdateSessionGoogle(t *testing.T) {
+	forEachBackend(t, func(c *qt.C, s store.Store) {
+		credentials, err := s.AddCredentialsGoogle(context.Background(), "client", "secret")
+		c.Assert(err, qt.IsNil)
 
-	userID, accessToken, refreshToken, expiry, scopes := randomString(c), randomString(c), randomString(c), time.Now().Round(time.Minute), randomString(c)
+		userID, accessToken, refreshToken, expiry, scopes := randomString(c), randomString(c), randomString(c), time.Now().Round(time.Minute), randomString(c)
 
-	session, err := s.CreateSessionGoogle(context.Background(), userID, accessToken, refreshToken, scopes, expiry, *credentials)
-	c.Assert(err, qt.IsNil)
-	c.Assert(session.UserID, qt.Equals, userID)
-	c.Assert(session.AccessToken, qt.Equals, accessToken)
-	c.Assert(session.RefreshToken, qt.Equals, refreshToken)
-	c.Assert(session.Expiry, qt.Equals, expiry)
-	c.Assert(session.CredentialsID, qt.Equals, credentials.ID)
-	c.Assert(session.Scopes, qt.Equals, scopes)
-
-	retrieved, err := s.GetSessionGoogleByCredentialsID(context.Background(), credentials.ID, userID)
-	c.Assert(err, qt.IsNil)
-	c.Assert(retrieved, qt.DeepEquals, session)
+		session, err := s.CreateSessionGoogle(context.Background(), userID, "channel", "Channel", accessToken, refreshToken, scopes, expiry, *credentials)
+		c.Assert(err, qt.IsNil)
 
-	err = s.RemoveSessionGoogle(context.Background(), userID, credentials.ID)
-	c.Assert(err, qt.IsNil)
+		newAccessToken, newRefreshToken := randomString(c), randomString(c)
+		session.AccessToken = newAccessToken
+		session.RefreshToken = newRefreshToken
 
-	_, err = s.GetSessionGoogleByCredentialsID(context.Background(), credentials.ID, userID)
-	c.Assert(err, qt.IsNotNil)
+		err = s.UpdateSessionGoogle(context.Background(), session)
+		c.Assert(err, qt.IsNil)
+
+		retrieved, err := s.GetSessionGoogle(context.Background(), userID, session.ID)
+		c.Assert(err, qt.IsNil)
+		c.Assert(retrieved, qt.DeepEquals, session)
+	})
 }
 
-func TestUpdateSessionGoogle(t *testing.T) {
-	c := qt.New(t)
-	s := setupStore(c)
+func TestGetSessionGoogleAll(t *testing.T) {
+	forEachBackend(t, func(c *qt.C, s store.Store) {
+		credentials, err := s.AddCredentialsGoogle(context.Background(), "client", "secret")
+		c.Assert(err, qt.IsNil)
 
-	credentials, err := s.AddCredentialsGoogle(context.Background(), "client", "secret")
-	c.Assert(err, qt.IsNil)
+		userID, accessToken, refreshToken, expiry, scopes := randomString(c), randomString(c), randomString(c), time.Now().Round(time.Minute), randomString(c)
+		session1, err := s.CreateSessionGoogle(context.Background(), userID, randomString(c), "", accessToken, refreshToken, scopes, expiry, *credentials)
+		c.Assert(err, qt.IsNil)
 
-	userID, accessToken, refreshToken, expiry, scopes := randomString(c), randomString(c), randomString(c), time.Now().Round(time.Minute), randomString(c)
+		accessToken, refreshToken, expiry, scopes = randomString(c), randomString(c), time.Now().Round(time.Minute), randomString(c)
+		session2, err := s.CreateSessionGoogle(context.Background(), userID, randomString(c), "", accessToken, refreshToken, scopes, expiry, *credentials)
+		c.Assert(err, qt.IsNil)
 
-	session, err := s.CreateSessionGoogle(context.Background(), userID, accessToken, refreshToken, scopes, expiry, *credentials)
-	c.Assert(err, qt.IsNil)
+		sessions, err := s.GetSessionGoogleAll(context.Background(), userID)
+		c.Assert(err, qt.IsNil)
 
-	newAccessToken, newRefreshToken := randomString(c), randomString(c)
-	session.AccessToken = newAccessToken
-	session.RefreshToken = newRefreshToken
+		c.Assert(sessions, qt.Contains, *session1)
+		c.Assert(sessions, qt.Contains, *session2)
+	})
+}
 
-	err = s.UpdateSessionGoogle(context.Background(), session)
-	c.Assert(err, qt.IsNil)
+func TestGetSessionGoogle(t *testing.T) {
+	forEachBackend(t, func(c *qt.C, s store.Store) {
+		credentials, err := s.AddCredentialsGoogle(context.Background(), "client", "secret")
+		c.Assert(err, qt.IsNil)
+
+		userID, accessToken, refreshToken, expiry, scopes := randomString(c), randomString(c), randomString(c), time.Now().Round(time.Minute), randomString(c)
+		session, err := s.CreateSessionGoogle(context.Background(), userID, "channel", "Channel", accessToken, refreshToken, scopes, expiry, *credentials)
+		c.Assert(err, qt.IsNil)
+		other, err := s.CreateSessionGoogle(context.Background(), userID, "other", "Other", randomString(c), randomString(c), scopes, expiry, *credentials)
+		c.Assert(err, qt.IsNil)
+
+		// Sessions of channels authenticated with the same credentials are separate.
+		retrieved, err := s.GetSessionGoogle(context.Background(), userID, session.ID)
+		c.Assert(err, qt.IsNil)
+		c.Assert(retrieved, qt.DeepEquals, session)
+		retrieved, err = s.GetSessionGoogle(context.Background(), userID, other.ID)
+		c.Assert(err, qt.IsNil)
+		c.Assert(retrieved, qt.DeepEquals, other)
+
+		err = s.RemoveSessionGoogle(context.Background(), userID, session.ID)
+		c.Assert(err, qt.IsNil)
+		_, err = s.GetSessionGoogle(context.Background(), userID, other.ID)
+		c.Assert(err, qt.IsNil)
+
+		// Sessions of other users aren't accessible.
+		_, err = s.GetSessionGoogle(context.Background(), randomString(c), other.ID)
+		c.Assert(err, qt.Equals, errs.NotFound)
+		err = s.RemoveSessionGoogle(context.Background(), randomString(c), other.ID)
+		c.Assert(err, qt.Equals, errs.NotFound)
+	})
+}
 
-	retrieved, err := s.GetSessionGoogleByCredentialsID(context.Background(), credentials.ID, userID)
-	c.Assert(err, qt.IsNil)
-	c.Assert(retrieved, qt.DeepEquals, session)
+func TestGetSessionGoogleByAvailableCost(t *testing.T) {
+	forEachBackend(t, func(c *qt.C, s store.Store) {
+		credentials, err := s.AddCredentialsGoogle(context.Background(), "client", "secret")
+		c.Assert(err, qt.IsNil)
+
+		userID, accessToken, refreshToken, expiry, scopes := randomString(c), randomString(c), randomString(c), time.Now().Round(time.Minute), randomString(c)
+
+		session, err := s.CreateSessionGoogle(context.Background(), userID, "channel", "Channel", accessToken, refreshToken, scopes, expiry, *credentials)
+		c.Assert(err, qt.IsNil)
+
+		newSession, revert, err := s.GetSessionGoogleByAvailableCost(context.Background(), userID, "channel", 1000)
+		c.Assert(err, qt.IsNil)
+		c.Assert(newSession.ID, qt.DeepEquals, session.ID)
+		c.Assert(newSession.Credentials.Usage, qt.Equals, uint(1000))
+
+		retrieved, err := s.GetSessionGoogle(context.Background(), userID, session.ID)
+		c.Assert(err, qt.IsNil)
+		c.Assert(retrieved.Credentials.Usage, qt.Equals, uint(1000))
+
+		retrieved, _, err = s.GetSessionGoogleByAvailableCost(context.Background(), userID, "channel", 500)
+		c.Assert(err, qt.IsNil)
+		c.Assert(retrieved.ID, qt.DeepEquals, session.ID)
+		c.Assert(retrieved.Credentials.Usage, qt.Equals, uint(1500))
+
+		err = revert()
+		c.Assert(err, qt.IsNil)
+
+		retrieved, err = s.GetSessionGoogle(context.Background(), userID, session.ID)
+		c.Assert(err, qt.IsNil)
+		c.Assert(retrieved.Credentials.Usage, qt.Equals, uint(500))
+	})
 }
 
-func TestGetSessionGoogleAll(t *testing.T) {
+// Transactions on SQLite take the write lock when they begin, so concurrent reservations can't exceed the quota.
+func TestGetSessionGoogleByAvailableCostConcurrentSQLite(t *testing.T) {
 	c := qt.New(t)
-	s := setupStore(c)
+	s := setupStoreSQLite(c)
+	ctx := context.Background()
+
+	credentials, err := s.AddCredentialsGoogle(ctx, "client", "secret")
+	c.Assert(err, qt.IsNil)
+	userID := randomString(c)
+	session, err := s.CreateSessionGoogle(ctx, userID, "channel", "Channel", randomString(c), randomString(c), "", time.Now(), *credentials)
+	c.Assert(err, qt.IsNil)
+
+	// Reservations fit while the usage is below three quarters of the quota.
+	cost := uint(quota.Google / 4)
+	var reserved atomic.Int32
+	var group errgroup.Group
+	for range 8 {
+		group.Go(func() error {
+			_, _, err := s.GetSessionGoogleByAvailableCost(ctx, userID, "channel", cost)
+			switch err {
+			case nil:
+				reserved.Add(1)
+				return nil
+			case errs.NotFound:
+				return nil
+			default:
+				return err
+			}
+		})
+	}
+	c.Assert(group.Wait(), qt.IsNil)
+	c.Assert(reserved.Load(), qt.Equals, int32(3))
+
+	retrieved, err := s.GetSessionGoogle(ctx, userID, session.ID)
+	c.Assert(err, qt.IsNil)
+	c.Assert(retrieved.Credentials.Usage, qt.Equals, 3*cost)
+}
 
-	credentials, err := s.AddCredentialsGoogle(context.Background(), "client", "secret")
-	c.Assert(err, qt.IsNil)
+func TestMarkCredentialsGoogleExhausted(t *testing.T) {
+	forEachBackend(t, func(c *qt.C, s store.Store) {
+		credentials, err := s.AddCredentialsGoogle(context.Background(), "client", "secret")
+		c.Assert(err, qt.IsNil)
 
-	userID, accessToken, refreshToken, expiry, scopes := randomString(c), randomString(c), randomString(c), time.Now().Round(time.Minute), randomString(c)
-	session1, err := s.CreateSessionGoogle(context.Background(), userID, accessToken, refreshToken, scopes, expiry, *credentials)
-	c.Assert(err, qt.IsNil)
+		userID := randomString(c)
+		_, err = s.CreateSessionGoogle(context.Background(), userID, "channel", "Channel", randomString(c), randomString(c), "", time.Now(), *credentials)
+		c.Assert(err, qt.IsNil)
 
-	accessToken, refreshToken, expiry, scopes = randomString(c), randomString(c), time.Now().Round(time.Minute), randomString(c)
-	session2, err := s.CreateSessionGoogle(context.Background(), userID, accessToken, refreshToken, scopes, expiry, *credentials)
-	c.Assert(err, qt.IsNil)
+		err = s.MarkCredentialsGoogleExhausted(context.Background(), credentials.ID)
+		c.Assert(err, qt.IsNil)
+		err = s.MarkCredentialsGoogleExhausted(context.Background(), 0)
+		c.Assert(err, qt.Equals, errs.NotFound)
 
-	sessions, err := s.GetSessionGoogleAll(context.Background(), userID)
-	c.Assert(err, qt.IsNil)
+		retrieved, err := s.GetCredentialsGoogleByID(context.Background(), credentials.ID)
+		c.Assert(err, qt.IsNil)
+		c.Assert(retrieved.Usage, qt.Equals, uint(quota.Google))
 
-	c.Assert(sessions, qt.Contains, *session1)
-	c.Assert(sessions, qt.Contains, *session2)
+		_, _, err = s.GetSessionGoogleByAvailableCost(context.Background(), userID, "channel", 1)
+		c.Assert(err, qt.Equals, errs.NotFound)
+	})
 }
 
-func TestGetSessionGoogleByCredentialsID(t *testing.T) {
+func TestCredentialsGoogleUsageResetSQLite(t *testing.T) {
 	c := qt.New(t)
-	s := setupStore(c)
+	path := filepath.Join(c.TempDir(), "autocc.db")
+	s, err := store.NewSQLite(path, randomKeyring(c))
+	c.Assert(err, qt.IsNil)
+	ctx := context.Background()
+
+	credentials, err := s.AddCredentialsGoogle(ctx, "client", "secret")
+	c.Assert(err, qt.IsNil)
+	userID := randomString(c)
+	_, err = s.CreateSessionGoogle(ctx, userID, "channel", "Channel", randomString(c), randomString(c), "", time.Now(), *credentials)
+	c.Assert(err, qt.IsNil)
 
-	credentials, err := s.AddCredentialsGoogle(context.Background(), "client", "secret")
+	err = s.MarkCredentialsGoogleExhausted(ctx, credentials.ID)
 	c.Assert(err, qt.IsNil)
+	_, _, err = s.GetSessionGoogleByAvailableCost(ctx, userID, "channel", 1)
+	c.Assert(err, qt.Equals, errs.NotFound)
 
-	userID, accessToken, refreshToken, expiry, scopes := randomString(c), randomString(c), randomString(c), time.Now().Round(time.Minute), randomString(c)
-	session, err := s.CreateSessionGoogle(context.Background(), userID, accessToken, refreshToken, scopes, expiry, *credentials)
+	// Exhausted credentials return once the quota day they were exhausted on has passed.
+	db, err := gorm.Open(sqlite.Open(path), &gorm.Config{})
+	c.Assert(err, qt.IsNil)
+	sqlDB, err := db.DB()
 	c.Assert(err, qt.IsNil)
+	defer sqlDB.Close()
+	yesterday := quota.GoogleDayStart(time.Now()).Add(-time.Hour * 24)
+	c.Assert(db.Exec("UPDATE credentials_google SET usage_reset_at = ?", yesterday).Error, qt.IsNil)
 
-	retrieved, err := s.GetSessionGoogleByCredentialsID(context.Background(), credentials.ID, userID)
+	cost := uint(quota.YoutubeCaptionsList)
+	retrieved, _, err := s.GetSessionGoogleByAvailableCost(ctx, userID, "channel", cost)
 	c.Assert(err, qt.IsNil)
-	c.Assert(retrieved, qt.DeepEquals, session)
+	c.Assert(retrieved.Credentials.Usage, qt.Equals, cost)
+	c.Assert(retrieved.Credentials.UsageResetAt.Equal(quota.GoogleDayStart(time.Now())), qt.IsTrue)
 }
 
-func TestGetSessionGoogleByAvailableCost(t *testing.T) {
-	c := qt.New(t)
-	s := setupStore(c)
+func TestSessionGoogleChannels(t *testing.T) {
+	forEachBackend(t, func(c *qt.C, s store.Store) {
+		credentials1, err := s.AddCredentialsGoogle(context.Background(), "client1", "secret")
+		c.Assert(err, qt.IsNil)
+		credentials2, err := s.AddCredentialsGoogle(context.Background(), "client2", "secret")
+		c.Assert(err, qt.IsNil)
+
+		userID, expiry := randomString(c), time.Now().Round(time.Minute)
+		session1, err := s.CreateSessionGoogle(context.Background(), userID, "channel1", "Channel 1", randomString(c), randomString(c), "", expiry, *credentials1)
+		c.Assert(err, qt.IsNil)
+		session2, err := s.CreateSessionGoogle(context.Background(), userID, "channel2", "Channel 2", randomString(c), randomString(c), "", expiry, *credentials2)
+		c.Assert(err, qt.IsNil)
+
+		// Sessions are only used for their own channel.
+		retrieved, _, err := s.GetSessionGoogleByAvailableCost(context.Background(), userID, "channel1", 1)
+		c.Assert(err, qt.IsNil)
+		c.Assert(retrieved.ID, qt.Equals, session1.ID)
+		retrieved, _, err = s.GetSessionGoogleByAvailableCost(context.Background(), userID, "channel2", 1)
+		c.Assert(err, qt.IsNil)
+		c.Assert(retrieved.ID, qt.Equals, session2.ID)
+		_, _, err = s.GetSessionGoogleByAvailableCost(context.Background(), userID, "channel3", 1)
+		c.Assert(err, qt.Equals, errs.NotFound)
+
+		// The same credentials can hold sessions for multiple channels.
+		session3, err := s.CreateSessionGoogle(context.Background(), userID, "channel2", "Channel 2", randomString(c), randomString(c), "", expiry, *credentials1)
+		c.Assert(err, qt.IsNil)
+
+		sessions, err := s.GetSessionGoogleAll(context.Background(), userID)
+		c.Assert(err, qt.IsNil)
+		c.Assert(sessions, qt.HasLen, 3)
+
+		// Authenticating the same channel again replaces its session.
+		session4, err := s.CreateSessionGoogle(context.Background(), userID, "channel2", "Channel 2", randomString(c), randomString(c), "", expiry, *credentials1)
+		c.Assert(err, qt.IsNil)
+
+		sessions, err = s.GetSessionGoogleAll(context.Background(), userID)
+		c.Assert(err, qt.IsNil)
+		c.Assert(sessions, qt.HasLen, 3)
+		ids := []uint{sessions[0].ID, sessions[1].ID, sessions[2].ID}
+		c.Assert(ids, qt.Contains, session4.ID)
+		c.Assert(slices.Contains(ids, session3.ID), qt.IsFalse)
+	})
+}
 
-	credentials, err := s.AddCredentialsGoogle(context.Background(), "client", "secret")
-	c.Assert(err, qt.IsNil)
+func TestMarkSessionGoogleBroken(t *testing.T) {
+	forEachBackend(t, func(c *qt.C, s store.Store) {
+		credentials, err := s.AddCredentialsGoogle(context.Background(), "client", "secret")
+		c.Assert(err, qt.IsNil)
 
-	userID, accessToken, refreshToken, expiry, scopes := randomString(c), randomString(c), randomString(c), time.Now().Round(time.Minute), randomString(c)
+		userID, expiry := randomString(c), time.Now().Round(time.Minute)
+		session, err := s.CreateSessionGoogle(context.Background(), userID, "channel", "Channel", randomString(c), randomString(c), "", expiry, *credentials)
+		c.Assert(err, qt.IsNil)
 
-	session, err := s.CreateSessionGoogle(context.Background(), userID, accessToken, refreshToken, scopes, expiry, *credentials)
-	c.Assert(err, qt.IsNil)
+		err = s.MarkSessionGoogleBroken(context.Background(), session.ID)
+		c.Assert(err, qt.IsNil)
+		err = s.MarkSessionGoogleBroken(context.Background(), 0)
+		c.Assert(err, qt.Equals, errs.NotFound)
 
-	newSession, revert, err := s.GetSessionGoogleByAvailableCost(context.Background(), userID, 1000)
-	c.Assert(err, qt.IsNil)
-	c.Assert(newSession.ID, qt.DeepEquals, session.ID)
-	c.Assert(newSession.Credentials.Usage, qt.Equals, uint(1000))
+		retrieved, err := s.GetSessionGoogle(context.Background(), userID, session.ID)
+		c.Assert(err, qt.IsNil)
+		c.Assert(retrieved.Broken, qt.IsTrue)
 
-	retrieved, err := s.GetSessionGoogleByCredentialsID(context.Background(), credentials.ID, userID)
-	c.Assert(err, qt.IsNil)
-	c.Assert(retrieved.Credentials.Usage, qt.Equals, uint(1000))
+		_, _, err = s.GetSessionGoogleByAvailableCost(context.Background(), userID, "channel", 1)
+		c.Assert(err, qt.Equals, errs.NotFound)
 
-	retrieved, _, err = s.GetSessionGoogleByAvailableCost(context.Background(), userID, 500)
-	c.Assert(err, qt.IsNil)
-	c.Assert(retrieved.ID, qt.DeepEquals, session.ID)
-	c.Assert(retrieved.Credentials.Usage, qt.Equals, uint(1500))
+		// Authenticating again replaces the broken session.
+		newSession, err := s.CreateSessionGoogle(context.Background(), userID, "channel", "Channel", randomString(c), randomString(c), "", expiry, *credentials)
+		c.Assert(err, qt.IsNil)
 
-	err = revert()
-	c.Assert(err, qt.IsNil)
+		sessions, err := s.GetSessionGoogleAll(context.Background(), userID)
+		c.Assert(err, qt.IsNil)
+		c.Assert(sessions, qt.HasLen, 1)
+		c.Assert(sessions[0].ID, qt.Equals, newSession.ID)
+		c.Assert(sessions[0].Broken, qt.IsFalse)
 
-	retrieved, err = s.GetSessionGoogleByCredentialsID(context.Background(), credentials.ID, userID)
-	c.Assert(err, qt.IsNil)
-	c.Assert(retrieved.Credentials.Usage, qt.Equals, uint(500))
+		retrieved, _, err = s.GetSessionGoogleByAvailableCost(context.Background(), userID, "channel", 1)
+		c.Assert(err, qt.IsNil)
+		c.Assert(retrieved.ID, qt.Equals, newSession.ID)
+	})
 }
 
-func TestTransaction(t *testing.T) {
-	c := qt.New(t)
-	s := setupStore(c)
+func TestSourceCC(t *testing.T) {
+	forEachBackend(t, func(c *qt.C, s store.Store) {
+		userID, videoID, srt := randomString(c), randomString(c), randomString(c)
 
-	var google *model.CredentialsGoogle
-	var deepl *model.CredentialsDeepL
+		saved, err := s.SaveSourceCC(context.Background(), userID, videoID, "en", srt, false)
+		c.Assert(err, qt.IsNil)
+		c.Assert(saved.Srt, qt.Equals, srt)
 
-	err := s.Transaction(context.Background(), func(ctx context.Context, store store.Store) error {
-		id, secret := randomString(c), randomString(c)
-		var err error
-		google, err = store.AddCredentialsGoogle(ctx, id, secret)
-		if err != nil {
-			return err
-		}
+		retrieved, err := s.GetSourceCC(context.Background(), userID, videoID)
+		c.Assert(err, qt.IsNil)
+		c.Assert(retrieved.Language, qt.Equals, "en")
+		c.Assert(retrieved.Srt, qt.Equals, srt)
+		c.Assert(retrieved.UploadOriginal, qt.IsFalse)
 
-		key := randomString(c)
-		deepl, err = store.AddCredentialsDeepL(ctx, key, 10)
-		return err
+		newSrt := randomString(c)
+		_, err = s.SaveSourceCC(context.Background(), userID, videoID, "pl", newSrt, true)
+		c.Assert(err, qt.IsNil)
+
+		retrieved, err = s.GetSourceCC(context.Background(), userID, videoID)
+		c.Assert(err, qt.IsNil)
+		c.Assert(retrieved.Language, qt.Equals, "pl")
+		c.Assert(retrieved.Srt, qt.Equals, newSrt)
+		c.Assert(retrieved.UploadOriginal, qt.IsTrue)
+
+		_, err = s.GetSourceCC(context.Background(), randomString(c), videoID)
+		c.Assert(err, qt.Equals, errs.NotFound)
+
+		err = s.RemoveSourceCC(context.Background(), userID, videoID)
+		c.Assert(err, qt.IsNil)
+
+		_, err = s.GetSourceCC(context.Background(), userID, videoID)
+		c.Assert(err, qt.Equals, errs.NotFound)
 	})
-	c.Assert(err, qt.IsNil)
+}
 
-	googleAll, err := s.GetCredentialsGoogleAll(context.Background())
-	c.Assert(err, qt.IsNil)
-	c.Assert(googleAll, qt.Contains, *google)
+func TestTranslationMemory(t *testing.T) {
+	forEachBackend(t, func(c *qt.C, s store.Store) {
+		sourceLanguage, targetLanguage := randomString(c), randomString(c)
+		first, second := randomString(c), randomString(c)
 
-	deeplAll, err := s.GetCredentialsDeepLAll(context.Background())
-	c.Assert(err, qt.IsNil)
-	c.Assert(deeplAll, qt.Contains, *deepl)
+		memory, err := s.GetTranslationMemory(context.Background(), sourceLanguage, targetLanguage, []string{first, second})
+		c.Assert(err, qt.IsNil)
+		c.Assert(memory, qt.HasLen, 0)
 
-	err = s.Transaction(context.Background(), func(ctx context.Context, store store.Store) error {
-		id, secret := randomString(c), randomString(c)
-		var err error
-		google, err = store.AddCredentialsGoogle(ctx, id, secret)
-		if err != nil {
-			return err
+		err = s.SaveTranslationMemory(context.Background(), sourceLanguage, targetLanguage, map[string]string{first: "a"})
+		c.Assert(err, qt.IsNil)
+
+		memory, err = s.GetTranslationMemory(context.Background(), sourceLanguage, targetLanguage, []string{first, second})
+		c.Assert(err, qt.IsNil)
+		c.Assert(memory, qt.DeepEquals, map[string]string{first: "a"})
+
+		err = s.SaveTranslationMemory(context.Background(), sourceLanguage, targetLanguage, map[string]string{first: "b", second: "c"})
+		c.Assert(err, qt.IsNil)
+
+		memory, err = s.GetTranslationMemory(context.Background(), sourceLanguage, targetLanguage, []string{first, second})
+		c.Assert(err, qt.IsNil)
+		c.Assert(memory, qt.DeepEquals, map[string]string{first: "b", second: "c"})
+
+		memory, err = s.GetTranslationMemory(context.Background(), targetLanguage, sourceLanguage, []string{first, second})
+		c.Assert(err, qt.IsNil)
+		c.Assert(memory, qt.HasLen, 0)
+	})
+}
+
+func TestRemoveTranslationMemory(t *testing.T) {
+	forEachBackend(t, func(c *qt.C, s store.Store) {
+		ctx := context.Background()
+		language, other := randomString(c), randomString(c)
+		text := randomString(c)
+
+		err := s.SaveTranslationMemory(ctx, language, other, map[string]string{text: "a"})
+		c.Assert(err, qt.IsNil)
+		err = s.SaveTranslationMemory(ctx, other, language, map[string]string{text: "b"})
+		c.Assert(err, qt.IsNil)
+
+		_, err = s.RemoveTranslationMemory(ctx, "", "")
+		c.Assert(err, qt.Equals, errs.InvalidInput)
+
+		removed, err := s.RemoveTranslationMemory(ctx, strings.ToUpper(language), "")
+		c.Assert(err, qt.IsNil)
+		c.Assert(removed, qt.Equals, 1)
+
+		memory, err := s.GetTranslationMemory(ctx, language, other, []string{text})
+		c.Assert(err, qt.IsNil)
+		c.Assert(memory, qt.HasLen, 0)
+		memory, err = s.GetTranslationMemory(ctx, other, language, []string{text})
+		c.Assert(err, qt.IsNil)
+		c.Assert(memory, qt.DeepEquals, map[string]string{text: "b"})
+
+		removed, err = s.RemoveTranslationMemory(ctx, "", language)
+		c.Assert(err, qt.IsNil)
+		c.Assert(removed, qt.Equals, 1)
+	})
+}
+
+func TestLocalizations(t *testing.T) {
+	forEachBackend(t, func(c *qt.C, s store.Store) {
+		ctx := context.Background()
+		resourceID := randomString(c)
+
+		localizations, err := s.GetLocalizations(ctx, model.LocalizationKindVideo, resourceID)
+		c.Assert(err, qt.IsNil)
+		c.Assert(localizations, qt.HasLen, 0)
+
+		err = s.SaveLocalizations(ctx, []model.Localization{
+			{Kind: model.LocalizationKindVideo, ResourceID: resourceID, Language: "fr", Hash: "a", SourceHash: "source"},
+			{Kind: model.LocalizationKindVideo, ResourceID: resourceID, Language: "de", Hash: "b", SourceHash: "source"},
+			{Kind: model.LocalizationKindPlaylist, ResourceID: resourceID, Language: "de", Hash: "c", SourceHash: "source"},
+		})
+		c.Assert(err, qt.IsNil)
+
+		err = s.SaveLocalizations(ctx, []model.Localization{
+			{Kind: model.LocalizationKindVideo, ResourceID: resourceID, Language: "de", Hash: "d", SourceHash: "changed"},
+		})
+		c.Assert(err, qt.IsNil)
+
+		localizations, err = s.GetLocalizations(ctx, model.LocalizationKindVideo, resourceID)
+		c.Assert(err, qt.IsNil)
+		c.Assert(localizations, qt.HasLen, 2)
+		c.Assert(localizations[0].Language, qt.Equals, "de")
+		c.Assert(localizations[0].Hash, qt.Equals, "d")
+		c.Assert(localizations[0].SourceHash, qt.Equals, "changed")
+		c.Assert(localizations[1].Language, qt.Equals, "fr")
+		c.Assert(localizations[1].Hash, qt.Equals, "a")
+
+		localizations, err = s.GetLocalizations(ctx, model.LocalizationKindPlaylist, resourceID)
+		c.Assert(err, qt.IsNil)
+		c.Assert(localizations, qt.HasLen, 1)
+
+		// Localizations of many resources are returned at once.
+		otherID := randomString(c)
+		err = s.SaveLocalizations(ctx, []model.Localization{
+			{Kind: model.LocalizationKindVideo, ResourceID: otherID, Language: "es", Hash: "e", SourceHash: "source"},
+		})
+		c.Assert(err, qt.IsNil)
+		localizations, err = s.GetLocalizations(ctx, model.LocalizationKindVideo, resourceID, otherID, randomString(c))
+		c.Assert(err, qt.IsNil)
+		c.Assert(localizations, qt.HasLen, 3)
+		for _, localization := range localizations {
+			c.Assert(localization.ResourceID == resourceID || localization.ResourceID == otherID, qt.IsTrue)
 		}
 
-		ctx, cancel := context.WithCancel(ctx)
-		cancel()
+		localizations, err = s.GetLocalizations(ctx, model.LocalizationKindVideo)
+		c.Assert(err, qt.IsNil)
+		c.Assert(localizations, qt.HasLen, 0)
+	})
+}
 
-		key := randomString(c)
-		deepl, err = store.AddCredentialsDeepL(ctx, key, 20)
-		return err
+func TestTranslatedCC(t *testing.T) {
+	forEachBackend(t, func(c *qt.C, s store.Store) {
+		ctx := context.Background()
+		videoID, otherID := randomString(c), randomString(c)
+
+		translatedCC, err := s.GetTranslatedCC(ctx, videoID)
+		c.Assert(err, qt.IsNil)
+		c.Assert(translatedCC, qt.HasLen, 0)
+
+		err = s.SaveTranslatedCC(ctx, []model.TranslatedCC{
+			{VideoID: videoID, Language: "fr", TrackID: "a"},
+			{VideoID: videoID, Language: "de", TrackID: "b"},
+			{VideoID: otherID, Language: "de", TrackID: "c"},
+		})
+		c.Assert(err, qt.IsNil)
+		translatedCC, err = s.GetTranslatedCC(ctx, videoID)
+		c.Assert(err, qt.IsNil)
+		c.Assert(translatedCC, qt.HasLen, 2)
+		translatedAt := translatedCC[0].UpdatedAt
+
+		// Translating again replaces the track and the time of the translation.
+		err = s.SaveTranslatedCC(ctx, []model.TranslatedCC{{VideoID: videoID, Language: "de", TrackID: "d"}})
+		c.Assert(err, qt.IsNil)
+
+		translatedCC, err = s.GetTranslatedCC(ctx, videoID)
+		c.Assert(err, qt.IsNil)
+		c.Assert(translatedCC, qt.HasLen, 2)
+		c.Assert(translatedCC[0].Language, qt.Equals, "de")
+		c.Assert(translatedCC[0].TrackID, qt.Equals, "d")
+		c.Assert(translatedCC[0].UpdatedAt.After(translatedAt), qt.IsTrue)
+		c.Assert(translatedCC[1].Language, qt.Equals, "fr")
+		c.Assert(translatedCC[1].TrackID, qt.Equals, "a")
+
+		translatedCC, err = s.GetTranslatedCC(ctx, videoID, otherID)
+		c.Assert(err, qt.IsNil)
+		c.Assert(translatedCC, qt.HasLen, 3)
+
+		translatedCC, err = s.GetTranslatedCC(ctx)
+		c.Assert(err, qt.IsNil)
+		c.Assert(translatedCC, qt.HasLen, 0)
 	})
-	c.Assert(err, qt.IsNotNil)
+}
 
-	newGoogleAll, err := s.GetCredentialsGoogleAll(context.Background())
-	c.Assert(err, qt.IsNil)
-	c.Assert(googleAll, qt.DeepEquals, newGoogleAll)
+func TestAddedTags(t *testing.T) {
+	forEachBackend(t, func(c *qt.C, s store.Store) {
+		ctx := context.Background()
+		videoID, otherID := randomString(c), randomString(c)
 
-	newDeepLAll, err := s.GetCredentialsDeepLAll(context.Background())
-	c.Assert(err, qt.IsNil)
-	c.Assert(deeplAll, qt.DeepEquals, newDeepLAll)
+		tags, err := s.GetAddedTags(ctx, videoID)
+		c.Assert(err, qt.IsNil)
+		c.Assert(tags, qt.HasLen, 0)
+
+		err = s.SaveAddedTags(ctx, videoID, []string{"Musik", "chanson"})
+		c.Assert(err, qt.IsNil)
+		err = s.SaveAddedTags(ctx, otherID, []string{"musique"})
+		c.Assert(err, qt.IsNil)
+
+		// Tags recorded already are skipped.
+		err = s.SaveAddedTags(ctx, videoID, []string{"musik", "canción"})
+		c.Assert(err, qt.IsNil)
+
+		tags, err = s.GetAddedTags(ctx, videoID)
+		c.Assert(err, qt.IsNil)
+		c.Assert(tags, qt.DeepEquals, []string{"canción", "chanson", "musik"})
+	})
 }
 
-func TestContext(t *testing.T) {
-	c := qt.New(t)
-	s := setupStore(c)
+func TestTransaction(t *testing.T) {
+	forEachBackend(t, func(c *qt.C, s store.Store) {
+		var google *model.CredentialsGoogle
+		var deepl *model.CredentialsDeepL
+
+		err := s.Transaction(context.Background(), func(ctx context.Context, store store.Store) error {
+			id, secret := randomString(c), randomString(c)
+			var err error
+			google, err = store.AddCredentialsGoogle(ctx, id, secret)
+			if err != nil {
+				return err
+			}
+
+			key := randomString(c)
+			deepl, err = store.AddCredentialsDeepL(ctx, key, 10)
+			return err
+		})
+		c.Assert(err, qt.IsNil)
+
+		googleAll, err := s.GetCredentialsGoogleAll(context.Background())
+		c.Assert(err, qt.IsNil)
+		c.Assert(googleAll, qt.Contains, *google)
+
+		deeplAll, err := s.GetCredentialsDeepLAll(context.Background())
+		c.Assert(err, qt.IsNil)
+		c.Assert(deeplAll, qt.Contains, *deepl)
+
+		err = s.Transaction(context.Background(), func(ctx context.Context, store store.Store) error {
+			id, secret := randomString(c), randomString(c)
+			var err error
+			google, err = store.AddCredentialsGoogle(ctx, id, secret)
+			if err != nil {
+				return err
+			}
+
+			ctx, cancel := context.WithCancel(ctx)
+			cancel()
+
+			key := randomString(c)
+			deepl, err = store.AddCredentialsDeepL(ctx, key, 20)
+			return err
+		})
+		c.Assert(err, qt.IsNotNil)
 
-	ctx, cancel := context.WithCancel(context.Background())
-	cancel()
+		newGoogleAll, err := s.GetCredentialsGoogleAll(context.Background())
+		c.Assert(err, qt.IsNil)
+		c.Assert(googleAll, qt.DeepEquals, newGoogleAll)
 
-	_, err := s.AddCredentialsGoogle(ctx, randomString(c), randomString(c))
-	c.Assert(err, qt.IsNotNil)
+		newDeepLAll, err := s.GetCredentialsDeepLAll(context.Background())
+		c.Assert(err, qt.IsNil)
+		c.Assert(deeplAll, qt.DeepEquals, newDeepLAll)
+	})
+}
 
-	_, err = s.AddCredentialsDeepL(ctx, randomString(c), 0)
-	c.Assert(err, qt.IsNotNil)
+func TestContext(t *testing.T) {
+	forEachBackend(t, func(c *qt.C, s store.Store) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
 
-	_, err = s.GetCredentialsGoogleAll(ctx)
-	c.Assert(err, qt.IsNotNil)
+		_, err := s.AddCredentialsGoogle(ctx, randomString(c), randomString(c))
+		c.Assert(err, qt.IsNotNil)
 
-	_, err = s.GetCredentialsDeepLAll(ctx)
-	c.Assert(err, qt.IsNotNil)
+		_, err = s.AddCredentialsDeepL(ctx, randomString(c), 0)
+		c.Assert(err, qt.IsNotNil)
 
-	_, err = s.GetCredentialsGoogleByID(ctx, 1)
-	c.Assert(err, qt.IsNotNil)
+		_, err = s.GetCredentialsGoogleAll(ctx)
+		c.Assert(err, qt.IsNotNil)
 
-	_, err = s.GetCredentialsDeepLByID(ctx, 1)
-	c.Assert(err, qt.IsNotNil)
+		_, err = s.GetCredentialsDeepLAll(ctx)
+		c.Assert(err, qt.IsNotNil)
 
-	err = s.RemoveCredentialsGoogle(ctx, 1)
-	c.Assert(err, qt.IsNotNil)
+		_, err = s.GetCredentialsGoogleByID(ctx, 1)
+		c.Assert(err, qt.IsNotNil)
 
-	err = s.RemoveCredentialsDeepL(ctx, 1)
-	c.Assert(err, qt.IsNotNil)
+		_, err = s.GetCredentialsDeepLByID(ctx, 1)
+		c.Assert(err, qt.IsNotNil)
 
-	_, err = s.CreateSessionGoogle(ctx, randomString(c), randomString(c), randomString(c), randomString(c), time.Now(), model.CredentialsGoogle{})
-	c.Assert(err, qt.IsNotNil)
+		err = s.RemoveCredentialsGoogle(ctx, 1)
+		c.Assert(err, qt.IsNotNil)
 
-	_, err = s.GetSessionGoogleAll(ctx, randomString(c))
-	c.Assert(err, qt.IsNotNil)
+		err = s.RemoveCredentialsDeepL(ctx, 1)
+		c.Assert(err, qt.IsNotNil)
 
-	err = s.RemoveSessionGoogle(ctx, randomString(c), uint(1))
-	c.Assert(err, qt.IsNotNil)
+		_, err = s.CreateSessionGoogle(ctx, randomString(c), randomString(c), randomString(c), randomString(c), randomString(c), randomString(c), time.Now(), model.CredentialsGoogle{})
+		c.Assert(err, qt.IsNotNil)
 
-	err = s.SaveSessionState(ctx, uint(1), randomString(c), randomString(c), randomString(c), "https://example.com")
-	c.Assert(err, qt.IsNotNil)
+		_, err = s.GetSessionGoogleAll(ctx, randomString(c))
+		c.Assert(err, qt.IsNotNil)
 
-	_, err = s.GetSessionState(ctx, randomString(c))
-	c.Assert(err, qt.IsNotNil)
+		err = s.RemoveSessionGoogle(ctx, randomString(c), uint(1))
+		c.Assert(err, qt.IsNotNil)
 
-	_, _, err = s.GetSessionGoogleByAvailableCost(ctx, randomString(c), 1)
-	c.Assert(err, qt.IsNotNil)
+		err = s.SaveSessionState(ctx, uint(1), randomString(c), randomString(c), randomString(c), randomString(c), "https://example.com")
+		c.Assert(err, qt.IsNotNil)
 
-	_ = s.UpdateSessionGoogle(ctx, &model.SessionGoogle{})
-	c.Assert(err, qt.IsNotNil)
+		_, err = s.GetSessionState(ctx, randomString(c))
+		c.Assert(err, qt.IsNotNil)
+
+		_, _, err = s.GetSessionGoogleByAvailableCost(ctx, randomString(c), randomString(c), 1)
+		c.Assert(err, qt.IsNotNil)
 
-	err = s.Transaction(ctx, func(ctx context.Context, store store.Store) error {
-		return nil
+		_ = s.UpdateSessionGoogle(ctx, &model.SessionGoogle{})
+		c.Assert(err, qt.IsNotNil)
+
+		err = s.Transaction(ctx, func(ctx context.Context, store store.Store) error {
+			return nil
+		})
+		c.Assert(err, qt.IsNotNil)
 	})
-	c.Assert(err, qt.IsNotNil)
 }
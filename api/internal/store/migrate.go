@@ -0,0 +1,307 @@
+package store
+
+import (
+	"context"
+	"embed"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path"
+	"regexp"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"gorm.io/gorm"
+)
+
+//go:embed migrations
+var migrationsFS embed.FS
+
+// ErrSchemaTooNew is returned when the database schema was migrated by a newer version of AutoCC.
+var ErrSchemaTooNew = errors.New("store: database schema is newer than supported")
+
+var migrationFileRegex = regexp.MustCompile(`^(\d+)_(\w+)\.(up|down)\.sql$`)
+
+// Migration is a versioned change of the database schema.
+type Migration struct {
+	Version uint
+	Name    string
+	Up      string
+	Down    string
+}
+
+// MigrationStatus describes whether a migration was applied.
+type MigrationStatus struct {
+	Version uint
+	Name    string
+	// AppliedAt is nil if the migration wasn't applied.
+	AppliedAt *time.Time
+}
+
+const createMigrationsTable = `CREATE TABLE IF NOT EXISTS schema_migrations (
+	version BIGINT PRIMARY KEY,
+	name TEXT NOT NULL,
+	applied_at TIMESTAMP NOT NULL
+)`
+
+// schemaMigration is a row of the table recording applied migrations.
+type schemaMigration struct {
+	Version   uint `gorm:"primaryKey"`
+	Name      string
+	AppliedAt time.Time
+}
+
+// TableName returns the table name for the model.
+func (m *schemaMigration) TableName() string {
+	return "schema_migrations"
+}
+
+// Migrations returns migrations embedded in the binary for the SQL dialect, ordered by version.
+func Migrations(dialect string) ([]Migration, error) {
+	dir := path.Join("migrations", dialect)
+	entries, err := fs.ReadDir(migrationsFS, dir)
+	if err != nil {
+		return nil, fmt.Errorf("unsupported dialect %s: %w", dialect, err)
+	}
+
+	byVersion := make(map[uint]*Migration)
+	for _, entry := range entries {
+		match := migrationFileRegex.FindStringSubmatch(entry.Name())
+		if match == nil {
+			return nil, fmt.Errorf("invalid migration file name: %s", entry.Name())
+		}
+		version, err := strconv.ParseUint(match[1], 10, 64)
+		if err != nil {
+			return nil, err
+		}
+
+		data, err := fs.ReadFile(migrationsFS, path.Join(dir, entry.Name()))
+		if err != nil {
+			return nil, err
+		}
+
+		migration, ok := byVersion[uint(version)]
+		if !ok {
+			migration = &Migration{Version: uint(version), Name: match[2]}
+			byVersion[uint(version)] = migration
+		}
+		if migration.Name != match[2] {
+			return nil, fmt.Errorf("conflicting names of migration %d: %s, %s", version, migration.Name, match[2])
+		}
+		if match[3] == "up" {
+			migration.Up = string(data)
+		} else {
+			migration.Down = string(data)
+		}
+	}
+
+	migrations := make([]Migration, 0, len(byVersion))
+	for _, m := range byVersion {
+		if m.Up == "" || m.Down == "" {
+			return nil, fmt.Errorf("migration %d is missing its up or down file", m.Version)
+		}
+		migrations = append(migrations, *m)
+	}
+	slices.SortFunc(migrations, func(a, b Migration) int {
+		return int(a.Version) - int(b.Version)
+	})
+	for i, m := range migrations {
+		if m.Version != uint(i+1) {
+			return nil, fmt.Errorf("missing migration %d", i+1)
+		}
+	}
+
+	return migrations, nil
+}
+
+// migrationLockID identifies the PostgreSQL advisory lock held while migrating, the value is arbitrary.
+const migrationLockID = 0x6175746f6363
+
+type migrator struct {
+	db         *gorm.DB
+	migrations []Migration
+}
+
+// NewMigrator creates a new migrator of the PostgreSQL database schema.
+func NewMigrator(host string, port uint16, user, pass, dbName string) (*migrator, error) {
+	db, err := openPostgres(host, port, user, pass, dbName)
+	if err != nil {
+		return nil, err
+	}
+	return newMigrator(db)
+}
+
+// NewSQLiteMigrator creates a new migrator of the SQLite database schema.
+func NewSQLiteMigrator(path string) (*migrator, error) {
+	db, err := openSQLite(path)
+	if err != nil {
+		return nil, err
+	}
+	return newMigrator(db)
+}
+
+func newMigrator(db *gorm.DB) (*migrator, error) {
+	migrations, err := Migrations(db.Dialector.Name())
+	if err != nil {
+		return nil, err
+	}
+
+	return &migrator{
+		db:         db,
+		migrations: migrations,
+	}, nil
+}
+
+// locked runs fn with the migrations locked, so instances starting at the same time don't apply them concurrently.
+// PostgreSQL holds an advisory lock on a single connection, SQLite runs fn in a transaction begun with BEGIN IMMEDIATE.
+func (m *migrator) locked(ctx context.Context, fn func(db *gorm.DB) error) error {
+	db := m.db.WithContext(ctx)
+	if db.Dialector.Name() != "postgres" {
+		return db.Transaction(fn)
+	}
+
+	return db.Connection(func(conn *gorm.DB) error {
+		if err := conn.Exec("SELECT pg_advisory_lock(?)", migrationLockID).Error; err != nil {
+			return err
+		}
+		defer func() {
+			// The lock belongs to the connection, which returns to the pool, so it's released even if the context is canceled.
+			if err := conn.WithContext(context.WithoutCancel(ctx)).Exec("SELECT pg_advisory_unlock(?)", migrationLockID).Error; err != nil {
+				log.Error().Err(err).Msg("failed to release migration lock")
+			}
+		}()
+		return fn(conn)
+	})
+}
+
+// applied returns the applied migrations by version, none if the table recording them wasn't created yet.
+func (m *migrator) applied(db *gorm.DB) (map[uint]schemaMigration, error) {
+	if !db.Migrator().HasTable(&schemaMigration{}) {
+		return map[uint]schemaMigration{}, nil
+	}
+
+	var rows []schemaMigration
+	if err := db.Find(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	applied := make(map[uint]schemaMigration, len(rows))
+	for _, row := range rows {
+		applied[row.Version] = row
+	}
+	return applied, nil
+}
+
+// Check returns ErrSchemaTooNew if the database has migrations applied which are unknown to this binary.
+func (m *migrator) Check(ctx context.Context) error {
+	_, err := m.check(m.db.WithContext(ctx))
+	return err
+}
+
+// check returns the applied migrations, or ErrSchemaTooNew if some of them are unknown to this binary.
+func (m *migrator) check(db *gorm.DB) (map[uint]schemaMigration, error) {
+	applied, err := m.applied(db)
+	if err != nil {
+		return nil, err
+	}
+
+	latest := uint(len(m.migrations))
+	for version := range applied {
+		if version > latest {
+			log.Error().Uint("version", version).Uint("supported", latest).Msg("database schema is newer than supported")
+			return nil, ErrSchemaTooNew
+		}
+	}
+	return applied, nil
+}
+
+// Up applies all pending migrations and returns their count.
+// Migrations are locked while they're applied.
+func (m *migrator) Up(ctx context.Context) (int, error) {
+	count := 0
+	err := m.locked(ctx, func(db *gorm.DB) error {
+		// The table is created with the lock held, concurrent CREATE TABLE IF NOT EXISTS can fail on PostgreSQL.
+		if err := db.Exec(createMigrationsTable).Error; err != nil {
+			return err
+		}
+		applied, err := m.check(db)
+		if err != nil {
+			return err
+		}
+
+		for _, migration := range m.migrations {
+			if _, ok := applied[migration.Version]; ok {
+				continue
+			}
+
+			err := db.Transaction(func(tx *gorm.DB) error {
+				if err := tx.Exec(migration.Up).Error; err != nil {
+					return err
+				}
+				return tx.Create(&schemaMigration{Version: migration.Version, Name: migration.Name, AppliedAt: time.Now()}).Error
+			})
+			if err != nil {
+				return fmt.Errorf("failed to apply migration %d_%s: %w", migration.Version, migration.Name, err)
+			}
+
+			log.Info().Uint("version", migration.Version).Str("name", migration.Name).Msg("applied migration")
+			count++
+		}
+		return nil
+	})
+	return count, err
+}
+
+// Down reverts up to the given number of the most recently applied migrations and returns their count.
+// Migrations are locked while they're reverted.
+func (m *migrator) Down(ctx context.Context, steps int) (int, error) {
+	count := 0
+	err := m.locked(ctx, func(db *gorm.DB) error {
+		applied, err := m.check(db)
+		if err != nil {
+			return err
+		}
+
+		for i := len(m.migrations) - 1; i >= 0 && count < steps; i-- {
+			migration := m.migrations[i]
+			if _, ok := applied[migration.Version]; !ok {
+				continue
+			}
+
+			err := db.Transaction(func(tx *gorm.DB) error {
+				if err := tx.Exec(migration.Down).Error; err != nil {
+					return err
+				}
+				return tx.Delete(&schemaMigration{Version: migration.Version}).Error
+			})
+			if err != nil {
+				return fmt.Errorf("failed to revert migration %d_%s: %w", migration.Version, migration.Name, err)
+			}
+
+			log.Info().Uint("version", migration.Version).Str("name", migration.Name).Msg("reverted migration")
+			count++
+		}
+		return nil
+	})
+	return count, err
+}
+
+// Status returns the status of all migrations known to this binary.
+func (m *migrator) Status(ctx context.Context) ([]MigrationStatus, error) {
+	applied, err := m.applied(m.db.WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	status := make([]MigrationStatus, 0, len(m.migrations))
+	for _, migration := range m.migrations {
+		s := MigrationStatus{Version: migration.Version, Name: migration.Name}
+		if row, ok := applied[migration.Version]; ok {
+			s.AppliedAt = &row.AppliedAt
+		}
+		status = append(status, s)
+	}
+	return status, nil
+}
@@ -0,0 +1,134 @@
+package store_test
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+	"golang.org/x/sync/errgroup"
+
+	"github.com/pkulik0/autocc/api/internal/store"
+)
+
+func TestMigrations(t *testing.T) {
+	c := qt.New(t)
+
+	migrations, err := store.Migrations("postgres")
+	c.Assert(err, qt.IsNil)
+	c.Assert(len(migrations) > 0, qt.IsTrue)
+	for i, m := range migrations {
+		c.Assert(m.Version, qt.Equals, uint(i+1))
+		c.Assert(m.Name, qt.Not(qt.Equals), "")
+		c.Assert(m.Up, qt.Not(qt.Equals), "")
+		c.Assert(m.Down, qt.Not(qt.Equals), "")
+	}
+
+	sqlite, err := store.Migrations("sqlite")
+	c.Assert(err, qt.IsNil)
+	c.Assert(sqlite, qt.HasLen, len(migrations))
+
+	_, err = store.Migrations("oracle")
+	c.Assert(err, qt.IsNotNil)
+}
+
+type migrator interface {
+	Check(ctx context.Context) error
+	Up(ctx context.Context) (int, error)
+	Down(ctx context.Context, steps int) (int, error)
+	Status(ctx context.Context) ([]store.MigrationStatus, error)
+}
+
+func TestMigrator(t *testing.T) {
+	c := qt.New(t)
+
+	testCases := []struct {
+		name  string
+		setup func(c *qt.C) migrator
+	}{
+		{
+			name: "postgres",
+			setup: func(c *qt.C) migrator {
+				_ = setupStore(c)
+				m, err := store.NewMigrator("localhost", 5432, "autocc", "autocc", "autocc")
+				c.Assert(err, qt.IsNil)
+				return m
+			},
+		},
+		{
+			name: "sqlite",
+			setup: func(c *qt.C) migrator {
+				path := filepath.Join(c.TempDir(), "autocc.db")
+				_, err := store.NewSQLite(path, nil)
+				c.Assert(err, qt.IsNil)
+				m, err := store.NewSQLiteMigrator(path)
+				c.Assert(err, qt.IsNil)
+				return m
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		c.Run(tc.name, func(c *qt.C) {
+			m := tc.setup(c)
+
+			err := m.Check(context.Background())
+			c.Assert(err, qt.IsNil)
+
+			status, err := m.Status(context.Background())
+			c.Assert(err, qt.IsNil)
+			for _, s := range status {
+				c.Assert(s.AppliedAt, qt.IsNotNil)
+			}
+
+			count, err := m.Down(context.Background(), 1)
+			c.Assert(err, qt.IsNil)
+			c.Assert(count, qt.Equals, 1)
+
+			status, err = m.Status(context.Background())
+			c.Assert(err, qt.IsNil)
+			c.Assert(status[len(status)-1].AppliedAt, qt.IsNil)
+
+			count, err = m.Up(context.Background())
+			c.Assert(err, qt.IsNil)
+			c.Assert(count, qt.Equals, 1)
+		})
+	}
+}
+
+func TestMigratorConcurrentUp(t *testing.T) {
+	c := qt.New(t)
+	path := filepath.Join(c.TempDir(), "autocc.db")
+
+	migrations, err := store.Migrations("sqlite")
+	c.Assert(err, qt.IsNil)
+
+	// The table recording migrations is created only once they're locked.
+	m, err := store.NewSQLiteMigrator(path)
+	c.Assert(err, qt.IsNil)
+	status, err := m.Status(context.Background())
+	c.Assert(err, qt.IsNil)
+	c.Assert(status, qt.HasLen, len(migrations))
+	c.Assert(status[0].AppliedAt, qt.IsNil)
+
+	// Both migrators see no applied migrations, the lock makes the second one wait and apply none.
+	counts := make(chan int, 2)
+	var group errgroup.Group
+	for range 2 {
+		m, err := store.NewSQLiteMigrator(path)
+		c.Assert(err, qt.IsNil)
+		group.Go(func() error {
+			count, err := m.Up(context.Background())
+			counts <- count
+			return err
+		})
+	}
+	c.Assert(group.Wait(), qt.IsNil)
+	close(counts)
+
+	total := 0
+	for count := range counts {
+		total += count
+	}
+	c.Assert(total, qt.Equals, len(migrations))
+}
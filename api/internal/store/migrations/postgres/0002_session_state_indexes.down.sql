@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_sessions_state_created_at;
+DROP INDEX IF EXISTS idx_sessions_state_state;
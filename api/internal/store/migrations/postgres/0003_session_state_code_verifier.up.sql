@@ -0,0 +1,2 @@
+-- PKCE code verifiers are sent with the authorization code exchange.
+ALTER TABLE sessions_state ADD COLUMN code_verifier TEXT;
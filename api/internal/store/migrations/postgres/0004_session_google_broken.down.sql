@@ -0,0 +1 @@
+ALTER TABLE sessions_google DROP COLUMN broken;
@@ -0,0 +1,2 @@
+-- Sessions whose refresh token was revoked or expired are skipped until the user authenticates again.
+ALTER TABLE sessions_google ADD COLUMN broken BOOLEAN NOT NULL DEFAULT FALSE;
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_sessions_google_user_channel;
+ALTER TABLE sessions_google DROP COLUMN channel_title;
+ALTER TABLE sessions_google DROP COLUMN channel_id;
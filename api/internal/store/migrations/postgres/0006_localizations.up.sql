@@ -0,0 +1,14 @@
+-- Localizations authored by AutoCC, used to tell them apart from the ones written by hand.
+CREATE TABLE IF NOT EXISTS localizations (
+    id BIGSERIAL PRIMARY KEY,
+    created_at TIMESTAMPTZ,
+    updated_at TIMESTAMPTZ,
+    deleted_at TIMESTAMPTZ,
+    kind TEXT,
+    resource_id TEXT,
+    language TEXT,
+    hash TEXT,
+    source_hash TEXT
+);
+CREATE INDEX IF NOT EXISTS idx_localizations_deleted_at ON localizations (deleted_at);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_localizations_resource ON localizations (kind, resource_id, language);
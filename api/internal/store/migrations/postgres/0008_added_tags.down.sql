@@ -0,0 +1 @@
+DROP TABLE IF EXISTS added_tags;
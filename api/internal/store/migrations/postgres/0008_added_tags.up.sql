@@ -0,0 +1,11 @@
+-- Tags added to videos by AutoCC, used to tell them apart from the ones added by hand.
+CREATE TABLE IF NOT EXISTS added_tags (
+    id BIGSERIAL PRIMARY KEY,
+    created_at TIMESTAMPTZ,
+    updated_at TIMESTAMPTZ,
+    deleted_at TIMESTAMPTZ,
+    video_id TEXT,
+    tag TEXT
+);
+CREATE INDEX IF NOT EXISTS idx_added_tags_deleted_at ON added_tags (deleted_at);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_added_tags_video_tag ON added_tags (video_id, tag);
@@ -0,0 +1,2 @@
+-- Usage of Google credentials is counted per quota day, it starts over after the day it was reset on.
+ALTER TABLE credentials_google ADD COLUMN usage_reset_at TIMESTAMPTZ;
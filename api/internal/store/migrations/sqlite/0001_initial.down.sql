@@ -0,0 +1,6 @@
+DROP TABLE IF EXISTS translation_memory;
+DROP TABLE IF EXISTS source_cc;
+DROP TABLE IF EXISTS sessions_state;
+DROP TABLE IF EXISTS sessions_google;
+DROP TABLE IF EXISTS credentials_deepl;
+DROP TABLE IF EXISTS credentials_google;
@@ -0,0 +1,77 @@
+CREATE TABLE IF NOT EXISTS credentials_google (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    created_at DATETIME,
+    updated_at DATETIME,
+    deleted_at DATETIME,
+    client_id TEXT,
+    client_secret TEXT,
+    usage INTEGER
+);
+CREATE INDEX IF NOT EXISTS idx_credentials_google_deleted_at ON credentials_google (deleted_at);
+
+CREATE TABLE IF NOT EXISTS credentials_deepl (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    created_at DATETIME,
+    updated_at DATETIME,
+    deleted_at DATETIME,
+    key TEXT,
+    usage INTEGER
+);
+CREATE INDEX IF NOT EXISTS idx_credentials_deepl_deleted_at ON credentials_deepl (deleted_at);
+
+CREATE TABLE IF NOT EXISTS sessions_google (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    created_at DATETIME,
+    updated_at DATETIME,
+    deleted_at DATETIME,
+    user_id TEXT,
+    access_token TEXT,
+    refresh_token TEXT,
+    expiry DATETIME,
+    credentials_id INTEGER,
+    scopes TEXT,
+    CONSTRAINT fk_sessions_google_credentials FOREIGN KEY (credentials_id) REFERENCES credentials_google (id)
+);
+CREATE INDEX IF NOT EXISTS idx_sessions_google_deleted_at ON sessions_google (deleted_at);
+
+CREATE TABLE IF NOT EXISTS sessions_state (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    created_at DATETIME,
+    updated_at DATETIME,
+    deleted_at DATETIME,
+    user_id TEXT,
+    state TEXT,
+    credentials_id INTEGER,
+    scopes TEXT,
+    redirect_url TEXT,
+    CONSTRAINT fk_sessions_state_credentials FOREIGN KEY (credentials_id) REFERENCES credentials_google (id)
+);
+CREATE INDEX IF NOT EXISTS idx_sessions_state_deleted_at ON sessions_state (deleted_at);
+
+CREATE TABLE IF NOT EXISTS source_cc (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    created_at DATETIME,
+    updated_at DATETIME,
+    deleted_at DATETIME,
+    user_id TEXT,
+    video_id TEXT,
+    language TEXT,
+    srt TEXT,
+    upload_original NUMERIC
+);
+CREATE INDEX IF NOT EXISTS idx_source_cc_deleted_at ON source_cc (deleted_at);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_source_cc_user_video ON source_cc (user_id, video_id);
+
+CREATE TABLE IF NOT EXISTS translation_memory (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    created_at DATETIME,
+    updated_at DATETIME,
+    deleted_at DATETIME,
+    source_language TEXT,
+    target_language TEXT,
+    source_hash TEXT,
+    source_text TEXT,
+    translated_text TEXT
+);
+CREATE INDEX IF NOT EXISTS idx_translation_memory_deleted_at ON translation_memory (deleted_at);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_translation_memory_segment ON translation_memory (source_language, target_language, source_hash);
@@ -0,0 +1,3 @@
+-- States are looked up by value in OAuth2 callbacks and purged by age.
+CREATE UNIQUE INDEX IF NOT EXISTS idx_sessions_state_state ON sessions_state (state);
+CREATE INDEX IF NOT EXISTS idx_sessions_state_created_at ON sessions_state (created_at);
@@ -0,0 +1 @@
+ALTER TABLE sessions_state DROP COLUMN code_verifier;
@@ -0,0 +1,4 @@
+-- Sessions are scoped to the YouTube channel they were authorized for.
+ALTER TABLE sessions_google ADD COLUMN channel_id TEXT NOT NULL DEFAULT '';
+ALTER TABLE sessions_google ADD COLUMN channel_title TEXT NOT NULL DEFAULT '';
+CREATE INDEX IF NOT EXISTS idx_sessions_google_user_channel ON sessions_google (user_id, channel_id);
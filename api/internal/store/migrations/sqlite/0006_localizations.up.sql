@@ -0,0 +1,14 @@
+-- Localizations authored by AutoCC, used to tell them apart from the ones written by hand.
+CREATE TABLE IF NOT EXISTS localizations (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    created_at DATETIME,
+    updated_at DATETIME,
+    deleted_at DATETIME,
+    kind TEXT,
+    resource_id TEXT,
+    language TEXT,
+    hash TEXT,
+    source_hash TEXT
+);
+CREATE INDEX IF NOT EXISTS idx_localizations_deleted_at ON localizations (deleted_at);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_localizations_resource ON localizations (kind, resource_id, language);
@@ -0,0 +1,12 @@
+-- Closed captions translated and uploaded by AutoCC, used to tell them apart from the ones uploaded by hand.
+CREATE TABLE IF NOT EXISTS translated_cc (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    created_at DATETIME,
+    updated_at DATETIME,
+    deleted_at DATETIME,
+    video_id TEXT,
+    language TEXT,
+    track_id TEXT
+);
+CREATE INDEX IF NOT EXISTS idx_translated_cc_deleted_at ON translated_cc (deleted_at);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_translated_cc_video_language ON translated_cc (video_id, language);
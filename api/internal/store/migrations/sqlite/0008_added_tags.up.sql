@@ -0,0 +1,11 @@
+-- Tags added to videos by AutoCC, used to tell them apart from the ones added by hand.
+CREATE TABLE IF NOT EXISTS added_tags (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    created_at DATETIME,
+    updated_at DATETIME,
+    deleted_at DATETIME,
+    video_id TEXT,
+    tag TEXT
+);
+CREATE INDEX IF NOT EXISTS idx_added_tags_deleted_at ON added_tags (deleted_at);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_added_tags_video_tag ON added_tags (video_id, tag);
@@ -0,0 +1 @@
+ALTER TABLE credentials_google DROP COLUMN usage_reset_at;
@@ -0,0 +1,163 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/rs/zerolog/log"
+	"gorm.io/gorm"
+	"gorm.io/gorm/schema"
+
+	"github.com/pkulik0/autocc/api/internal/model"
+	"github.com/pkulik0/autocc/api/internal/secret"
+)
+
+// ErrNoMasterKey is returned when secrets can't be encrypted because no master key was configured.
+var ErrNoMasterKey = errors.New("store: no master key configured")
+
+func init() {
+	schema.RegisterSerializer("encrypted", encryptedSerializer{})
+}
+
+type keyringContextKey struct{}
+
+// encryptedSerializer encrypts string fields tagged with `serializer:encrypted` using the keyring of the store.
+// The keyring is passed through the statement context by a callback registered in useKeyring.
+type encryptedSerializer struct{}
+
+func keyringFromContext(ctx context.Context) *secret.Keyring {
+	keyring, _ := ctx.Value(keyringContextKey{}).(*secret.Keyring)
+	return keyring
+}
+
+func (encryptedSerializer) Scan(ctx context.Context, field *schema.Field, dst reflect.Value, dbValue any) error {
+	var value string
+	switch v := dbValue.(type) {
+	case nil:
+	case string:
+		value = v
+	case []byte:
+		value = string(v)
+	default:
+		return fmt.Errorf("failed to scan encrypted value of type %T", dbValue)
+	}
+
+	if secret.IsEncrypted(value) {
+		keyring := keyringFromContext(ctx)
+		if keyring == nil {
+			return ErrNoMasterKey
+		}
+		decrypted, err := keyring.Decrypt(value)
+		if err != nil {
+			return err
+		}
+		value = decrypted
+	}
+
+	return field.Set(ctx, dst, value)
+}
+
+func (encryptedSerializer) Value(ctx context.Context, field *schema.Field, dst reflect.Value, fieldValue any) (any, error) {
+	value, ok := fieldValue.(string)
+	if !ok {
+		return nil, fmt.Errorf("failed to encrypt value of type %T", fieldValue)
+	}
+
+	keyring := keyringFromContext(ctx)
+	if keyring == nil {
+		return value, nil
+	}
+	return keyring.Encrypt(value)
+}
+
+// useKeyring makes the keyring available to the serializer of encrypted fields in all statements of the database.
+func useKeyring(db *gorm.DB, keyring *secret.Keyring) error {
+	if keyring == nil {
+		log.Warn().Msg("no master key configured, secrets are stored in plaintext")
+		return nil
+	}
+
+	setKeyring := func(db *gorm.DB) {
+		db.Statement.Context = context.WithValue(db.Statement.Context, keyringContextKey{}, keyring)
+	}
+	callbacks := db.Callback()
+	for _, err := range []error{
+		callbacks.Create().Before("*").Register("secret:keyring", setKeyring),
+		callbacks.Query().Before("*").Register("secret:keyring", setKeyring),
+		callbacks.Update().Before("*").Register("secret:keyring", setKeyring),
+		callbacks.Delete().Before("*").Register("secret:keyring", setKeyring),
+		callbacks.Row().Before("*").Register("secret:keyring", setKeyring),
+		callbacks.Raw().Before("*").Register("secret:keyring", setKeyring),
+	} {
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// encryptSecrets rewrites secrets of a model which aren't prefixed with the prefix, returning the number of updated rows.
+func encryptSecrets[T any](ctx context.Context, db *gorm.DB, prefix string, columns ...string) (int, error) {
+	conditions := make([]string, 0, len(columns))
+	args := make([]any, 0, len(columns))
+	for _, column := range columns {
+		column = db.Statement.Quote(column)
+		conditions = append(conditions, fmt.Sprintf("(%s <> '' AND %s NOT LIKE ?)", column, column))
+		args = append(args, prefix+"%")
+	}
+
+	var rows []T
+	result := db.WithContext(ctx).Unscoped().Where(strings.Join(conditions, " OR "), args...).Find(&rows)
+	if result.Error != nil {
+		return 0, result.Error
+	}
+
+	for i := range rows {
+		result := db.WithContext(ctx).Unscoped().Model(&rows[i]).Select(columns).UpdateColumns(&rows[i])
+		if result.Error != nil {
+			return 0, result.Error
+		}
+	}
+	return len(rows), nil
+}
+
+// encryptAllSecrets rewrites all secrets which aren't prefixed with the prefix, returning the number of updated rows.
+func encryptAllSecrets(ctx context.Context, db *gorm.DB, prefix string) (int, error) {
+	count := 0
+	err := db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		for _, encrypt := range []func() (int, error){
+			func() (int, error) { return encryptSecrets[model.CredentialsDeepL](ctx, tx, prefix, "key") },
+			func() (int, error) { return encryptSecrets[model.CredentialsGoogle](ctx, tx, prefix, "client_secret") },
+			func() (int, error) {
+				return encryptSecrets[model.SessionGoogle](ctx, tx, prefix, "access_token", "refresh_token")
+			},
+		} {
+			n, err := encrypt()
+			if err != nil {
+				return err
+			}
+			count += n
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+func (s *gormStore) ReencryptSecrets(ctx context.Context) (int, error) {
+	if s.keyring == nil {
+		return 0, ErrNoMasterKey
+	}
+
+	count, err := encryptAllSecrets(ctx, s.db, s.keyring.CurrentPrefix())
+	if err != nil {
+		return 0, err
+	}
+	log.Info().Int("count", count).Msg("re-encrypted secrets")
+	return count, nil
+}
@@ -0,0 +1,96 @@
+package store_test
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+	"github.com/glebarez/sqlite"
+	"gorm.io/gorm"
+
+	"github.com/pkulik0/autocc/api/internal/secret"
+	"github.com/pkulik0/autocc/api/internal/store"
+)
+
+// rawSecrets returns secrets as they are stored in the SQLite database.
+func rawSecrets(c *qt.C, path string) []string {
+	db, err := gorm.Open(sqlite.Open(path), &gorm.Config{})
+	c.Assert(err, qt.IsNil)
+	sqlDB, err := db.DB()
+	c.Assert(err, qt.IsNil)
+	defer sqlDB.Close()
+
+	var deepL, google []string
+	c.Assert(db.Raw("SELECT key FROM credentials_deepl").Scan(&deepL).Error, qt.IsNil)
+	c.Assert(db.Raw("SELECT client_secret FROM credentials_google").Scan(&google).Error, qt.IsNil)
+	return append(deepL, google...)
+}
+
+func TestSecrets(t *testing.T) {
+	c := qt.New(t)
+	ctx := context.Background()
+	path := filepath.Join(c.TempDir(), "autocc.db")
+
+	// Secrets are stored in plaintext without a master key.
+	s, err := store.NewSQLite(path, nil)
+	c.Assert(err, qt.IsNil)
+	deepL, err := s.AddCredentialsDeepL(ctx, "deepl-key", 0)
+	c.Assert(err, qt.IsNil)
+	google, err := s.AddCredentialsGoogle(ctx, "client", "client-secret")
+	c.Assert(err, qt.IsNil)
+	c.Assert(rawSecrets(c, path), qt.DeepEquals, []string{"deepl-key", "client-secret"})
+
+	_, err = s.ReencryptSecrets(ctx)
+	c.Assert(err, qt.Equals, store.ErrNoMasterKey)
+
+	// Plaintext secrets are encrypted when the store is opened with a master key.
+	oldKey, newKey := make([]byte, secret.KeySize), make([]byte, secret.KeySize)
+	newKey[0] = 1
+	oldKeyring, err := secret.NewKeyring(oldKey)
+	c.Assert(err, qt.IsNil)
+	s, err = store.NewSQLite(path, oldKeyring)
+	c.Assert(err, qt.IsNil)
+	for _, raw := range rawSecrets(c, path) {
+		c.Assert(strings.HasPrefix(raw, oldKeyring.CurrentPrefix()), qt.IsTrue)
+	}
+	retrievedDeepL, err := s.GetCredentialsDeepLByID(ctx, deepL.ID)
+	c.Assert(err, qt.IsNil)
+	c.Assert(retrievedDeepL.Key, qt.Equals, "deepl-key")
+
+	// Rotation keeps previous keys readable until secrets are re-encrypted.
+	newKeyring, err := secret.NewKeyring(newKey)
+	c.Assert(err, qt.IsNil)
+	rotatingKeyring, err := secret.NewKeyring(newKey, oldKey)
+	c.Assert(err, qt.IsNil)
+
+	s, err = store.NewSQLite(path, rotatingKeyring)
+	c.Assert(err, qt.IsNil)
+	retrievedGoogle, err := s.GetCredentialsGoogleByID(ctx, google.ID)
+	c.Assert(err, qt.IsNil)
+	c.Assert(retrievedGoogle.ClientSecret, qt.Equals, "client-secret")
+
+	count, err := s.ReencryptSecrets(ctx)
+	c.Assert(err, qt.IsNil)
+	c.Assert(count, qt.Equals, 2)
+	for _, raw := range rawSecrets(c, path) {
+		c.Assert(strings.HasPrefix(raw, newKeyring.CurrentPrefix()), qt.IsTrue)
+	}
+
+	count, err = s.ReencryptSecrets(ctx)
+	c.Assert(err, qt.IsNil)
+	c.Assert(count, qt.Equals, 0)
+
+	s, err = store.NewSQLite(path, newKeyring)
+	c.Assert(err, qt.IsNil)
+	retrievedGoogle, err = s.GetCredentialsGoogleByID(ctx, google.ID)
+	c.Assert(err, qt.IsNil)
+	c.Assert(retrievedGoogle.ClientSecret, qt.Equals, "client-secret")
+
+	// Encrypted secrets can't be read without the master key.
+	s, err = store.NewSQLite(path, nil)
+	c.Assert(err, qt.IsNil)
+	_, err = s.GetCredentialsGoogleByID(ctx, google.ID)
+	c.Assert(err, qt.IsNotNil)
+}
@@ -37,22 +37,66 @@ type Store interface {
 	// RemoveCredentialsDeepL removes DeepL client credentials from the store.
 	RemoveCredentialsDeepL(ctx context.Context, id uint) error
 
-	// CreateSessionGoogle creates a new Google API session.
-	CreateSessionGoogle(ctx context.Context, userID, accessToken, refreshToken, scopes string, expiry time.Time, credentials model.CredentialsGoogle) (*model.SessionGoogle, error)
-	// GetSessionGoogleByCredentialsID returns a Google API session by credentials ID.
-	GetSessionGoogleByCredentialsID(ctx context.Context, credentialsID uint, userID string) (*model.SessionGoogle, error)
+	// CreateSessionGoogle creates a new Google API session of the YouTube channel, replacing previous sessions of the channel with the same credentials.
+	// It returns errs.NotFound if the credentials were removed.
+	CreateSessionGoogle(ctx context.Context, userID, channelID, channelTitle, accessToken, refreshToken, scopes string, expiry time.Time, credentials model.CredentialsGoogle) (*model.SessionGoogle, error)
+	// GetSessionGoogle returns a Google API session of the user by its ID.
+	GetSessionGoogle(ctx context.Context, userID string, id uint) (*model.SessionGoogle, error)
 	// GetSessionGoogleAll returns all Google API sessions for a user.
 	GetSessionGoogleAll(ctx context.Context, userID string) ([]model.SessionGoogle, error)
-	// GetUserSessionGoogleByQuotaAvailable returns a Google API session with N cost to spend.
-	// It updates the credentials usage and returns a function to revert the operation.
-	GetSessionGoogleByAvailableCost(ctx context.Context, userID string, cost uint) (*model.SessionGoogle, func() error, error)
+	// GetUserSessionGoogleByQuotaAvailable returns a Google API session of the YouTube channel with N cost to spend, broken sessions are skipped.
+	// It updates the credentials usage and returns a function to revert the operation, usage counted before the quota was last reset starts over.
+	GetSessionGoogleByAvailableCost(ctx context.Context, userID, channelID string, cost uint) (*model.SessionGoogle, func() error, error)
+	// MarkSessionGoogleBroken marks a Google API session as broken, so it isn't used until the user authenticates again.
+	MarkSessionGoogleBroken(ctx context.Context, id uint) error
+	// MarkCredentialsGoogleExhausted sets the usage of Google client credentials to the whole quota, so their sessions aren't used until the quota is reset.
+	MarkCredentialsGoogleExhausted(ctx context.Context, id uint) error
 	// UpdateSessionGoogle updates a Google API session.
 	UpdateSessionGoogle(ctx context.Context, session *model.SessionGoogle) error
-	// RemoveSessionGoogle removes a Google API session.
-	RemoveSessionGoogle(ctx context.Context, userID string, credentialsID uint) error
+	// RemoveSessionGoogle removes a Google API session of the user by its ID.
+	RemoveSessionGoogle(ctx context.Context, userID string, id uint) error
 
-	// SaveSessionState saves a state value used in OAuth2.
-	SaveSessionState(ctx context.Context, credentialsID uint, userID, state, scopes, redirectURL string) error
+	// SaveSessionState saves a state value used in OAuth2, replacing pending states of the user for the same credentials.
+	SaveSessionState(ctx context.Context, credentialsID uint, userID, state, codeVerifier, scopes, redirectURL string) error
 	// GetSessionState returns a state value used in OAuth2.
+	// It returns errs.NotFound if the state doesn't exist or its credentials were removed.
 	GetSessionState(ctx context.Context, state string) (*model.SessionState, error)
+	// RemoveSessionState removes a state value used in OAuth2, it returns errs.NotFound if it was already removed.
+	RemoveSessionState(ctx context.Context, state string) error
+	// RemoveSessionStatesBefore removes state values created before the given time and returns their count.
+	RemoveSessionStatesBefore(ctx context.Context, before time.Time) (int, error)
+
+	// SaveSourceCC saves closed captions uploaded as the translation source of a video, replacing previous ones.
+	SaveSourceCC(ctx context.Context, userID, videoID, language, srt string, uploadOriginal bool) (*model.SourceCC, error)
+	// GetSourceCC returns closed captions uploaded as the translation source of a video.
+	GetSourceCC(ctx context.Context, userID, videoID string) (*model.SourceCC, error)
+	// RemoveSourceCC removes closed captions uploaded as the translation source of a video.
+	RemoveSourceCC(ctx context.Context, userID, videoID string) error
+
+	// GetTranslationMemory returns stored translations of the given segments, keyed by the source text.
+	// Segments without a stored translation are omitted.
+	GetTranslationMemory(ctx context.Context, sourceLanguage, targetLanguage string, text []string) (map[string]string, error)
+	// SaveTranslationMemory saves translations of segments, keyed by the source text.
+	SaveTranslationMemory(ctx context.Context, sourceLanguage, targetLanguage string, translations map[string]string) error
+	// RemoveTranslationMemory removes stored translations between the languages and returns their number.
+	// An empty language matches any language but one of them must be given, languages are compared case-insensitively.
+	RemoveTranslationMemory(ctx context.Context, sourceLanguage, targetLanguage string) (int, error)
+
+	// GetLocalizations returns the localizations of the resources authored by AutoCC ordered by resource and language.
+	GetLocalizations(ctx context.Context, kind string, resourceIDs ...string) ([]model.Localization, error)
+	// SaveLocalizations saves localizations authored by AutoCC, replacing previous ones of the same resource and language.
+	SaveLocalizations(ctx context.Context, localizations []model.Localization) error
+
+	// GetTranslatedCC returns the closed captions of the videos translated by AutoCC ordered by video and language.
+	GetTranslatedCC(ctx context.Context, videoIDs ...string) ([]model.TranslatedCC, error)
+	// SaveTranslatedCC saves closed captions translated by AutoCC, replacing previous ones of the same video and language.
+	SaveTranslatedCC(ctx context.Context, translatedCC []model.TranslatedCC) error
+
+	// GetAddedTags returns the tags AutoCC added to the video, lowercase and sorted.
+	GetAddedTags(ctx context.Context, videoID string) ([]string, error)
+	// SaveAddedTags records tags AutoCC added to the video, tags which were recorded already are skipped.
+	SaveAddedTags(ctx context.Context, videoID string, tags []string) error
+
+	// ReencryptSecrets encrypts all secrets which weren't encrypted with the current master key and returns the number of updated records.
+	ReencryptSecrets(ctx context.Context) (int, error)
 }
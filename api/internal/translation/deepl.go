@@ -8,11 +8,19 @@ import (
 	"io"
 	"net/http"
 
+	"github.com/rs/zerolog/log"
+
 	"github.com/pkulik0/autocc/api/internal/store"
 )
 
+// DeepLFreeURL is the base URL of the DeepL API Free.
+const DeepLFreeURL = "https://api-free.deepl.com/v2/"
+
 const (
-	baseURL = "https://api-free.deepl.com/v2/"
+	// Maximum number of texts DeepL accepts in a single translation request.
+	maxTextsPerRequest = 50
+	// Maximum length of texts sent in a single translation request, it keeps the request body under the 128 KiB limit.
+	maxRequestTextLen = 50_000
 )
 
 type deeplTransport struct {
@@ -33,17 +41,19 @@ func (t *deeplTransport) RoundTrip(req *http.Request) (*http.Response, error) {
 }
 
 type deeplApiClient struct {
+	baseURL    string
 	client     *http.Client
 	revertCost func() error
 }
 
-func newDeeplApiClient(ctx context.Context, store store.Store, neededQuota uint) (*deeplApiClient, error) {
+func newDeeplApiClient(ctx context.Context, store store.Store, baseURL string, neededQuota uint) (*deeplApiClient, error) {
 	credentials, revert, err := store.GetCredentialsDeepLByAvailableCost(ctx, neededQuota)
 	if err != nil {
 		return nil, err
 	}
 
 	return &deeplApiClient{
+		baseURL: baseURL,
 		client: &http.Client{
 			Transport: newDeeplTransport(http.DefaultTransport, credentials.Key),
 		},
@@ -52,7 +62,7 @@ func newDeeplApiClient(ctx context.Context, store store.Store, neededQuota uint)
 }
 
 func (c *deeplApiClient) request(ctx context.Context, method, path string, body io.Reader) (*http.Response, error) {
-	req, err := http.NewRequestWithContext(ctx, method, baseURL+path, body)
+	req, err := http.NewRequestWithContext(ctx, method, c.baseURL+path, body)
 	if err != nil {
 		return nil, err
 	}
@@ -63,6 +73,19 @@ func (c *deeplApiClient) request(ctx context.Context, method, path string, body
 	return c.client.Do(req)
 }
 
+// readBody reads the body of the response and returns an error if the request wasn't successful.
+func readBody(resp *http.Response) ([]byte, error) {
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code: %d, body: %s", resp.StatusCode, bytes.TrimSpace(body))
+	}
+	return body, nil
+}
+
 type languageResponse struct {
 	Language string `json:"language"`
 	Name     string `json:"name"`
@@ -73,9 +96,7 @@ func (c *deeplApiClient) getLanguages(ctx context.Context) ([]string, error) {
 	if err != nil {
 		return nil, err
 	}
-
-	defer resp.Body.Close()
-	body, err := io.ReadAll(resp.Body)
+	body, err := readBody(resp)
 	if err != nil {
 		return nil, err
 	}
@@ -94,51 +115,68 @@ func (c *deeplApiClient) getLanguages(ctx context.Context) ([]string, error) {
 }
 
 type translateRequest struct {
-	Text           []string `json:"text"`
-	SourceLanguage string   `json:"source_lang"`
-	TargetLanguage string   `json:"target_lang"`
+	Text             []string `json:"text"`
+	SourceLanguage   string   `json:"source_lang,omitempty"`
+	TargetLanguage   string   `json:"target_lang"`
+	TagHandling      string   `json:"tag_handling,omitempty"`
+	NonSplittingTags []string `json:"non_splitting_tags,omitempty"`
 }
 
 type translateResponse struct {
-	Translations []struct {
-		DetectedSourceLanguage string `json:"detected_source_language"`
-		Text                   string `json:"text"`
-	} `json:"translations"`
+	Translations []translateResult `json:"translations"`
 }
 
-func (c *deeplApiClient) translate(ctx context.Context, text []string, sourceLanguage, targetLanguage string) ([]string, error) {
-	data, err := json.Marshal(&translateRequest{
+type translateResult struct {
+	DetectedSourceLanguage string `json:"detected_source_language"`
+	Text                   string `json:"text"`
+}
+
+// translate translates the text, the reserved cost is reverted if it fails.
+// The source language is detected by DeepL if it's empty.
+// Text with formatting tags protected by srt.ProtectTags is sent with XML tag handling if xmlTags is set.
+func (c *deeplApiClient) translate(ctx context.Context, text []string, sourceLanguage, targetLanguage string, xmlTags bool) ([]translateResult, error) {
+	translations, err := c.doTranslate(ctx, text, sourceLanguage, targetLanguage, xmlTags)
+	if err != nil {
+		if err := c.revertCost(); err != nil {
+			log.Error().Err(err).Msg("failed to revert DeepL usage")
+		}
+		return nil, err
+	}
+	return translations, nil
+}
+
+func (c *deeplApiClient) doTranslate(ctx context.Context, text []string, sourceLanguage, targetLanguage string, xmlTags bool) ([]translateResult, error) {
+	req := &translateRequest{
 		Text:           text,
 		SourceLanguage: sourceLanguage,
 		TargetLanguage: targetLanguage,
-	})
-	if err != nil {
-		return nil, err
+	}
+	if xmlTags {
+		// Formatting tags are sent as XML placeholders, see srt.ProtectTags.
+		req.TagHandling = "xml"
+		req.NonSplittingTags = []string{"g", "x"}
 	}
 
-	resp, err := c.request(ctx, http.MethodPost, "translate", bytes.NewBuffer(data))
-	if err != nil {
+	// HTML escaping would inflate the placeholders and the request size.
+	data := &bytes.Buffer{}
+	encoder := json.NewEncoder(data)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(req); err != nil {
 		return nil, err
 	}
 
-	defer resp.Body.Close()
-	body, err := io.ReadAll(resp.Body)
+	resp, err := c.request(ctx, http.MethodPost, "translate", data)
 	if err != nil {
 		return nil, err
 	}
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("unexpected status code: %d, body: %s", resp.StatusCode, body)
+	body, err := readBody(resp)
+	if err != nil {
+		return nil, err
 	}
 
 	result := &translateResponse{}
 	if err := json.Unmarshal(body, &result); err != nil {
 		return nil, err
 	}
-
-	translations := make([]string, len(result.Translations))
-	for i, t := range result.Translations {
-		translations[i] = t.Text
-	}
-
-	return translations, nil
+	return result.Translations, nil
 }
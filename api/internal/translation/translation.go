@@ -3,15 +3,19 @@ package translation
 import (
 	"context"
 	"encoding/json"
-	"io"
+	"fmt"
 	"net/http"
+	"slices"
 	"strings"
 	"time"
+	"unicode/utf8"
 
 	"github.com/rs/zerolog/log"
+	"golang.org/x/sync/singleflight"
 
 	"github.com/pkulik0/autocc/api/internal/cache"
 	"github.com/pkulik0/autocc/api/internal/errs"
+	"github.com/pkulik0/autocc/api/internal/srt"
 	"github.com/pkulik0/autocc/api/internal/store"
 )
 
@@ -23,28 +27,36 @@ type Translator interface {
 	GetLanguages(ctx context.Context) ([]string, error)
 	// Translate translates the text from the source language to the target language.
 	Translate(ctx context.Context, text []string, sourceLanguage, targetLanguage string) ([]string, error)
+	// DetectLanguage detects the language of the text, it's billed like a translation of a sample of the text.
+	DetectLanguage(ctx context.Context, text []string) (string, error)
 	// GetUsageDeepL returns the usage of the DeepL API.
 	GetUsageDeepL(ctx context.Context, apiKey string) (uint, error)
 }
 
+// Maximum time a translation can take, used to expire the lock held during it.
+const lockExpiration = time.Minute * 5
+
 type translator struct {
-	store store.Store
-	cache cache.Cache
+	store   store.Store
+	cache   cache.Cache
+	baseURL string
+	group   singleflight.Group
 }
 
 var _ Translator = &translator{}
 
-// New creates a new translation service.
-func New(store store.Store, cache cache.Cache) *translator {
-	log.Debug().Msg("created translation service")
+// New creates a new translation service using the DeepL API at the base URL, see DeepLFreeURL.
+func New(store store.Store, cache cache.Cache, baseURL string) *translator {
+	log.Debug().Str("base_url", baseURL).Msg("created translation service")
 	return &translator{
-		store: store,
-		cache: cache,
+		store:   store,
+		cache:   cache,
+		baseURL: baseURL,
 	}
 }
 
 func (t *translator) GetLanguages(ctx context.Context) ([]string, error) {
-	apiClient, err := newDeeplApiClient(ctx, t.store, 0)
+	apiClient, err := newDeeplApiClient(ctx, t.store, t.baseURL, 0)
 	if err != nil {
 		return nil, err
 	}
@@ -69,50 +81,234 @@ func countTextLen(text []string) uint {
 	return count
 }
 
+// batches splits the text into batches which fit into a single translation request.
+func batches(text []string) [][]string {
+	var result [][]string
+	var batch []string
+	var batchLen uint
+	for _, t := range text {
+		if len(batch) == maxTextsPerRequest || (len(batch) > 0 && batchLen+uint(len(t)) > maxRequestTextLen) {
+			result = append(result, batch)
+			batch, batchLen = nil, 0
+		}
+		batch = append(batch, t)
+		batchLen += uint(len(t))
+	}
+	if len(batch) > 0 {
+		result = append(result, batch)
+	}
+	return result
+}
+
 func (t *translator) Translate(ctx context.Context, text []string, sourceLanguage, targetLanguage string) ([]string, error) {
 	if len(text) == 0 || sourceLanguage == "" || targetLanguage == "" {
 		return nil, errs.InvalidInput
 	}
 
 	// Check if the translation is already in the cache.
-	key := cache.CreateKey(append(text, sourceLanguage, targetLanguage)...)
+	key := cache.CreateKey(cache.NamespaceTranslation, append([]string{sourceLanguage, targetLanguage}, text...)...)
 	log.Trace().Str("key", key).Strs("text", text).Str("source_language", sourceLanguage).Str("target_language", targetLanguage).Msg("checking cache")
 	if value, err := t.cache.GetList(ctx, key); err == nil {
 		log.Trace().Strs("text", text).Strs("translated_text", value).Msg("cache hit")
 		return value, nil
 	}
 
-	apiClient, err := newDeeplApiClient(ctx, t.store, countTextLen(text))
+	// Concurrent translations of the same text share a single call.
+	select {
+	case result := <-t.group.DoChan(key, func() (any, error) {
+		// The call is shared, so it can't be canceled by any single caller.
+		ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), lockExpiration)
+		defer cancel()
+		return t.translate(ctx, key, text, sourceLanguage, targetLanguage)
+	}):
+		if result.Err != nil {
+			return nil, result.Err
+		}
+		return slices.Clone(result.Val.([]string)), nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+func (t *translator) translate(ctx context.Context, key string, text []string, sourceLanguage, targetLanguage string) ([]string, error) {
+	// Other instances might be translating the same text, wait for them and reuse their result.
+	unlock, err := t.cache.Lock(ctx, key, lockExpiration)
 	if err != nil {
-		log.Error().Err(err).Msg("failed to create DeepL API client")
 		return nil, err
 	}
+	defer func() {
+		if err := unlock(); err != nil {
+			log.Warn().Err(err).Str("key", key).Msg("failed to release lock")
+		}
+	}()
+	if value, err := t.cache.GetList(ctx, key); err == nil {
+		log.Trace().Strs("text", text).Strs("translated_text", value).Msg("cache hit after acquiring lock")
+		return value, nil
+	}
 
-	log.Trace().Str("source_language", sourceLanguage).Str("target_language", targetLanguage).Strs("text", text).Msg("translating text")
-	translatedText, err := apiClient.translate(ctx, text, sourceLanguage, targetLanguage)
+	// Look up the translation memory per segment, only the missing ones are sent to the provider.
+	memory, err := t.store.GetTranslationMemory(ctx, sourceLanguage, targetLanguage, text)
 	if err != nil {
-		return nil, err
+		log.Error().Err(err).Msg("failed to get translation memory")
+		memory = make(map[string]string)
 	}
 
-	go func() {
-		log.Trace().Str("key", key).Str("source_language", sourceLanguage).Str("target_language", targetLanguage).Strs("translated_text", translatedText).Msg("setting cache")
-		ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
-		defer cancel()
+	var missing []string
+	seen := make(map[string]struct{})
+	for _, segment := range text {
+		if _, ok := memory[segment]; ok {
+			continue
+		}
+		if _, ok := seen[segment]; ok {
+			continue
+		}
+		seen[segment] = struct{}{}
+		missing = append(missing, segment)
+	}
+	log.Trace().Int("segments", len(text)).Int("missing", len(missing)).Msg("checked translation memory")
 
-		err := t.cache.SetList(ctx, key, translatedText, time.Hour*24)
+	if len(missing) > 0 {
+		translatedMissing, err := t.translateSegments(ctx, missing, sourceLanguage, targetLanguage)
 		if err != nil {
-			log.Error().Err(err).Str("key", key).Msg("failed to set cache")
+			return nil, err
 		}
-	}()
+
+		translations := make(map[string]string, len(missing))
+		for i, segment := range missing {
+			translations[segment] = translatedMissing[i]
+			memory[segment] = translatedMissing[i]
+		}
+		if err := t.store.SaveTranslationMemory(ctx, sourceLanguage, targetLanguage, translations); err != nil {
+			log.Error().Err(err).Msg("failed to save translation memory")
+		}
+	}
+
+	translatedText := make([]string, len(text))
+	for i, segment := range text {
+		translatedText[i] = memory[segment]
+	}
+
+	// The cache is set before releasing the lock, so waiting instances find the result.
+	log.Trace().Str("key", key).Str("source_language", sourceLanguage).Str("target_language", targetLanguage).Strs("translated_text", translatedText).Msg("setting cache")
+	if err := t.cache.SetList(ctx, key, translatedText, time.Hour*24); err != nil {
+		log.Error().Err(err).Str("key", key).Msg("failed to set cache")
+	}
+	tags := append(cache.TagsFromContext(ctx), cache.TagLanguage(sourceLanguage), cache.TagLanguage(targetLanguage))
+	if err := t.cache.Tag(ctx, key, tags, time.Hour*24); err != nil {
+		log.Error().Err(err).Str("key", key).Msg("failed to tag cache")
+	}
+	return translatedText, nil
+}
+
+func (t *translator) translateSegments(ctx context.Context, text []string, sourceLanguage, targetLanguage string) ([]string, error) {
+	// Replace formatting tags with placeholders so they aren't translated or lost.
+	protectedText := make([]string, len(text))
+	markups := make([]*srt.Markup, len(text))
+	for i, segment := range text {
+		protectedText[i], markups[i] = srt.ProtectTags(segment)
+	}
+
+	var translatedText []string
+	for _, batch := range batches(protectedText) {
+		apiClient, err := newDeeplApiClient(ctx, t.store, t.baseURL, countTextLen(batch))
+		if err != nil {
+			log.Error().Err(err).Msg("failed to create DeepL API client")
+			return nil, err
+		}
+
+		log.Trace().Str("source_language", sourceLanguage).Str("target_language", targetLanguage).Strs("text", batch).Msg("translating text")
+		translatedBatch, err := apiClient.translate(ctx, batch, sourceLanguage, targetLanguage, true)
+		if err != nil {
+			return nil, err
+		}
+		if len(translatedBatch) != len(batch) {
+			return nil, fmt.Errorf("expected %d translations, got %d", len(batch), len(translatedBatch))
+		}
+		for _, result := range translatedBatch {
+			translatedText = append(translatedText, result.Text)
+		}
+	}
+
+	for i, segment := range translatedText {
+		var err error
+		translatedText[i], err = markups[i].Restore(segment)
+		if err != nil {
+			log.Error().Err(err).Str("text", segment).Msg("failed to restore formatting tags")
+			return nil, err
+		}
+	}
+
 	return translatedText, nil
 }
 
+const (
+	// Maximum length of the text sent to detect its language, longer text doesn't make the detection more accurate.
+	detectionSampleLen = 1000
+	// The detected language doesn't depend on the target language of the translation.
+	detectionTargetLanguage = "DE"
+)
+
+// detectionSample joins the segments of the text until the sample reaches detectionSampleLen.
+// Formatting tags are removed, so the sample is plain text which can be cut anywhere.
+func detectionSample(text []string) string {
+	var sample strings.Builder
+	for _, t := range text {
+		t = strings.TrimSpace(srt.StripTags(t))
+		if t == "" {
+			continue
+		}
+		if sample.Len() > 0 {
+			sample.WriteString("\n")
+		}
+		sample.WriteString(t)
+		if sample.Len() >= detectionSampleLen {
+			break
+		}
+	}
+
+	s := sample.String()
+	if len(s) <= detectionSampleLen {
+		return s
+	}
+	// Cut at a rune boundary.
+	end := detectionSampleLen
+	for end > 0 && !utf8.RuneStart(s[end]) {
+		end--
+	}
+	return s[:end]
+}
+
+func (t *translator) DetectLanguage(ctx context.Context, text []string) (string, error) {
+	sample := detectionSample(text)
+	if sample == "" {
+		return "", errs.InvalidInput
+	}
+
+	apiClient, err := newDeeplApiClient(ctx, t.store, t.baseURL, uint(len(sample)))
+	if err != nil {
+		log.Error().Err(err).Msg("failed to create DeepL API client")
+		return "", err
+	}
+
+	result, err := apiClient.translate(ctx, []string{sample}, "", detectionTargetLanguage, false)
+	if err != nil {
+		return "", err
+	}
+	if len(result) != 1 || result[0].DetectedSourceLanguage == "" {
+		return "", fmt.Errorf("no language detected")
+	}
+
+	language := strings.ToLower(result[0].DetectedSourceLanguage)
+	log.Debug().Str("language", language).Int("sample_len", len(sample)).Msg("detected language")
+	return language, nil
+}
+
 func (t *translator) GetUsageDeepL(ctx context.Context, apiKey string) (uint, error) {
 	client := &http.Client{
 		Transport: newDeeplTransport(http.DefaultTransport, apiKey),
 	}
 
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, baseURL+"usage", nil)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, t.baseURL+"usage", nil)
 	if err != nil {
 		return 0, err
 	}
@@ -121,9 +317,7 @@ func (t *translator) GetUsageDeepL(ctx context.Context, apiKey string) (uint, er
 	if err != nil {
 		return 0, err
 	}
-	defer resp.Body.Close()
-
-	body, err := io.ReadAll(resp.Body)
+	body, err := readBody(resp)
 	if err != nil {
 		return 0, err
 	}
@@ -0,0 +1,162 @@
+package translation_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+
+	"github.com/pkulik0/autocc/api/internal/cache"
+	"github.com/pkulik0/autocc/api/internal/errs"
+	"github.com/pkulik0/autocc/api/internal/fake"
+	"github.com/pkulik0/autocc/api/internal/model"
+	"github.com/pkulik0/autocc/api/internal/store"
+	"github.com/pkulik0/autocc/api/internal/translation"
+)
+
+// setup returns a translator using a fake DeepL server with a key added to the store.
+func setup(c *qt.C, limit uint) (*fake.DeepL, store.Store, translation.Translator, *model.CredentialsDeepL) {
+	f := fake.NewDeepL()
+	c.Cleanup(f.Close)
+	f.AddKey("key", limit)
+
+	s, err := store.NewSQLite(filepath.Join(c.TempDir(), "autocc.db"), nil)
+	c.Assert(err, qt.IsNil)
+	credentials, err := s.AddCredentialsDeepL(context.Background(), "key", 0)
+	c.Assert(err, qt.IsNil)
+
+	return f, s, translation.New(s, cache.NewMemory(100), f.URL()), credentials
+}
+
+func TestGetLanguages(t *testing.T) {
+	c := qt.New(t)
+	f, _, translator, _ := setup(c, 1000)
+	f.SetLanguages("DE", "EN", "NB")
+
+	languages, err := translator.GetLanguages(context.Background())
+	c.Assert(err, qt.IsNil)
+	c.Assert(languages, qt.DeepEquals, []string{"de", "en", "nb"})
+}
+
+func TestTranslate(t *testing.T) {
+	c := qt.New(t)
+	f, _, translator, _ := setup(c, 1000)
+	ctx := context.Background()
+
+	translated, err := translator.Translate(ctx, []string{"Hello", "<i>World</i>", "Hello"}, "en", "de")
+	c.Assert(err, qt.IsNil)
+	c.Assert(translated, qt.DeepEquals, []string{"[DE] Hello", "[DE] <i>World</i>", "[DE] Hello"})
+	usage := f.Usage("key")
+	c.Assert(usage > 0, qt.IsTrue)
+
+	// Translated segments are reused without calling the API.
+	translated, err = translator.Translate(ctx, []string{"World", "Hello"}, "en", "de")
+	c.Assert(err, qt.IsNil)
+	c.Assert(translated, qt.DeepEquals, []string{"[DE] World", "[DE] Hello"})
+	c.Assert(f.Usage("key"), qt.Equals, usage+uint(len("World")))
+
+	reported, err := translator.GetUsageDeepL(ctx, "key")
+	c.Assert(err, qt.IsNil)
+	c.Assert(reported, qt.Equals, f.Usage("key"))
+}
+
+func TestTranslateBatches(t *testing.T) {
+	c := qt.New(t)
+	_, _, translator, _ := setup(c, 10000)
+
+	text := make([]string, fake.DeepLMaxTexts*2+10)
+	expected := make([]string, len(text))
+	for i := range text {
+		text[i] = fmt.Sprintf("Segment %d", i)
+		expected[i] = fake.PseudoTranslate(text[i], "fr")
+	}
+
+	translated, err := translator.Translate(context.Background(), text, "en", "fr")
+	c.Assert(err, qt.IsNil)
+	c.Assert(translated, qt.DeepEquals, expected)
+}
+
+func TestTranslateErrors(t *testing.T) {
+	c := qt.New(t)
+
+	testCases := []struct {
+		name string
+		code int
+	}{
+		{name: "quota exceeded", code: fake.StatusQuotaExceeded},
+		{name: "too many requests", code: http.StatusTooManyRequests},
+		{name: "internal server error", code: http.StatusInternalServerError},
+		{name: "service unavailable", code: http.StatusServiceUnavailable},
+	}
+
+	for _, tc := range testCases {
+		c.Run(tc.name, func(c *qt.C) {
+			f, s, translator, credentials := setup(c, 1000)
+			ctx := context.Background()
+
+			f.FailNext(tc.code)
+			_, err := translator.Translate(ctx, []string{"Hello"}, "en", "de")
+			c.Assert(err, qt.ErrorMatches, fmt.Sprintf("unexpected status code: %d, .*", tc.code))
+
+			// The usage reserved for the failed request is reverted.
+			credentials, err = s.GetCredentialsDeepLByID(ctx, credentials.ID)
+			c.Assert(err, qt.IsNil)
+			c.Assert(credentials.Usage, qt.Equals, uint(0))
+
+			translated, err := translator.Translate(ctx, []string{"Hello"}, "en", "de")
+			c.Assert(err, qt.IsNil)
+			c.Assert(translated, qt.DeepEquals, []string{"[DE] Hello"})
+		})
+	}
+}
+
+func TestTranslateLimit(t *testing.T) {
+	c := qt.New(t)
+	f, _, translator, _ := setup(c, 5)
+
+	_, err := translator.Translate(context.Background(), []string{"Hello, World!"}, "en", "de")
+	c.Assert(err, qt.ErrorMatches, fmt.Sprintf("unexpected status code: %d, .*", fake.StatusQuotaExceeded))
+	c.Assert(f.Usage("key"), qt.Equals, uint(0))
+}
+
+func TestDetectLanguage(t *testing.T) {
+	c := qt.New(t)
+	f, _, translator, _ := setup(c, 10000)
+	ctx := context.Background()
+	f.SetDetectedLanguage("PL")
+
+	language, err := translator.DetectLanguage(ctx, []string{"Tytuł", "", strings.Repeat("Opis filmu. ", 500)})
+	c.Assert(err, qt.IsNil)
+	c.Assert(language, qt.Equals, "pl")
+	// Only a sample of the text is sent.
+	usage := f.Usage("key")
+	c.Assert(usage > 0 && usage <= 1000, qt.IsTrue, qt.Commentf("usage: %d", usage))
+
+	_, err = translator.DetectLanguage(ctx, []string{" ", ""})
+	c.Assert(err, qt.Equals, errs.InvalidInput)
+}
+
+func TestDetectLanguageTags(t *testing.T) {
+	c := qt.New(t)
+	f, _, translator, _ := setup(c, 10000)
+	f.SetDetectedLanguage("EN")
+
+	// The sample is cut inside a segment crossing its maximum length, formatting tags are removed so the cut can't break them.
+	segment := strings.Repeat("<i>Tom & Jerry</i> ", 100)
+	language, err := translator.DetectLanguage(context.Background(), []string{"{\\an8}Cartoon", segment})
+	c.Assert(err, qt.IsNil)
+	c.Assert(language, qt.Equals, "en")
+	c.Assert(f.Usage("key"), qt.Equals, uint(1000))
+}
+
+func TestGetUsageDeepLInvalidKey(t *testing.T) {
+	c := qt.New(t)
+	_, _, translator, _ := setup(c, 1000)
+
+	_, err := translator.GetUsageDeepL(context.Background(), "invalid")
+	c.Assert(err, qt.ErrorMatches, "unexpected status code: 403, .*")
+}
@@ -10,6 +10,7 @@ import (
 
 	"github.com/pkulik0/autocc/api/internal/cache"
 	"github.com/pkulik0/autocc/api/internal/errs"
+	"github.com/pkulik0/autocc/api/internal/pb"
 	"github.com/pkulik0/autocc/api/internal/quota"
 	"github.com/pkulik0/autocc/api/internal/srt"
 	"github.com/rs/zerolog/log"
@@ -19,54 +20,73 @@ const (
 	captionsFormat = "srt"
 )
 
+const (
+	// TrackKindASR is the kind of closed captions generated by YouTube's speech recognition.
+	TrackKindASR = "asr"
+)
+
+// Times of changes on YouTube come from its clock, they're compared to the local time with this tolerance.
+const clockSkew = time.Minute
+
 type CC struct {
 	Id       string
 	Language string
+	// TrackKind is the kind of the closed captions, e.g. TrackKindASR.
+	TrackKind string
+	// LastUpdated is when the closed captions were last changed, it's zero if YouTube didn't return it.
+	LastUpdated time.Time
 }
 
-func (y *youtube) GetCC(ctx context.Context, userID, videoID string) ([]*CC, error) {
-	if userID == "" || videoID == "" {
-		return nil, errs.InvalidInput
+// ToProto converts the closed captions to a protobuf message.
+func (c *CC) ToProto() *pb.ClosedCaptions {
+	return &pb.ClosedCaptions{
+		Id:       c.Id,
+		Language: c.Language,
 	}
+}
 
-	service, err := y.getInstance(ctx, userID, quota.YoutubeCaptionsList)
-	if err != nil {
-		return nil, err
+func (y *youtube) GetCC(ctx context.Context, userID, channelID, videoID string) ([]*CC, error) {
+	if userID == "" || channelID == "" || videoID == "" {
+		return nil, errs.InvalidInput
 	}
 
-	resp, err := service.Captions.List([]string{"id", "snippet"}, videoID).Do()
+	resp, err := call(ctx, y, userID, channelID, quota.YoutubeCaptionsList, func(service *yt.Service) (*yt.CaptionListResponse, error) {
+		return service.Captions.List([]string{"id", "snippet"}, videoID).Do()
+	})
 	if err != nil {
 		return nil, err
 	}
 
 	var captions []*CC
 	for _, item := range resp.Items {
-		captions = append(captions, &CC{
-			Id:       item.Id,
-			Language: item.Snippet.Language,
-		})
+		cc := &CC{
+			Id:        item.Id,
+			Language:  item.Snippet.Language,
+			TrackKind: strings.ToLower(item.Snippet.TrackKind),
+		}
+		if lastUpdated, err := time.Parse(time.RFC3339, item.Snippet.LastUpdated); err == nil {
+			cc.LastUpdated = lastUpdated
+		}
+		captions = append(captions, cc)
 	}
 
+	y.setCaptionLanguages(ctx, userID, captionsKey(channelID, videoID), videoID, captionLanguages(captions))
 	return captions, nil
 }
 
-func (y *youtube) DownloadCC(ctx context.Context, userID, ccID string) (*srt.Srt, error) {
-	if userID == "" || ccID == "" {
+func (y *youtube) DownloadCC(ctx context.Context, userID, channelID, ccID string) (*srt.Srt, error) {
+	if userID == "" || channelID == "" || ccID == "" {
 		return nil, errs.InvalidInput
 	}
 
-	service, err := y.getInstance(ctx, userID, quota.YoutubeCaptionsDownload)
-	if err != nil {
-		return nil, err
-	}
-
-	resp, err := service.Captions.Download(ccID).Tfmt(captionsFormat).Download()
-	if err != nil {
-		return nil, err
-	}
-
-	defer resp.Body.Close()
-	body, err := io.ReadAll(resp.Body)
+	body, err := call(ctx, y, userID, channelID, quota.YoutubeCaptionsDownload, func(service *yt.Service) ([]byte, error) {
+		resp, err := service.Captions.Download(ccID).Tfmt(captionsFormat).Download()
+		if err != nil {
+			return nil, err
+		}
+		defer resp.Body.Close()
+		return io.ReadAll(resp.Body)
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -78,41 +98,88 @@ func (y *youtube) DownloadCC(ctx context.Context, userID, ccID string) (*srt.Srt
 	return srt, nil
 }
 
-func (y *youtube) UploadCC(ctx context.Context, userID, videoID, language string, srt *srt.Srt) (string, error) {
-	if userID == "" || videoID == "" || language == "" || srt == nil {
+func (y *youtube) UploadCC(ctx context.Context, userID, channelID, videoID, language string, srt *srt.Srt) (string, error) {
+	if userID == "" || channelID == "" || videoID == "" || language == "" || srt == nil {
 		return "", errs.InvalidInput
 	}
 
-	key := cache.CreateKey(userID, videoID, language, srt.String())
+	key := cache.CreateKey(cache.NamespaceUpload, userID, channelID, videoID, language, srt.String())
 	if value, err := y.cache.Get(ctx, key); err == nil {
 		return value, nil
 	}
 
-	service, err := y.getInstance(ctx, userID, quota.YoutubeCaptionsUpload)
+	// Concurrent uploads of the same closed captions share a single call.
+	select {
+	case result := <-y.group.DoChan(key, func() (any, error) {
+		// The call is shared, so it can't be canceled by any single caller.
+		ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), lockExpiration)
+		defer cancel()
+		return y.uploadCC(ctx, key, userID, channelID, videoID, language, srt)
+	}):
+		if result.Err != nil {
+			return "", result.Err
+		}
+		return result.Val.(string), nil
+	case <-ctx.Done():
+		return "", ctx.Err()
+	}
+}
+
+// findUploadedCC returns closed captions in the language changed since the upload started, if there are any.
+// Retrying an upload which took effect would add another track in the same language.
+func (y *youtube) findUploadedCC(ctx context.Context, userID, channelID, videoID, language string, started time.Time) (*yt.Caption, bool, error) {
+	allCC, err := y.GetCC(ctx, userID, channelID, videoID)
+	if err != nil {
+		return nil, false, err
+	}
+	for _, cc := range allCC {
+		if cc.TrackKind != TrackKindASR && strings.EqualFold(cc.Language, language) && cc.LastUpdated.After(started.Add(-clockSkew)) {
+			return &yt.Caption{Id: cc.Id}, true, nil
+		}
+	}
+	return nil, false, nil
+}
+
+func (y *youtube) uploadCC(ctx context.Context, key, userID, channelID, videoID, language string, srt *srt.Srt) (string, error) {
+	// Other instances might be uploading the same closed captions, wait for them and reuse their result.
+	unlock, err := y.cache.Lock(ctx, key, lockExpiration)
 	if err != nil {
 		return "", err
 	}
+	defer func() {
+		if err := unlock(); err != nil {
+			log.Warn().Err(err).Str("key", key).Msg("failed to release lock")
+		}
+	}()
+	if value, err := y.cache.Get(ctx, key); err == nil {
+		return value, nil
+	}
 
 	log.Trace().Str("video_id", videoID).Str("language", language).Msg("uploading closed captions")
-	resp, err := service.Captions.Insert([]string{"snippet"}, &yt.Caption{Snippet: &yt.CaptionSnippet{
-		Language:        language,
-		VideoId:         videoID,
-		Name:            "",
-		ForceSendFields: []string{"Name"}, // If not set `omitempty` kicks in and the required field is not sent.
-	}}).Media(strings.NewReader(srt.String())).Do()
+	started := time.Now()
+	resp, err := callChecked(ctx, y, userID, channelID, quota.YoutubeCaptionsUpload, func(service *yt.Service) (*yt.Caption, error) {
+		return service.Captions.Insert([]string{"snippet"}, &yt.Caption{Snippet: &yt.CaptionSnippet{
+			Language:        language,
+			VideoId:         videoID,
+			Name:            "",
+			ForceSendFields: []string{"Name"}, // If not set `omitempty` kicks in and the required field is not sent.
+		}}).Media(strings.NewReader(srt.String())).Do()
+	}, func() (*yt.Caption, bool, error) {
+		return y.findUploadedCC(ctx, userID, channelID, videoID, language, started)
+	})
 	if err != nil {
 		return "", err
 	}
 
-	go func() {
-		log.Trace().Str("key", key).Str("id", resp.Id).Msg("setting cache")
-
-		ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
-		defer cancel()
-
-		if err := y.cache.Set(ctx, key, resp.Id, time.Hour*24); err != nil {
-			log.Error().Err(err).Str("key", key).Msg("failed to set cache")
-		}
-	}()
+	// The cache is set before releasing the lock, so waiting instances find the result.
+	log.Trace().Str("key", key).Str("id", resp.Id).Msg("setting cache")
+	if err := y.cache.Set(ctx, key, resp.Id, time.Hour*24); err != nil {
+		log.Error().Err(err).Str("key", key).Msg("failed to set cache")
+	}
+	tags := append(cache.TagsFromContext(ctx), cache.TagUser(userID), cache.TagVideo(videoID), cache.TagLanguage(language))
+	if err := y.cache.Tag(ctx, key, tags, time.Hour*24); err != nil {
+		log.Error().Err(err).Str("key", key).Msg("failed to tag cache")
+	}
+	y.addCaptionLanguage(ctx, userID, channelID, videoID, language)
 	return resp.Id, nil
 }
@@ -0,0 +1,179 @@
+package youtube
+
+import (
+	"context"
+	"maps"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/rs/zerolog/log"
+	yt "google.golang.org/api/youtube/v3"
+
+	"github.com/pkulik0/autocc/api/internal/errs"
+	"github.com/pkulik0/autocc/api/internal/model"
+	"github.com/pkulik0/autocc/api/internal/pb"
+	"github.com/pkulik0/autocc/api/internal/quota"
+)
+
+// Channel is a YouTube channel the user authorized access to.
+type Channel struct {
+	ID    string
+	Title string
+	// Broken is set if none of the sessions of the channel can be used until the user authenticates again.
+	Broken bool
+}
+
+// ToProto converts the channel to a protobuf message.
+func (c *Channel) ToProto() *pb.Channel {
+	return &pb.Channel{
+		Id:     c.ID,
+		Title:  c.Title,
+		Broken: c.Broken,
+	}
+}
+
+func (y *youtube) GetChannels(ctx context.Context, userID string) ([]*Channel, error) {
+	if userID == "" {
+		return nil, errs.InvalidInput
+	}
+
+	sessions, err := y.store.GetSessionGoogleAll(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	byID := make(map[string]*Channel)
+	for _, session := range sessions {
+		// Sessions created before channels were recorded can't be used.
+		if session.ChannelID == "" {
+			continue
+		}
+
+		channel, ok := byID[session.ChannelID]
+		if !ok {
+			channel = &Channel{ID: session.ChannelID, Title: session.ChannelTitle, Broken: true}
+			byID[session.ChannelID] = channel
+		}
+		channel.Broken = channel.Broken && session.Broken
+	}
+
+	channels := make([]*Channel, 0, len(byID))
+	for _, channel := range byID {
+		channels = append(channels, channel)
+	}
+	slices.SortFunc(channels, func(a, b *Channel) int {
+		return strings.Compare(a.Title, b.Title)
+	})
+	return channels, nil
+}
+
+func (y *youtube) GetAuthorizedChannel(ctx context.Context, client *http.Client) (*Channel, error) {
+	service, err := y.newService(ctx, client)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := service.Channels.List([]string{"snippet"}).Mine(true).Do()
+	if err != nil {
+		return nil, err
+	}
+	if len(resp.Items) == 0 {
+		return nil, errs.NotFound
+	}
+
+	return &Channel{
+		ID:    resp.Items[0].Id,
+		Title: resp.Items[0].Snippet.Title,
+	}, nil
+}
+
+func (y *youtube) GetChannelMetadata(ctx context.Context, userID, channelID string) (*Metadata, error) {
+	if userID == "" || channelID == "" {
+		return nil, errs.InvalidInput
+	}
+
+	channel, err := y.getChannel(ctx, userID, channelID)
+	if err != nil {
+		return nil, err
+	}
+
+	language := channel.Snippet.DefaultLanguage
+	if channel.BrandingSettings != nil && channel.BrandingSettings.Channel != nil && channel.BrandingSettings.Channel.DefaultLanguage != "" {
+		language = channel.BrandingSettings.Channel.DefaultLanguage
+	}
+	return &Metadata{
+		Title:       channel.Snippet.Title,
+		Description: channel.Snippet.Description,
+		Language:    language,
+	}, nil
+}
+
+func (y *youtube) getChannel(ctx context.Context, userID, channelID string) (*yt.Channel, error) {
+	resp, err := call(ctx, y, userID, channelID, quota.YoutubeChannelsList, func(service *yt.Service) (*yt.ChannelListResponse, error) {
+		return service.Channels.List([]string{"snippet", "brandingSettings", "localizations"}).Id(channelID).Do()
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(resp.Items) == 0 {
+		return nil, errs.NotFound
+	}
+	return resp.Items[0], nil
+}
+
+// UpdateChannelMetadata merges the metadata into the localizations of the channel according to the policy, the source is its metadata.
+// Localizations require the default language of the channel, it's set to the source language if the channel doesn't have one.
+func (y *youtube) UpdateChannelMetadata(ctx context.Context, userID, channelID string, source *Metadata, metadata map[string]*Metadata, policy MergePolicy) error {
+	if userID == "" || channelID == "" || source == nil || source.Language == "" || len(metadata) == 0 {
+		return errs.InvalidInput
+	}
+
+	// Branding settings are replaced as a whole, so the current ones are sent back.
+	channel, err := y.getChannel(ctx, userID, channelID)
+	if err != nil {
+		return err
+	}
+	settings := channel.BrandingSettings
+	if settings == nil {
+		settings = &yt.ChannelBrandingSettings{}
+	}
+	if settings.Channel == nil {
+		settings.Channel = &yt.ChannelSettings{}
+	}
+	languageSet := settings.Channel.DefaultLanguage != ""
+	if !languageSet {
+		settings.Channel.DefaultLanguage = source.Language
+	}
+
+	existing := make(map[string]localization, len(channel.Localizations))
+	for lang, l := range channel.Localizations {
+		existing[lang] = localization{Title: l.Title, Description: l.Description}
+	}
+	merged, authored, err := y.mergeLocalizations(ctx, model.LocalizationKindChannel, channelID, source, existing, metadata, policy)
+	if err != nil {
+		return err
+	}
+	if languageSet && maps.Equal(merged, existing) {
+		log.Debug().Str("channel_id", channelID).Msg("localizations are up to date")
+		return nil
+	}
+
+	localizations := make(map[string]yt.ChannelLocalization, len(merged))
+	for lang, l := range merged {
+		localizations[lang] = yt.ChannelLocalization{Title: l.Title, Description: l.Description}
+	}
+
+	_, err = call(ctx, y, userID, channelID, quota.YoutubeChannelsUpdate, func(service *yt.Service) (*yt.Channel, error) {
+		return service.Channels.Update([]string{"brandingSettings", "localizations"}, &yt.Channel{
+			Id:               channelID,
+			BrandingSettings: settings,
+			Localizations:    localizations,
+		}).Do()
+	})
+	if err != nil {
+		return err
+	}
+	y.saveLocalizations(ctx, authored)
+	return nil
+}
@@ -2,18 +2,35 @@ package youtube
 
 import (
 	"context"
+	"errors"
 
 	"github.com/rs/zerolog/log"
 	"golang.org/x/oauth2"
-	"google.golang.org/api/option"
 	yt "google.golang.org/api/youtube/v3"
+
+	"github.com/pkulik0/autocc/api/internal/model"
+	"github.com/pkulik0/autocc/api/internal/oauth"
 )
 
-// getInstance returns an authenticated Youtube service with enough quota to make the request.
-func (y *youtube) getInstance(ctx context.Context, userID string, neededQuota uint) (service *yt.Service, err error) {
-	session, revert, err := y.store.GetSessionGoogleByAvailableCost(ctx, userID, neededQuota)
+// errSessionBroken is returned when the refresh token of the chosen session was revoked or expired.
+var errSessionBroken = errors.New("youtube: session broken")
+
+// getInstance returns a Youtube service authenticated for the channel with enough quota to make the request.
+// Sessions with a revoked or expired refresh token are marked as broken and skipped.
+func (y *youtube) getInstance(ctx context.Context, userID, channelID string, neededQuota uint) (*yt.Service, *model.SessionGoogle, error) {
+	for {
+		service, session, err := y.getInstanceFromSession(ctx, userID, channelID, neededQuota)
+		if err == errSessionBroken {
+			continue
+		}
+		return service, session, err
+	}
+}
+
+func (y *youtube) getInstanceFromSession(ctx context.Context, userID, channelID string, neededQuota uint) (service *yt.Service, session *model.SessionGoogle, err error) {
+	session, revert, err := y.store.GetSessionGoogleByAvailableCost(ctx, userID, channelID, neededQuota)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 	defer func() {
 		if err == nil {
@@ -38,15 +55,22 @@ func (y *youtube) getInstance(ctx context.Context, userID string, neededQuota ui
 			log.Debug().Str("user_id", userID).Uint("session_id", session.ID).Msg("updated session token")
 		}
 	})
+	if oauth.IsInvalidGrant(err) {
+		log.Warn().Err(err).Str("user_id", userID).Uint("session_id", session.ID).Msg("session refresh token is invalid, marking as broken")
+		if err := y.store.MarkSessionGoogleBroken(ctx, session.ID); err != nil {
+			return nil, nil, err
+		}
+		return nil, nil, errSessionBroken
+	}
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 	client := oauth2.NewClient(ctx, src)
 
-	service, err = yt.NewService(ctx, option.WithHTTPClient(client))
+	service, err = y.newService(ctx, client)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
-	return service, nil
+	return service, session, nil
 }
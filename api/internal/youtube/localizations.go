@@ -0,0 +1,154 @@
+package youtube
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"maps"
+	"slices"
+
+	"github.com/rs/zerolog/log"
+	yt "google.golang.org/api/youtube/v3"
+
+	"github.com/pkulik0/autocc/api/internal/errs"
+	"github.com/pkulik0/autocc/api/internal/model"
+	"github.com/pkulik0/autocc/api/internal/quota"
+)
+
+// MergePolicy decides which existing localizations are replaced by translated ones.
+// Localizations without a record of AutoCC authoring them, or edited afterwards, are considered written by hand.
+type MergePolicy int
+
+const (
+	// MergeKeepManual keeps localizations written by hand, the ones authored by AutoCC are replaced if their source changed.
+	MergeKeepManual MergePolicy = iota
+	// MergeOverwriteAuto keeps localizations written by hand and replaces all of the ones authored by AutoCC.
+	MergeOverwriteAuto
+	// MergeOverwriteAll replaces all localizations.
+	MergeOverwriteAll
+)
+
+// localization is the translated title and description of a video, a playlist or a channel.
+type localization struct {
+	Title       string
+	Description string
+}
+
+func (l localization) hash() string {
+	return hashMetadata(l.Title, l.Description)
+}
+
+func hashMetadata(title, description string) string {
+	hash := sha256.Sum256([]byte(title + "\x00" + description))
+	return hex.EncodeToString(hash[:])
+}
+
+// replaces returns whether the policy replaces the current localization, the record is nil if AutoCC didn't author it.
+func (p MergePolicy) replaces(current localization, record *model.Localization, sourceHash string) bool {
+	if p == MergeOverwriteAll {
+		return true
+	}
+	if record == nil || record.Hash != current.hash() {
+		return false
+	}
+	return p == MergeOverwriteAuto || record.SourceHash != sourceHash
+}
+
+// mergeLocalizations merges the translated metadata into the existing localizations of the resource according to the policy.
+// It returns the merged localizations and records of the ones authored by AutoCC, see saveLocalizations.
+func (y *youtube) mergeLocalizations(ctx context.Context, kind, resourceID string, source *Metadata, existing map[string]localization, translated map[string]*Metadata, policy MergePolicy) (map[string]localization, []model.Localization, error) {
+	stored, err := y.store.GetLocalizations(ctx, kind, resourceID)
+	if err != nil {
+		return nil, nil, err
+	}
+	records := make(map[string]*model.Localization, len(stored))
+	for i := range stored {
+		records[stored[i].Language] = &stored[i]
+	}
+
+	sourceHash := hashMetadata(source.Title, source.Description)
+	merged := maps.Clone(existing)
+	if merged == nil {
+		merged = make(map[string]localization)
+	}
+	var authored []model.Localization
+	for lang, meta := range translated {
+		if current, ok := existing[lang]; ok && !policy.replaces(current, records[lang], sourceHash) {
+			continue
+		}
+
+		l := localization{Title: meta.Title, Description: meta.Description}
+		merged[lang] = l
+		authored = append(authored, model.Localization{
+			Kind:       kind,
+			ResourceID: resourceID,
+			Language:   lang,
+			Hash:       l.hash(),
+			SourceHash: sourceHash,
+		})
+	}
+
+	log.Debug().Str("kind", kind).Str("resource_id", resourceID).Int("existing", len(existing)).Int("authored", len(authored)).Msg("merged localizations")
+	return merged, authored, nil
+}
+
+// saveLocalizations records the localizations authored by AutoCC after they were set on YouTube.
+func (y *youtube) saveLocalizations(ctx context.Context, authored []model.Localization) {
+	if err := y.store.SaveLocalizations(ctx, authored); err != nil {
+		log.Error().Err(err).Msg("failed to save localizations")
+	}
+}
+
+// LocalizationState tells who authored a localization of a video's title and description.
+type LocalizationState struct {
+	Language string
+	// Authored is set if AutoCC authored the localization and it wasn't edited afterwards.
+	Authored bool
+	// Stale is set if AutoCC translated the localization from a title or description which changed since.
+	Stale bool
+}
+
+func (y *youtube) GetLocalizationStates(ctx context.Context, userID, channelID string, videoIDs ...string) (map[string][]LocalizationState, error) {
+	if userID == "" || channelID == "" || len(videoIDs) > videosMaxResults {
+		return nil, errs.InvalidInput
+	}
+	states := make(map[string][]LocalizationState, len(videoIDs))
+	if len(videoIDs) == 0 {
+		return states, nil
+	}
+
+	resp, err := call(ctx, y, userID, channelID, quota.YoutubeVideosList, func(service *yt.Service) (*yt.VideoListResponse, error) {
+		return service.Videos.List([]string{"snippet", "localizations"}).Id(videoIDs...).Do()
+	})
+	if err != nil {
+		return nil, err
+	}
+	stored, err := y.store.GetLocalizations(ctx, model.LocalizationKindVideo, videoIDs...)
+	if err != nil {
+		return nil, err
+	}
+	records := make(map[string]map[string]*model.Localization)
+	for i, record := range stored {
+		if records[record.ResourceID] == nil {
+			records[record.ResourceID] = make(map[string]*model.Localization)
+		}
+		records[record.ResourceID][record.Language] = &stored[i]
+	}
+
+	for _, video := range resp.Items {
+		sourceHash := hashMetadata(video.Snippet.Title, video.Snippet.Description)
+		videoStates := make([]LocalizationState, 0, len(video.Localizations))
+		for _, lang := range slices.Sorted(maps.Keys(video.Localizations)) {
+			l := localization{Title: video.Localizations[lang].Title, Description: video.Localizations[lang].Description}
+			record := records[video.Id][lang]
+			authored := record != nil && record.Hash == l.hash()
+			videoStates = append(videoStates, LocalizationState{
+				Language: lang,
+				Authored: authored,
+				Stale:    authored && record.SourceHash != sourceHash,
+			})
+		}
+		states[video.Id] = videoStates
+	}
+	return states, nil
+}
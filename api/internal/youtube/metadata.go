@@ -2,67 +2,197 @@ package youtube
 
 import (
 	"context"
+	"maps"
+	"slices"
+	"strings"
 
+	"github.com/rs/zerolog/log"
 	yt "google.golang.org/api/youtube/v3"
 
 	"github.com/pkulik0/autocc/api/internal/errs"
+	"github.com/pkulik0/autocc/api/internal/model"
 	"github.com/pkulik0/autocc/api/internal/quota"
 )
 
+// Maximum length of the tags of a video according to the API documentation.
+// Tags are counted as if they were separated by commas, and tags with spaces as if they were quoted.
+const maxTagsLen = 500
+
 type Metadata struct {
 	Title       string
 	Description string
 	Language    string
+	Tags        []string
 }
 
-func (y *youtube) GetMetadata(ctx context.Context, userID, videoID string) (*Metadata, error) {
-	if userID == "" || videoID == "" {
+// GetMetadata returns the metadata of the video, tags added by AutoCC are left out so only the original ones get translated.
+func (y *youtube) GetMetadata(ctx context.Context, userID, channelID, videoID string) (*Metadata, error) {
+	if userID == "" || channelID == "" || videoID == "" {
 		return nil, errs.InvalidInput
 	}
 
-	service, err := y.getInstance(ctx, userID, quota.YoutubeVideosList)
+	video, err := y.getVideo(ctx, userID, channelID, videoID, "snippet")
 	if err != nil {
 		return nil, err
 	}
+	snippet := video.Snippet
 
-	resp, err := service.Videos.List([]string{"snippet"}).Id(videoID).Do()
+	added, err := y.store.GetAddedTags(ctx, videoID)
 	if err != nil {
 		return nil, err
 	}
-	if len(resp.Items) == 0 {
-		return nil, errs.NotFound
-	}
+	tags := slices.DeleteFunc(slices.Clone(snippet.Tags), func(tag string) bool {
+		_, found := slices.BinarySearch(added, strings.ToLower(tag))
+		return found
+	})
 
-	metadata := resp.Items[0].Snippet
 	return &Metadata{
-		Title:       metadata.Title,
-		Description: metadata.Description,
-		Language:    metadata.DefaultLanguage,
+		Title:       snippet.Title,
+		Description: snippet.Description,
+		Language:    snippet.DefaultLanguage,
+		Tags:        tags,
 	}, nil
 }
 
-func (y *youtube) UpdateMetadata(ctx context.Context, userID, videoID string, metadata map[string]*Metadata) error {
-	if userID == "" || videoID == "" || len(metadata) == 0 {
+func (y *youtube) getVideo(ctx context.Context, userID, channelID, videoID string, parts ...string) (*yt.Video, error) {
+	resp, err := call(ctx, y, userID, channelID, quota.YoutubeVideosList, func(service *yt.Service) (*yt.VideoListResponse, error) {
+		return service.Videos.List(parts).Id(videoID).Do()
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(resp.Items) == 0 {
+		return nil, errs.NotFound
+	}
+	return resp.Items[0], nil
+}
+
+// UpdateMetadata merges the metadata into the localizations of the video according to the policy.
+// The source is the metadata the localizations were translated from, it's used to detect localizations with a stale source.
+// YouTube doesn't support localized tags, so tags of the metadata are added to the tags of the video as long as they fit.
+func (y *youtube) UpdateMetadata(ctx context.Context, userID, channelID, videoID string, source *Metadata, metadata map[string]*Metadata, policy MergePolicy) error {
+	if userID == "" || channelID == "" || videoID == "" || source == nil || len(metadata) == 0 {
 		return errs.InvalidInput
 	}
 
-	service, err := y.getInstance(ctx, userID, quota.YoutubeVideosUpdate)
+	video, err := y.getVideo(ctx, userID, channelID, videoID, "snippet", "localizations")
 	if err != nil {
 		return err
 	}
 
-	localizations := make(map[string]yt.VideoLocalization)
-	for lang, meta := range metadata {
-		localizations[lang] = yt.VideoLocalization{
-			Title:       meta.Title,
-			Description: meta.Description,
+	existing := make(map[string]localization, len(video.Localizations))
+	for lang, l := range video.Localizations {
+		existing[lang] = localization{Title: l.Title, Description: l.Description}
+	}
+	merged, authored, err := y.mergeLocalizations(ctx, model.LocalizationKindVideo, videoID, source, existing, metadata, policy)
+	if err != nil {
+		return err
+	}
+
+	var tags [][]string
+	for _, lang := range slices.Sorted(maps.Keys(metadata)) {
+		if len(metadata[lang].Tags) > 0 {
+			tags = append(tags, metadata[lang].Tags)
 		}
 	}
+	// Tags are a part of the snippet, which is replaced as a whole.
+	snippet := video.Snippet
+	mergedTags := mergeTags(snippet.Tags, tags...)
+	addedTags := mergedTags[len(snippet.Tags):]
+	tagsChanged := len(addedTags) > 0
+
+	if maps.Equal(merged, existing) && !tagsChanged {
+		log.Debug().Str("video_id", videoID).Msg("localizations are up to date")
+		return nil
+	}
 
-	_, err = service.Videos.Update([]string{"localizations"}, &yt.Video{
+	update := &yt.Video{
 		Id:            videoID,
-		Localizations: localizations,
-	}).Do()
-	// TODO: Handle not found
-	return err
+		Localizations: make(map[string]yt.VideoLocalization, len(merged)),
+	}
+	for lang, l := range merged {
+		update.Localizations[lang] = yt.VideoLocalization{Title: l.Title, Description: l.Description}
+	}
+	parts := []string{"localizations"}
+	if tagsChanged {
+		snippet.Tags = mergedTags
+		update.Snippet = snippet
+		parts = append(parts, "snippet")
+	}
+
+	_, err = call(ctx, y, userID, channelID, quota.YoutubeVideosUpdate, func(service *yt.Service) (*yt.Video, error) {
+		return service.Videos.Update(parts, update).Do()
+	})
+	if err != nil {
+		return err
+	}
+	y.saveLocalizations(ctx, authored)
+	if err := y.store.SaveAddedTags(ctx, videoID, addedTags); err != nil {
+		log.Error().Err(err).Str("video_id", videoID).Msg("failed to save added tags")
+	}
+	return nil
+}
+
+// SetLanguage sets the default language of the video.
+// The snippet is replaced as a whole, so the current one is sent back.
+func (y *youtube) SetLanguage(ctx context.Context, userID, channelID, videoID, language string) error {
+	if userID == "" || channelID == "" || videoID == "" || language == "" {
+		return errs.InvalidInput
+	}
+
+	video, err := y.getVideo(ctx, userID, channelID, videoID, "snippet")
+	if err != nil {
+		return err
+	}
+	snippet := video.Snippet
+	if snippet.DefaultLanguage == language {
+		return nil
+	}
+	snippet.DefaultLanguage = language
+
+	_, err = call(ctx, y, userID, channelID, quota.YoutubeVideosUpdate, func(service *yt.Service) (*yt.Video, error) {
+		return service.Videos.Update([]string{"snippet"}, &yt.Video{Id: videoID, Snippet: snippet}).Do()
+	})
+	if err != nil {
+		return err
+	}
+	log.Debug().Str("video_id", videoID).Str("language", language).Msg("set default language")
+	return nil
+}
+
+func tagLen(tag string) int {
+	if strings.Contains(tag, " ") {
+		return len(tag) + 2
+	}
+	return len(tag)
+}
+
+// mergeTags adds the tags which aren't present yet until the tags reach maxTagsLen.
+func mergeTags(tags []string, more ...[]string) []string {
+	merged := slices.Clone(tags)
+	seen := make(map[string]struct{})
+	length := -1
+	for _, tag := range tags {
+		seen[strings.ToLower(tag)] = struct{}{}
+		length += tagLen(tag) + 1
+	}
+
+	for _, t := range more {
+		for _, tag := range t {
+			tag = strings.TrimSpace(tag)
+			if tag == "" {
+				continue
+			}
+			if _, ok := seen[strings.ToLower(tag)]; ok {
+				continue
+			}
+			if length+tagLen(tag)+1 > maxTagsLen {
+				continue
+			}
+			seen[strings.ToLower(tag)] = struct{}{}
+			length += tagLen(tag) + 1
+			merged = append(merged, tag)
+		}
+	}
+	return merged
 }
@@ -0,0 +1,43 @@
+package youtube
+
+import (
+	"strings"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+)
+
+func TestMergeTags(t *testing.T) {
+	c := qt.New(t)
+
+	testCases := []struct {
+		name   string
+		tags   []string
+		more   [][]string
+		merged []string
+	}{
+		{
+			name:   "no tags",
+			more:   [][]string{{"a", "b"}},
+			merged: []string{"a", "b"},
+		},
+		{
+			name:   "duplicates",
+			tags:   []string{"Tag"},
+			more:   [][]string{{"tag", "other"}, {"OTHER", " spaced "}},
+			merged: []string{"Tag", "other", "spaced"},
+		},
+		{
+			name:   "too long",
+			tags:   []string{strings.Repeat("a", 490)},
+			more:   [][]string{{"two words", "tag", "b"}},
+			merged: []string{strings.Repeat("a", 490), "tag", "b"},
+		},
+	}
+
+	for _, tc := range testCases {
+		c.Run(tc.name, func(c *qt.C) {
+			c.Assert(mergeTags(tc.tags, tc.more...), qt.DeepEquals, tc.merged)
+		})
+	}
+}
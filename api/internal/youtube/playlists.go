@@ -0,0 +1,142 @@
+package youtube
+
+import (
+	"context"
+	"maps"
+
+	"github.com/rs/zerolog/log"
+	yt "google.golang.org/api/youtube/v3"
+
+	"github.com/pkulik0/autocc/api/internal/errs"
+	"github.com/pkulik0/autocc/api/internal/model"
+	"github.com/pkulik0/autocc/api/internal/quota"
+)
+
+const (
+	// Maximum value according to the API documentation.
+	playlistsMaxResults = 50
+)
+
+// Playlist is a playlist of a channel, its metadata language is the default language of the playlist.
+type Playlist struct {
+	ID string
+	Metadata
+}
+
+// GetPlaylists returns the playlists of the channel containing the video, including private and unlisted ones.
+// Each playlist is checked for the video with a separate request, so like the coverage of a channel, its cost in quota isn't bounded.
+func (y *youtube) GetPlaylists(ctx context.Context, userID, channelID, videoID string) ([]*Playlist, error) {
+	if userID == "" || channelID == "" || videoID == "" {
+		return nil, errs.InvalidInput
+	}
+
+	var playlists []*Playlist
+	nextPageToken := ""
+	for {
+		resp, err := call(ctx, y, userID, channelID, quota.YoutubePlaylistsList, func(service *yt.Service) (*yt.PlaylistListResponse, error) {
+			list := service.Playlists.List([]string{"snippet"}).Mine(true).MaxResults(playlistsMaxResults)
+			if nextPageToken != "" {
+				list.PageToken(nextPageToken)
+			}
+			return list.Do()
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		for _, item := range resp.Items {
+			contains, err := y.playlistContains(ctx, userID, channelID, item.Id, videoID)
+			if err != nil {
+				return nil, err
+			}
+			if !contains {
+				continue
+			}
+
+			playlists = append(playlists, &Playlist{
+				ID: item.Id,
+				Metadata: Metadata{
+					Title:       item.Snippet.Title,
+					Description: item.Snippet.Description,
+					Language:    item.Snippet.DefaultLanguage,
+				},
+			})
+		}
+
+		nextPageToken = resp.NextPageToken
+		if nextPageToken == "" {
+			return playlists, nil
+		}
+	}
+}
+
+func (y *youtube) playlistContains(ctx context.Context, userID, channelID, playlistID, videoID string) (bool, error) {
+	resp, err := call(ctx, y, userID, channelID, quota.YoutubePlaylistItemsList, func(service *yt.Service) (*yt.PlaylistItemListResponse, error) {
+		return service.PlaylistItems.List([]string{"id"}).PlaylistId(playlistID).VideoId(videoID).MaxResults(1).Do()
+	})
+	if err != nil {
+		return false, err
+	}
+	return len(resp.Items) > 0, nil
+}
+
+// UpdatePlaylistMetadata merges the metadata into the localizations of the playlist according to the policy, the source is its metadata.
+// The snippet is replaced as well, so the default language of the playlist is set to the language of its metadata.
+func (y *youtube) UpdatePlaylistMetadata(ctx context.Context, userID, channelID string, playlist *Playlist, metadata map[string]*Metadata, policy MergePolicy) error {
+	if userID == "" || channelID == "" || playlist == nil || playlist.ID == "" || playlist.Language == "" || len(metadata) == 0 {
+		return errs.InvalidInput
+	}
+
+	current, err := y.getPlaylist(ctx, userID, channelID, playlist.ID)
+	if err != nil {
+		return err
+	}
+
+	existing := make(map[string]localization, len(current.Localizations))
+	for lang, l := range current.Localizations {
+		existing[lang] = localization{Title: l.Title, Description: l.Description}
+	}
+	merged, authored, err := y.mergeLocalizations(ctx, model.LocalizationKindPlaylist, playlist.ID, &playlist.Metadata, existing, metadata, policy)
+	if err != nil {
+		return err
+	}
+	if current.Snippet.DefaultLanguage == playlist.Language && maps.Equal(merged, existing) {
+		log.Debug().Str("playlist_id", playlist.ID).Msg("localizations are up to date")
+		return nil
+	}
+
+	localizations := make(map[string]yt.PlaylistLocalization, len(merged))
+	for lang, l := range merged {
+		localizations[lang] = yt.PlaylistLocalization{Title: l.Title, Description: l.Description}
+	}
+
+	_, err = call(ctx, y, userID, channelID, quota.YoutubePlaylistsUpdate, func(service *yt.Service) (*yt.Playlist, error) {
+		return service.Playlists.Update([]string{"snippet", "localizations"}, &yt.Playlist{
+			Id: playlist.ID,
+			Snippet: &yt.PlaylistSnippet{
+				Title:           current.Snippet.Title,
+				Description:     current.Snippet.Description,
+				DefaultLanguage: playlist.Language,
+			},
+			Localizations: localizations,
+		}).Do()
+	})
+	if err != nil {
+		return err
+	}
+	y.saveLocalizations(ctx, authored)
+	return nil
+}
+
+func (y *youtube) getPlaylist(ctx context.Context, userID, channelID, playlistID string) (*yt.Playlist, error) {
+	resp, err := call(ctx, y, userID, channelID, quota.YoutubePlaylistsList, func(service *yt.Service) (*yt.PlaylistListResponse, error) {
+		return service.Playlists.List([]string{"snippet", "localizations"}).Id(playlistID).Do()
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(resp.Items) == 0 {
+		return nil, errs.NotFound
+	}
+	return resp.Items[0], nil
+}
@@ -0,0 +1,152 @@
+package youtube
+
+import (
+	"context"
+	"errors"
+	"math/rand/v2"
+	"net/http"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"google.golang.org/api/googleapi"
+	yt "google.golang.org/api/youtube/v3"
+
+	"github.com/pkulik0/autocc/api/internal/errs"
+)
+
+// Maximum number of retries of a request failing with a transient error.
+const maxRetries = 4
+
+var (
+	// Delay before the first retry, it's doubled with each retry.
+	retryBaseDelay = time.Millisecond * 500
+	// Maximum delay between retries.
+	retryMaxDelay = time.Second * 16
+)
+
+type errorKind int
+
+const (
+	// errorFatal is returned for errors which won't go away when the request is retried.
+	errorFatal errorKind = iota
+	// errorTransient is returned for errors which might go away when the request is retried after a while.
+	errorTransient
+	// errorQuotaExceeded is returned when the quota of the credentials used for the request is exceeded.
+	errorQuotaExceeded
+)
+
+// classifyError returns the kind of an error returned by the YouTube API.
+func classifyError(err error) errorKind {
+	var apiErr *googleapi.Error
+	if !errors.As(err, &apiErr) {
+		return errorFatal
+	}
+
+	for _, item := range apiErr.Errors {
+		switch item.Reason {
+		case "quotaExceeded", "dailyLimitExceeded":
+			return errorQuotaExceeded
+		case "backendError", "internalError", "rateLimitExceeded", "userRateLimitExceeded":
+			return errorTransient
+		}
+	}
+	if apiErr.Code == http.StatusTooManyRequests || apiErr.Code >= http.StatusInternalServerError {
+		return errorTransient
+	}
+	return errorFatal
+}
+
+// mapError converts an error returned by the YouTube API to one of errs if it has a matching value.
+func mapError(err error) error {
+	var apiErr *googleapi.Error
+	if !errors.As(err, &apiErr) {
+		return err
+	}
+
+	switch apiErr.Code {
+	case http.StatusNotFound:
+		return errs.NotFound
+	case http.StatusBadRequest:
+		log.Debug().Err(err).Msg("youtube rejected request")
+		return errs.InvalidInput
+	default:
+		return err
+	}
+}
+
+// backoff returns a random delay before the retry, the upper bound grows exponentially with the number of retries.
+func backoff(retry int) time.Duration {
+	delay := min(retryBaseDelay<<retry, retryMaxDelay)
+	return rand.N(delay)
+}
+
+// call makes a request with a service of the channel and returns its result.
+//
+// The cost is reserved once for the call, requests failing with a transient error are retried with backoff using the same session.
+// If the quota of the credentials was exceeded they're marked as exhausted and the request is made with another session.
+func call[T any](ctx context.Context, y *youtube, userID, channelID string, cost uint, request func(service *yt.Service) (T, error)) (T, error) {
+	return callChecked(ctx, y, userID, channelID, cost, request, nil)
+}
+
+// callChecked is like call, but for requests which mustn't be repeated if they took effect, like uploads.
+// A server error might be returned after the request was handled, so before it's retried check looks for its result.
+// If check finds it, it's returned instead of retrying the request.
+func callChecked[T any](ctx context.Context, y *youtube, userID, channelID string, cost uint, request func(service *yt.Service) (T, error), check func() (T, bool, error)) (T, error) {
+	var zero T
+	service, session, err := y.getInstance(ctx, userID, channelID, cost)
+	if err != nil {
+		return zero, err
+	}
+
+	retry := 0
+	for {
+		result, err := request(service)
+		if err == nil {
+			return result, nil
+		}
+
+		switch classifyError(err) {
+		case errorQuotaExceeded:
+			log.Warn().Err(err).Str("user_id", userID).Uint("credentials_id", session.CredentialsID).Msg("quota exceeded, marking credentials as exhausted")
+			if err := y.store.MarkCredentialsGoogleExhausted(ctx, session.CredentialsID); err != nil {
+				return zero, err
+			}
+			service, session, err = y.getInstance(ctx, userID, channelID, cost)
+			if err != nil {
+				return zero, err
+			}
+		case errorTransient:
+			if retry >= maxRetries {
+				return zero, err
+			}
+			delay := backoff(retry)
+			retry++
+			log.Debug().Err(err).Str("user_id", userID).Int("retry", retry).Dur("delay", delay).Msg("retrying youtube request")
+
+			select {
+			case <-time.After(delay):
+			case <-ctx.Done():
+				return zero, ctx.Err()
+			}
+
+			if check != nil && isServerError(err) {
+				result, ok, err := check()
+				if err != nil {
+					return zero, err
+				}
+				if ok {
+					log.Debug().Str("user_id", userID).Msg("request took effect despite the error, not retrying")
+					return result, nil
+				}
+			}
+		default:
+			return zero, mapError(err)
+		}
+	}
+}
+
+// isServerError returns true if the request failed on the server, after it might have been handled.
+func isServerError(err error) bool {
+	var apiErr *googleapi.Error
+	return errors.As(err, &apiErr) && apiErr.Code >= http.StatusInternalServerError
+}
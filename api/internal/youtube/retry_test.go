@@ -0,0 +1,273 @@
+package youtube
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"slices"
+	"testing"
+	"time"
+
+	qt "github.com/frankban/quicktest"
+	"google.golang.org/api/googleapi"
+	yt "google.golang.org/api/youtube/v3"
+
+	"github.com/pkulik0/autocc/api/internal/errs"
+	"github.com/pkulik0/autocc/api/internal/model"
+	"github.com/pkulik0/autocc/api/internal/store"
+)
+
+func apiError(code int, reason string) error {
+	err := &googleapi.Error{Code: code}
+	if reason != "" {
+		err.Errors = []googleapi.ErrorItem{{Reason: reason}}
+	}
+	return err
+}
+
+func TestClassifyError(t *testing.T) {
+	c := qt.New(t)
+
+	testCases := []struct {
+		name string
+		err  error
+		kind errorKind
+	}{
+		{name: "not api error", err: errors.New("error"), kind: errorFatal},
+		{name: "not found", err: apiError(http.StatusNotFound, "videoNotFound"), kind: errorFatal},
+		{name: "forbidden", err: apiError(http.StatusForbidden, "forbidden"), kind: errorFatal},
+		{name: "internal", err: apiError(http.StatusInternalServerError, ""), kind: errorTransient},
+		{name: "unavailable", err: apiError(http.StatusServiceUnavailable, ""), kind: errorTransient},
+		{name: "too many requests", err: apiError(http.StatusTooManyRequests, ""), kind: errorTransient},
+		{name: "backend error", err: apiError(http.StatusInternalServerError, "backendError"), kind: errorTransient},
+		{name: "rate limit", err: apiError(http.StatusForbidden, "rateLimitExceeded"), kind: errorTransient},
+		{name: "quota exceeded", err: apiError(http.StatusForbidden, "quotaExceeded"), kind: errorQuotaExceeded},
+		{name: "daily limit exceeded", err: apiError(http.StatusForbidden, "dailyLimitExceeded"), kind: errorQuotaExceeded},
+	}
+
+	for _, tc := range testCases {
+		c.Run(tc.name, func(c *qt.C) {
+			c.Assert(classifyError(tc.err), qt.Equals, tc.kind)
+		})
+	}
+}
+
+func TestMapError(t *testing.T) {
+	c := qt.New(t)
+
+	err := errors.New("error")
+	c.Assert(mapError(err), qt.Equals, err)
+	c.Assert(mapError(apiError(http.StatusNotFound, "videoNotFound")), qt.Equals, errs.NotFound)
+	c.Assert(mapError(apiError(http.StatusBadRequest, "invalidValue")), qt.Equals, errs.InvalidInput)
+
+	err = apiError(http.StatusForbidden, "forbidden")
+	c.Assert(mapError(err), qt.Equals, err)
+}
+
+func TestBackoff(t *testing.T) {
+	c := qt.New(t)
+
+	for retry := range 10 {
+		delay := backoff(retry)
+		c.Assert(delay >= 0, qt.IsTrue)
+		c.Assert(delay < min(retryBaseDelay<<retry, retryMaxDelay), qt.IsTrue)
+	}
+}
+
+// fakeStore hands out sessions of credentials which weren't marked as exhausted.
+type fakeStore struct {
+	store.Store
+	sessions     []*model.SessionGoogle
+	exhausted    []uint
+	reservations int
+}
+
+func (s *fakeStore) GetSessionGoogleByAvailableCost(ctx context.Context, userID, channelID string, cost uint) (*model.SessionGoogle, func() error, error) {
+	for _, session := range s.sessions {
+		if !slices.Contains(s.exhausted, session.CredentialsID) {
+			s.reservations++
+			return session, func() error { return nil }, nil
+		}
+	}
+	return nil, nil, errs.NotFound
+}
+
+func (s *fakeStore) MarkCredentialsGoogleExhausted(ctx context.Context, id uint) error {
+	s.exhausted = append(s.exhausted, id)
+	return nil
+}
+
+func newFakeSession(credentialsID uint) *model.SessionGoogle {
+	return &model.SessionGoogle{
+		AccessToken:   "access",
+		Expiry:        time.Now().Add(time.Hour),
+		CredentialsID: credentialsID,
+	}
+}
+
+func TestCall(t *testing.T) {
+	c := qt.New(t)
+	c.Patch(&retryBaseDelay, time.Millisecond)
+	c.Patch(&retryMaxDelay, time.Millisecond*5)
+
+	testCases := []struct {
+		name         string
+		errs         []error
+		result       string
+		err          error
+		attempts     int
+		exhausted    []uint
+		reservations int
+	}{
+		{
+			name:         "success",
+			errs:         []error{nil},
+			result:       "ok",
+			attempts:     1,
+			reservations: 1,
+		},
+		{
+			name:         "transient",
+			errs:         []error{apiError(http.StatusServiceUnavailable, ""), apiError(http.StatusInternalServerError, "backendError"), nil},
+			result:       "ok",
+			attempts:     3,
+			reservations: 1,
+		},
+		{
+			name:         "quota exceeded",
+			errs:         []error{apiError(http.StatusForbidden, "quotaExceeded"), nil},
+			result:       "ok",
+			attempts:     2,
+			exhausted:    []uint{1},
+			reservations: 2,
+		},
+		{
+			name:         "quota exceeded on all credentials",
+			errs:         []error{apiError(http.StatusForbidden, "quotaExceeded"), apiError(http.StatusForbidden, "dailyLimitExceeded")},
+			err:          errs.NotFound,
+			attempts:     2,
+			exhausted:    []uint{1, 2},
+			reservations: 2,
+		},
+		{
+			name:         "not found",
+			errs:         []error{apiError(http.StatusNotFound, "videoNotFound")},
+			err:          errs.NotFound,
+			attempts:     1,
+			reservations: 1,
+		},
+	}
+
+	for _, tc := range testCases {
+		c.Run(tc.name, func(c *qt.C) {
+			s := &fakeStore{sessions: []*model.SessionGoogle{newFakeSession(1), newFakeSession(2)}}
+			y := New(s, nil)
+
+			attempts := 0
+			result, err := call(context.Background(), y, "userID", "channelID", 1, func(service *yt.Service) (string, error) {
+				err := tc.errs[attempts]
+				attempts++
+				if err != nil {
+					return "", err
+				}
+				return "ok", nil
+			})
+			c.Assert(err, qt.Equals, tc.err)
+			c.Assert(result, qt.Equals, tc.result)
+			c.Assert(attempts, qt.Equals, tc.attempts)
+			c.Assert(s.exhausted, qt.DeepEquals, tc.exhausted)
+			c.Assert(s.reservations, qt.Equals, tc.reservations)
+		})
+	}
+
+	c.Run("retries exhausted", func(c *qt.C) {
+		y := New(&fakeStore{sessions: []*model.SessionGoogle{newFakeSession(1)}}, nil)
+
+		attempts := 0
+		retErr := apiError(http.StatusServiceUnavailable, "")
+		_, err := call(context.Background(), y, "userID", "channelID", 1, func(service *yt.Service) (string, error) {
+			attempts++
+			return "", retErr
+		})
+		c.Assert(err, qt.Equals, retErr)
+		c.Assert(attempts, qt.Equals, maxRetries+1)
+	})
+
+	c.Run("canceled", func(c *qt.C) {
+		c.Patch(&retryBaseDelay, time.Hour)
+		c.Patch(&retryMaxDelay, time.Hour)
+		y := New(&fakeStore{sessions: []*model.SessionGoogle{newFakeSession(1)}}, nil)
+
+		ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond*10)
+		defer cancel()
+		_, err := call(ctx, y, "userID", "channelID", 1, func(service *yt.Service) (string, error) {
+			return "", apiError(http.StatusServiceUnavailable, "")
+		})
+		c.Assert(err, qt.Equals, context.DeadlineExceeded)
+	})
+}
+
+func TestCallChecked(t *testing.T) {
+	c := qt.New(t)
+	c.Patch(&retryBaseDelay, time.Millisecond)
+	c.Patch(&retryMaxDelay, time.Millisecond*5)
+
+	testCases := []struct {
+		name     string
+		err      error
+		found    bool
+		result   string
+		attempts int
+		checks   int
+	}{
+		{
+			name:     "server error, found",
+			err:      apiError(http.StatusServiceUnavailable, "backendError"),
+			found:    true,
+			result:   "found",
+			attempts: 1,
+			checks:   1,
+		},
+		{
+			name:     "server error, not found",
+			err:      apiError(http.StatusServiceUnavailable, "backendError"),
+			result:   "ok",
+			attempts: 2,
+			checks:   1,
+		},
+		{
+			name:     "too many requests",
+			err:      apiError(http.StatusTooManyRequests, ""),
+			found:    true,
+			result:   "ok",
+			attempts: 2,
+		},
+	}
+
+	for _, tc := range testCases {
+		c.Run(tc.name, func(c *qt.C) {
+			s := &fakeStore{sessions: []*model.SessionGoogle{newFakeSession(1)}}
+			y := New(s, nil)
+
+			attempts, checks := 0, 0
+			result, err := callChecked(context.Background(), y, "userID", "channelID", 1, func(service *yt.Service) (string, error) {
+				attempts++
+				if attempts == 1 {
+					return "", tc.err
+				}
+				return "ok", nil
+			}, func() (string, bool, error) {
+				checks++
+				if tc.found {
+					return "found", true, nil
+				}
+				return "", false, nil
+			})
+			c.Assert(err, qt.IsNil)
+			c.Assert(result, qt.Equals, tc.result)
+			c.Assert(attempts, qt.Equals, tc.attempts)
+			c.Assert(checks, qt.Equals, tc.checks)
+			c.Assert(s.reservations, qt.Equals, 1)
+		})
+	}
+}
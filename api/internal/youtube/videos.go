@@ -2,10 +2,19 @@ package youtube
 
 import (
 	"context"
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
 	"time"
 
+	"github.com/rs/zerolog/log"
+	"golang.org/x/sync/errgroup"
+	yt "google.golang.org/api/youtube/v3"
 	"google.golang.org/protobuf/types/known/timestamppb"
 
+	"github.com/pkulik0/autocc/api/internal/cache"
 	"github.com/pkulik0/autocc/api/internal/errs"
 	"github.com/pkulik0/autocc/api/internal/pb"
 	"github.com/pkulik0/autocc/api/internal/quota"
@@ -14,43 +23,228 @@ import (
 const (
 	// Maximum value according to the API documentation.
 	videosMaxResults = 50
+	// Maximum number of concurrent requests listing closed captions of videos.
+	captionsConcurrency = 8
+	// Closed captions can be changed outside of AutoCC, so their languages are cached only for a while.
+	// They're cached whenever closed captions of a video are listed, see GetCC.
+	captionsExpiration = time.Hour
 )
 
-func (y *youtube) GetVideos(ctx context.Context, userID, nextPageToken string) ([]*pb.Video, string, error) {
-	if userID == "" {
+// GetVideos pages through the uploads playlist of the channel, which costs less quota than searching.
+// Closed captions aren't listed, only languages cached by earlier listings are set, see ListCaptionLanguages.
+func (y *youtube) GetVideos(ctx context.Context, userID, channelID, nextPageToken string) ([]*pb.Video, string, error) {
+	if userID == "" || channelID == "" {
 		return nil, "", errs.InvalidInput
 	}
 
-	service, err := y.getInstance(ctx, userID, quota.YoutubeSearchList)
+	uploadsID, err := y.getUploadsPlaylist(ctx, userID, channelID)
 	if err != nil {
 		return nil, "", err
 	}
 
-	call := service.Search.List([]string{"snippet"}).ForMine(true).MaxResults(videosMaxResults).Type("video")
-	if nextPageToken != "" {
-		call.PageToken(nextPageToken)
+	resp, err := call(ctx, y, userID, channelID, quota.YoutubePlaylistItemsList, func(service *yt.Service) (*yt.PlaylistItemListResponse, error) {
+		list := service.PlaylistItems.List([]string{"contentDetails"}).PlaylistId(uploadsID).MaxResults(videosMaxResults)
+		if nextPageToken != "" {
+			list.PageToken(nextPageToken)
+		}
+		return list.Do()
+	})
+	if err != nil {
+		return nil, "", err
+	}
+	ids := make([]string, 0, len(resp.Items))
+	for _, item := range resp.Items {
+		ids = append(ids, item.ContentDetails.VideoId)
+	}
+	if len(ids) == 0 {
+		return []*pb.Video{}, resp.NextPageToken, nil
 	}
 
-	resp, err := call.Do()
+	videosResp, err := call(ctx, y, userID, channelID, quota.YoutubeVideosList, func(service *yt.Service) (*yt.VideoListResponse, error) {
+		return service.Videos.List([]string{"snippet", "contentDetails", "status"}).Id(ids...).MaxResults(videosMaxResults).Do()
+	})
 	if err != nil {
 		return nil, "", err
 	}
+	byID := make(map[string]*yt.Video, len(videosResp.Items))
+	for _, video := range videosResp.Items {
+		byID[video.Id] = video
+	}
 
-	videos := make([]*pb.Video, len(resp.Items))
-	for i, item := range resp.Items {
-		publishedAt, err := time.Parse(time.RFC3339, item.Snippet.PublishedAt)
-		if err != nil {
+	// The order of the playlist is kept, videos which were deleted are still listed in it.
+	videos := make([]*pb.Video, 0, len(ids))
+	for _, id := range ids {
+		video, ok := byID[id]
+		if !ok {
 			continue
 		}
-
-		videos[i] = &pb.Video{
-			Id:           item.Id.VideoId,
-			Title:        item.Snippet.Title,
-			ThumbnailUrl: item.Snippet.Thumbnails.High.Url,
-			Description:  item.Snippet.Description,
-			PublishedAt:  &timestamppb.Timestamp{Seconds: publishedAt.Unix()},
+		v := videoToProto(video)
+		if v.HasCaptions {
+			v.CaptionLanguages = y.cachedCaptionLanguages(ctx, channelID, v.Id)
 		}
+		videos = append(videos, v)
 	}
 
+	log.Debug().Str("channel_id", channelID).Int("videos", len(videos)).Msg("listed videos")
 	return videos, resp.NextPageToken, nil
 }
+
+// ListCaptionLanguages lists the closed captions of at most limit videos with unknown caption languages, see GetVideos.
+func (y *youtube) ListCaptionLanguages(ctx context.Context, userID, channelID string, videos []*pb.Video, limit int) (int, error) {
+	if userID == "" || channelID == "" {
+		return 0, errs.InvalidInput
+	}
+
+	var unknown []*pb.Video
+	for _, video := range videos {
+		if len(unknown) == limit {
+			break
+		}
+		if video.HasCaptions && len(video.CaptionLanguages) == 0 {
+			unknown = append(unknown, video)
+		}
+	}
+
+	group, groupCtx := errgroup.WithContext(ctx)
+	group.SetLimit(captionsConcurrency)
+	for _, video := range unknown {
+		group.Go(func() error {
+			allCC, err := y.GetCC(groupCtx, userID, channelID, video.Id)
+			if err != nil {
+				return err
+			}
+			video.CaptionLanguages = captionLanguages(allCC)
+			return nil
+		})
+	}
+	if err := group.Wait(); err != nil {
+		return 0, err
+	}
+
+	log.Debug().Str("channel_id", channelID).Int("listed", len(unknown)).Msg("listed caption languages")
+	return len(unknown), nil
+}
+
+func (y *youtube) getUploadsPlaylist(ctx context.Context, userID, channelID string) (string, error) {
+	resp, err := call(ctx, y, userID, channelID, quota.YoutubeChannelsList, func(service *yt.Service) (*yt.ChannelListResponse, error) {
+		return service.Channels.List([]string{"contentDetails"}).Id(channelID).Do()
+	})
+	if err != nil {
+		return "", err
+	}
+	if len(resp.Items) == 0 {
+		return "", errs.NotFound
+	}
+	details := resp.Items[0].ContentDetails
+	if details == nil || details.RelatedPlaylists == nil || details.RelatedPlaylists.Uploads == "" {
+		return "", errs.NotFound
+	}
+	return details.RelatedPlaylists.Uploads, nil
+}
+
+func videoToProto(video *yt.Video) *pb.Video {
+	v := &pb.Video{
+		Id:          video.Id,
+		Title:       video.Snippet.Title,
+		Description: video.Snippet.Description,
+		Language:    video.Snippet.DefaultLanguage,
+	}
+	if thumbnails := video.Snippet.Thumbnails; thumbnails != nil && thumbnails.High != nil {
+		v.ThumbnailUrl = thumbnails.High.Url
+	}
+	if publishedAt, err := time.Parse(time.RFC3339, video.Snippet.PublishedAt); err == nil {
+		v.PublishedAt = timestamppb.New(publishedAt)
+	} else {
+		log.Warn().Err(err).Str("video_id", video.Id).Msg("invalid publish date")
+	}
+	if video.Status != nil {
+		v.PrivacyStatus = video.Status.PrivacyStatus
+	}
+	if video.ContentDetails != nil {
+		v.HasCaptions = video.ContentDetails.Caption == "true"
+		if duration, err := parseDuration(video.ContentDetails.Duration); err == nil {
+			v.DurationSeconds = uint32(duration.Seconds())
+		} else {
+			log.Warn().Err(err).Str("video_id", video.Id).Msg("invalid duration")
+		}
+	}
+	return v
+}
+
+var durationRegexp = regexp.MustCompile(`^P(?:(\d+)D)?(?:T(?:(\d+)H)?(?:(\d+)M)?(?:(\d+)S)?)?$`)
+
+// parseDuration parses an ISO 8601 duration as returned by YouTube, e.g. PT1H2M3S.
+func parseDuration(s string) (time.Duration, error) {
+	match := durationRegexp.FindStringSubmatch(s)
+	if match == nil || s == "P" || strings.HasSuffix(s, "T") {
+		return 0, fmt.Errorf("invalid duration: %q", s)
+	}
+
+	var duration time.Duration
+	for i, unit := range []time.Duration{24 * time.Hour, time.Hour, time.Minute, time.Second} {
+		if match[i+1] == "" {
+			continue
+		}
+		n, err := strconv.Atoi(match[i+1])
+		if err != nil {
+			return 0, err
+		}
+		duration += time.Duration(n) * unit
+	}
+	return duration, nil
+}
+
+func captionsKey(channelID, videoID string) string {
+	return cache.CreateKey(cache.NamespaceCaptions, channelID, videoID)
+}
+
+// cachedCaptionLanguages returns the sorted languages of the closed captions of the video if they're cached.
+func (y *youtube) cachedCaptionLanguages(ctx context.Context, channelID, videoID string) []string {
+	value, err := y.cache.Get(ctx, captionsKey(channelID, videoID))
+	if err != nil || value == "" {
+		return nil
+	}
+	return strings.Split(value, ",")
+}
+
+// captionLanguages returns the sorted languages of the closed captions.
+func captionLanguages(allCC []*CC) []string {
+	var languages []string
+	for _, cc := range allCC {
+		if !slices.Contains(languages, cc.Language) {
+			languages = append(languages, cc.Language)
+		}
+	}
+	slices.Sort(languages)
+	return languages
+}
+
+// addCaptionLanguage adds the language of uploaded closed captions to the cached languages of the video.
+func (y *youtube) addCaptionLanguage(ctx context.Context, userID, channelID, videoID, language string) {
+	key := captionsKey(channelID, videoID)
+	value, err := y.cache.Get(ctx, key)
+	if err != nil {
+		return
+	}
+
+	var languages []string
+	if value != "" {
+		languages = strings.Split(value, ",")
+	}
+	if slices.Contains(languages, language) {
+		return
+	}
+	languages = append(languages, language)
+	slices.Sort(languages)
+	y.setCaptionLanguages(ctx, userID, key, videoID, languages)
+}
+
+func (y *youtube) setCaptionLanguages(ctx context.Context, userID, key, videoID string, languages []string) {
+	if err := y.cache.Set(ctx, key, strings.Join(languages, ","), captionsExpiration); err != nil {
+		log.Error().Err(err).Str("key", key).Msg("failed to set cache")
+		return
+	}
+	if err := y.cache.Tag(ctx, key, []string{cache.TagUser(userID), cache.TagVideo(videoID)}, captionsExpiration); err != nil {
+		log.Error().Err(err).Str("key", key).Msg("failed to tag cache")
+	}
+}
@@ -0,0 +1,41 @@
+package youtube
+
+import (
+	"testing"
+	"time"
+
+	qt "github.com/frankban/quicktest"
+)
+
+func TestParseDuration(t *testing.T) {
+	c := qt.New(t)
+
+	testCases := []struct {
+		duration string
+		expected time.Duration
+		valid    bool
+	}{
+		{duration: "PT15S", expected: 15 * time.Second, valid: true},
+		{duration: "PT1M30S", expected: 90 * time.Second, valid: true},
+		{duration: "PT2H", expected: 2 * time.Hour, valid: true},
+		{duration: "P1DT1H2M3S", expected: 25*time.Hour + 2*time.Minute + 3*time.Second, valid: true},
+		// Live streams which haven't ended.
+		{duration: "P0D", expected: 0, valid: true},
+		{duration: "", valid: false},
+		{duration: "P", valid: false},
+		{duration: "PT", valid: false},
+		{duration: "1M30S", valid: false},
+	}
+
+	for _, tc := range testCases {
+		c.Run(tc.duration, func(c *qt.C) {
+			duration, err := parseDuration(tc.duration)
+			if !tc.valid {
+				c.Assert(err, qt.IsNotNil)
+				return
+			}
+			c.Assert(err, qt.IsNil)
+			c.Assert(duration, qt.Equals, tc.expected)
+		})
+	}
+}
@@ -2,8 +2,13 @@ package youtube
 
 import (
 	"context"
+	"net/http"
+	"time"
 
 	"github.com/rs/zerolog/log"
+	"golang.org/x/sync/singleflight"
+	"google.golang.org/api/option"
+	yt "google.golang.org/api/youtube/v3"
 
 	"github.com/pkulik0/autocc/api/internal/cache"
 	"github.com/pkulik0/autocc/api/internal/pb"
@@ -15,34 +20,71 @@ import (
 //
 //go:generate mockgen -destination=../mock/youtube.go -package=mock . Youtube
 type Youtube interface {
-	// GetVideos returns a list of videos uploaded by the authenticated user.
-	GetVideos(ctx context.Context, userID, nextPageToken string) ([]*pb.Video, string, error)
+	// GetChannels returns the YouTube channels the user authorized access to.
+	GetChannels(ctx context.Context, userID string) ([]*Channel, error)
+	// GetAuthorizedChannel returns the YouTube channel of the account authorized by the client.
+	GetAuthorizedChannel(ctx context.Context, client *http.Client) (*Channel, error)
 
-	// GetMetadata returns metadata for a video.
-	GetMetadata(ctx context.Context, userID, videoID string) (*Metadata, error)
-	// UpdateMetadata updates metadata for a video for each language.
-	UpdateMetadata(ctx context.Context, userID, videoID string, metadata map[string]*Metadata) error
+	// GetVideos returns a list of videos uploaded to the channel.
+	// Languages of the closed captions are set only if they're known without listing them, which costs 50 units of quota per video.
+	GetVideos(ctx context.Context, userID, channelID, nextPageToken string) ([]*pb.Video, string, error)
+	// ListCaptionLanguages sets the languages of the closed captions of the videos which have them but whose languages aren't known.
+	// At most limit videos are listed, it returns their number.
+	ListCaptionLanguages(ctx context.Context, userID, channelID string, videos []*pb.Video, limit int) (int, error)
+
+	// GetMetadata returns metadata for a video, without the tags added by AutoCC.
+	GetMetadata(ctx context.Context, userID, channelID, videoID string) (*Metadata, error)
+	// SetLanguage sets the default language of a video.
+	SetLanguage(ctx context.Context, userID, channelID, videoID, language string) error
+	// UpdateMetadata merges metadata translated from the source into the localizations of a video according to the policy.
+	UpdateMetadata(ctx context.Context, userID, channelID, videoID string, source *Metadata, metadata map[string]*Metadata, policy MergePolicy) error
+	// GetLocalizationStates returns the states of the localizations of the videos' titles and descriptions by video, ordered by language.
+	// Up to 50 videos are checked at once, the ones which don't exist are left out.
+	GetLocalizationStates(ctx context.Context, userID, channelID string, videoIDs ...string) (map[string][]LocalizationState, error)
+
+	// GetPlaylists returns the playlists of the channel containing the video, including private and unlisted ones.
+	// Every playlist of the channel is checked for the video, so the cost in quota grows with their number.
+	GetPlaylists(ctx context.Context, userID, channelID, videoID string) ([]*Playlist, error)
+	// UpdatePlaylistMetadata merges metadata translated from the playlist into its localizations according to the policy.
+	UpdatePlaylistMetadata(ctx context.Context, userID, channelID string, playlist *Playlist, metadata map[string]*Metadata, policy MergePolicy) error
+
+	// GetChannelMetadata returns the name and description of the channel.
+	GetChannelMetadata(ctx context.Context, userID, channelID string) (*Metadata, error)
+	// UpdateChannelMetadata merges the name and description translated from the source into the localizations of the channel according to the policy.
+	UpdateChannelMetadata(ctx context.Context, userID, channelID string, source *Metadata, metadata map[string]*Metadata, policy MergePolicy) error
 
 	// GetCC returns a list of closed captions for a video.
-	GetCC(ctx context.Context, userID, videoID string) ([]*CC, error)
+	GetCC(ctx context.Context, userID, channelID, videoID string) ([]*CC, error)
 	// DownloadCC downloads closed captions for a video.
-	DownloadCC(ctx context.Context, userID, ccID string) (*srt.Srt, error)
+	DownloadCC(ctx context.Context, userID, channelID, ccID string) (*srt.Srt, error)
 	// UploadCC uploads closed captions for a video.
-	UploadCC(ctx context.Context, userID, videoID, language string, srt *srt.Srt) (string, error)
+	UploadCC(ctx context.Context, userID, channelID, videoID, language string, srt *srt.Srt) (string, error)
 }
 
 var _ Youtube = &youtube{}
 
+// Maximum time an upload can take, used to expire the lock held during it.
+const lockExpiration = time.Minute * 5
+
 type youtube struct {
-	store store.Store
-	cache cache.Cache
+	store   store.Store
+	cache   cache.Cache
+	group   singleflight.Group
+	options []option.ClientOption
 }
 
 // New creates a new YouTube service.
-func New(store store.Store, cache cache.Cache) *youtube {
+// The options are passed to the YouTube API clients, e.g. to use another endpoint.
+func New(store store.Store, cache cache.Cache, options ...option.ClientOption) *youtube {
 	log.Debug().Msg("created youtube service")
 	return &youtube{
-		store: store,
-		cache: cache,
+		store:   store,
+		cache:   cache,
+		options: options,
 	}
 }
+
+// newService creates a YouTube API client making requests with the HTTP client.
+func (y *youtube) newService(ctx context.Context, client *http.Client) (*yt.Service, error) {
+	return yt.NewService(ctx, append([]option.ClientOption{option.WithHTTPClient(client)}, y.options...)...)
+}
@@ -0,0 +1,587 @@
+package youtube_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	qt "github.com/frankban/quicktest"
+	yt "google.golang.org/api/youtube/v3"
+
+	"github.com/pkulik0/autocc/api/internal/cache"
+	"github.com/pkulik0/autocc/api/internal/errs"
+	"github.com/pkulik0/autocc/api/internal/fake"
+	"github.com/pkulik0/autocc/api/internal/model"
+	"github.com/pkulik0/autocc/api/internal/quota"
+	"github.com/pkulik0/autocc/api/internal/srt"
+	"github.com/pkulik0/autocc/api/internal/store"
+	"github.com/pkulik0/autocc/api/internal/youtube"
+)
+
+func setup(c *qt.C) (*fake.Youtube, store.Store, youtube.Youtube) {
+	f := fake.NewYoutube()
+	c.Cleanup(f.Close)
+	s := fake.NewStore(c)
+	return f, s, youtube.New(s, cache.NewMemory(100), f.Options()...)
+}
+
+func newVideo(title, publishedAt string) *yt.Video {
+	return &yt.Video{Snippet: &yt.VideoSnippet{
+		Title:           title,
+		Description:     title + " description",
+		DefaultLanguage: "en",
+		CategoryId:      "22",
+		Tags:            []string{title},
+		PublishedAt:     publishedAt,
+		Thumbnails:      &yt.ThumbnailDetails{High: &yt.Thumbnail{Url: "https://example.com/" + title + ".jpg"}},
+	}}
+}
+
+func TestGetVideos(t *testing.T) {
+	c := qt.New(t)
+	f, s, y := setup(c)
+	ctx := context.Background()
+
+	f.AddChannel("channel1", "Channel 1", "token1")
+	f.AddChannel("channel2", "Channel 2", "token2")
+	f.Session(c, s, "user", "channel1", "token1")
+
+	older := f.AddVideo("channel1", newVideo("older", "2024-01-01T00:00:00Z"))
+	newer := newVideo("newer", "2024-02-01T00:00:00Z")
+	newer.Status = &yt.VideoStatus{PrivacyStatus: "unlisted"}
+	newer.ContentDetails = &yt.VideoContentDetails{Duration: "PT1M30S"}
+	newerID := f.AddVideo("channel1", newer)
+	invalid := f.AddVideo("channel1", newVideo("invalid", ""))
+	f.AddVideo("channel2", newVideo("other", "2024-03-01T00:00:00Z"))
+	f.AddCaption(newerID, "en", "", fake.Srt)
+	f.AddCaption(newerID, "de", "", fake.Srt)
+
+	videos, next, err := y.GetVideos(ctx, "user", "channel1", "")
+	c.Assert(err, qt.IsNil)
+	c.Assert(next, qt.Equals, "")
+	c.Assert(videos, qt.HasLen, 3)
+	c.Assert(videos[0].Id, qt.Equals, newerID)
+	c.Assert(videos[0].Title, qt.Equals, "newer")
+	c.Assert(videos[0].ThumbnailUrl, qt.Equals, "https://example.com/newer.jpg")
+	c.Assert(videos[0].PublishedAt.AsTime(), qt.Equals, time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC))
+	c.Assert(videos[0].PrivacyStatus, qt.Equals, "unlisted")
+	c.Assert(videos[0].DurationSeconds, qt.Equals, uint32(90))
+	c.Assert(videos[0].Language, qt.Equals, "en")
+	c.Assert(videos[0].HasCaptions, qt.IsTrue)
+	c.Assert(videos[0].CaptionLanguages, qt.HasLen, 0)
+	c.Assert(videos[1].Id, qt.Equals, older)
+	c.Assert(videos[1].HasCaptions, qt.IsFalse)
+
+	// Videos with an invalid publish date are listed without it.
+	c.Assert(videos[2].Id, qt.Equals, invalid)
+	c.Assert(videos[2].PublishedAt, qt.IsNil)
+
+	// Languages of listed closed captions are cached.
+	_, err = y.GetCC(ctx, "user", "channel1", newerID)
+	c.Assert(err, qt.IsNil)
+	videos, _, err = y.GetVideos(ctx, "user", "channel1", "")
+	c.Assert(err, qt.IsNil)
+	c.Assert(videos[0].CaptionLanguages, qt.DeepEquals, []string{"de", "en"})
+
+	// Languages of uploaded closed captions are added to the cached ones.
+	cc, err := srt.Parse(fake.Srt)
+	c.Assert(err, qt.IsNil)
+	_, err = y.UploadCC(ctx, "user", "channel1", newerID, "fr", cc)
+	c.Assert(err, qt.IsNil)
+	videos, _, err = y.GetVideos(ctx, "user", "channel1", "")
+	c.Assert(err, qt.IsNil)
+	c.Assert(videos[0].CaptionLanguages, qt.DeepEquals, []string{"de", "en", "fr"})
+
+	// The user doesn't have a session of the other channel.
+	_, _, err = y.GetVideos(ctx, "user", "channel2", "")
+	c.Assert(err, qt.Equals, errs.NotFound)
+}
+
+func TestMetadata(t *testing.T) {
+	c := qt.New(t)
+	f, s, y := setup(c)
+	ctx := context.Background()
+
+	f.AddChannel("channel", "Channel", "token")
+	f.Session(c, s, "user", "channel", "token")
+	videoID := f.AddVideo("channel", newVideo("title", "2024-01-01T00:00:00Z"))
+
+	metadata, err := y.GetMetadata(ctx, "user", "channel", videoID)
+	c.Assert(err, qt.IsNil)
+	c.Assert(metadata, qt.DeepEquals, &youtube.Metadata{Title: "title", Description: "title description", Language: "en", Tags: []string{"title"}})
+
+	err = y.UpdateMetadata(ctx, "user", "channel", videoID, metadata, map[string]*youtube.Metadata{
+		"de": {Title: "Titel", Description: "Beschreibung", Language: "de"},
+		"fr": {Title: "Titre", Description: "Description", Language: "fr"},
+	}, youtube.MergeKeepManual)
+	c.Assert(err, qt.IsNil)
+
+	video := f.Video(videoID)
+	c.Assert(video.Localizations, qt.DeepEquals, map[string]yt.VideoLocalization{
+		"de": {Title: "Titel", Description: "Beschreibung"},
+		"fr": {Title: "Titre", Description: "Description"},
+	})
+	c.Assert(video.Snippet.Title, qt.Equals, "title")
+	c.Assert(video.Snippet.Tags, qt.DeepEquals, []string{"title"})
+
+	// Tags are added to the tags of the video.
+	err = y.UpdateMetadata(ctx, "user", "channel", videoID, metadata, map[string]*youtube.Metadata{
+		"de": {Title: "Titel", Tags: []string{"Titel", "title"}},
+		"fr": {Title: "Titre", Tags: []string{"titre"}},
+	}, youtube.MergeKeepManual)
+	c.Assert(err, qt.IsNil)
+	video = f.Video(videoID)
+	c.Assert(video.Snippet.Tags, qt.DeepEquals, []string{"title", "Titel", "titre"})
+	c.Assert(video.Snippet.Title, qt.Equals, "title")
+	c.Assert(video.Snippet.PublishedAt, qt.Equals, "2024-01-01T00:00:00Z")
+
+	// Added tags aren't returned, so they aren't translated again.
+	metadata, err = y.GetMetadata(ctx, "user", "channel", videoID)
+	c.Assert(err, qt.IsNil)
+	c.Assert(metadata.Tags, qt.DeepEquals, []string{"title"})
+
+	_, err = y.GetMetadata(ctx, "user", "channel", "missing")
+	c.Assert(err, qt.Equals, errs.NotFound)
+	err = y.UpdateMetadata(ctx, "user", "channel", "missing", metadata, map[string]*youtube.Metadata{"de": {Title: "Titel"}}, youtube.MergeKeepManual)
+	c.Assert(err, qt.Equals, errs.NotFound)
+}
+
+func TestSetLanguage(t *testing.T) {
+	c := qt.New(t)
+	f, s, y := setup(c)
+	ctx := context.Background()
+
+	f.AddChannel("channel", "Channel", "token")
+	f.Session(c, s, "user", "channel", "token")
+	video := newVideo("title", "2024-01-01T00:00:00Z")
+	video.Snippet.DefaultLanguage = ""
+	videoID := f.AddVideo("channel", video)
+
+	err := y.SetLanguage(ctx, "user", "channel", videoID, "pl")
+	c.Assert(err, qt.IsNil)
+	video = f.Video(videoID)
+	c.Assert(video.Snippet.DefaultLanguage, qt.Equals, "pl")
+	c.Assert(video.Snippet.Title, qt.Equals, "title")
+	c.Assert(video.Snippet.Tags, qt.DeepEquals, []string{"title"})
+
+	metadata, err := y.GetMetadata(ctx, "user", "channel", videoID)
+	c.Assert(err, qt.IsNil)
+	c.Assert(metadata.Language, qt.Equals, "pl")
+
+	err = y.SetLanguage(ctx, "user", "channel", "missing", "pl")
+	c.Assert(err, qt.Equals, errs.NotFound)
+	err = y.SetLanguage(ctx, "user", "channel", videoID, "")
+	c.Assert(err, qt.Equals, errs.InvalidInput)
+}
+
+func TestListCaptionLanguages(t *testing.T) {
+	c := qt.New(t)
+	f, s, y := setup(c)
+	ctx := context.Background()
+
+	f.AddChannel("channel", "Channel", "token")
+	credentials := f.Session(c, s, "user", "channel", "token")
+	for i := range 3 {
+		id := f.AddVideo("channel", newVideo("captioned", fmt.Sprintf("2024-01-0%dT00:00:00Z", i+1)))
+		f.AddCaption(id, "en", "", fake.Srt)
+	}
+	f.AddVideo("channel", newVideo("other", "2024-02-01T00:00:00Z"))
+
+	// Listing videos doesn't list their closed captions.
+	videos, _, err := y.GetVideos(ctx, "user", "channel", "")
+	c.Assert(err, qt.IsNil)
+	c.Assert(videos, qt.HasLen, 4)
+	retrieved, err := s.GetCredentialsGoogleByID(ctx, credentials.ID)
+	c.Assert(err, qt.IsNil)
+	usage := retrieved.Usage
+
+	// Only videos with closed captions are listed, up to the limit.
+	listed, err := y.ListCaptionLanguages(ctx, "user", "channel", videos, 2)
+	c.Assert(err, qt.IsNil)
+	c.Assert(listed, qt.Equals, 2)
+	c.Assert(videos[0].CaptionLanguages, qt.HasLen, 0)
+	c.Assert(videos[1].CaptionLanguages, qt.DeepEquals, []string{"en"})
+	c.Assert(videos[2].CaptionLanguages, qt.DeepEquals, []string{"en"})
+	c.Assert(videos[3].CaptionLanguages, qt.HasLen, 0)
+	retrieved, err = s.GetCredentialsGoogleByID(ctx, credentials.ID)
+	c.Assert(err, qt.IsNil)
+	c.Assert(retrieved.Usage-usage, qt.Equals, uint(2*quota.YoutubeCaptionsList))
+
+	// Videos with known languages aren't listed again.
+	listed, err = y.ListCaptionLanguages(ctx, "user", "channel", videos, 2)
+	c.Assert(err, qt.IsNil)
+	c.Assert(listed, qt.Equals, 1)
+	c.Assert(videos[3].CaptionLanguages, qt.DeepEquals, []string{"en"})
+}
+
+func TestPlaylists(t *testing.T) {
+	c := qt.New(t)
+	f, s, y := setup(c)
+	ctx := context.Background()
+
+	f.AddChannel("channel", "Channel", "token")
+	f.Session(c, s, "user", "channel", "token")
+	videoID := f.AddVideo("channel", newVideo("title", "2024-01-01T00:00:00Z"))
+	playlistID := f.AddPlaylist("channel", &yt.Playlist{Snippet: &yt.PlaylistSnippet{Title: "Playlist", Description: "Videos", DefaultLanguage: "en"}}, videoID)
+	f.AddPlaylist("channel", &yt.Playlist{Snippet: &yt.PlaylistSnippet{Title: "Other"}})
+	privateID := f.AddPlaylist("channel", &yt.Playlist{
+		Snippet: &yt.PlaylistSnippet{Title: "Private"},
+		Status:  &yt.PlaylistStatus{PrivacyStatus: "private"},
+	}, videoID)
+
+	// Private playlists are included.
+	playlists, err := y.GetPlaylists(ctx, "user", "channel", videoID)
+	c.Assert(err, qt.IsNil)
+	c.Assert(playlists, qt.DeepEquals, []*youtube.Playlist{{
+		ID:       playlistID,
+		Metadata: youtube.Metadata{Title: "Playlist", Description: "Videos", Language: "en"},
+	}, {
+		ID:       privateID,
+		Metadata: youtube.Metadata{Title: "Private"},
+	}})
+
+	err = y.UpdatePlaylistMetadata(ctx, "user", "channel", playlists[0], map[string]*youtube.Metadata{
+		"de": {Title: "Wiedergabeliste", Description: "Videos"},
+	}, youtube.MergeKeepManual)
+	c.Assert(err, qt.IsNil)
+	playlist := f.Playlist(playlistID)
+	c.Assert(playlist.Snippet.Title, qt.Equals, "Playlist")
+	c.Assert(playlist.Localizations, qt.DeepEquals, map[string]yt.PlaylistLocalization{
+		"de": {Title: "Wiedergabeliste", Description: "Videos"},
+	})
+
+	err = y.UpdatePlaylistMetadata(ctx, "user", "channel", &youtube.Playlist{ID: "missing", Metadata: youtube.Metadata{Title: "Missing", Language: "en"}}, map[string]*youtube.Metadata{
+		"de": {Title: "Fehlt"},
+	}, youtube.MergeKeepManual)
+	c.Assert(err, qt.Equals, errs.NotFound)
+}
+
+func TestChannelMetadata(t *testing.T) {
+	c := qt.New(t)
+	f, s, y := setup(c)
+	ctx := context.Background()
+
+	f.AddChannel("channel", "Channel", "token")
+	f.Session(c, s, "user", "channel", "token")
+
+	metadata, err := y.GetChannelMetadata(ctx, "user", "channel")
+	c.Assert(err, qt.IsNil)
+	c.Assert(metadata, qt.DeepEquals, &youtube.Metadata{Title: "Channel"})
+
+	// The default language is set, because the channel doesn't have one.
+	err = y.UpdateChannelMetadata(ctx, "user", "channel", &youtube.Metadata{Title: "Channel", Language: "en"}, map[string]*youtube.Metadata{
+		"de": {Title: "Kanal", Description: "Beschreibung"},
+	}, youtube.MergeKeepManual)
+	c.Assert(err, qt.IsNil)
+	channel := f.Channel("channel")
+	c.Assert(channel.BrandingSettings.Channel.DefaultLanguage, qt.Equals, "en")
+	c.Assert(channel.Localizations, qt.DeepEquals, map[string]yt.ChannelLocalization{
+		"de": {Title: "Kanal", Description: "Beschreibung"},
+	})
+
+	metadata, err = y.GetChannelMetadata(ctx, "user", "channel")
+	c.Assert(err, qt.IsNil)
+	c.Assert(metadata.Language, qt.Equals, "en")
+}
+
+func TestMergeLocalizations(t *testing.T) {
+	c := qt.New(t)
+
+	source := &youtube.Metadata{Title: "title", Description: "description", Language: "en"}
+	changed := &youtube.Metadata{Title: "new title", Description: "description", Language: "en"}
+	translated := map[string]*youtube.Metadata{
+		"de": {Title: "Titel (neu)"},
+		"es": {Title: "Título (nuevo)"},
+		"fr": {Title: "Titre (nouveau)"},
+		"it": {Title: "Titolo (nuovo)"},
+	}
+
+	testCases := []struct {
+		name     string
+		source   *youtube.Metadata
+		policy   youtube.MergePolicy
+		expected map[string]string
+		recorded []string
+	}{
+		{
+			name:   "keep manual",
+			source: source,
+			policy: youtube.MergeKeepManual,
+			// Only the missing language is added.
+			expected: map[string]string{"de": "Titel", "es": "Título (manual)", "fr": "Titre (edited)", "it": "Titolo (nuovo)"},
+			recorded: []string{"de", "fr", "it"},
+		},
+		{
+			name:   "keep manual with a changed source",
+			source: changed,
+			policy: youtube.MergeKeepManual,
+			// The stale localization authored by AutoCC is replaced.
+			expected: map[string]string{"de": "Titel (neu)", "es": "Título (manual)", "fr": "Titre (edited)", "it": "Titolo (nuovo)"},
+			recorded: []string{"de", "fr", "it"},
+		},
+		{
+			name:     "overwrite auto",
+			source:   source,
+			policy:   youtube.MergeOverwriteAuto,
+			expected: map[string]string{"de": "Titel (neu)", "es": "Título (manual)", "fr": "Titre (edited)", "it": "Titolo (nuovo)"},
+			recorded: []string{"de", "fr", "it"},
+		},
+		{
+			name:     "overwrite all",
+			source:   source,
+			policy:   youtube.MergeOverwriteAll,
+			expected: map[string]string{"de": "Titel (neu)", "es": "Título (nuevo)", "fr": "Titre (nouveau)", "it": "Titolo (nuovo)"},
+			recorded: []string{"de", "es", "fr", "it"},
+		},
+	}
+
+	for _, tc := range testCases {
+		c.Run(tc.name, func(c *qt.C) {
+			f, s, y := setup(c)
+			ctx := context.Background()
+
+			f.AddChannel("channel", "Channel", "token")
+			f.Session(c, s, "user", "channel", "token")
+			video := newVideo("title", "2024-01-01T00:00:00Z")
+			video.Snippet.Description = "description"
+			video.Localizations = map[string]yt.VideoLocalization{"es": {Title: "Título (manual)"}}
+			videoID := f.AddVideo("channel", video)
+
+			// AutoCC authors the German and French localizations, then the French one is edited by hand.
+			err := y.UpdateMetadata(ctx, "user", "channel", videoID, source, map[string]*youtube.Metadata{
+				"de": {Title: "Titel"},
+				"fr": {Title: "Titre"},
+			}, youtube.MergeKeepManual)
+			c.Assert(err, qt.IsNil)
+			video = f.Video(videoID)
+			video.Localizations["fr"] = yt.VideoLocalization{Title: "Titre (edited)"}
+			f.AddVideo("channel", video)
+
+			err = y.UpdateMetadata(ctx, "user", "channel", videoID, tc.source, translated, tc.policy)
+			c.Assert(err, qt.IsNil)
+
+			titles := make(map[string]string)
+			for lang, l := range f.Video(videoID).Localizations {
+				titles[lang] = l.Title
+			}
+			c.Assert(titles, qt.DeepEquals, tc.expected)
+
+			records, err := s.GetLocalizations(ctx, model.LocalizationKindVideo, videoID)
+			c.Assert(err, qt.IsNil)
+			var recorded []string
+			for _, record := range records {
+				recorded = append(recorded, record.Language)
+			}
+			c.Assert(recorded, qt.DeepEquals, tc.recorded)
+		})
+	}
+}
+
+func TestGetLocalizationStates(t *testing.T) {
+	c := qt.New(t)
+	f, s, y := setup(c)
+	ctx := context.Background()
+
+	f.AddChannel("channel", "Channel", "token")
+	f.Session(c, s, "user", "channel", "token")
+	video := newVideo("title", "2024-01-01T00:00:00Z")
+	video.Snippet.Description = "description"
+	video.Localizations = map[string]yt.VideoLocalization{"es": {Title: "Título"}}
+	videoID := f.AddVideo("channel", video)
+	otherID := f.AddVideo("channel", newVideo("other", "2024-01-02T00:00:00Z"))
+
+	source := &youtube.Metadata{Title: "title", Description: "description", Language: "en"}
+	err := y.UpdateMetadata(ctx, "user", "channel", videoID, source, map[string]*youtube.Metadata{
+		"de": {Title: "Titel"},
+		"fr": {Title: "Titre"},
+	}, youtube.MergeKeepManual)
+	c.Assert(err, qt.IsNil)
+
+	states, err := y.GetLocalizationStates(ctx, "user", "channel", videoID, otherID, "missing")
+	c.Assert(err, qt.IsNil)
+	c.Assert(states, qt.DeepEquals, map[string][]youtube.LocalizationState{
+		videoID: {
+			{Language: "de", Authored: true},
+			{Language: "es"},
+			{Language: "fr", Authored: true},
+		},
+		otherID: {},
+	})
+
+	// Edited localizations are written by hand, the ones translated from a changed title are stale.
+	video = f.Video(videoID)
+	video.Snippet.Title = "new title"
+	video.Localizations["fr"] = yt.VideoLocalization{Title: "Titre (edited)"}
+	f.AddVideo("channel", video)
+
+	states, err = y.GetLocalizationStates(ctx, "user", "channel", videoID)
+	c.Assert(err, qt.IsNil)
+	c.Assert(states[videoID], qt.DeepEquals, []youtube.LocalizationState{
+		{Language: "de", Authored: true, Stale: true},
+		{Language: "es"},
+		{Language: "fr"},
+	})
+}
+
+func TestCaptions(t *testing.T) {
+	c := qt.New(t)
+	f, s, y := setup(c)
+	ctx := context.Background()
+
+	f.AddChannel("channel", "Channel", "token")
+	f.Session(c, s, "user", "channel", "token")
+	videoID := f.AddVideo("channel", newVideo("title", "2024-01-01T00:00:00Z"))
+	ccID := f.AddCaption(videoID, "en", "", fake.Srt)
+
+	allCC, err := y.GetCC(ctx, "user", "channel", videoID)
+	c.Assert(err, qt.IsNil)
+	c.Assert(allCC, qt.HasLen, 1)
+	c.Assert(allCC[0].Id, qt.Equals, ccID)
+	c.Assert(allCC[0].Language, qt.Equals, "en")
+	c.Assert(allCC[0].TrackKind, qt.Equals, "standard")
+	c.Assert(allCC[0].LastUpdated.IsZero(), qt.IsFalse)
+
+	cc, err := y.DownloadCC(ctx, "user", "channel", ccID)
+	c.Assert(err, qt.IsNil)
+	c.Assert(cc.Text(), qt.DeepEquals, []string{"Hello", "World"})
+
+	_, err = y.DownloadCC(ctx, "user", "channel", "missing")
+	c.Assert(err, qt.Equals, errs.NotFound)
+
+	translated := cc.Clone()
+	c.Assert(translated.ReplaceText([]string{"Hallo", "Welt"}), qt.IsNil)
+	uploadedID, err := y.UploadCC(ctx, "user", "channel", videoID, "de", translated)
+	c.Assert(err, qt.IsNil)
+
+	captions := f.Captions(videoID)
+	c.Assert(captions, qt.HasLen, 2)
+	c.Assert(captions[1].ID, qt.Equals, uploadedID)
+	c.Assert(captions[1].Language, qt.Equals, "de")
+	c.Assert(captions[1].Name, qt.Equals, "")
+	uploaded, err := srt.Parse(captions[1].Body)
+	c.Assert(err, qt.IsNil)
+	c.Assert(uploaded.Text(), qt.DeepEquals, []string{"Hallo", "Welt"})
+
+	// Uploading the same closed captions again reuses the previous upload.
+	id, err := y.UploadCC(ctx, "user", "channel", videoID, "de", translated)
+	c.Assert(err, qt.IsNil)
+	c.Assert(id, qt.Equals, uploadedID)
+	c.Assert(f.Captions(videoID), qt.HasLen, 2)
+}
+
+func TestQuotaExceeded(t *testing.T) {
+	c := qt.New(t)
+	f, s, y := setup(c)
+	ctx := context.Background()
+
+	f.AddChannel("channel", "Channel", "token1")
+	credentials1 := f.Session(c, s, "user", "channel", "token1")
+	credentials2 := f.Session(c, s, "user", "channel", "token2")
+	videoID := f.AddVideo("channel", newVideo("title", "2024-01-01T00:00:00Z"))
+
+	// The request is made with the other session.
+	f.ExceedQuota("token1")
+	_, err := y.GetMetadata(ctx, "user", "channel", videoID)
+	c.Assert(err, qt.IsNil)
+
+	f.ExceedQuota("token2")
+	_, err = y.GetMetadata(ctx, "user", "channel", videoID)
+	c.Assert(err, qt.Equals, errs.NotFound)
+
+	for _, id := range []uint{credentials1.ID, credentials2.ID} {
+		credentials, err := s.GetCredentialsGoogleByID(ctx, id)
+		c.Assert(err, qt.IsNil)
+		c.Assert(credentials.Usage, qt.Equals, uint(quota.Google))
+	}
+}
+
+func TestTransientError(t *testing.T) {
+	c := qt.New(t)
+	f, s, y := setup(c)
+	ctx := context.Background()
+
+	f.AddChannel("channel", "Channel", "token")
+	credentials := f.Session(c, s, "user", "channel", "token")
+	videoID := f.AddVideo("channel", newVideo("title", "2024-01-01T00:00:00Z"))
+
+	f.FailNext(http.StatusServiceUnavailable, "backendError")
+	metadata, err := y.GetMetadata(ctx, "user", "channel", videoID)
+	c.Assert(err, qt.IsNil)
+	c.Assert(metadata.Title, qt.Equals, "title")
+
+	// The retry uses the quota reserved for the first request.
+	credentials, err = s.GetCredentialsGoogleByID(ctx, credentials.ID)
+	c.Assert(err, qt.IsNil)
+	c.Assert(credentials.Usage, qt.Equals, uint(quota.YoutubeVideosList))
+}
+
+func TestUploadCCLostResponse(t *testing.T) {
+	c := qt.New(t)
+	f, s, y := setup(c)
+	ctx := context.Background()
+
+	f.AddChannel("channel", "Channel", "token")
+	credentials := f.Session(c, s, "user", "channel", "token")
+	videoID := f.AddVideo("channel", newVideo("title", "2024-01-01T00:00:00Z"))
+	cc, err := srt.Parse(fake.Srt)
+	c.Assert(err, qt.IsNil)
+
+	// The upload succeeds but YouTube responds with an error, it's found instead of being uploaded again.
+	f.LoseNextResponse(http.StatusServiceUnavailable, "backendError")
+	id, err := y.UploadCC(ctx, "user", "channel", videoID, "de", cc)
+	c.Assert(err, qt.IsNil)
+	captions := f.Captions(videoID)
+	c.Assert(captions, qt.HasLen, 1)
+	c.Assert(captions[0].ID, qt.Equals, id)
+
+	credentials, err = s.GetCredentialsGoogleByID(ctx, credentials.ID)
+	c.Assert(err, qt.IsNil)
+	c.Assert(credentials.Usage, qt.Equals, uint(quota.YoutubeCaptionsUpload+quota.YoutubeCaptionsList))
+
+	// Requests rejected by the server are retried.
+	f.FailNext(http.StatusServiceUnavailable, "backendError")
+	_, err = y.UploadCC(ctx, "user", "channel", videoID, "fr", cc)
+	c.Assert(err, qt.IsNil)
+	c.Assert(f.Captions(videoID), qt.HasLen, 2)
+}
+
+func TestGetAuthorizedChannel(t *testing.T) {
+	c := qt.New(t)
+	f, _, y := setup(c)
+	ctx := context.Background()
+
+	f.AddChannel("channel", "Channel", "token")
+
+	channel, err := y.GetAuthorizedChannel(ctx, f.Client("token"))
+	c.Assert(err, qt.IsNil)
+	c.Assert(channel, qt.DeepEquals, &youtube.Channel{ID: "channel", Title: "Channel"})
+
+	_, err = y.GetAuthorizedChannel(ctx, f.Client("invalid"))
+	c.Assert(err, qt.IsNotNil)
+}
+
+func TestGetChannels(t *testing.T) {
+	c := qt.New(t)
+	_, s, y := setup(c)
+	ctx := context.Background()
+
+	credentials, err := s.AddCredentialsGoogle(ctx, "client", "secret")
+	c.Assert(err, qt.IsNil)
+	expiry := time.Now().Add(time.Hour)
+	_, err = s.CreateSessionGoogle(ctx, "user", "b", "B", "access", "refresh", "", expiry, *credentials)
+	c.Assert(err, qt.IsNil)
+	broken, err := s.CreateSessionGoogle(ctx, "user", "a", "A", "access", "refresh", "", expiry, *credentials)
+	c.Assert(err, qt.IsNil)
+	c.Assert(s.MarkSessionGoogleBroken(ctx, broken.ID), qt.IsNil)
+	_, err = s.CreateSessionGoogle(ctx, "other", "c", "C", "access", "refresh", "", expiry, *credentials)
+	c.Assert(err, qt.IsNil)
+
+	channels, err := y.GetChannels(ctx, "user")
+	c.Assert(err, qt.IsNil)
+	c.Assert(channels, qt.DeepEquals, []*youtube.Channel{
+		{ID: "a", Title: "A", Broken: true},
+		{ID: "b", Title: "B"},
+	})
+}
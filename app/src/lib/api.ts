@@ -11,7 +11,14 @@ import {
 	GetSessionGoogleURLResponse,
 	GetUserSessionsGoogleResponse
 } from './pb/credentials';
-import { GetYoutubeVideosResponse } from './pb/youtube';
+import {
+	DetectVideoLanguageResponse,
+	GetChannelCoverageResponse,
+	GetYoutubeChannelsResponse,
+	GetYoutubeVideosResponse,
+	ProcessVideoRequest,
+	type Channel
+} from './pb/youtube';
 
 const getApiUrl = (endpoint: string) => {
 	if (!endpoint.startsWith('/')) endpoint = '/' + endpoint;
@@ -114,7 +121,7 @@ export const removeCredentials = async (type: 'google' | 'deepl', id: number): P
 	}
 };
 
-export const getUserSessionsGoogle = async (): Promise<number[]> => {
+export const getUserSessionsGoogle = async (): Promise<GetUserSessionsGoogleResponse> => {
 	const u = await userManager.getUser();
 	if (!u) throw new Error('User not logged in');
 	const token = u.access_token;
@@ -129,16 +136,16 @@ export const getUserSessionsGoogle = async (): Promise<number[]> => {
 	}
 
 	const data = await res.arrayBuffer();
-	return GetUserSessionsGoogleResponse.decode(new Uint8Array(data)).credentialIds;
+	return GetUserSessionsGoogleResponse.decode(new Uint8Array(data));
 };
 
-export const getSessionGoogleURL = async (id: number): Promise<string> => {
+export const getSessionGoogleURL = async (credentialsId: number): Promise<string> => {
 	const u = await userManager.getUser();
 	if (!u) throw new Error('User not logged in');
 	const token = u.access_token;
 
 	const redirectUrl = encodeURIComponent(`${window.location.href}`);
-	const res = await fetch(getApiUrl(`/sessions/google/${id}?redirect_url=${redirectUrl}`), {
+	const res = await fetch(getApiUrl(`/sessions/google/${credentialsId}?redirect_url=${redirectUrl}`), {
 		headers: {
 			Authorization: `Bearer ${token}`
 		}
@@ -151,12 +158,12 @@ export const getSessionGoogleURL = async (id: number): Promise<string> => {
 	return GetSessionGoogleURLResponse.decode(new Uint8Array(data)).url;
 };
 
-export const removeSessionGoogle = async (id: number): Promise<void> => {
+export const removeSessionGoogle = async (sessionId: number): Promise<void> => {
 	const u = await userManager.getUser();
 	if (!u) throw new Error('User not logged in');
 	const token = u.access_token;
 
-	const res = await fetch(getApiUrl(`/sessions/google/${id}`), {
+	const res = await fetch(getApiUrl(`/sessions/google/${sessionId}`), {
 		method: 'DELETE',
 		headers: {
 			Authorization: `Bearer ${token}`
@@ -167,12 +174,48 @@ export const removeSessionGoogle = async (id: number): Promise<void> => {
 	}
 };
 
-export const getVideos = async (nextPageToken?: string): Promise<GetYoutubeVideosResponse> => {
+export const getChannels = async (): Promise<Channel[]> => {
 	const u = await userManager.getUser();
 	if (!u) throw new Error('User not logged in');
 	const token = u.access_token;
 
-	const res = await fetch(getApiUrl(`/youtube/videos?next_page_token=${nextPageToken || ''}`), {
+	const res = await fetch(getApiUrl('/youtube/channels'), {
+		headers: {
+			Authorization: `Bearer ${token}`
+		}
+	});
+	if (!res.ok) {
+		throw new Error('Failed to get channels');
+	}
+
+	const data = await res.arrayBuffer();
+	return GetYoutubeChannelsResponse.decode(new Uint8Array(data)).channels;
+};
+
+export type VideoSort = 'newest' | 'oldest' | 'title' | 'missing';
+
+export interface VideoFilters {
+	unprocessed: boolean;
+	missingLanguages: boolean;
+	sort: VideoSort;
+}
+
+export const getVideos = async (
+	channelId: string,
+	nextPageToken?: string,
+	filters?: VideoFilters
+): Promise<GetYoutubeVideosResponse> => {
+	const u = await userManager.getUser();
+	if (!u) throw new Error('User not logged in');
+	const token = u.access_token;
+
+	const params = new URLSearchParams({ next_page_token: nextPageToken || '' });
+	if (filters) {
+		if (filters.unprocessed) params.set('unprocessed', 'true');
+		if (filters.missingLanguages) params.set('missing_languages', 'true');
+		params.set('sort', filters.sort);
+	}
+	const res = await fetch(getApiUrl(`/youtube/channels/${channelId}/videos?${params}`), {
 		headers: {
 			Authorization: `Bearer ${token}`
 		}
@@ -187,19 +230,76 @@ export const getVideos = async (nextPageToken?: string): Promise<GetYoutubeVideo
 	return resp;
 };
 
-export const process = async (videoId: string): Promise<void> => {
+// Thrown when the video doesn't have a default language, see detectLanguage.
+export class LanguageNotSetError extends Error {
+	constructor() {
+		super('Video language not set');
+	}
+}
+
+export const process = async (
+	channelId: string,
+	videoId: string,
+	options: ProcessVideoRequest
+): Promise<void> => {
 	const u = await userManager.getUser();
 	if (!u) throw new Error('User not logged in');
 	const token = u.access_token;
 
-	const res = await fetch(getApiUrl(`/youtube/videos/${videoId}/process`), {
+	const res = await fetch(getApiUrl(`/youtube/channels/${channelId}/videos/${videoId}`), {
 		method: 'POST',
+		headers: {
+			Authorization: `Bearer ${token}`,
+			'Content-Type': 'application/octet-stream'
+		},
+		body: ProcessVideoRequest.encode(options).finish()
+	});
+	if (res.status === 409) {
+		throw new LanguageNotSetError();
+	}
+	if (!res.ok) {
+		throw new Error('Failed to process video');
+	}
+};
+
+export const detectLanguage = async (channelId: string, videoId: string): Promise<string> => {
+	const u = await userManager.getUser();
+	if (!u) throw new Error('User not logged in');
+	const token = u.access_token;
+
+	const res = await fetch(getApiUrl(`/youtube/channels/${channelId}/videos/${videoId}/language`), {
 		headers: {
 			Authorization: `Bearer ${token}`
 		}
 	});
 	if (!res.ok) {
-		throw new Error('Failed to process video');
+		throw new Error('Failed to detect language');
 	}
+
+	const data = await res.arrayBuffer();
+	return DetectVideoLanguageResponse.decode(new Uint8Array(data)).language;
 };
 
+export const getChannelCoverage = async (
+	channelId: string,
+	nextPageToken?: string
+): Promise<GetChannelCoverageResponse> => {
+	const u = await userManager.getUser();
+	if (!u) throw new Error('User not logged in');
+	const token = u.access_token;
+
+	const res = await fetch(
+		getApiUrl(`/youtube/channels/${channelId}/coverage?next_page_token=${nextPageToken || ''}`),
+		{
+			headers: {
+				Authorization: `Bearer ${token}`
+			}
+		}
+	);
+	if (!res.ok) {
+		throw new Error('Failed to get coverage');
+	}
+
+	const data = await res.arrayBuffer();
+	return GetChannelCoverageResponse.decode(new Uint8Array(data));
+};
@@ -0,0 +1,423 @@
+// Code generated by protoc-gen-ts_proto. DO NOT EDIT.
+// versions:
+//   protoc-gen-ts_proto  v2.2.0
+//   protoc               unknown
+// source: pb/cache.proto
+
+/* eslint-disable */
+import { BinaryReader, BinaryWriter } from "@bufbuild/protobuf/wire";
+
+export const protobufPackage = "pb";
+
+export interface CacheStats {
+  namespace: string;
+  hits: number;
+  misses: number;
+}
+
+export interface GetCacheStatsResponse {
+  stats: CacheStats[];
+}
+
+export interface CacheKey {
+  key: string;
+  ttlSeconds: number;
+}
+
+export interface GetCacheKeysResponse {
+  keys: CacheKey[];
+}
+
+export interface InvalidateCacheResponse {
+  removed: number;
+  removedSegments: number;
+}
+
+function createBaseCacheStats(): CacheStats {
+  return { namespace: "", hits: 0, misses: 0 };
+}
+
+export const CacheStats: MessageFns<CacheStats> = {
+  encode(message: CacheStats, writer: BinaryWriter = new BinaryWriter()): BinaryWriter {
+    if (message.namespace !== "") {
+      writer.uint32(10).string(message.namespace);
+    }
+    if (message.hits !== 0) {
+      writer.uint32(16).uint64(message.hits);
+    }
+    if (message.misses !== 0) {
+      writer.uint32(24).uint64(message.misses);
+    }
+    return writer;
+  },
+
+  decode(input: BinaryReader | Uint8Array, length?: number): CacheStats {
+    const reader = input instanceof BinaryReader ? input : new BinaryReader(input);
+    let end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBaseCacheStats();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+        case 1:
+          if (tag !== 10) {
+            break;
+          }
+
+          message.namespace = reader.string();
+          continue;
+        case 2:
+          if (tag !== 16) {
+            break;
+          }
+
+          message.hits = longToNumber(reader.uint64());
+          continue;
+        case 3:
+          if (tag !== 24) {
+            break;
+          }
+
+          message.misses = longToNumber(reader.uint64());
+          continue;
+      }
+      if ((tag & 7) === 4 || tag === 0) {
+        break;
+      }
+      reader.skip(tag & 7);
+    }
+    return message;
+  },
+
+  fromJSON(object: any): CacheStats {
+    return {
+      namespace: isSet(object.namespace) ? globalThis.String(object.namespace) : "",
+      hits: isSet(object.hits) ? globalThis.Number(object.hits) : 0,
+      misses: isSet(object.misses) ? globalThis.Number(object.misses) : 0,
+    };
+  },
+
+  toJSON(message: CacheStats): unknown {
+    const obj: any = {};
+    if (message.namespace !== "") {
+      obj.namespace = message.namespace;
+    }
+    if (message.hits !== 0) {
+      obj.hits = Math.round(message.hits);
+    }
+    if (message.misses !== 0) {
+      obj.misses = Math.round(message.misses);
+    }
+    return obj;
+  },
+
+  create<I extends Exact<DeepPartial<CacheStats>, I>>(base?: I): CacheStats {
+    return CacheStats.fromPartial(base ?? ({} as any));
+  },
+  fromPartial<I extends Exact<DeepPartial<CacheStats>, I>>(object: I): CacheStats {
+    const message = createBaseCacheStats();
+    message.namespace = object.namespace ?? "";
+    message.hits = object.hits ?? 0;
+    message.misses = object.misses ?? 0;
+    return message;
+  },
+};
+
+function createBaseGetCacheStatsResponse(): GetCacheStatsResponse {
+  return { stats: [] };
+}
+
+export const GetCacheStatsResponse: MessageFns<GetCacheStatsResponse> = {
+  encode(message: GetCacheStatsResponse, writer: BinaryWriter = new BinaryWriter()): BinaryWriter {
+    for (const v of message.stats) {
+      CacheStats.encode(v!, writer.uint32(10).fork()).join();
+    }
+    return writer;
+  },
+
+  decode(input: BinaryReader | Uint8Array, length?: number): GetCacheStatsResponse {
+    const reader = input instanceof BinaryReader ? input : new BinaryReader(input);
+    let end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBaseGetCacheStatsResponse();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+        case 1:
+          if (tag !== 10) {
+            break;
+          }
+
+          message.stats.push(CacheStats.decode(reader, reader.uint32()));
+          continue;
+      }
+      if ((tag & 7) === 4 || tag === 0) {
+        break;
+      }
+      reader.skip(tag & 7);
+    }
+    return message;
+  },
+
+  fromJSON(object: any): GetCacheStatsResponse {
+    return {
+      stats: globalThis.Array.isArray(object?.stats) ? object.stats.map((e: any) => CacheStats.fromJSON(e)) : [],
+    };
+  },
+
+  toJSON(message: GetCacheStatsResponse): unknown {
+    const obj: any = {};
+    if (message.stats?.length) {
+      obj.stats = message.stats.map((e) => CacheStats.toJSON(e));
+    }
+    return obj;
+  },
+
+  create<I extends Exact<DeepPartial<GetCacheStatsResponse>, I>>(base?: I): GetCacheStatsResponse {
+    return GetCacheStatsResponse.fromPartial(base ?? ({} as any));
+  },
+  fromPartial<I extends Exact<DeepPartial<GetCacheStatsResponse>, I>>(object: I): GetCacheStatsResponse {
+    const message = createBaseGetCacheStatsResponse();
+    message.stats = object.stats?.map((e) => CacheStats.fromPartial(e)) || [];
+    return message;
+  },
+};
+
+function createBaseCacheKey(): CacheKey {
+  return { key: "", ttlSeconds: 0 };
+}
+
+export const CacheKey: MessageFns<CacheKey> = {
+  encode(message: CacheKey, writer: BinaryWriter = new BinaryWriter()): BinaryWriter {
+    if (message.key !== "") {
+      writer.uint32(10).string(message.key);
+    }
+    if (message.ttlSeconds !== 0) {
+      writer.uint32(16).int64(message.ttlSeconds);
+    }
+    return writer;
+  },
+
+  decode(input: BinaryReader | Uint8Array, length?: number): CacheKey {
+    const reader = input instanceof BinaryReader ? input : new BinaryReader(input);
+    let end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBaseCacheKey();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+        case 1:
+          if (tag !== 10) {
+            break;
+          }
+
+          message.key = reader.string();
+          continue;
+        case 2:
+          if (tag !== 16) {
+            break;
+          }
+
+          message.ttlSeconds = longToNumber(reader.int64());
+          continue;
+      }
+      if ((tag & 7) === 4 || tag === 0) {
+        break;
+      }
+      reader.skip(tag & 7);
+    }
+    return message;
+  },
+
+  fromJSON(object: any): CacheKey {
+    return {
+      key: isSet(object.key) ? globalThis.String(object.key) : "",
+      ttlSeconds: isSet(object.ttlSeconds) ? globalThis.Number(object.ttlSeconds) : 0,
+    };
+  },
+
+  toJSON(message: CacheKey): unknown {
+    const obj: any = {};
+    if (message.key !== "") {
+      obj.key = message.key;
+    }
+    if (message.ttlSeconds !== 0) {
+      obj.ttlSeconds = Math.round(message.ttlSeconds);
+    }
+    return obj;
+  },
+
+  create<I extends Exact<DeepPartial<CacheKey>, I>>(base?: I): CacheKey {
+    return CacheKey.fromPartial(base ?? ({} as any));
+  },
+  fromPartial<I extends Exact<DeepPartial<CacheKey>, I>>(object: I): CacheKey {
+    const message = createBaseCacheKey();
+    message.key = object.key ?? "";
+    message.ttlSeconds = object.ttlSeconds ?? 0;
+    return message;
+  },
+};
+
+function createBaseGetCacheKeysResponse(): GetCacheKeysResponse {
+  return { keys: [] };
+}
+
+export const GetCacheKeysResponse: MessageFns<GetCacheKeysResponse> = {
+  encode(message: GetCacheKeysResponse, writer: BinaryWriter = new BinaryWriter()): BinaryWriter {
+    for (const v of message.keys) {
+      CacheKey.encode(v!, writer.uint32(10).fork()).join();
+    }
+    return writer;
+  },
+
+  decode(input: BinaryReader | Uint8Array, length?: number): GetCacheKeysResponse {
+    const reader = input instanceof BinaryReader ? input : new BinaryReader(input);
+    let end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBaseGetCacheKeysResponse();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+        case 1:
+          if (tag !== 10) {
+            break;
+          }
+
+          message.keys.push(CacheKey.decode(reader, reader.uint32()));
+          continue;
+      }
+      if ((tag & 7) === 4 || tag === 0) {
+        break;
+      }
+      reader.skip(tag & 7);
+    }
+    return message;
+  },
+
+  fromJSON(object: any): GetCacheKeysResponse {
+    return { keys: globalThis.Array.isArray(object?.keys) ? object.keys.map((e: any) => CacheKey.fromJSON(e)) : [] };
+  },
+
+  toJSON(message: GetCacheKeysResponse): unknown {
+    const obj: any = {};
+    if (message.keys?.length) {
+      obj.keys = message.keys.map((e) => CacheKey.toJSON(e));
+    }
+    return obj;
+  },
+
+  create<I extends Exact<DeepPartial<GetCacheKeysResponse>, I>>(base?: I): GetCacheKeysResponse {
+    return GetCacheKeysResponse.fromPartial(base ?? ({} as any));
+  },
+  fromPartial<I extends Exact<DeepPartial<GetCacheKeysResponse>, I>>(object: I): GetCacheKeysResponse {
+    const message = createBaseGetCacheKeysResponse();
+    message.keys = object.keys?.map((e) => CacheKey.fromPartial(e)) || [];
+    return message;
+  },
+};
+
+function createBaseInvalidateCacheResponse(): InvalidateCacheResponse {
+  return { removed: 0, removedSegments: 0 };
+}
+
+export const InvalidateCacheResponse: MessageFns<InvalidateCacheResponse> = {
+  encode(message: InvalidateCacheResponse, writer: BinaryWriter = new BinaryWriter()): BinaryWriter {
+    if (message.removed !== 0) {
+      writer.uint32(8).uint64(message.removed);
+    }
+    if (message.removedSegments !== 0) {
+      writer.uint32(16).uint64(message.removedSegments);
+    }
+    return writer;
+  },
+
+  decode(input: BinaryReader | Uint8Array, length?: number): InvalidateCacheResponse {
+    const reader = input instanceof BinaryReader ? input : new BinaryReader(input);
+    let end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBaseInvalidateCacheResponse();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+        case 1:
+          if (tag !== 8) {
+            break;
+          }
+
+          message.removed = longToNumber(reader.uint64());
+          continue;
+        case 2:
+          if (tag !== 16) {
+            break;
+          }
+
+          message.removedSegments = longToNumber(reader.uint64());
+          continue;
+      }
+      if ((tag & 7) === 4 || tag === 0) {
+        break;
+      }
+      reader.skip(tag & 7);
+    }
+    return message;
+  },
+
+  fromJSON(object: any): InvalidateCacheResponse {
+    return {
+      removed: isSet(object.removed) ? globalThis.Number(object.removed) : 0,
+      removedSegments: isSet(object.removedSegments) ? globalThis.Number(object.removedSegments) : 0,
+    };
+  },
+
+  toJSON(message: InvalidateCacheResponse): unknown {
+    const obj: any = {};
+    if (message.removed !== 0) {
+      obj.removed = Math.round(message.removed);
+    }
+    if (message.removedSegments !== 0) {
+      obj.removedSegments = Math.round(message.removedSegments);
+    }
+    return obj;
+  },
+
+  create<I extends Exact<DeepPartial<InvalidateCacheResponse>, I>>(base?: I): InvalidateCacheResponse {
+    return InvalidateCacheResponse.fromPartial(base ?? ({} as any));
+  },
+  fromPartial<I extends Exact<DeepPartial<InvalidateCacheResponse>, I>>(object: I): InvalidateCacheResponse {
+    const message = createBaseInvalidateCacheResponse();
+    message.removed = object.removed ?? 0;
+    message.removedSegments = object.removedSegments ?? 0;
+    return message;
+  },
+};
+
+type Builtin = Date | Function | Uint8Array | string | number | boolean | undefined;
+
+export type DeepPartial<T> = T extends Builtin ? T
+  : T extends globalThis.Array<infer U> ? globalThis.Array<DeepPartial<U>>
+  : T extends ReadonlyArray<infer U> ? ReadonlyArray<DeepPartial<U>>
+  : T extends {} ? { [K in keyof T]?: DeepPartial<T[K]> }
+  : Partial<T>;
+
+type KeysOfUnion<T> = T extends T ? keyof T : never;
+export type Exact<P, I extends P> = P extends Builtin ? P
+  : P & { [K in keyof P]: Exact<P[K], I[K]> } & { [K in Exclude<keyof I, KeysOfUnion<P>>]: never };
+
+function longToNumber(int64: { toString(): string }): number {
+  const num = globalThis.Number(int64.toString());
+  if (num > globalThis.Number.MAX_SAFE_INTEGER) {
+    throw new globalThis.Error("Value is larger than Number.MAX_SAFE_INTEGER");
+  }
+  if (num < globalThis.Number.MIN_SAFE_INTEGER) {
+    throw new globalThis.Error("Value is smaller than Number.MIN_SAFE_INTEGER");
+  }
+  return num;
+}
+
+function isSet(value: any): boolean {
+  return value !== null && value !== undefined;
+}
+
+export interface MessageFns<T> {
+  encode(message: T, writer?: BinaryWriter): BinaryWriter;
+  decode(input: BinaryReader | Uint8Array, length?: number): T;
+  fromJSON(object: any): T;
+  toJSON(message: T): unknown;
+  create<I extends Exact<DeepPartial<T>, I>>(base?: I): T;
+  fromPartial<I extends Exact<DeepPartial<T>, I>>(object: I): T;
+}
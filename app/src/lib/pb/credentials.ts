@@ -48,8 +48,16 @@ export interface GetSessionGoogleURLResponse {
   url: string;
 }
 
+export interface SessionGoogle {
+  id: number;
+  credentialsId: number;
+  channelId: string;
+  channelTitle: string;
+  broken: boolean;
+}
+
 export interface GetUserSessionsGoogleResponse {
-  credentialIds: number[];
+  sessions: SessionGoogle[];
 }
 
 function createBaseAddCredentialsGoogleRequest(): AddCredentialsGoogleRequest {
@@ -627,44 +635,151 @@ export const GetSessionGoogleURLResponse: MessageFns<GetSessionGoogleURLResponse
   },
 };
 
-function createBaseGetUserSessionsGoogleResponse(): GetUserSessionsGoogleResponse {
-  return { credentialIds: [] };
+function createBaseSessionGoogle(): SessionGoogle {
+  return { id: 0, credentialsId: 0, channelId: "", channelTitle: "", broken: false };
 }
 
-export const GetUserSessionsGoogleResponse: MessageFns<GetUserSessionsGoogleResponse> = {
-  encode(message: GetUserSessionsGoogleResponse, writer: BinaryWriter = new BinaryWriter()): BinaryWriter {
-    writer.uint32(10).fork();
-    for (const v of message.credentialIds) {
-      writer.uint64(v);
+export const SessionGoogle: MessageFns<SessionGoogle> = {
+  encode(message: SessionGoogle, writer: BinaryWriter = new BinaryWriter()): BinaryWriter {
+    if (message.id !== 0) {
+      writer.uint32(8).uint64(message.id);
+    }
+    if (message.credentialsId !== 0) {
+      writer.uint32(16).uint64(message.credentialsId);
+    }
+    if (message.channelId !== "") {
+      writer.uint32(26).string(message.channelId);
+    }
+    if (message.channelTitle !== "") {
+      writer.uint32(34).string(message.channelTitle);
+    }
+    if (message.broken !== false) {
+      writer.uint32(40).bool(message.broken);
     }
-    writer.join();
     return writer;
   },
 
-  decode(input: BinaryReader | Uint8Array, length?: number): GetUserSessionsGoogleResponse {
+  decode(input: BinaryReader | Uint8Array, length?: number): SessionGoogle {
     const reader = input instanceof BinaryReader ? input : new BinaryReader(input);
     let end = length === undefined ? reader.len : reader.pos + length;
-    const message = createBaseGetUserSessionsGoogleResponse();
+    const message = createBaseSessionGoogle();
     while (reader.pos < end) {
       const tag = reader.uint32();
       switch (tag >>> 3) {
         case 1:
-          if (tag === 8) {
-            message.credentialIds.push(longToNumber(reader.uint64()));
+          if (tag !== 8) {
+            break;
+          }
+
+          message.id = longToNumber(reader.uint64());
+          continue;
+        case 2:
+          if (tag !== 16) {
+            break;
+          }
 
-            continue;
+          message.credentialsId = longToNumber(reader.uint64());
+          continue;
+        case 3:
+          if (tag !== 26) {
+            break;
           }
 
-          if (tag === 10) {
-            const end2 = reader.uint32() + reader.pos;
-            while (reader.pos < end2) {
-              message.credentialIds.push(longToNumber(reader.uint64()));
-            }
+          message.channelId = reader.string();
+          continue;
+        case 4:
+          if (tag !== 34) {
+            break;
+          }
 
-            continue;
+          message.channelTitle = reader.string();
+          continue;
+        case 5:
+          if (tag !== 40) {
+            break;
           }
 
-          break;
+          message.broken = reader.bool();
+          continue;
+      }
+      if ((tag & 7) === 4 || tag === 0) {
+        break;
+      }
+      reader.skip(tag & 7);
+    }
+    return message;
+  },
+
+  fromJSON(object: any): SessionGoogle {
+    return {
+      id: isSet(object.id) ? globalThis.Number(object.id) : 0,
+      credentialsId: isSet(object.credentialsId) ? globalThis.Number(object.credentialsId) : 0,
+      channelId: isSet(object.channelId) ? globalThis.String(object.channelId) : "",
+      channelTitle: isSet(object.channelTitle) ? globalThis.String(object.channelTitle) : "",
+      broken: isSet(object.broken) ? globalThis.Boolean(object.broken) : false,
+    };
+  },
+
+  toJSON(message: SessionGoogle): unknown {
+    const obj: any = {};
+    if (message.id !== 0) {
+      obj.id = Math.round(message.id);
+    }
+    if (message.credentialsId !== 0) {
+      obj.credentialsId = Math.round(message.credentialsId);
+    }
+    if (message.channelId !== "") {
+      obj.channelId = message.channelId;
+    }
+    if (message.channelTitle !== "") {
+      obj.channelTitle = message.channelTitle;
+    }
+    if (message.broken !== false) {
+      obj.broken = message.broken;
+    }
+    return obj;
+  },
+
+  create<I extends Exact<DeepPartial<SessionGoogle>, I>>(base?: I): SessionGoogle {
+    return SessionGoogle.fromPartial(base ?? ({} as any));
+  },
+  fromPartial<I extends Exact<DeepPartial<SessionGoogle>, I>>(object: I): SessionGoogle {
+    const message = createBaseSessionGoogle();
+    message.id = object.id ?? 0;
+    message.credentialsId = object.credentialsId ?? 0;
+    message.channelId = object.channelId ?? "";
+    message.channelTitle = object.channelTitle ?? "";
+    message.broken = object.broken ?? false;
+    return message;
+  },
+};
+
+function createBaseGetUserSessionsGoogleResponse(): GetUserSessionsGoogleResponse {
+  return { sessions: [] };
+}
+
+export const GetUserSessionsGoogleResponse: MessageFns<GetUserSessionsGoogleResponse> = {
+  encode(message: GetUserSessionsGoogleResponse, writer: BinaryWriter = new BinaryWriter()): BinaryWriter {
+    for (const v of message.sessions) {
+      SessionGoogle.encode(v!, writer.uint32(26).fork()).join();
+    }
+    return writer;
+  },
+
+  decode(input: BinaryReader | Uint8Array, length?: number): GetUserSessionsGoogleResponse {
+    const reader = input instanceof BinaryReader ? input : new BinaryReader(input);
+    let end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBaseGetUserSessionsGoogleResponse();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+        case 3:
+          if (tag !== 26) {
+            break;
+          }
+
+          message.sessions.push(SessionGoogle.decode(reader, reader.uint32()));
+          continue;
       }
       if ((tag & 7) === 4 || tag === 0) {
         break;
@@ -676,16 +791,16 @@ export const GetUserSessionsGoogleResponse: MessageFns<GetUserSessionsGoogleResp
 
   fromJSON(object: any): GetUserSessionsGoogleResponse {
     return {
-      credentialIds: globalThis.Array.isArray(object?.credentialIds)
-        ? object.credentialIds.map((e: any) => globalThis.Number(e))
+      sessions: globalThis.Array.isArray(object?.sessions)
+        ? object.sessions.map((e: any) => SessionGoogle.fromJSON(e))
         : [],
     };
   },
 
   toJSON(message: GetUserSessionsGoogleResponse): unknown {
     const obj: any = {};
-    if (message.credentialIds?.length) {
-      obj.credentialIds = message.credentialIds.map((e) => Math.round(e));
+    if (message.sessions?.length) {
+      obj.sessions = message.sessions.map((e) => SessionGoogle.toJSON(e));
     }
     return obj;
   },
@@ -697,7 +812,7 @@ export const GetUserSessionsGoogleResponse: MessageFns<GetUserSessionsGoogleResp
     object: I,
   ): GetUserSessionsGoogleResponse {
     const message = createBaseGetUserSessionsGoogleResponse();
-    message.credentialIds = object.credentialIds?.map((e) => e) || [];
+    message.sessions = object.sessions?.map((e) => SessionGoogle.fromPartial(e)) || [];
     return message;
   },
 };
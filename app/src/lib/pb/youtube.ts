@@ -10,12 +10,114 @@ import { Timestamp } from "../google/protobuf/timestamp";
 
 export const protobufPackage = "pb";
 
+/**
+ * Decides which existing localizations are replaced by translated ones.
+ * Localizations which AutoCC didn't author, or which were edited afterwards, are considered manual.
+ */
+export enum MergePolicy {
+  /** Manual localizations are kept, the ones authored by AutoCC are replaced if their source changed. */
+  MERGE_POLICY_KEEP_MANUAL = 0,
+  /** Manual localizations are kept, all of the ones authored by AutoCC are replaced. */
+  MERGE_POLICY_OVERWRITE_AUTO = 1,
+  /** All localizations are replaced. */
+  MERGE_POLICY_OVERWRITE_ALL = 2,
+  UNRECOGNIZED = -1,
+}
+
+export function mergePolicyFromJSON(object: any): MergePolicy {
+  switch (object) {
+    case 0:
+    case "MERGE_POLICY_KEEP_MANUAL":
+      return MergePolicy.MERGE_POLICY_KEEP_MANUAL;
+    case 1:
+    case "MERGE_POLICY_OVERWRITE_AUTO":
+      return MergePolicy.MERGE_POLICY_OVERWRITE_AUTO;
+    case 2:
+    case "MERGE_POLICY_OVERWRITE_ALL":
+      return MergePolicy.MERGE_POLICY_OVERWRITE_ALL;
+    case -1:
+    case "UNRECOGNIZED":
+    default:
+      return MergePolicy.UNRECOGNIZED;
+  }
+}
+
+export function mergePolicyToJSON(object: MergePolicy): string {
+  switch (object) {
+    case MergePolicy.MERGE_POLICY_KEEP_MANUAL:
+      return "MERGE_POLICY_KEEP_MANUAL";
+    case MergePolicy.MERGE_POLICY_OVERWRITE_AUTO:
+      return "MERGE_POLICY_OVERWRITE_AUTO";
+    case MergePolicy.MERGE_POLICY_OVERWRITE_ALL:
+      return "MERGE_POLICY_OVERWRITE_ALL";
+    case MergePolicy.UNRECOGNIZED:
+    default:
+      return "UNRECOGNIZED";
+  }
+}
+
+/** Tells who made the closed captions or the localized metadata of a video in a language. */
+export enum Author {
+  /** There's nothing in the language. */
+  AUTHOR_NONE = 0,
+  /** Uploaded or written by hand, or authored by AutoCC and edited afterwards. */
+  AUTHOR_HUMAN = 1,
+  AUTHOR_AUTOCC = 2,
+  /** Generated by YouTube's speech recognition, only closed captions can have it. */
+  AUTHOR_YOUTUBE = 3,
+  UNRECOGNIZED = -1,
+}
+
+export function authorFromJSON(object: any): Author {
+  switch (object) {
+    case 0:
+    case "AUTHOR_NONE":
+      return Author.AUTHOR_NONE;
+    case 1:
+    case "AUTHOR_HUMAN":
+      return Author.AUTHOR_HUMAN;
+    case 2:
+    case "AUTHOR_AUTOCC":
+      return Author.AUTHOR_AUTOCC;
+    case 3:
+    case "AUTHOR_YOUTUBE":
+      return Author.AUTHOR_YOUTUBE;
+    case -1:
+    case "UNRECOGNIZED":
+    default:
+      return Author.UNRECOGNIZED;
+  }
+}
+
+export function authorToJSON(object: Author): string {
+  switch (object) {
+    case Author.AUTHOR_NONE:
+      return "AUTHOR_NONE";
+    case Author.AUTHOR_HUMAN:
+      return "AUTHOR_HUMAN";
+    case Author.AUTHOR_AUTOCC:
+      return "AUTHOR_AUTOCC";
+    case Author.AUTHOR_YOUTUBE:
+      return "AUTHOR_YOUTUBE";
+    case Author.UNRECOGNIZED:
+    default:
+      return "UNRECOGNIZED";
+  }
+}
+
 export interface Video {
   id: string;
   title: string;
   thumbnailUrl: string;
   description: string;
   publishedAt: Date | undefined;
+  privacyStatus: string;
+  durationSeconds: number;
+  language: string;
+  captionLanguages: string[];
+  processed: boolean;
+  missingLanguages: string[];
+  hasCaptions: boolean;
 }
 
 export interface GetYoutubeVideosResponse {
@@ -23,8 +125,107 @@ export interface GetYoutubeVideosResponse {
   videos: Video[];
 }
 
+export interface Channel {
+  id: string;
+  title: string;
+  broken: boolean;
+}
+
+export interface GetYoutubeChannelsResponse {
+  channels: Channel[];
+}
+
+export interface ProcessVideoRequest {
+  tags: boolean;
+  playlists: boolean;
+  channel: boolean;
+  policy: MergePolicy;
+  language: string;
+}
+
+export interface DetectVideoLanguageResponse {
+  language: string;
+}
+
+export interface ClosedCaptions {
+  id: string;
+  language: string;
+}
+
+export interface GetYoutubeClosedCaptionsResponse {
+  closedCaptions: ClosedCaptions[];
+}
+
+export interface SyncPoint {
+  fromMs: number;
+  toMs: number;
+}
+
+export interface AdjustSourceTimingRequest {
+  syncPoints: SyncPoint[];
+  framerateFrom: number;
+  framerateTo: number;
+  shiftMs: number;
+  minDurationMs: number;
+  maxDurationMs: number;
+}
+
+export interface TranslationState {
+  author: Author;
+  stale: boolean;
+}
+
+export interface LanguageCoverage {
+  language: string;
+  captions: TranslationState | undefined;
+  metadata: TranslationState | undefined;
+}
+
+export interface VideoCoverage {
+  videoId: string;
+  title: string;
+  language: string;
+  languages: LanguageCoverage[];
+}
+
+export interface GetVideoCoverageResponse {
+  coverage: VideoCoverage | undefined;
+}
+
+export interface LanguageSummary {
+  language: string;
+  captionsMissing: number;
+  captionsHuman: number;
+  captionsAutocc: number;
+  captionsYoutube: number;
+  captionsStale: number;
+  metadataMissing: number;
+  metadataHuman: number;
+  metadataAutocc: number;
+  metadataStale: number;
+}
+
+export interface GetChannelCoverageResponse {
+  nextPageToken: string;
+  videos: VideoCoverage[];
+  languages: LanguageSummary[];
+}
+
 function createBaseVideo(): Video {
-  return { id: "", title: "", thumbnailUrl: "", description: "", publishedAt: undefined };
+  return {
+    id: "",
+    title: "",
+    thumbnailUrl: "",
+    description: "",
+    publishedAt: undefined,
+    privacyStatus: "",
+    durationSeconds: 0,
+    language: "",
+    captionLanguages: [],
+    processed: false,
+    missingLanguages: [],
+    hasCaptions: false,
+  };
 }
 
 export const Video: MessageFns<Video> = {
@@ -44,6 +245,27 @@ export const Video: MessageFns<Video> = {
     if (message.publishedAt !== undefined) {
       Timestamp.encode(toTimestamp(message.publishedAt), writer.uint32(42).fork()).join();
     }
+    if (message.privacyStatus !== "") {
+      writer.uint32(50).string(message.privacyStatus);
+    }
+    if (message.durationSeconds !== 0) {
+      writer.uint32(56).uint32(message.durationSeconds);
+    }
+    if (message.language !== "") {
+      writer.uint32(66).string(message.language);
+    }
+    for (const v of message.captionLanguages) {
+      writer.uint32(74).string(v!);
+    }
+    if (message.processed !== false) {
+      writer.uint32(80).bool(message.processed);
+    }
+    for (const v of message.missingLanguages) {
+      writer.uint32(90).string(v!);
+    }
+    if (message.hasCaptions !== false) {
+      writer.uint32(96).bool(message.hasCaptions);
+    }
     return writer;
   },
 
@@ -89,6 +311,55 @@ export const Video: MessageFns<Video> = {
 
           message.publishedAt = fromTimestamp(Timestamp.decode(reader, reader.uint32()));
           continue;
+        case 6:
+          if (tag !== 50) {
+            break;
+          }
+
+          message.privacyStatus = reader.string();
+          continue;
+        case 7:
+          if (tag !== 56) {
+            break;
+          }
+
+          message.durationSeconds = reader.uint32();
+          continue;
+        case 8:
+          if (tag !== 66) {
+            break;
+          }
+
+          message.language = reader.string();
+          continue;
+        case 9:
+          if (tag !== 74) {
+            break;
+          }
+
+          message.captionLanguages.push(reader.string());
+          continue;
+        case 10:
+          if (tag !== 80) {
+            break;
+          }
+
+          message.processed = reader.bool();
+          continue;
+        case 11:
+          if (tag !== 90) {
+            break;
+          }
+
+          message.missingLanguages.push(reader.string());
+          continue;
+        case 12:
+          if (tag !== 96) {
+            break;
+          }
+
+          message.hasCaptions = reader.bool();
+          continue;
       }
       if ((tag & 7) === 4 || tag === 0) {
         break;
@@ -105,6 +376,17 @@ export const Video: MessageFns<Video> = {
       thumbnailUrl: isSet(object.thumbnailUrl) ? globalThis.String(object.thumbnailUrl) : "",
       description: isSet(object.description) ? globalThis.String(object.description) : "",
       publishedAt: isSet(object.publishedAt) ? fromJsonTimestamp(object.publishedAt) : undefined,
+      privacyStatus: isSet(object.privacyStatus) ? globalThis.String(object.privacyStatus) : "",
+      durationSeconds: isSet(object.durationSeconds) ? globalThis.Number(object.durationSeconds) : 0,
+      language: isSet(object.language) ? globalThis.String(object.language) : "",
+      captionLanguages: globalThis.Array.isArray(object?.captionLanguages)
+        ? object.captionLanguages.map((e: any) => globalThis.String(e))
+        : [],
+      processed: isSet(object.processed) ? globalThis.Boolean(object.processed) : false,
+      missingLanguages: globalThis.Array.isArray(object?.missingLanguages)
+        ? object.missingLanguages.map((e: any) => globalThis.String(e))
+        : [],
+      hasCaptions: isSet(object.hasCaptions) ? globalThis.Boolean(object.hasCaptions) : false,
     };
   },
 
@@ -125,6 +407,27 @@ export const Video: MessageFns<Video> = {
     if (message.publishedAt !== undefined) {
       obj.publishedAt = message.publishedAt.toISOString();
     }
+    if (message.privacyStatus !== "") {
+      obj.privacyStatus = message.privacyStatus;
+    }
+    if (message.durationSeconds !== 0) {
+      obj.durationSeconds = Math.round(message.durationSeconds);
+    }
+    if (message.language !== "") {
+      obj.language = message.language;
+    }
+    if (message.captionLanguages?.length) {
+      obj.captionLanguages = message.captionLanguages;
+    }
+    if (message.processed !== false) {
+      obj.processed = message.processed;
+    }
+    if (message.missingLanguages?.length) {
+      obj.missingLanguages = message.missingLanguages;
+    }
+    if (message.hasCaptions !== false) {
+      obj.hasCaptions = message.hasCaptions;
+    }
     return obj;
   },
 
@@ -138,6 +441,13 @@ export const Video: MessageFns<Video> = {
     message.thumbnailUrl = object.thumbnailUrl ?? "";
     message.description = object.description ?? "";
     message.publishedAt = object.publishedAt ?? undefined;
+    message.privacyStatus = object.privacyStatus ?? "";
+    message.durationSeconds = object.durationSeconds ?? 0;
+    message.language = object.language ?? "";
+    message.captionLanguages = object.captionLanguages?.map((e) => e) || [];
+    message.processed = object.processed ?? false;
+    message.missingLanguages = object.missingLanguages?.map((e) => e) || [];
+    message.hasCaptions = object.hasCaptions ?? false;
     return message;
   },
 };
@@ -216,38 +526,1350 @@ export const GetYoutubeVideosResponse: MessageFns<GetYoutubeVideosResponse> = {
   },
 };
 
-type Builtin = Date | Function | Uint8Array | string | number | boolean | undefined;
+function createBaseChannel(): Channel {
+  return { id: "", title: "", broken: false };
+}
 
-export type DeepPartial<T> = T extends Builtin ? T
-  : T extends globalThis.Array<infer U> ? globalThis.Array<DeepPartial<U>>
-  : T extends ReadonlyArray<infer U> ? ReadonlyArray<DeepPartial<U>>
-  : T extends {} ? { [K in keyof T]?: DeepPartial<T[K]> }
-  : Partial<T>;
+export const Channel: MessageFns<Channel> = {
+  encode(message: Channel, writer: BinaryWriter = new BinaryWriter()): BinaryWriter {
+    if (message.id !== "") {
+      writer.uint32(10).string(message.id);
+    }
+    if (message.title !== "") {
+      writer.uint32(18).string(message.title);
+    }
+    if (message.broken !== false) {
+      writer.uint32(24).bool(message.broken);
+    }
+    return writer;
+  },
 
-type KeysOfUnion<T> = T extends T ? keyof T : never;
-export type Exact<P, I extends P> = P extends Builtin ? P
-  : P & { [K in keyof P]: Exact<P[K], I[K]> } & { [K in Exclude<keyof I, KeysOfUnion<P>>]: never };
+  decode(input: BinaryReader | Uint8Array, length?: number): Channel {
+    const reader = input instanceof BinaryReader ? input : new BinaryReader(input);
+    let end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBaseChannel();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+        case 1:
+          if (tag !== 10) {
+            break;
+          }
 
-function toTimestamp(date: Date): Timestamp {
-  const seconds = Math.trunc(date.getTime() / 1_000);
-  const nanos = (date.getTime() % 1_000) * 1_000_000;
-  return { seconds, nanos };
+          message.id = reader.string();
+          continue;
+        case 2:
+          if (tag !== 18) {
+            break;
+          }
+
+          message.title = reader.string();
+          continue;
+        case 3:
+          if (tag !== 24) {
+            break;
+          }
+
+          message.broken = reader.bool();
+          continue;
+      }
+      if ((tag & 7) === 4 || tag === 0) {
+        break;
+      }
+      reader.skip(tag & 7);
+    }
+    return message;
+  },
+
+  fromJSON(object: any): Channel {
+    return {
+      id: isSet(object.id) ? globalThis.String(object.id) : "",
+      title: isSet(object.title) ? globalThis.String(object.title) : "",
+      broken: isSet(object.broken) ? globalThis.Boolean(object.broken) : false,
+    };
+  },
+
+  toJSON(message: Channel): unknown {
+    const obj: any = {};
+    if (message.id !== "") {
+      obj.id = message.id;
+    }
+    if (message.title !== "") {
+      obj.title = message.title;
+    }
+    if (message.broken !== false) {
+      obj.broken = message.broken;
+    }
+    return obj;
+  },
+
+  create<I extends Exact<DeepPartial<Channel>, I>>(base?: I): Channel {
+    return Channel.fromPartial(base ?? ({} as any));
+  },
+  fromPartial<I extends Exact<DeepPartial<Channel>, I>>(object: I): Channel {
+    const message = createBaseChannel();
+    message.id = object.id ?? "";
+    message.title = object.title ?? "";
+    message.broken = object.broken ?? false;
+    return message;
+  },
+};
+
+function createBaseGetYoutubeChannelsResponse(): GetYoutubeChannelsResponse {
+  return { channels: [] };
 }
 
-function fromTimestamp(t: Timestamp): Date {
-  let millis = (t.seconds || 0) * 1_000;
-  millis += (t.nanos || 0) / 1_000_000;
-  return new globalThis.Date(millis);
+export const GetYoutubeChannelsResponse: MessageFns<GetYoutubeChannelsResponse> = {
+  encode(message: GetYoutubeChannelsResponse, writer: BinaryWriter = new BinaryWriter()): BinaryWriter {
+    for (const v of message.channels) {
+      Channel.encode(v!, writer.uint32(10).fork()).join();
+    }
+    return writer;
+  },
+
+  decode(input: BinaryReader | Uint8Array, length?: number): GetYoutubeChannelsResponse {
+    const reader = input instanceof BinaryReader ? input : new BinaryReader(input);
+    let end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBaseGetYoutubeChannelsResponse();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+        case 1:
+          if (tag !== 10) {
+            break;
+          }
+
+          message.channels.push(Channel.decode(reader, reader.uint32()));
+          continue;
+      }
+      if ((tag & 7) === 4 || tag === 0) {
+        break;
+      }
+      reader.skip(tag & 7);
+    }
+    return message;
+  },
+
+  fromJSON(object: any): GetYoutubeChannelsResponse {
+    return {
+      channels: globalThis.Array.isArray(object?.channels) ? object.channels.map((e: any) => Channel.fromJSON(e)) : [],
+    };
+  },
+
+  toJSON(message: GetYoutubeChannelsResponse): unknown {
+    const obj: any = {};
+    if (message.channels?.length) {
+      obj.channels = message.channels.map((e) => Channel.toJSON(e));
+    }
+    return obj;
+  },
+
+  create<I extends Exact<DeepPartial<GetYoutubeChannelsResponse>, I>>(base?: I): GetYoutubeChannelsResponse {
+    return GetYoutubeChannelsResponse.fromPartial(base ?? ({} as any));
+  },
+  fromPartial<I extends Exact<DeepPartial<GetYoutubeChannelsResponse>, I>>(object: I): GetYoutubeChannelsResponse {
+    const message = createBaseGetYoutubeChannelsResponse();
+    message.channels = object.channels?.map((e) => Channel.fromPartial(e)) || [];
+    return message;
+  },
+};
+
+function createBaseProcessVideoRequest(): ProcessVideoRequest {
+  return { tags: false, playlists: false, channel: false, policy: 0, language: "" };
 }
 
-function fromJsonTimestamp(o: any): Date {
-  if (o instanceof globalThis.Date) {
-    return o;
-  } else if (typeof o === "string") {
-    return new globalThis.Date(o);
-  } else {
-    return fromTimestamp(Timestamp.fromJSON(o));
+export const ProcessVideoRequest: MessageFns<ProcessVideoRequest> = {
+  encode(message: ProcessVideoRequest, writer: BinaryWriter = new BinaryWriter()): BinaryWriter {
+    if (message.tags !== false) {
+      writer.uint32(8).bool(message.tags);
+    }
+    if (message.playlists !== false) {
+      writer.uint32(16).bool(message.playlists);
+    }
+    if (message.channel !== false) {
+      writer.uint32(24).bool(message.channel);
+    }
+    if (message.policy !== 0) {
+      writer.uint32(32).int32(message.policy);
+    }
+    if (message.language !== "") {
+      writer.uint32(42).string(message.language);
+    }
+    return writer;
+  },
+
+  decode(input: BinaryReader | Uint8Array, length?: number): ProcessVideoRequest {
+    const reader = input instanceof BinaryReader ? input : new BinaryReader(input);
+    let end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBaseProcessVideoRequest();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+        case 1:
+          if (tag !== 8) {
+            break;
+          }
+
+          message.tags = reader.bool();
+          continue;
+        case 2:
+          if (tag !== 16) {
+            break;
+          }
+
+          message.playlists = reader.bool();
+          continue;
+        case 3:
+          if (tag !== 24) {
+            break;
+          }
+
+          message.channel = reader.bool();
+          continue;
+        case 4:
+          if (tag !== 32) {
+            break;
+          }
+
+          message.policy = reader.int32() as any;
+          continue;
+        case 5:
+          if (tag !== 42) {
+            break;
+          }
+
+          message.language = reader.string();
+          continue;
+      }
+      if ((tag & 7) === 4 || tag === 0) {
+        break;
+      }
+      reader.skip(tag & 7);
+    }
+    return message;
+  },
+
+  fromJSON(object: any): ProcessVideoRequest {
+    return {
+      tags: isSet(object.tags) ? globalThis.Boolean(object.tags) : false,
+      playlists: isSet(object.playlists) ? globalThis.Boolean(object.playlists) : false,
+      channel: isSet(object.channel) ? globalThis.Boolean(object.channel) : false,
+      policy: isSet(object.policy) ? mergePolicyFromJSON(object.policy) : 0,
+      language: isSet(object.language) ? globalThis.String(object.language) : "",
+    };
+  },
+
+  toJSON(message: ProcessVideoRequest): unknown {
+    const obj: any = {};
+    if (message.tags !== false) {
+      obj.tags = message.tags;
+    }
+    if (message.playlists !== false) {
+      obj.playlists = message.playlists;
+    }
+    if (message.channel !== false) {
+      obj.channel = message.channel;
+    }
+    if (message.policy !== 0) {
+      obj.policy = mergePolicyToJSON(message.policy);
+    }
+    if (message.language !== "") {
+      obj.language = message.language;
+    }
+    return obj;
+  },
+
+  create<I extends Exact<DeepPartial<ProcessVideoRequest>, I>>(base?: I): ProcessVideoRequest {
+    return ProcessVideoRequest.fromPartial(base ?? ({} as any));
+  },
+  fromPartial<I extends Exact<DeepPartial<ProcessVideoRequest>, I>>(object: I): ProcessVideoRequest {
+    const message = createBaseProcessVideoRequest();
+    message.tags = object.tags ?? false;
+    message.playlists = object.playlists ?? false;
+    message.channel = object.channel ?? false;
+    message.policy = object.policy ?? 0;
+    message.language = object.language ?? "";
+    return message;
+  },
+};
+
+function createBaseDetectVideoLanguageResponse(): DetectVideoLanguageResponse {
+  return { language: "" };
+}
+
+export const DetectVideoLanguageResponse: MessageFns<DetectVideoLanguageResponse> = {
+  encode(message: DetectVideoLanguageResponse, writer: BinaryWriter = new BinaryWriter()): BinaryWriter {
+    if (message.language !== "") {
+      writer.uint32(10).string(message.language);
+    }
+    return writer;
+  },
+
+  decode(input: BinaryReader | Uint8Array, length?: number): DetectVideoLanguageResponse {
+    const reader = input instanceof BinaryReader ? input : new BinaryReader(input);
+    let end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBaseDetectVideoLanguageResponse();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+        case 1:
+          if (tag !== 10) {
+            break;
+          }
+
+          message.language = reader.string();
+          continue;
+      }
+      if ((tag & 7) === 4 || tag === 0) {
+        break;
+      }
+      reader.skip(tag & 7);
+    }
+    return message;
+  },
+
+  fromJSON(object: any): DetectVideoLanguageResponse {
+    return { language: isSet(object.language) ? globalThis.String(object.language) : "" };
+  },
+
+  toJSON(message: DetectVideoLanguageResponse): unknown {
+    const obj: any = {};
+    if (message.language !== "") {
+      obj.language = message.language;
+    }
+    return obj;
+  },
+
+  create<I extends Exact<DeepPartial<DetectVideoLanguageResponse>, I>>(base?: I): DetectVideoLanguageResponse {
+    return DetectVideoLanguageResponse.fromPartial(base ?? ({} as any));
+  },
+  fromPartial<I extends Exact<DeepPartial<DetectVideoLanguageResponse>, I>>(object: I): DetectVideoLanguageResponse {
+    const message = createBaseDetectVideoLanguageResponse();
+    message.language = object.language ?? "";
+    return message;
+  },
+};
+
+function createBaseClosedCaptions(): ClosedCaptions {
+  return { id: "", language: "" };
+}
+
+export const ClosedCaptions: MessageFns<ClosedCaptions> = {
+  encode(message: ClosedCaptions, writer: BinaryWriter = new BinaryWriter()): BinaryWriter {
+    if (message.id !== "") {
+      writer.uint32(10).string(message.id);
+    }
+    if (message.language !== "") {
+      writer.uint32(18).string(message.language);
+    }
+    return writer;
+  },
+
+  decode(input: BinaryReader | Uint8Array, length?: number): ClosedCaptions {
+    const reader = input instanceof BinaryReader ? input : new BinaryReader(input);
+    let end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBaseClosedCaptions();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+        case 1:
+          if (tag !== 10) {
+            break;
+          }
+
+          message.id = reader.string();
+          continue;
+        case 2:
+          if (tag !== 18) {
+            break;
+          }
+
+          message.language = reader.string();
+          continue;
+      }
+      if ((tag & 7) === 4 || tag === 0) {
+        break;
+      }
+      reader.skip(tag & 7);
+    }
+    return message;
+  },
+
+  fromJSON(object: any): ClosedCaptions {
+    return {
+      id: isSet(object.id) ? globalThis.String(object.id) : "",
+      language: isSet(object.language) ? globalThis.String(object.language) : "",
+    };
+  },
+
+  toJSON(message: ClosedCaptions): unknown {
+    const obj: any = {};
+    if (message.id !== "") {
+      obj.id = message.id;
+    }
+    if (message.language !== "") {
+      obj.language = message.language;
+    }
+    return obj;
+  },
+
+  create<I extends Exact<DeepPartial<ClosedCaptions>, I>>(base?: I): ClosedCaptions {
+    return ClosedCaptions.fromPartial(base ?? ({} as any));
+  },
+  fromPartial<I extends Exact<DeepPartial<ClosedCaptions>, I>>(object: I): ClosedCaptions {
+    const message = createBaseClosedCaptions();
+    message.id = object.id ?? "";
+    message.language = object.language ?? "";
+    return message;
+  },
+};
+
+function createBaseGetYoutubeClosedCaptionsResponse(): GetYoutubeClosedCaptionsResponse {
+  return { closedCaptions: [] };
+}
+
+export const GetYoutubeClosedCaptionsResponse: MessageFns<GetYoutubeClosedCaptionsResponse> = {
+  encode(message: GetYoutubeClosedCaptionsResponse, writer: BinaryWriter = new BinaryWriter()): BinaryWriter {
+    for (const v of message.closedCaptions) {
+      ClosedCaptions.encode(v!, writer.uint32(10).fork()).join();
+    }
+    return writer;
+  },
+
+  decode(input: BinaryReader | Uint8Array, length?: number): GetYoutubeClosedCaptionsResponse {
+    const reader = input instanceof BinaryReader ? input : new BinaryReader(input);
+    let end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBaseGetYoutubeClosedCaptionsResponse();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+        case 1:
+          if (tag !== 10) {
+            break;
+          }
+
+          message.closedCaptions.push(ClosedCaptions.decode(reader, reader.uint32()));
+          continue;
+      }
+      if ((tag & 7) === 4 || tag === 0) {
+        break;
+      }
+      reader.skip(tag & 7);
+    }
+    return message;
+  },
+
+  fromJSON(object: any): GetYoutubeClosedCaptionsResponse {
+    return {
+      closedCaptions: globalThis.Array.isArray(object?.closedCaptions)
+        ? object.closedCaptions.map((e: any) => ClosedCaptions.fromJSON(e))
+        : [],
+    };
+  },
+
+  toJSON(message: GetYoutubeClosedCaptionsResponse): unknown {
+    const obj: any = {};
+    if (message.closedCaptions?.length) {
+      obj.closedCaptions = message.closedCaptions.map((e) => ClosedCaptions.toJSON(e));
+    }
+    return obj;
+  },
+
+  create<I extends Exact<DeepPartial<GetYoutubeClosedCaptionsResponse>, I>>(
+    base?: I,
+  ): GetYoutubeClosedCaptionsResponse {
+    return GetYoutubeClosedCaptionsResponse.fromPartial(base ?? ({} as any));
+  },
+  fromPartial<I extends Exact<DeepPartial<GetYoutubeClosedCaptionsResponse>, I>>(
+    object: I,
+  ): GetYoutubeClosedCaptionsResponse {
+    const message = createBaseGetYoutubeClosedCaptionsResponse();
+    message.closedCaptions = object.closedCaptions?.map((e) => ClosedCaptions.fromPartial(e)) || [];
+    return message;
+  },
+};
+
+function createBaseSyncPoint(): SyncPoint {
+  return { fromMs: 0, toMs: 0 };
+}
+
+export const SyncPoint: MessageFns<SyncPoint> = {
+  encode(message: SyncPoint, writer: BinaryWriter = new BinaryWriter()): BinaryWriter {
+    if (message.fromMs !== 0) {
+      writer.uint32(8).int64(message.fromMs);
+    }
+    if (message.toMs !== 0) {
+      writer.uint32(16).int64(message.toMs);
+    }
+    return writer;
+  },
+
+  decode(input: BinaryReader | Uint8Array, length?: number): SyncPoint {
+    const reader = input instanceof BinaryReader ? input : new BinaryReader(input);
+    let end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBaseSyncPoint();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+        case 1:
+          if (tag !== 8) {
+            break;
+          }
+
+          message.fromMs = longToNumber(reader.int64());
+          continue;
+        case 2:
+          if (tag !== 16) {
+            break;
+          }
+
+          message.toMs = longToNumber(reader.int64());
+          continue;
+      }
+      if ((tag & 7) === 4 || tag === 0) {
+        break;
+      }
+      reader.skip(tag & 7);
+    }
+    return message;
+  },
+
+  fromJSON(object: any): SyncPoint {
+    return {
+      fromMs: isSet(object.fromMs) ? globalThis.Number(object.fromMs) : 0,
+      toMs: isSet(object.toMs) ? globalThis.Number(object.toMs) : 0,
+    };
+  },
+
+  toJSON(message: SyncPoint): unknown {
+    const obj: any = {};
+    if (message.fromMs !== 0) {
+      obj.fromMs = Math.round(message.fromMs);
+    }
+    if (message.toMs !== 0) {
+      obj.toMs = Math.round(message.toMs);
+    }
+    return obj;
+  },
+
+  create<I extends Exact<DeepPartial<SyncPoint>, I>>(base?: I): SyncPoint {
+    return SyncPoint.fromPartial(base ?? ({} as any));
+  },
+  fromPartial<I extends Exact<DeepPartial<SyncPoint>, I>>(object: I): SyncPoint {
+    const message = createBaseSyncPoint();
+    message.fromMs = object.fromMs ?? 0;
+    message.toMs = object.toMs ?? 0;
+    return message;
+  },
+};
+
+function createBaseAdjustSourceTimingRequest(): AdjustSourceTimingRequest {
+  return { syncPoints: [], framerateFrom: 0, framerateTo: 0, shiftMs: 0, minDurationMs: 0, maxDurationMs: 0 };
+}
+
+export const AdjustSourceTimingRequest: MessageFns<AdjustSourceTimingRequest> = {
+  encode(message: AdjustSourceTimingRequest, writer: BinaryWriter = new BinaryWriter()): BinaryWriter {
+    for (const v of message.syncPoints) {
+      SyncPoint.encode(v!, writer.uint32(10).fork()).join();
+    }
+    if (message.framerateFrom !== 0) {
+      writer.uint32(17).double(message.framerateFrom);
+    }
+    if (message.framerateTo !== 0) {
+      writer.uint32(25).double(message.framerateTo);
+    }
+    if (message.shiftMs !== 0) {
+      writer.uint32(32).int64(message.shiftMs);
+    }
+    if (message.minDurationMs !== 0) {
+      writer.uint32(40).int64(message.minDurationMs);
+    }
+    if (message.maxDurationMs !== 0) {
+      writer.uint32(48).int64(message.maxDurationMs);
+    }
+    return writer;
+  },
+
+  decode(input: BinaryReader | Uint8Array, length?: number): AdjustSourceTimingRequest {
+    const reader = input instanceof BinaryReader ? input : new BinaryReader(input);
+    let end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBaseAdjustSourceTimingRequest();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+        case 1:
+          if (tag !== 10) {
+            break;
+          }
+
+          message.syncPoints.push(SyncPoint.decode(reader, reader.uint32()));
+          continue;
+        case 2:
+          if (tag !== 17) {
+            break;
+          }
+
+          message.framerateFrom = reader.double();
+          continue;
+        case 3:
+          if (tag !== 25) {
+            break;
+          }
+
+          message.framerateTo = reader.double();
+          continue;
+        case 4:
+          if (tag !== 32) {
+            break;
+          }
+
+          message.shiftMs = longToNumber(reader.int64());
+          continue;
+        case 5:
+          if (tag !== 40) {
+            break;
+          }
+
+          message.minDurationMs = longToNumber(reader.int64());
+          continue;
+        case 6:
+          if (tag !== 48) {
+            break;
+          }
+
+          message.maxDurationMs = longToNumber(reader.int64());
+          continue;
+      }
+      if ((tag & 7) === 4 || tag === 0) {
+        break;
+      }
+      reader.skip(tag & 7);
+    }
+    return message;
+  },
+
+  fromJSON(object: any): AdjustSourceTimingRequest {
+    return {
+      syncPoints: globalThis.Array.isArray(object?.syncPoints)
+        ? object.syncPoints.map((e: any) => SyncPoint.fromJSON(e))
+        : [],
+      framerateFrom: isSet(object.framerateFrom) ? globalThis.Number(object.framerateFrom) : 0,
+      framerateTo: isSet(object.framerateTo) ? globalThis.Number(object.framerateTo) : 0,
+      shiftMs: isSet(object.shiftMs) ? globalThis.Number(object.shiftMs) : 0,
+      minDurationMs: isSet(object.minDurationMs) ? globalThis.Number(object.minDurationMs) : 0,
+      maxDurationMs: isSet(object.maxDurationMs) ? globalThis.Number(object.maxDurationMs) : 0,
+    };
+  },
+
+  toJSON(message: AdjustSourceTimingRequest): unknown {
+    const obj: any = {};
+    if (message.syncPoints?.length) {
+      obj.syncPoints = message.syncPoints.map((e) => SyncPoint.toJSON(e));
+    }
+    if (message.framerateFrom !== 0) {
+      obj.framerateFrom = message.framerateFrom;
+    }
+    if (message.framerateTo !== 0) {
+      obj.framerateTo = message.framerateTo;
+    }
+    if (message.shiftMs !== 0) {
+      obj.shiftMs = Math.round(message.shiftMs);
+    }
+    if (message.minDurationMs !== 0) {
+      obj.minDurationMs = Math.round(message.minDurationMs);
+    }
+    if (message.maxDurationMs !== 0) {
+      obj.maxDurationMs = Math.round(message.maxDurationMs);
+    }
+    return obj;
+  },
+
+  create<I extends Exact<DeepPartial<AdjustSourceTimingRequest>, I>>(base?: I): AdjustSourceTimingRequest {
+    return AdjustSourceTimingRequest.fromPartial(base ?? ({} as any));
+  },
+  fromPartial<I extends Exact<DeepPartial<AdjustSourceTimingRequest>, I>>(object: I): AdjustSourceTimingRequest {
+    const message = createBaseAdjustSourceTimingRequest();
+    message.syncPoints = object.syncPoints?.map((e) => SyncPoint.fromPartial(e)) || [];
+    message.framerateFrom = object.framerateFrom ?? 0;
+    message.framerateTo = object.framerateTo ?? 0;
+    message.shiftMs = object.shiftMs ?? 0;
+    message.minDurationMs = object.minDurationMs ?? 0;
+    message.maxDurationMs = object.maxDurationMs ?? 0;
+    return message;
+  },
+};
+
+function createBaseTranslationState(): TranslationState {
+  return { author: 0, stale: false };
+}
+
+export const TranslationState: MessageFns<TranslationState> = {
+  encode(message: TranslationState, writer: BinaryWriter = new BinaryWriter()): BinaryWriter {
+    if (message.author !== 0) {
+      writer.uint32(8).int32(message.author);
+    }
+    if (message.stale !== false) {
+      writer.uint32(16).bool(message.stale);
+    }
+    return writer;
+  },
+
+  decode(input: BinaryReader | Uint8Array, length?: number): TranslationState {
+    const reader = input instanceof BinaryReader ? input : new BinaryReader(input);
+    let end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBaseTranslationState();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+        case 1:
+          if (tag !== 8) {
+            break;
+          }
+
+          message.author = reader.int32() as any;
+          continue;
+        case 2:
+          if (tag !== 16) {
+            break;
+          }
+
+          message.stale = reader.bool();
+          continue;
+      }
+      if ((tag & 7) === 4 || tag === 0) {
+        break;
+      }
+      reader.skip(tag & 7);
+    }
+    return message;
+  },
+
+  fromJSON(object: any): TranslationState {
+    return {
+      author: isSet(object.author) ? authorFromJSON(object.author) : 0,
+      stale: isSet(object.stale) ? globalThis.Boolean(object.stale) : false,
+    };
+  },
+
+  toJSON(message: TranslationState): unknown {
+    const obj: any = {};
+    if (message.author !== 0) {
+      obj.author = authorToJSON(message.author);
+    }
+    if (message.stale !== false) {
+      obj.stale = message.stale;
+    }
+    return obj;
+  },
+
+  create<I extends Exact<DeepPartial<TranslationState>, I>>(base?: I): TranslationState {
+    return TranslationState.fromPartial(base ?? ({} as any));
+  },
+  fromPartial<I extends Exact<DeepPartial<TranslationState>, I>>(object: I): TranslationState {
+    const message = createBaseTranslationState();
+    message.author = object.author ?? 0;
+    message.stale = object.stale ?? false;
+    return message;
+  },
+};
+
+function createBaseLanguageCoverage(): LanguageCoverage {
+  return { language: "", captions: undefined, metadata: undefined };
+}
+
+export const LanguageCoverage: MessageFns<LanguageCoverage> = {
+  encode(message: LanguageCoverage, writer: BinaryWriter = new BinaryWriter()): BinaryWriter {
+    if (message.language !== "") {
+      writer.uint32(10).string(message.language);
+    }
+    if (message.captions !== undefined) {
+      TranslationState.encode(message.captions, writer.uint32(18).fork()).join();
+    }
+    if (message.metadata !== undefined) {
+      TranslationState.encode(message.metadata, writer.uint32(26).fork()).join();
+    }
+    return writer;
+  },
+
+  decode(input: BinaryReader | Uint8Array, length?: number): LanguageCoverage {
+    const reader = input instanceof BinaryReader ? input : new BinaryReader(input);
+    let end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBaseLanguageCoverage();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+        case 1:
+          if (tag !== 10) {
+            break;
+          }
+
+          message.language = reader.string();
+          continue;
+        case 2:
+          if (tag !== 18) {
+            break;
+          }
+
+          message.captions = TranslationState.decode(reader, reader.uint32());
+          continue;
+        case 3:
+          if (tag !== 26) {
+            break;
+          }
+
+          message.metadata = TranslationState.decode(reader, reader.uint32());
+          continue;
+      }
+      if ((tag & 7) === 4 || tag === 0) {
+        break;
+      }
+      reader.skip(tag & 7);
+    }
+    return message;
+  },
+
+  fromJSON(object: any): LanguageCoverage {
+    return {
+      language: isSet(object.language) ? globalThis.String(object.language) : "",
+      captions: isSet(object.captions) ? TranslationState.fromJSON(object.captions) : undefined,
+      metadata: isSet(object.metadata) ? TranslationState.fromJSON(object.metadata) : undefined,
+    };
+  },
+
+  toJSON(message: LanguageCoverage): unknown {
+    const obj: any = {};
+    if (message.language !== "") {
+      obj.language = message.language;
+    }
+    if (message.captions !== undefined) {
+      obj.captions = TranslationState.toJSON(message.captions);
+    }
+    if (message.metadata !== undefined) {
+      obj.metadata = TranslationState.toJSON(message.metadata);
+    }
+    return obj;
+  },
+
+  create<I extends Exact<DeepPartial<LanguageCoverage>, I>>(base?: I): LanguageCoverage {
+    return LanguageCoverage.fromPartial(base ?? ({} as any));
+  },
+  fromPartial<I extends Exact<DeepPartial<LanguageCoverage>, I>>(object: I): LanguageCoverage {
+    const message = createBaseLanguageCoverage();
+    message.language = object.language ?? "";
+    message.captions = (object.captions !== undefined && object.captions !== null)
+      ? TranslationState.fromPartial(object.captions)
+      : undefined;
+    message.metadata = (object.metadata !== undefined && object.metadata !== null)
+      ? TranslationState.fromPartial(object.metadata)
+      : undefined;
+    return message;
+  },
+};
+
+function createBaseVideoCoverage(): VideoCoverage {
+  return { videoId: "", title: "", language: "", languages: [] };
+}
+
+export const VideoCoverage: MessageFns<VideoCoverage> = {
+  encode(message: VideoCoverage, writer: BinaryWriter = new BinaryWriter()): BinaryWriter {
+    if (message.videoId !== "") {
+      writer.uint32(10).string(message.videoId);
+    }
+    if (message.title !== "") {
+      writer.uint32(18).string(message.title);
+    }
+    if (message.language !== "") {
+      writer.uint32(26).string(message.language);
+    }
+    for (const v of message.languages) {
+      LanguageCoverage.encode(v!, writer.uint32(34).fork()).join();
+    }
+    return writer;
+  },
+
+  decode(input: BinaryReader | Uint8Array, length?: number): VideoCoverage {
+    const reader = input instanceof BinaryReader ? input : new BinaryReader(input);
+    let end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBaseVideoCoverage();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+        case 1:
+          if (tag !== 10) {
+            break;
+          }
+
+          message.videoId = reader.string();
+          continue;
+        case 2:
+          if (tag !== 18) {
+            break;
+          }
+
+          message.title = reader.string();
+          continue;
+        case 3:
+          if (tag !== 26) {
+            break;
+          }
+
+          message.language = reader.string();
+          continue;
+        case 4:
+          if (tag !== 34) {
+            break;
+          }
+
+          message.languages.push(LanguageCoverage.decode(reader, reader.uint32()));
+          continue;
+      }
+      if ((tag & 7) === 4 || tag === 0) {
+        break;
+      }
+      reader.skip(tag & 7);
+    }
+    return message;
+  },
+
+  fromJSON(object: any): VideoCoverage {
+    return {
+      videoId: isSet(object.videoId) ? globalThis.String(object.videoId) : "",
+      title: isSet(object.title) ? globalThis.String(object.title) : "",
+      language: isSet(object.language) ? globalThis.String(object.language) : "",
+      languages: globalThis.Array.isArray(object?.languages)
+        ? object.languages.map((e: any) => LanguageCoverage.fromJSON(e))
+        : [],
+    };
+  },
+
+  toJSON(message: VideoCoverage): unknown {
+    const obj: any = {};
+    if (message.videoId !== "") {
+      obj.videoId = message.videoId;
+    }
+    if (message.title !== "") {
+      obj.title = message.title;
+    }
+    if (message.language !== "") {
+      obj.language = message.language;
+    }
+    if (message.languages?.length) {
+      obj.languages = message.languages.map((e) => LanguageCoverage.toJSON(e));
+    }
+    return obj;
+  },
+
+  create<I extends Exact<DeepPartial<VideoCoverage>, I>>(base?: I): VideoCoverage {
+    return VideoCoverage.fromPartial(base ?? ({} as any));
+  },
+  fromPartial<I extends Exact<DeepPartial<VideoCoverage>, I>>(object: I): VideoCoverage {
+    const message = createBaseVideoCoverage();
+    message.videoId = object.videoId ?? "";
+    message.title = object.title ?? "";
+    message.language = object.language ?? "";
+    message.languages = object.languages?.map((e) => LanguageCoverage.fromPartial(e)) || [];
+    return message;
+  },
+};
+
+function createBaseGetVideoCoverageResponse(): GetVideoCoverageResponse {
+  return { coverage: undefined };
+}
+
+export const GetVideoCoverageResponse: MessageFns<GetVideoCoverageResponse> = {
+  encode(message: GetVideoCoverageResponse, writer: BinaryWriter = new BinaryWriter()): BinaryWriter {
+    if (message.coverage !== undefined) {
+      VideoCoverage.encode(message.coverage, writer.uint32(10).fork()).join();
+    }
+    return writer;
+  },
+
+  decode(input: BinaryReader | Uint8Array, length?: number): GetVideoCoverageResponse {
+    const reader = input instanceof BinaryReader ? input : new BinaryReader(input);
+    let end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBaseGetVideoCoverageResponse();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+        case 1:
+          if (tag !== 10) {
+            break;
+          }
+
+          message.coverage = VideoCoverage.decode(reader, reader.uint32());
+          continue;
+      }
+      if ((tag & 7) === 4 || tag === 0) {
+        break;
+      }
+      reader.skip(tag & 7);
+    }
+    return message;
+  },
+
+  fromJSON(object: any): GetVideoCoverageResponse {
+    return { coverage: isSet(object.coverage) ? VideoCoverage.fromJSON(object.coverage) : undefined };
+  },
+
+  toJSON(message: GetVideoCoverageResponse): unknown {
+    const obj: any = {};
+    if (message.coverage !== undefined) {
+      obj.coverage = VideoCoverage.toJSON(message.coverage);
+    }
+    return obj;
+  },
+
+  create<I extends Exact<DeepPartial<GetVideoCoverageResponse>, I>>(base?: I): GetVideoCoverageResponse {
+    return GetVideoCoverageResponse.fromPartial(base ?? ({} as any));
+  },
+  fromPartial<I extends Exact<DeepPartial<GetVideoCoverageResponse>, I>>(object: I): GetVideoCoverageResponse {
+    const message = createBaseGetVideoCoverageResponse();
+    message.coverage = (object.coverage !== undefined && object.coverage !== null)
+      ? VideoCoverage.fromPartial(object.coverage)
+      : undefined;
+    return message;
+  },
+};
+
+function createBaseLanguageSummary(): LanguageSummary {
+  return {
+    language: "",
+    captionsMissing: 0,
+    captionsHuman: 0,
+    captionsAutocc: 0,
+    captionsYoutube: 0,
+    captionsStale: 0,
+    metadataMissing: 0,
+    metadataHuman: 0,
+    metadataAutocc: 0,
+    metadataStale: 0,
+  };
+}
+
+export const LanguageSummary: MessageFns<LanguageSummary> = {
+  encode(message: LanguageSummary, writer: BinaryWriter = new BinaryWriter()): BinaryWriter {
+    if (message.language !== "") {
+      writer.uint32(10).string(message.language);
+    }
+    if (message.captionsMissing !== 0) {
+      writer.uint32(16).uint32(message.captionsMissing);
+    }
+    if (message.captionsHuman !== 0) {
+      writer.uint32(24).uint32(message.captionsHuman);
+    }
+    if (message.captionsAutocc !== 0) {
+      writer.uint32(32).uint32(message.captionsAutocc);
+    }
+    if (message.captionsYoutube !== 0) {
+      writer.uint32(40).uint32(message.captionsYoutube);
+    }
+    if (message.captionsStale !== 0) {
+      writer.uint32(48).uint32(message.captionsStale);
+    }
+    if (message.metadataMissing !== 0) {
+      writer.uint32(56).uint32(message.metadataMissing);
+    }
+    if (message.metadataHuman !== 0) {
+      writer.uint32(64).uint32(message.metadataHuman);
+    }
+    if (message.metadataAutocc !== 0) {
+      writer.uint32(72).uint32(message.metadataAutocc);
+    }
+    if (message.metadataStale !== 0) {
+      writer.uint32(80).uint32(message.metadataStale);
+    }
+    return writer;
+  },
+
+  decode(input: BinaryReader | Uint8Array, length?: number): LanguageSummary {
+    const reader = input instanceof BinaryReader ? input : new BinaryReader(input);
+    let end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBaseLanguageSummary();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+        case 1:
+          if (tag !== 10) {
+            break;
+          }
+
+          message.language = reader.string();
+          continue;
+        case 2:
+          if (tag !== 16) {
+            break;
+          }
+
+          message.captionsMissing = reader.uint32();
+          continue;
+        case 3:
+          if (tag !== 24) {
+            break;
+          }
+
+          message.captionsHuman = reader.uint32();
+          continue;
+        case 4:
+          if (tag !== 32) {
+            break;
+          }
+
+          message.captionsAutocc = reader.uint32();
+          continue;
+        case 5:
+          if (tag !== 40) {
+            break;
+          }
+
+          message.captionsYoutube = reader.uint32();
+          continue;
+        case 6:
+          if (tag !== 48) {
+            break;
+          }
+
+          message.captionsStale = reader.uint32();
+          continue;
+        case 7:
+          if (tag !== 56) {
+            break;
+          }
+
+          message.metadataMissing = reader.uint32();
+          continue;
+        case 8:
+          if (tag !== 64) {
+            break;
+          }
+
+          message.metadataHuman = reader.uint32();
+          continue;
+        case 9:
+          if (tag !== 72) {
+            break;
+          }
+
+          message.metadataAutocc = reader.uint32();
+          continue;
+        case 10:
+          if (tag !== 80) {
+            break;
+          }
+
+          message.metadataStale = reader.uint32();
+          continue;
+      }
+      if ((tag & 7) === 4 || tag === 0) {
+        break;
+      }
+      reader.skip(tag & 7);
+    }
+    return message;
+  },
+
+  fromJSON(object: any): LanguageSummary {
+    return {
+      language: isSet(object.language) ? globalThis.String(object.language) : "",
+      captionsMissing: isSet(object.captionsMissing) ? globalThis.Number(object.captionsMissing) : 0,
+      captionsHuman: isSet(object.captionsHuman) ? globalThis.Number(object.captionsHuman) : 0,
+      captionsAutocc: isSet(object.captionsAutocc) ? globalThis.Number(object.captionsAutocc) : 0,
+      captionsYoutube: isSet(object.captionsYoutube) ? globalThis.Number(object.captionsYoutube) : 0,
+      captionsStale: isSet(object.captionsStale) ? globalThis.Number(object.captionsStale) : 0,
+      metadataMissing: isSet(object.metadataMissing) ? globalThis.Number(object.metadataMissing) : 0,
+      metadataHuman: isSet(object.metadataHuman) ? globalThis.Number(object.metadataHuman) : 0,
+      metadataAutocc: isSet(object.metadataAutocc) ? globalThis.Number(object.metadataAutocc) : 0,
+      metadataStale: isSet(object.metadataStale) ? globalThis.Number(object.metadataStale) : 0,
+    };
+  },
+
+  toJSON(message: LanguageSummary): unknown {
+    const obj: any = {};
+    if (message.language !== "") {
+      obj.language = message.language;
+    }
+    if (message.captionsMissing !== 0) {
+      obj.captionsMissing = Math.round(message.captionsMissing);
+    }
+    if (message.captionsHuman !== 0) {
+      obj.captionsHuman = Math.round(message.captionsHuman);
+    }
+    if (message.captionsAutocc !== 0) {
+      obj.captionsAutocc = Math.round(message.captionsAutocc);
+    }
+    if (message.captionsYoutube !== 0) {
+      obj.captionsYoutube = Math.round(message.captionsYoutube);
+    }
+    if (message.captionsStale !== 0) {
+      obj.captionsStale = Math.round(message.captionsStale);
+    }
+    if (message.metadataMissing !== 0) {
+      obj.metadataMissing = Math.round(message.metadataMissing);
+    }
+    if (message.metadataHuman !== 0) {
+      obj.metadataHuman = Math.round(message.metadataHuman);
+    }
+    if (message.metadataAutocc !== 0) {
+      obj.metadataAutocc = Math.round(message.metadataAutocc);
+    }
+    if (message.metadataStale !== 0) {
+      obj.metadataStale = Math.round(message.metadataStale);
+    }
+    return obj;
+  },
+
+  create<I extends Exact<DeepPartial<LanguageSummary>, I>>(base?: I): LanguageSummary {
+    return LanguageSummary.fromPartial(base ?? ({} as any));
+  },
+  fromPartial<I extends Exact<DeepPartial<LanguageSummary>, I>>(object: I): LanguageSummary {
+    const message = createBaseLanguageSummary();
+    message.language = object.language ?? "";
+    message.captionsMissing = object.captionsMissing ?? 0;
+    message.captionsHuman = object.captionsHuman ?? 0;
+    message.captionsAutocc = object.captionsAutocc ?? 0;
+    message.captionsYoutube = object.captionsYoutube ?? 0;
+    message.captionsStale = object.captionsStale ?? 0;
+    message.metadataMissing = object.metadataMissing ?? 0;
+    message.metadataHuman = object.metadataHuman ?? 0;
+    message.metadataAutocc = object.metadataAutocc ?? 0;
+    message.metadataStale = object.metadataStale ?? 0;
+    return message;
+  },
+};
+
+function createBaseGetChannelCoverageResponse(): GetChannelCoverageResponse {
+  return { nextPageToken: "", videos: [], languages: [] };
+}
+
+export const GetChannelCoverageResponse: MessageFns<GetChannelCoverageResponse> = {
+  encode(message: GetChannelCoverageResponse, writer: BinaryWriter = new BinaryWriter()): BinaryWriter {
+    if (message.nextPageToken !== "") {
+      writer.uint32(10).string(message.nextPageToken);
+    }
+    for (const v of message.videos) {
+      VideoCoverage.encode(v!, writer.uint32(18).fork()).join();
+    }
+    for (const v of message.languages) {
+      LanguageSummary.encode(v!, writer.uint32(26).fork()).join();
+    }
+    return writer;
+  },
+
+  decode(input: BinaryReader | Uint8Array, length?: number): GetChannelCoverageResponse {
+    const reader = input instanceof BinaryReader ? input : new BinaryReader(input);
+    let end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBaseGetChannelCoverageResponse();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+        case 1:
+          if (tag !== 10) {
+            break;
+          }
+
+          message.nextPageToken = reader.string();
+          continue;
+        case 2:
+          if (tag !== 18) {
+            break;
+          }
+
+          message.videos.push(VideoCoverage.decode(reader, reader.uint32()));
+          continue;
+        case 3:
+          if (tag !== 26) {
+            break;
+          }
+
+          message.languages.push(LanguageSummary.decode(reader, reader.uint32()));
+          continue;
+      }
+      if ((tag & 7) === 4 || tag === 0) {
+        break;
+      }
+      reader.skip(tag & 7);
+    }
+    return message;
+  },
+
+  fromJSON(object: any): GetChannelCoverageResponse {
+    return {
+      nextPageToken: isSet(object.nextPageToken) ? globalThis.String(object.nextPageToken) : "",
+      videos: globalThis.Array.isArray(object?.videos) ? object.videos.map((e: any) => VideoCoverage.fromJSON(e)) : [],
+      languages: globalThis.Array.isArray(object?.languages)
+        ? object.languages.map((e: any) => LanguageSummary.fromJSON(e))
+        : [],
+    };
+  },
+
+  toJSON(message: GetChannelCoverageResponse): unknown {
+    const obj: any = {};
+    if (message.nextPageToken !== "") {
+      obj.nextPageToken = message.nextPageToken;
+    }
+    if (message.videos?.length) {
+      obj.videos = message.videos.map((e) => VideoCoverage.toJSON(e));
+    }
+    if (message.languages?.length) {
+      obj.languages = message.languages.map((e) => LanguageSummary.toJSON(e));
+    }
+    return obj;
+  },
+
+  create<I extends Exact<DeepPartial<GetChannelCoverageResponse>, I>>(base?: I): GetChannelCoverageResponse {
+    return GetChannelCoverageResponse.fromPartial(base ?? ({} as any));
+  },
+  fromPartial<I extends Exact<DeepPartial<GetChannelCoverageResponse>, I>>(object: I): GetChannelCoverageResponse {
+    const message = createBaseGetChannelCoverageResponse();
+    message.nextPageToken = object.nextPageToken ?? "";
+    message.videos = object.videos?.map((e) => VideoCoverage.fromPartial(e)) || [];
+    message.languages = object.languages?.map((e) => LanguageSummary.fromPartial(e)) || [];
+    return message;
+  },
+};
+
+type Builtin = Date | Function | Uint8Array | string | number | boolean | undefined;
+
+export type DeepPartial<T> = T extends Builtin ? T
+  : T extends globalThis.Array<infer U> ? globalThis.Array<DeepPartial<U>>
+  : T extends ReadonlyArray<infer U> ? ReadonlyArray<DeepPartial<U>>
+  : T extends {} ? { [K in keyof T]?: DeepPartial<T[K]> }
+  : Partial<T>;
+
+type KeysOfUnion<T> = T extends T ? keyof T : never;
+export type Exact<P, I extends P> = P extends Builtin ? P
+  : P & { [K in keyof P]: Exact<P[K], I[K]> } & { [K in Exclude<keyof I, KeysOfUnion<P>>]: never };
+
+function toTimestamp(date: Date): Timestamp {
+  const seconds = Math.trunc(date.getTime() / 1_000);
+  const nanos = (date.getTime() % 1_000) * 1_000_000;
+  return { seconds, nanos };
+}
+
+function fromTimestamp(t: Timestamp): Date {
+  let millis = (t.seconds || 0) * 1_000;
+  millis += (t.nanos || 0) / 1_000_000;
+  return new globalThis.Date(millis);
+}
+
+function fromJsonTimestamp(o: any): Date {
+  if (o instanceof globalThis.Date) {
+    return o;
+  } else if (typeof o === "string") {
+    return new globalThis.Date(o);
+  } else {
+    return fromTimestamp(Timestamp.fromJSON(o));
+  }
+}
+
+function longToNumber(int64: { toString(): string }): number {
+  const num = globalThis.Number(int64.toString());
+  if (num > globalThis.Number.MAX_SAFE_INTEGER) {
+    throw new globalThis.Error("Value is larger than Number.MAX_SAFE_INTEGER");
+  }
+  if (num < globalThis.Number.MIN_SAFE_INTEGER) {
+    throw new globalThis.Error("Value is smaller than Number.MIN_SAFE_INTEGER");
   }
+  return num;
 }
 
 function isSet(value: any): boolean {